@@ -1,10 +1,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
 	"reflect"
-	"sort"
 	"strings"
 
 	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
@@ -12,8 +14,57 @@ import (
 	"github.com/Coloc3G/othello-engine/models/utils"
 )
 
+// options are the settings of the command line
+type options struct {
+	mode  string
+	games int
+	depth int
+	seed  int64
+}
+
+// parseFlags parses the command line arguments, errors included, into options
+func parseFlags(fs *flag.FlagSet, args []string) (options, error) {
+	var opts options
+	fs.StringVar(&opts.mode, "mode", "match", "match: compare the board and bitboard functions on random boards, verify: play self-play games with both pipelines and compare them at every ply")
+	fs.IntVar(&opts.games, "games", 10, "Games played by -mode verify")
+	fs.IntVar(&opts.depth, "depth", 4, "Search depth of -mode verify")
+	fs.Int64Var(&opts.seed, "seed", 1, "Seed of the random boards of -mode match and of the random openings of -mode verify")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if opts.mode != "match" && opts.mode != "verify" {
+		return opts, fmt.Errorf("unknown mode %q, want match or verify", opts.mode)
+	}
+	return opts, nil
+}
+
+// run runs the mode of the options, writing its report to w, and reports whether the
+// board and bitboard functions agreed
+func run(w io.Writer, opts options) bool {
+	if opts.mode == "verify" {
+		return runVerify(w, opts.games, int8(opts.depth), opts.seed)
+	}
+	return runMatch(w, rand.New(rand.NewSource(opts.seed)), matchRandomBoards)
+}
+
 func main() {
-	fmt.Println("=== Testing Board and Bitboard Function Matching ===")
+	opts, err := parseFlags(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	if !run(os.Stdout, opts) {
+		os.Exit(1)
+	}
+}
+
+// matchRandomBoards is the number of random boards of -mode match
+const matchRandomBoards = 100
+
+// runMatch compares the board and bitboard functions on random boards drawn from rng and
+// a known position, prints a summary and reports whether every function agreed
+func runMatch(w io.Writer, rng *rand.Rand, numRandomBoards int) bool {
+	fmt.Fprintln(w, "=== Testing Board and Bitboard Function Matching ===")
 
 	// Test cases: various board states including random ones
 	testCases := []struct {
@@ -42,14 +93,13 @@ func main() {
 		// },
 	}
 
-	numRandomBoards := 100
 	for i := 0; i < numRandomBoards; i++ {
 		testCases = append(testCases, struct {
 			name  string
 			board game.Board
 		}{
 			name:  fmt.Sprintf("Random Board %d", i+1),
-			board: generateRandomBoard(),
+			board: generateRandomBoard(rng),
 		})
 	}
 
@@ -68,22 +118,21 @@ func main() {
 
 	// Test each case
 	for _, tc := range testCases {
-		result := testBoardBitboardMatch(tc.board)
+		result := testBoardBitboardMatch(w, tc.board)
 		result.TestCase = tc.name
 		results = append(results, result)
 	}
 
 	// Print summary
-	printSummary(results)
+	return printSummary(w, results)
 }
 
 func applyPosition(g *game.Game, pos []game.Position) (err error) {
 	for _, move := range pos {
-		ok := g.ApplyMove(move)
-		if !ok {
+		if err := g.ApplyMove(move); err != nil {
 			utils.PrintBoard(g.Board)
-			fmt.Printf("failed to apply move %s on board\n", utils.PositionToAlgebraic(move))
-			return
+			fmt.Printf("failed to apply move %s on board: %v\n", utils.PositionToAlgebraic(move), err)
+			return err
 		}
 	}
 	return
@@ -99,7 +148,7 @@ type TestResult struct {
 	EvaluationMatch         bool
 }
 
-func testBoardBitboardMatch(board game.Board) TestResult {
+func testBoardBitboardMatch(w io.Writer, board game.Board) TestResult {
 	// Convert board to bitboard for comparison
 	bitboard := utils.BoardToBits(board)
 
@@ -108,58 +157,51 @@ func testBoardBitboardMatch(board game.Board) TestResult {
 	// Test bitboard conversion match
 	convertedBack := utils.BitsToBoard(bitboard)
 	result.BitboardConversionMatch = reflect.DeepEqual(board, convertedBack)
+	if !result.BitboardConversionMatch {
+		diff, _ := utils.DiffBoards(board, convertedBack)
+		fmt.Fprintf(w, "Bitboard conversion mismatch:\n%s", diff)
+	}
 
 	// Test ValidMoves match
-	result.ValidMovesMatch = testValidMovesMatch(board, bitboard)
+	result.ValidMovesMatch = testValidMovesMatch(w, board, bitboard)
 
 	// Test ApplyMove match
-	result.ApplyMoveMatch = testApplyMoveMatch(board, bitboard)
+	result.ApplyMoveMatch = testApplyMoveMatch(w, board, bitboard)
 
 	// Test game state functions match
 	result.IsGameFinishedMatch = testIsGameFinishedMatch(board, bitboard)
 	result.CountPiecesMatch = testCountPiecesMatch(board, bitboard)
 
 	// Test evaluation functions match
-	result.EvaluationMatch = testEvaluationMatch(board, bitboard)
+	result.EvaluationMatch = testEvaluationMatch(w, board, bitboard)
 
 	return result
 }
 
-func testValidMovesMatch(board game.Board, bitboard game.BitBoard) bool {
+func testValidMovesMatch(w io.Writer, board game.Board, bitboard game.BitBoard) bool {
 	colors := []game.Piece{game.Black, game.White}
 
 	for _, color := range colors {
 		moves := game.ValidMoves(board, color)
 		bitboardMoves := game.ValidMovesBitBoard(bitboard, color)
-		fmt.Printf("Valid moves for color %d:\nBoard: %v\nBitboard: %v\n", color, utils.PositionsToAlgebraic(moves), utils.PositionsToAlgebraic(bitboardMoves))
+		fmt.Fprintf(w, "Valid moves for color %d:\nBoard: %v\nBitboard: %v\n", color, utils.PositionsToAlgebraic(moves), utils.PositionsToAlgebraic(bitboardMoves))
 		if len(moves)+len(bitboardMoves) == 0 {
 			continue
 		}
 		// Sort moves for consistent comparison
-		sort.Slice(moves, func(i, j int) bool {
-			if moves[i].Row == moves[j].Row {
-				return moves[i].Col < moves[j].Col
-			}
-			return moves[i].Row < moves[j].Row
-		})
-
-		sort.Slice(bitboardMoves, func(i, j int) bool {
-			if bitboardMoves[i].Row == bitboardMoves[j].Row {
-				return bitboardMoves[i].Col < bitboardMoves[j].Col
-			}
-			return bitboardMoves[i].Row < bitboardMoves[j].Row
-		})
+		sortPositions(moves)
+		sortPositions(bitboardMoves)
 
 		if !reflect.DeepEqual(moves, bitboardMoves) {
-			utils.PrintBoard(board)
-			fmt.Printf("Valid moves mismatch for color %d:\nBoard: %v\nBitboard: %v\n", color, moves, bitboardMoves)
+			utils.PrintBoardWithMoves(board, moves)
+			fmt.Fprintf(w, "Valid moves mismatch for color %d:\nBoard: %v\nBitboard: %v\n", color, moves, bitboardMoves)
 			return false
 		}
 	}
 	return true
 }
 
-func testApplyMoveMatch(board game.Board, bitboard game.BitBoard) bool {
+func testApplyMoveMatch(w io.Writer, board game.Board, bitboard game.BitBoard) bool {
 	colors := []game.Piece{game.Black, game.White}
 
 	for _, color := range colors {
@@ -181,6 +223,8 @@ func testApplyMoveMatch(board game.Board, bitboard game.BitBoard) bool {
 		if success1 && success2 {
 			convertedBoard := utils.BoardToBits(newBoard)
 			if !reflect.DeepEqual(convertedBoard, newBitboard) {
+				diff, _ := utils.DiffBitBoards(convertedBoard, newBitboard)
+				fmt.Fprintf(w, "Apply move %s mismatch for color %d (board left, bitboard right):\n%s", utils.PositionToAlgebraic(move), color, diff)
 				return false
 			}
 		}
@@ -200,7 +244,7 @@ func testCountPiecesMatch(board game.Board, bitboard game.BitBoard) bool {
 	return black1 == black2 && white1 == white2
 }
 
-func testEvaluationMatch(board game.Board, bitboard game.BitBoard) bool {
+func testEvaluationMatch(w io.Writer, board game.Board, bitboard game.BitBoard) bool {
 	pec := evaluation.PrecomputeEvaluation(board)
 	pecBit := evaluation.PrecomputeEvaluationBitBoard(bitboard)
 
@@ -210,40 +254,32 @@ func testEvaluationMatch(board game.Board, bitboard game.BitBoard) bool {
 		len(pec.BlackValidMoves) != len(pecBit.BlackValidMoves) ||
 		len(pec.WhiteValidMoves) != len(pecBit.WhiteValidMoves) {
 
-		fmt.Println("Evaluation mismatch:")
-		fmt.Printf("IsGameOver: %v vs %v\n", pec.IsGameOver, pecBit.IsGameOver)
-		fmt.Printf("BlackPieces: %d vs %d\n", pec.BlackPieces, pecBit.BlackPieces)
-		fmt.Printf("WhitePieces: %d vs %d\n", pec.WhitePieces, pecBit.WhitePieces)
-		fmt.Printf("BlackValidMoves: %v vs %v\n", pec.BlackValidMoves, pecBit.BlackValidMoves)
-		fmt.Printf("WhiteValidMoves: %v vs %v\n", pec.WhiteValidMoves, pecBit.WhiteValidMoves)
+		fmt.Fprintln(w, "Evaluation mismatch:")
+		fmt.Fprintf(w, "IsGameOver: %v vs %v\n", pec.IsGameOver, pecBit.IsGameOver)
+		fmt.Fprintf(w, "BlackPieces: %d vs %d\n", pec.BlackPieces, pecBit.BlackPieces)
+		fmt.Fprintf(w, "WhitePieces: %d vs %d\n", pec.WhitePieces, pecBit.WhitePieces)
+		fmt.Fprintf(w, "BlackValidMoves: %v vs %v\n", pec.BlackValidMoves, pecBit.BlackValidMoves)
+		fmt.Fprintf(w, "WhiteValidMoves: %v vs %v\n", pec.WhiteValidMoves, pecBit.WhiteValidMoves)
 		return false
 	}
 
 	// Sort valid moves for consistent comparison
-	sort.Slice(pec.BlackValidMoves, func(i, j int) bool {
-		if pec.BlackValidMoves[i].Row == pec.BlackValidMoves[j].Row {
-			return pec.BlackValidMoves[i].Col < pec.BlackValidMoves[j].Col
-		}
-		return pec.BlackValidMoves[i].Row < pec.BlackValidMoves[j].Row
-	})
-
-	sort.Slice(pecBit.BlackValidMoves, func(i, j int) bool {
-		if pecBit.BlackValidMoves[i].Row == pecBit.BlackValidMoves[j].Row {
-			return pecBit.BlackValidMoves[i].Col < pecBit.BlackValidMoves[j].Col
-		}
-		return pecBit.BlackValidMoves[i].Row < pecBit.BlackValidMoves[j].Row
-	})
+	sortPositions(pec.BlackValidMoves)
+	sortPositions(pec.WhiteValidMoves)
+	sortPositions(pecBit.BlackValidMoves)
+	sortPositions(pecBit.WhiteValidMoves)
 
 	return (reflect.DeepEqual(pec.BlackValidMoves, pecBit.BlackValidMoves) || len(pec.BlackValidMoves)+len(pecBit.BlackValidMoves) == 0) &&
 		(reflect.DeepEqual(pec.WhiteValidMoves, pecBit.WhiteValidMoves) || len(pec.WhiteValidMoves)+len(pecBit.WhiteValidMoves) == 0)
 
 }
 
-func printSummary(results []TestResult) {
-	fmt.Println("=== SUMMARY ===")
-	fmt.Printf("%-20s | %-12s | %-12s | %-15s | %-12s | %-20s | %-15s\n",
+// printSummary prints the results of the boards and reports whether they all passed
+func printSummary(w io.Writer, results []TestResult) bool {
+	fmt.Fprintln(w, "=== SUMMARY ===")
+	fmt.Fprintf(w, "%-20s | %-12s | %-12s | %-15s | %-12s | %-20s | %-15s\n",
 		"Test Case", "ValidMoves", "ApplyMove", "IsGameFinished", "CountPieces", "BitboardConversion", "Evaluation")
-	fmt.Println(strings.Repeat("-", 115))
+	fmt.Fprintln(w, strings.Repeat("-", 115))
 
 	totalTests := len(results)
 	passCount := map[string]int{
@@ -292,12 +328,12 @@ func printSummary(results []TestResult) {
 			passCount["Evaluation"]++
 		}
 
-		fmt.Printf("%-20s | %-12s | %-12s | %-15s | %-12s | %-20s | %-15s\n",
+		fmt.Fprintf(w, "%-20s | %-12s | %-12s | %-15s | %-12s | %-20s | %-15s\n",
 			result.TestCase, validMovesStatus, applyMoveStatus, gameFinishedStatus, countPiecesStatus, conversionStatus, evaluationStatus)
 	}
 
-	fmt.Println(strings.Repeat("-", 115))
-	fmt.Printf("%-20s | %-12s | %-12s | %-15s | %-12s | %-20s | %-15s\n",
+	fmt.Fprintln(w, strings.Repeat("-", 115))
+	fmt.Fprintf(w, "%-20s | %-12s | %-12s | %-15s | %-12s | %-20s | %-15s\n",
 		"TOTALS",
 		fmt.Sprintf("%d/%d", passCount["ValidMoves"], totalTests),
 		fmt.Sprintf("%d/%d", passCount["ApplyMove"], totalTests),
@@ -305,6 +341,13 @@ func printSummary(results []TestResult) {
 		fmt.Sprintf("%d/%d", passCount["CountPieces"], totalTests),
 		fmt.Sprintf("%d/%d", passCount["BitboardConversion"], totalTests),
 		fmt.Sprintf("%d/%d", passCount["Evaluation"], totalTests))
+
+	for _, count := range passCount {
+		if count != totalTests {
+			return false
+		}
+	}
+	return true
 }
 
 // Helper functions to create test boards
@@ -368,14 +411,14 @@ func getEmptyBoard() game.Board {
 }
 
 // generateRandomBoard creates a random board state for testing
-func generateRandomBoard() game.Board {
+func generateRandomBoard(rng *rand.Rand) game.Board {
 	var board game.Board
 
 	// Random density of pieces (between 5% and 80% of the board)
 	totalCells := 64
 	minPieces := totalCells * 5 / 100  // 5%
 	maxPieces := totalCells * 80 / 100 // 80%
-	numPieces := rand.Intn(maxPieces-minPieces+1) + minPieces
+	numPieces := rng.Intn(maxPieces-minPieces+1) + minPieces
 
 	// Create a slice of all possible positions
 	positions := make([]struct{ row, col int }, 0, totalCells)
@@ -386,7 +429,7 @@ func generateRandomBoard() game.Board {
 	}
 
 	// Shuffle positions
-	rand.Shuffle(len(positions), func(i, j int) {
+	rng.Shuffle(len(positions), func(i, j int) {
 		positions[i], positions[j] = positions[j], positions[i]
 	})
 
@@ -394,7 +437,7 @@ func generateRandomBoard() game.Board {
 	for i := 0; i < numPieces; i++ {
 		pos := positions[i]
 		// Randomly choose between Black and White (roughly equal distribution)
-		if rand.Float32() < 0.5 {
+		if rng.Float32() < 0.5 {
 			board[pos.row][pos.col] = game.Black
 		} else {
 			board[pos.row][pos.col] = game.White
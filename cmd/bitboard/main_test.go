@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func parse(args ...string) (options, error) {
+	fs := flag.NewFlagSet("bitboard", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return parseFlags(fs, args)
+}
+
+func TestParseFlags(t *testing.T) {
+	opts, err := parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts != (options{mode: "match", games: 10, depth: 4, seed: 1}) {
+		t.Errorf("default options %+v", opts)
+	}
+
+	opts, err = parse("-mode", "verify", "-games", "3", "-depth", "2", "-seed", "7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts != (options{mode: "verify", games: 3, depth: 2, seed: 7}) {
+		t.Errorf("options %+v", opts)
+	}
+
+	for _, args := range [][]string{{"-mode", "fast"}, {"-games", "x"}, {"-unknown"}} {
+		if _, err := parse(args...); err == nil {
+			t.Errorf("%v: no error", args)
+		}
+	}
+}
+
+func TestRunMatch(t *testing.T) {
+	var out bytes.Buffer
+	if !runMatch(&out, rand.New(rand.NewSource(1)), 20) {
+		t.Errorf("board and bitboard functions differ:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "TOTALS") || !strings.Contains(out.String(), "21/21") {
+		t.Errorf("summary without the totals of the 21 boards:\n%s", out.String())
+	}
+}
+
+func TestPrintSummaryFailure(t *testing.T) {
+	pass := TestResult{TestCase: "pass", ValidMovesMatch: true, ApplyMoveMatch: true, IsGameFinishedMatch: true,
+		CountPiecesMatch: true, BitboardConversionMatch: true, EvaluationMatch: true}
+	fail := pass
+	fail.TestCase, fail.EvaluationMatch = "fail", false
+
+	if !printSummary(io.Discard, []TestResult{pass, pass}) {
+		t.Error("passing results reported as failing")
+	}
+	var out bytes.Buffer
+	if printSummary(&out, []TestResult{pass, fail}) {
+		t.Error("failing result reported as passing")
+	}
+	if !strings.Contains(out.String(), "1/2") {
+		t.Errorf("summary without the failed check:\n%s", out.String())
+	}
+}
+
+func TestRunVerify(t *testing.T) {
+	var out bytes.Buffer
+	if !runVerify(&out, 2, 2, 1) {
+		t.Errorf("pipelines diverge:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "All 2 games identical") {
+		t.Errorf("report:\n%s", out.String())
+	}
+}
+
+func TestVerifyPassOpenings(t *testing.T) {
+	eval := evaluation.NewMixedEvaluation(evaluation.V1Coeff)
+	for _, transcript := range passOpenings {
+		plies, passes, d := verifyGame(utils.AlgebraicToPositions(transcript), 1, eval)
+		if d != nil {
+			t.Errorf("%s: divergence at ply %d: %s", transcript, d.ply, d.what)
+			continue
+		}
+		if passes == 0 {
+			t.Errorf("%s: game of %d plies without a pass", transcript, plies)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// passOpenings lead to a pass within their first plies, so the verification plays passes
+// from the start of the game instead of only in the endgame
+var passOpenings = []string{
+	"d3c3b3b2f5a3a1c1",
+	"e6f6g6g7g8h6c3f8g5h8",
+	"c4c5e6f5g6f7b5g4g8h7h3e8",
+	"e6f6f5f4g5h6g6d6d3d2g4h4h5",
+}
+
+// verifyRandomPlies is the length of the random openings of the games not starting with a
+// pass opening
+const verifyRandomPlies = 6
+
+// divergence is the first ply at which the array-board and bitboard pipelines disagree
+type divergence struct {
+	ply      int
+	what     string
+	history  []game.Position
+	board    game.Board    // Board of the array-board pipeline
+	bitboard game.BitBoard // Board of the bitboard pipeline
+}
+
+// runVerify plays games full self-play games, each move being chosen by the search of
+// both pipelines at depth with the same evaluation, and reports whether the pipelines
+// agreed at every ply. Even games start with a pass opening, odd games with random moves
+// drawn from seed. The games are reported to w, the first divergence with the transcript
+// replaying it.
+func runVerify(w io.Writer, games int, depth int8, seed int64) bool {
+	fmt.Fprintf(w, "=== Verifying board and bitboard pipelines: %d games, depth %d, seed %d ===\n", games, depth, seed)
+	eval := evaluation.NewMixedEvaluation(evaluation.V1Coeff)
+	rng := rand.New(rand.NewSource(seed))
+
+	for i := 0; i < games; i++ {
+		var opening []game.Position
+		if i%2 == 0 {
+			opening = utils.AlgebraicToPositions(passOpenings[(i/2)%len(passOpenings)])
+		} else {
+			opening = randomOpening(rng, verifyRandomPlies)
+		}
+
+		plies, passes, d := verifyGame(opening, depth, eval)
+		if d != nil {
+			fmt.Fprintf(w, "Game %d: divergence at ply %d: %s\n", i+1, d.ply, d.what)
+			fmt.Fprintf(w, "Transcript: %s\n", utils.TranscriptToAlgebraic(d.history))
+			fmt.Fprintf(w, "Reproduce with: -mode verify -games %d -depth %d -seed %d\n", i+1, depth, seed)
+			diff, _ := utils.DiffBoards(d.board, utils.BitsToBoard(d.bitboard))
+			fmt.Fprintf(w, "Boards (board left, bitboard right):\n%s", diff)
+			return false
+		}
+		fmt.Fprintf(w, "Game %d: %d plies, %d passes, identical\n", i+1, plies, passes)
+	}
+
+	fmt.Fprintf(w, "All %d games identical\n", games)
+	return true
+}
+
+// randomOpening returns plies random moves from the initial position, fewer when the game
+// needs a pass before
+func randomOpening(rng *rand.Rand, plies int) []game.Position {
+	g := game.NewGame("Black", "White")
+	var opening []game.Position
+	for len(opening) < plies && g.LegalState() == game.HasMoves {
+		moves := game.ValidMoves(g.Board, g.CurrentPlayer.Color)
+		move := moves[rng.Intn(len(moves))]
+		g.ApplyMove(move)
+		opening = append(opening, move)
+	}
+	return opening
+}
+
+// verifyGame plays a game with both pipelines, the moves of opening first, then the moves
+// of the searches. It returns the number of plies and passes played, or the first
+// divergence.
+func verifyGame(opening []game.Position, depth int8, eval evaluation.Evaluation) (plies, passes int, d *divergence) {
+	board := game.NewGame("Black", "White").Board
+	bb := utils.BoardToBits(board)
+	player := game.Black
+	var history []game.Position
+
+	diverge := func(format string, args ...any) (int, int, *divergence) {
+		return plies, passes, &divergence{ply: plies, what: fmt.Sprintf(format, args...), history: history, board: board, bitboard: bb}
+	}
+
+	for moveIndex := 0; ; plies++ {
+		if utils.BoardToBits(board) != bb {
+			return diverge("boards differ")
+		}
+		finished, finishedBits := game.IsGameFinished(board), game.IsGameFinishedBitBoard(bb)
+		if finished != finishedBits {
+			return diverge("game finished: board %v, bitboard %v", finished, finishedBits)
+		}
+		if finished {
+			return plies, passes, nil
+		}
+
+		moves := sortPositions(game.ValidMoves(board, player))
+		movesBits := sortPositions(game.ValidMovesBitBoard(bb, player))
+		if utils.PositionsToAlgebraic(moves) != utils.PositionsToAlgebraic(movesBits) {
+			return diverge("valid moves of %d: board %s, bitboard %s", player, utils.PositionsToAlgebraic(moves), utils.PositionsToAlgebraic(movesBits))
+		}
+		if len(moves) == 0 {
+			history = append(history, game.PassMove)
+			player = game.GetOpponentColor(player)
+			passes++
+			continue
+		}
+
+		var move game.Position
+		if moveIndex < len(opening) {
+			move = opening[moveIndex]
+			moveIndex++
+		} else {
+			var score, scoreBits int16
+			var moveBits game.Position
+			move, score = boardSearch(board, player, depth, -32768, 32767, eval)
+			moveBits, scoreBits = bitboardSearch(bb, player, depth, -32768, 32767, eval)
+			if move != moveBits || score != scoreBits {
+				return diverge("search: board %s (%d), bitboard %s (%d)", utils.PositionToAlgebraic(move), score, utils.PositionToAlgebraic(moveBits), scoreBits)
+			}
+		}
+
+		next, ok := game.ApplyMoveToBoard(board, player, move)
+		nextBits, okBits := game.ApplyMoveToBitBoard(bb, player, move)
+		history = append(history, move)
+		if ok != okBits {
+			return diverge("apply %s: board %v, bitboard %v", utils.PositionToAlgebraic(move), ok, okBits)
+		}
+		if !ok {
+			return diverge("illegal move %s", utils.PositionToAlgebraic(move))
+		}
+		board, bb = next, nextBits
+		player = game.GetOpponentColor(player)
+	}
+}
+
+// boardSearch is the search of the array-board pipeline: alpha-beta on game.Board, the
+// moves in row-major order. It returns the best move of player and its score from White's
+// perspective, game.PassMove when player has no move.
+func boardSearch(board game.Board, player game.Piece, depth int8, alpha, beta int16, eval evaluation.Evaluation) (game.Position, int16) {
+	pec := evaluation.PrecomputeEvaluation(board)
+	if depth == 0 || pec.IsGameOver {
+		return game.PassMove, eval.PECEvaluate(utils.BoardToBits(board), pec)
+	}
+	moves := sortPositions(game.ValidMoves(board, player))
+	if len(moves) == 0 {
+		_, score := boardSearch(board, game.GetOpponentColor(player), depth-1, alpha, beta, eval)
+		return game.PassMove, score
+	}
+
+	var best game.Position
+	var bestScore int16
+	for i, move := range moves {
+		next, _ := game.ApplyMoveToBoard(board, player, move)
+		_, score := boardSearch(next, game.GetOpponentColor(player), depth-1, alpha, beta, eval)
+		if i == 0 || (player == game.White && score > bestScore) || (player == game.Black && score < bestScore) {
+			best, bestScore = move, score
+		}
+		if player == game.White {
+			alpha = max(alpha, score)
+		} else {
+			beta = min(beta, score)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best, bestScore
+}
+
+// bitboardSearch is boardSearch with the functions of the bitboard pipeline
+func bitboardSearch(bb game.BitBoard, player game.Piece, depth int8, alpha, beta int16, eval evaluation.Evaluation) (game.Position, int16) {
+	pec := evaluation.PrecomputeEvaluationBitBoard(bb)
+	if depth == 0 || pec.IsGameOver {
+		return game.PassMove, eval.PECEvaluate(bb, pec)
+	}
+	moves := sortPositions(game.ValidMovesBitBoard(bb, player))
+	if len(moves) == 0 {
+		_, score := bitboardSearch(bb, game.GetOpponentColor(player), depth-1, alpha, beta, eval)
+		return game.PassMove, score
+	}
+
+	var best game.Position
+	var bestScore int16
+	for i, move := range moves {
+		next, _ := game.ApplyMoveToBitBoard(bb, player, move)
+		_, score := bitboardSearch(next, game.GetOpponentColor(player), depth-1, alpha, beta, eval)
+		if i == 0 || (player == game.White && score > bestScore) || (player == game.Black && score < bestScore) {
+			best, bestScore = move, score
+		}
+		if player == game.White {
+			alpha = max(alpha, score)
+		} else {
+			beta = min(beta, score)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best, bestScore
+}
+
+// sortPositions sorts positions in row-major order, the move ordering of both pipelines
+func sortPositions(positions []game.Position) []game.Position {
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Row == positions[j].Row {
+			return positions[i].Col < positions[j].Col
+		}
+		return positions[i].Row < positions[j].Row
+	})
+	return positions
+}
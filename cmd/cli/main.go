@@ -1,96 +1,263 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"flag"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
+	"github.com/Coloc3G/othello-engine/engine"
 	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/config"
 	"github.com/Coloc3G/othello-engine/models/game"
 	"github.com/Coloc3G/othello-engine/models/opening"
 	"github.com/Coloc3G/othello-engine/models/utils"
 )
 
-// applyOpening applies a predefined opening to a game
-func applyPosition(g *game.Game, pos []game.Position) (err error) {
-	for _, move := range pos {
-		if !game.IsValidMove(g.Board, g.CurrentPlayer.Color, move) {
-			return fmt.Errorf("invalid move %s for player %s", utils.PositionToAlgebraic(move), g.CurrentPlayer.Name)
-		}
-		// Apply the move
-		g.Board, _ = game.GetNewBoardAfterMove(g.Board, move, g.CurrentPlayer.Color)
-		g.CurrentPlayer = game.GetOtherPlayer(g.CurrentPlayer.Color)
-		if !game.HasAnyMoves(g.Board, g.CurrentPlayer.Color) {
-			g.CurrentPlayer = game.GetOtherPlayer(g.CurrentPlayer.Color)
-		}
+// writeTrace exports a search tree, as JSON when the file name ends with .json and as DOT otherwise
+func writeTrace(filename string, tree *evaluation.TraceTree) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
 	}
-	return
+	defer f.Close()
+	if strings.HasSuffix(filename, ".json") {
+		return tree.WriteJSON(f)
+	}
+	return tree.WriteDOT(f)
 }
 
-func main() {
+// options are the settings of the command line
+type options struct {
+	cfg         config.Config
+	debug       bool
+	mateDepth   int
+	traceFile   string
+	traceDepth  int
+	bookBias    int
+	useBook     bool
+	bookFile    string
+	bookGames   int
+	bookWinRate float64
+	variant     game.Variant
+	heatmap     string
+	hashMB      int
+	warm        bool
+	explain     bool
+	color       engine.Color
+}
 
-	debug := flag.Bool("debug", false, "Debug mode")
-	depth := flag.Int("depth", 10, "Search depth for AI evaluation")
-	mateDepth := flag.Int("mate-depth", 21, "Mate Search depth for AI evaluation")
-	flag.Parse()
+// parseFlags parses the command line arguments, errors included, into options
+func parseFlags(fs *flag.FlagSet, args []string) (options, error) {
+	opts := options{cfg: config.Default()}
+	opts.cfg.Depth = 10
+	config.RegisterFlags(fs, &opts.cfg, config.DepthFlag|config.ModelFlag)
+	fs.BoolVar(&opts.debug, "debug", false, "Debug mode")
+	fs.IntVar(&opts.mateDepth, "mate-depth", 21, "Mate Search depth for AI evaluation")
+	fs.StringVar(&opts.traceFile, "trace", "", "Export the search tree of each position to this file (.json for JSON, Graphviz DOT otherwise)")
+	fs.IntVar(&opts.traceDepth, "trace-depth", 3, fmt.Sprintf("Plies of the search tree exported with -trace, at most %d", evaluation.MaxTraceDepth))
+	fs.IntVar(&opts.bookBias, "book-bias", 0, "Bonus of the moves staying in a known opening during the first plies, used by the search when -book=false")
+	fs.BoolVar(&opts.useBook, "book", true, "Play the moves of the opening book without search")
+	fs.StringVar(&opts.bookFile, "book-file", "", "Games of a position book, one \"transcript result\" per line, whose moves are played without search as well")
+	fs.IntVar(&opts.bookGames, "book-min-games", opening.DefaultBookMinGames, "Games a position of -book-file needs to be played")
+	fs.Float64Var(&opts.bookWinRate, "book-min-win-rate", opening.DefaultBookMinWinRate, "Win rate a position of -book-file needs to be played")
+	variantName := fs.String("variant", "standard", "Rules of the game: standard or misere (fewest discs wins)")
+	fs.StringVar(&opts.heatmap, "heatmap", "", "Write the influence of each square on the evaluation of each position to this PNG file")
+	fs.IntVar(&opts.hashMB, "hash-mb", evaluation.DefaultHashMB, "Size of the transposition table in megabytes")
+	fs.BoolVar(&opts.warm, "warm", false, "Search the positions of the known openings at startup, so the searches from them start with a populated transposition table")
+	fs.BoolVar(&opts.explain, "explain", false, "Print the evaluation breakdown of each position, for the player to move")
+	colorName := fs.String("color", "auto", "Color the engine plays: black, white, or auto to move for the player to move. With a color, the engine answers \"waiting\" when the opponent is to move and \"pass\" when it has no move")
+	if err := config.Parse(fs, args, &opts.cfg); err != nil {
+		return opts, err
+	}
 
-	evaluator := evaluation.NewMixedEvaluation(evaluation.Models[len(evaluation.Models)-1]) // Use the latest evaluation model
+	var err error
+	if opts.variant, err = game.ParseVariant(*variantName); err != nil {
+		return opts, err
+	}
+	if opts.color, err = parseColor(*colorName); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// cli answers the positions read by the command line with the moves of the engine
+type cli struct {
+	opts      options
+	coeffs    evaluation.EvaluationCoefficients
+	eng       *engine.Engine
+	evaluator evaluation.Evaluation
+}
 
+// newCLI creates the engine and the evaluation of the options
+func newCLI(opts options) (*cli, error) {
+	coeffs, err := opts.cfg.Coefficients()
+	if err != nil {
+		return nil, err
+	}
+	var book *opening.Book
+	if opts.bookFile != "" {
+		if book, err = opening.LoadBook(opts.bookFile); err != nil {
+			return nil, err
+		}
+	}
+	eng := engine.New(
+		engine.WithModel(opts.cfg.Model),
+		engine.WithDepth(opts.cfg.Depth),
+		engine.WithEndgameDepth(opts.mateDepth),
+		engine.WithBook(opts.useBook),
+		engine.WithBookBias(opts.bookBias),
+		engine.WithMisere(opts.variant == game.Misere),
+		engine.WithPositionBook(book, opts.bookGames, opts.bookWinRate),
+		engine.WithHashMB(opts.hashMB),
+		engine.WithDeepeningStats(opts.debug))
+	if opts.warm {
+		eng.WarmCache(int(evaluation.DefaultWarmDepth))
+	}
+	return &cli{
+		opts:      opts,
+		coeffs:    coeffs,
+		eng:       eng,
+		evaluator: evaluation.ForVariant(evaluation.NewMixedEvaluation(coeffs), opts.variant),
+	}, nil
+}
+
+// run answers each transcript read from in until its end, prompting for it on out
+func (c *cli) run(in io.Reader, out io.Writer) {
+	scanner := bufio.NewScanner(in)
 	for {
-		algebraicPosition := ""
+		fmt.Fprint(out, "Board > ")
+		if !scanner.Scan() {
+			return
+		}
+		c.answer(strings.ToLower(strings.TrimSpace(scanner.Text())), out)
+	}
+}
 
-		fmt.Print("Board > ")
-		fmt.Scanln(&algebraicPosition)
-		algebraicPosition = strings.ToLower(algebraicPosition)
+// answer writes the move of the engine after transcript, preceded by the debugging
+// output of the options
+func (c *cli) answer(transcript string, out io.Writer) {
+	if err := c.eng.SetPosition(transcript); err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+	if c.opts.color != engine.None && !c.eng.GameOver() && c.eng.ToMove() != c.opts.color {
+		fmt.Fprintln(out, opponentTurn(transcript, c.opts.color))
+		return
+	}
 
+	if c.opts.explain {
 		g := game.NewGame("Black", "White")
-		pos := utils.AlgebraicToPositions(algebraicPosition)
-		err := applyPosition(g, pos)
-		if err != nil {
-			fmt.Println(err)
-			continue
+		utils.ApplyTranscript(g, transcript)
+		evaluation.DebugEvaluateCPU(g.Board, g.CurrentPlayer.Color, c.coeffs).WriteTable(out)
+	}
+
+	if c.opts.heatmap != "" {
+		if err := writeHeatmap(c.opts.heatmap, c.eng); err != nil {
+			fmt.Fprintln(out, err)
 		}
+	}
 
-		var move game.Position
-		found := false
-		var o opening.Opening
-		if openings := opening.MatchOpening(algebraicPosition); len(openings) > 0 {
-			best := opening.Opening{}
-			for _, opening := range openings {
-				if len(opening.Transcript) > len(best.Transcript) {
-					best = opening
-				}
+	move, analysis, err := c.eng.BestMove(context.Background())
+	if err != nil {
+		fmt.Fprintln(out, "No valid moves found")
+		return
+	}
+	// The search tree is exported from a second search, the engine not recording it
+	if c.opts.traceFile != "" && !analysis.Book {
+		g := game.NewGame("Black", "White")
+		g.Variant = c.opts.variant
+		if _, err := utils.ApplyTranscript(g, transcript); err == nil {
+			_, _, tree := evaluation.SolveWithTrace(g.Board, g.CurrentPlayer.Color, int8(analysis.Depth), c.evaluator, evaluation.TraceOptions{MaxDepth: c.opts.traceDepth})
+			if err := writeTrace(c.opts.traceFile, tree); err != nil {
+				fmt.Fprintln(out, err)
 			}
+		}
+	}
 
-			if len(best.Transcript) > len(algebraicPosition) {
-				found = true
-				nextMove := utils.AlgebraicToPosition(best.Transcript[len(algebraicPosition) : len(algebraicPosition)+2])
-				move = nextMove
-				o = best
+	if c.opts.debug {
+		if analysis.Opening != "" {
+			fmt.Fprintf(out, "Opening found: %s\n", analysis.Opening)
+		} else if analysis.Book {
+			fmt.Fprintln(out, "Book move")
+		} else {
+			score := engine.ScoreFromPerspective(analysis.Score, c.eng.ToMove())
+			fmt.Fprintf(out, "Depth %d (%d move) ; Score %d ; Continuation %s\n", analysis.Depth, len(transcript)/2, score, joinMoves(analysis.PV))
+			if analysis.Deepening != nil {
+				fmt.Fprintf(out, "Move ordering stable: %.0f%% of depths\n", analysis.Deepening.Stability()*100)
 			}
-
 		}
-		if !found {
+	}
 
-			var searchDepth = int8(*depth)
-			if len(pos) >= 64-*mateDepth {
-				searchDepth = int8(*mateDepth)
-			}
+	fmt.Fprintln(out, move)
+}
 
-			moves, score := evaluation.Solve(g.Board, g.CurrentPlayer.Color, searchDepth, evaluator)
-			if len(moves) == 0 || (len(moves) == 1 && moves[0].Row == -1 && moves[0].Col == -1) {
-				fmt.Println("No valid moves found")
-				continue
-			}
-			move = moves[0]
-			if *debug {
-				fmt.Printf("Depth %d (%d move) ; Score %d ; Continuation %s\n", searchDepth, len(pos), score, utils.PositionsToAlgebraic(moves))
-			}
-		} else if *debug {
-			fmt.Printf("Opening found: %s\n", o.Name)
+func main() {
+	opts, err := parseFlags(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	c, err := newCLI(opts)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	c.run(os.Stdin, os.Stdout)
+}
+
+// parseColor parses the -color flag, None standing for auto
+func parseColor(s string) (engine.Color, error) {
+	switch strings.ToLower(s) {
+	case "black":
+		return engine.Black, nil
+	case "white":
+		return engine.White, nil
+	case "auto":
+		return engine.None, nil
+	}
+	return engine.None, fmt.Errorf("invalid color %q, want black, white or auto", s)
+}
 
+// opponentTurn is the answer of the engine playing color when the opponent is to move
+// after transcript: "pass" when the opponent just moved and color has no move, "waiting"
+// when the transcript does not end on a move of the opponent
+func opponentTurn(transcript string, color engine.Color) string {
+	piece := game.White
+	if color == engine.Black {
+		piece = game.Black
+	}
+	history, err := utils.HistoryFromTranscript(transcript)
+	if err == nil && len(history) > 0 {
+		last := history[len(history)-1]
+		if last.Pass && last.Player == piece {
+			return "pass"
 		}
+	}
+	return "waiting"
+}
+
+// writeHeatmap writes the square influence of the position of the engine as a PNG
+func writeHeatmap(filename string, eng *engine.Engine) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := eng.WriteHeatmap(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
 
-		fmt.Println(utils.PositionToAlgebraic(move))
+// joinMoves writes moves as a transcript
+func joinMoves(moves []engine.Move) string {
+	var sb strings.Builder
+	for _, move := range moves {
+		sb.WriteString(string(move))
 	}
+	return sb.String()
 }
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/engine"
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// parse parses args as the command line, without a config file in the home directory
+func parse(t *testing.T, args ...string) (options, error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	fs := flag.NewFlagSet("cli", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return parseFlags(fs, args)
+}
+
+// newTestCLI returns a cli searching shallowly, with the options of args
+func newTestCLI(t *testing.T, args ...string) *cli {
+	t.Helper()
+	opts, err := parse(t, append([]string{"-depth", "2", "-mate-depth", "4", "-hash-mb", "1"}, args...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := newCLI(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// answer returns the lines written by the cli after transcript
+func answer(c *cli, transcript string) []string {
+	var out bytes.Buffer
+	c.answer(transcript, &out)
+	return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+}
+
+// passTranscript returns a random transcript after which the player to move must pass
+func passTranscript(t *testing.T, passer game.Piece) string {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	for range 1000 {
+		g := game.NewGame("Black", "White")
+		for g.LegalState() == game.HasMoves {
+			moves := g.GetValidMovesForCurrentPlayer()
+			if err := g.ApplyMove(moves[rng.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if g.LegalState() == game.MustPass && g.CurrentPlayer.Color == passer {
+			return utils.TranscriptToAlgebraic(g.History)
+		}
+	}
+	t.Fatal("no random game needed a pass")
+	return ""
+}
+
+func TestParseFlags(t *testing.T) {
+	opts, err := parse(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.cfg.Depth != 10 || opts.color != engine.None || opts.variant != game.Standard || !opts.useBook || opts.mateDepth != 21 {
+		t.Errorf("defaults: depth %d, color %v, variant %v, book %v, mate depth %d",
+			opts.cfg.Depth, opts.color, opts.variant, opts.useBook, opts.mateDepth)
+	}
+
+	opts, err = parse(t, "-color", "White", "-variant", "misere", "-book=false", "-depth", "4", "-debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.color != engine.White || opts.variant != game.Misere || opts.useBook || opts.cfg.Depth != 4 || !opts.debug {
+		t.Errorf("flags: color %v, variant %v, book %v, depth %d, debug %v",
+			opts.color, opts.variant, opts.useBook, opts.cfg.Depth, opts.debug)
+	}
+
+	for _, args := range [][]string{
+		{"-color", "red"},
+		{"-variant", "reversi"},
+		{"-unknown"},
+	} {
+		if _, err := parse(t, args...); err == nil {
+			t.Errorf("%v: no error", args)
+		}
+	}
+}
+
+func TestAnswerColor(t *testing.T) {
+	whitePass := passTranscript(t, game.White)
+	tests := []struct {
+		name       string
+		color      string
+		transcript string
+		want       string // Empty for a move of the engine
+	}{
+		{"auto moves for black", "auto", "", ""},
+		{"auto moves for white", "auto", "f5", ""},
+		{"black to move", "black", "", ""},
+		{"white waits for black", "white", "", "waiting"},
+		{"white to move", "white", "f5", ""},
+		{"black waits for white", "black", "f5", "waiting"},
+		{"white passes", "white", whitePass, "pass"},
+	}
+	for _, tt := range tests {
+		c := newTestCLI(t, "-color", tt.color)
+		lines := answer(c, tt.transcript)
+		got := lines[len(lines)-1]
+		if tt.want != "" {
+			if got != tt.want {
+				t.Errorf("%s: answered %q, want %q", tt.name, got, tt.want)
+			}
+			continue
+		}
+		legal := c.eng.LegalMoves()
+		if !slices.Contains(legal, engine.Move(got)) {
+			t.Errorf("%s: answered %q, not a legal move of %v", tt.name, got, legal)
+		}
+	}
+}
+
+func TestAnswerDebug(t *testing.T) {
+	c := newTestCLI(t, "-debug", "-book=false")
+	lines := answer(c, "f5d6")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "Depth ") || !strings.Contains(lines[0], "Continuation ") {
+		t.Fatalf("debug output %q, want the depth, score and continuation before the move", lines)
+	}
+	if !strings.HasPrefix(lines[0], "Depth 2 (2 move)") {
+		t.Errorf("debug line %q, want depth 2 after 2 moves", lines[0])
+	}
+
+	// A book move is reported as such
+	c = newTestCLI(t, "-debug")
+	if lines := answer(c, "c4"); !strings.HasPrefix(lines[0], "Opening found: ") {
+		t.Errorf("book answer %q, want the opening found", lines)
+	}
+}
+
+func TestAnswerInvalidTranscript(t *testing.T) {
+	c := newTestCLI(t)
+	for _, transcript := range []string{"f5f5", "f", "a1"} {
+		if lines := answer(c, transcript); len(lines) != 1 || strings.HasPrefix(lines[0], "Board") {
+			t.Errorf("%q: answered %q, want an error", transcript, lines)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	c := newTestCLI(t, "-color", "black")
+	var out bytes.Buffer
+	c.run(strings.NewReader("\nF5\n"), &out)
+	lines := strings.Split(out.String(), "Board > ")
+	// One prompt per line and one left unanswered at the end of the input
+	if len(lines) != 4 || lines[3] != "" {
+		t.Fatalf("output %q, want an answer to each of the 2 lines", out.String())
+	}
+	if move := strings.TrimSpace(lines[1]); !slices.Contains([]string{"d3", "c4", "f5", "e6"}, move) {
+		t.Errorf("first answer %q, want an opening move", move)
+	}
+	if answer := strings.TrimSpace(lines[2]); answer != "waiting" {
+		t.Errorf("answer to the upper case transcript %q, want waiting", answer)
+	}
+}
+
+func TestAnswerExplain(t *testing.T) {
+	c := newTestCLI(t, "-explain", "-book=false")
+	lines := answer(c, "f5")
+	if len(lines) < 4 || lines[0] != "Evaluation for white, phase 0" {
+		t.Fatalf("output %q, want the breakdown for white first", lines)
+	}
+	g := game.NewGame("Black", "White")
+	utils.ApplyTranscript(g, "f5")
+	want := evaluation.DebugEvaluateCPU(g.Board, game.White, c.coeffs)
+	if total := strings.Fields(lines[len(lines)-2]); len(total) != 2 || total[0] != "total" || total[1] != fmt.Sprint(want.Score) {
+		t.Errorf("total line %q, want the score %d", lines[len(lines)-2], want.Score)
+	}
+	if move := lines[len(lines)-1]; !slices.Contains([]string{"d6", "f6", "f4"}, move) {
+		t.Errorf("move %q after the breakdown, want a legal move", move)
+	}
+}
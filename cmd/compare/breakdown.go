@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+// tournamentResult is the record of a model over a set of games
+type tournamentResult struct {
+	Wins    int
+	Losses  int
+	Draws   int
+	Aborted int
+}
+
+// openingBreakdown returns the record of model, resultModel1 or resultModel2, for each
+// opening from the results of playMatches: games 2*i and 2*i+1 are played from opening.KNOWN_OPENINGS[i]
+func openingBreakdown(results []int, model int) map[string]tournamentResult {
+	breakdown := make(map[string]tournamentResult)
+	for i, result := range results {
+		name := opening.KNOWN_OPENINGS[i/2].Name
+		r := breakdown[name]
+		switch result {
+		case resultDraw:
+			r.Draws++
+		case resultAborted:
+			r.Aborted++
+		case model:
+			r.Wins++
+		default:
+			r.Losses++
+		}
+		breakdown[name] = r
+	}
+	return breakdown
+}
+
+// printWorstOpenings prints the count openings where the model lost the most games
+func printWorstOpenings(w io.Writer, breakdown map[string]tournamentResult, count int) {
+	names := make([]string, 0, len(breakdown))
+	for name, r := range breakdown {
+		if r.Losses > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := breakdown[names[i]], breakdown[names[j]]
+		if a.Losses != b.Losses {
+			return a.Losses > b.Losses
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > count {
+		names = names[:count]
+	}
+	for _, name := range names {
+		r := breakdown[name]
+		fmt.Fprintf(w, "  %s: %d losses, %d wins, %d draws\n", name, r.Losses, r.Wins, r.Draws)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+func TestOpeningBreakdown(t *testing.T) {
+	// Two games per opening, on the first four openings
+	results := []int{
+		resultModel1, resultModel1,
+		resultModel2, resultModel1,
+		resultDraw, resultModel2,
+		resultAborted, resultModel2,
+	}
+	names := make([]string, 4)
+	for i := range names {
+		names[i] = opening.KNOWN_OPENINGS[i].Name
+	}
+
+	for _, model := range []int{resultModel1, resultModel2} {
+		breakdown := openingBreakdown(results, model)
+		if len(breakdown) != len(names) {
+			t.Fatalf("model %d: %d openings, want %d", model, len(breakdown), len(names))
+		}
+		// The breakdown adds up to the record of the model
+		var sum tournamentResult
+		for _, r := range breakdown {
+			sum.Wins += r.Wins
+			sum.Losses += r.Losses
+			sum.Draws += r.Draws
+			sum.Aborted += r.Aborted
+		}
+		model1Wins, model2Wins, draws, aborted := tally(results)
+		wins, losses := model1Wins, model2Wins
+		if model == resultModel2 {
+			wins, losses = losses, wins
+		}
+		if want := (tournamentResult{wins, losses, draws, aborted}); sum != want {
+			t.Errorf("model %d: breakdown adds up to %+v, want %+v", model, sum, want)
+		}
+	}
+
+	breakdown := openingBreakdown(results, resultModel2)
+	want := map[string]tournamentResult{
+		names[0]: {Losses: 2},
+		names[1]: {Wins: 1, Losses: 1},
+		names[2]: {Wins: 1, Draws: 1},
+		names[3]: {Wins: 1, Aborted: 1},
+	}
+	for name, r := range want {
+		if breakdown[name] != r {
+			t.Errorf("%s: %+v, want %+v", name, breakdown[name], r)
+		}
+	}
+}
+
+func TestPrintWorstOpenings(t *testing.T) {
+	breakdown := map[string]tournamentResult{
+		"b": {Losses: 1, Wins: 1},
+		"a": {Losses: 1},
+		"c": {Losses: 2},
+		"d": {Wins: 2},
+	}
+	var out bytes.Buffer
+	printWorstOpenings(&out, breakdown, 2)
+	want := "  c: 2 losses, 0 wins, 0 draws\n  a: 1 losses, 0 wins, 0 draws\n"
+	if out.String() != want {
+		t.Errorf("printed %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	printWorstOpenings(&out, breakdown, 10)
+	if lines := strings.Count(out.String(), "\n"); lines != 3 {
+		t.Errorf("printed %d openings, want the 3 with a loss:\n%s", lines, out.String())
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// commentary is the replay of a historical game with a model taking over the side that lost
+type commentary struct {
+	Side      game.Piece // Side taken over by the model
+	Diverged  int        // Ply where the model first chose another move, -1 when it agreed with the whole game
+	Winner    game.Piece
+	Aborted   game.AbortReason
+	Forfeited bool
+}
+
+// result describes the outcome for the side the model played
+func (c commentary) result() string {
+	switch {
+	case c.Aborted != game.NotAborted:
+		return "aborted (" + c.Aborted.String() + ")"
+	case c.Forfeited:
+		return "forfeit"
+	case c.Winner == c.Side:
+		return "win"
+	case c.Winner == game.Empty:
+		return "draw"
+	default:
+		return "loss"
+	}
+}
+
+// commentGame replays a game with model taking over side from the first move it disagrees with.
+// From there the game is played to the end between model and other.
+func commentGame(model, other *Model, moves []game.Position, side game.Piece, variant game.Variant, retry bool) commentary {
+	c := commentary{Side: side, Diverged: -1}
+	g := game.NewGame("Black", "White")
+	g.Variant = variant
+	for ply, move := range moves {
+		if g.CurrentPlayer.Color == side {
+			raw, err := model.getNextMove(utils.TranscriptToAlgebraic(g.History))
+			if err != nil || utils.AlgebraicToPosition(strings.TrimSpace(raw)) != move {
+				c.Diverged = ply
+				break
+			}
+		}
+		if _, err := utils.ApplyTranscript(g, utils.PositionToAlgebraic(move)); err != nil {
+			// Checked by the caller, the transcript replays
+			break
+		}
+	}
+	if c.Diverged < 0 {
+		c.Winner = g.GetWinnerMethod()
+		return c
+	}
+
+	black, white := model, other
+	if side == game.White {
+		black, white = other, model
+	}
+	var ff *forfeit
+	c.Winner, c.Aborted, _, ff = playMatch(black, white, utils.TranscriptToAlgebraic(moves[:c.Diverged]), variant, retry)
+	c.Forfeited = ff != nil
+	return c
+}
+
+// commentPGN replays the games of a PGN file with each model taking over the side that lost,
+// black for draws, and prints how each model would have done
+func commentPGN(filename, model1, model2 string, variant game.Variant, retry bool) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	records, err := utils.ParseOthelloPGN(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+
+	type gameCommentary struct {
+		original game.Piece
+		ok       bool
+		models   [2]commentary
+	}
+	commentaries := make([]gameCommentary, len(records))
+	var wg sync.WaitGroup
+	for i, rec := range records {
+		g, err := replayMoves(utils.TranscriptToAlgebraic(rec.Moves))
+		if err != nil {
+			fmt.Printf("❌ Game %d does not replay: %v\n", i+1, err)
+			continue
+		}
+		g.Variant = variant
+		original := g.GetWinnerMethod()
+		side := game.Black
+		if original == game.Black {
+			side = game.White
+		}
+
+		wg.Add(1)
+		go func(i int, moves []game.Position) {
+			defer wg.Done()
+			m1, m2, err := createModels(model1, model2)
+			if err != nil {
+				println("❌ Failed to create models for game", i+1, ":", err.Error())
+				return
+			}
+			commentaries[i] = gameCommentary{
+				original: original,
+				ok:       true,
+				models: [2]commentary{
+					commentGame(m1, m2, moves, side, variant, retry),
+					commentGame(m2, m1, moves, side, variant, retry),
+				},
+			}
+			m1.stop()
+			m2.stop()
+		}(i, rec.Moves)
+	}
+	wg.Wait()
+
+	// Outcomes of each model over the games, by result
+	var totals [2]map[string]int
+	totals[0], totals[1] = map[string]int{}, map[string]int{}
+	games := 0
+	fmt.Printf("%-6s %-10s %-8s %-6s %-24s %-24s\n", "Game", "Round", "Original", "Side", "Model 1", "Model 2")
+	for i, c := range commentaries {
+		if !c.ok {
+			continue
+		}
+		games++
+		cells := [2]string{}
+		for m, mc := range c.models {
+			totals[m][mc.result()]++
+			cells[m] = mc.result()
+			if mc.Diverged >= 0 {
+				cells[m] += fmt.Sprintf(" (from ply %d)", mc.Diverged+1)
+			}
+		}
+		fmt.Printf("%-6d %-10s %-8s %-6s %-24s %-24s\n",
+			i+1, records[i].Meta.Round, colorName(c.original), colorName(c.models[0].Side), cells[0], cells[1])
+	}
+
+	fmt.Printf("\nSummary over %d games, each model playing the side that lost (black on draws)\n", games)
+	fmt.Printf("%-8s %6s %6s %6s %8s %8s\n", "Model", "Wins", "Draws", "Losses", "Forfeit", "Aborted")
+	for m, name := range []string{model1, model2} {
+		aborted := 0
+		for result, n := range totals[m] {
+			if strings.HasPrefix(result, "aborted") {
+				aborted += n
+			}
+		}
+		fmt.Printf("%-8s %6d %6d %6d %8d %8d  %s\n", fmt.Sprintf("Model %d", m+1),
+			totals[m]["win"], totals[m]["draw"], totals[m]["loss"], totals[m]["forfeit"], aborted, name)
+	}
+	return nil
+}
@@ -4,23 +4,39 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
 	"sync"
 
+	"github.com/Coloc3G/othello-engine/models/config"
 	"github.com/Coloc3G/othello-engine/models/game"
 	"github.com/Coloc3G/othello-engine/models/opening"
 	"github.com/Coloc3G/othello-engine/models/utils"
 )
 
+// Model is an engine answering moves on its standard input and output, see cmd/cli
 type Model struct {
+	name   string // Executable path
 	cmd    *exec.Cmd
 	stdin  io.WriteCloser
 	stdout io.ReadCloser
 	stderr io.ReadCloser
 }
 
+// modelFactory starts the engines playing black and white
+type modelFactory func(black, white string) (*Model, *Model, error)
+
+// stop asks the engine to exit and kills its process
+func (m *Model) stop() error {
+	m.sendLine("exit")
+	if m.cmd == nil || m.cmd.Process == nil {
+		return nil
+	}
+	return m.cmd.Process.Kill()
+}
+
 func (m *Model) recvUntil(delim []byte) ([]byte, error) {
 	var buffer []byte
 	buf := make([]byte, 1)
@@ -68,39 +84,55 @@ func (m *Model) getNextMove(board string) (string, error) {
 		return "", err
 	}
 
-	// Receive the next move
+	// Receive the next move, the raw line is kept for the forfeit records
 	move, err := m.recvLine()
 	if err != nil {
 		println("❌ Failed to receive move from model:", err.Error())
-		return "", err
+		return move, err
 	}
 
-	return strings.TrimSpace(move), nil
+	return move, nil
 }
 
-// applyOpening applies a predefined opening to a game
-func applyPosition(g *game.Game, pos []game.Position) (err error) {
-	for _, move := range pos {
-		if !game.IsValidMove(g.Board, g.CurrentPlayer.Color, move) {
-			return fmt.Errorf("invalid move %s for player %s", utils.PositionToAlgebraic(move), g.CurrentPlayer.Name)
-		}
-		// Apply the move
-		g.ApplyMove(move)
-		if !game.HasAnyMoves(g.Board, g.CurrentPlayer.Color) {
-			g.CurrentPlayer = game.GetOtherPlayer(g.CurrentPlayer.Color)
-		}
-	}
-	return
+// forfeit is the context of a game lost by an engine that failed to answer or
+// answered an illegal move
+type forfeit struct {
+	Engine     string `json:"engine"`
+	Transcript string `json:"transcript"` // Moves sent to the engine
+	Raw        string `json:"raw"`        // Line received, empty when nothing was read
+	Move       string `json:"move"`       // Position parsed from the line
+	Board      string `json:"board"`      // utils.BoardToString of the position
+	Error      string `json:"error"`
+	Retried    bool   `json:"retried,omitempty"` // The engine was asked again before forfeiting
 }
 
-func playMatch(model1, model2 *Model, open []game.Position) game.Piece {
+// playMatch returns the winner color, or the reason the watchdog stopped the game, and the game moves.
+// When an engine fails to answer or answers an illegal move, it loses the game and the context
+// is returned as a forfeit. With retry, an engine answering an illegal move is asked once more.
+func playMatch(model1, model2 *Model, open string, variant game.Variant, retry bool) (game.Piece, game.AbortReason, []game.Position, *forfeit) {
 	g := game.NewGame("Model 1", "Model 2")
-	if err := applyPosition(g, open); err != nil {
+	g.Variant = variant
+	if _, err := utils.ApplyTranscript(g, open); err != nil {
 		println("❌ Failed to apply opening:", err.Error())
-		return 0
+		return 0, game.NotAborted, g.History, nil
 	}
 
-	for !game.IsGameFinished(g.Board) {
+	watchdog := game.NewWatchdog()
+	for {
+		if watchdog.RecordGame(g) {
+			println("❌ Game aborted:", watchdog.Reason.String(), "path:", utils.TranscriptToAlgebraic(g.History))
+			return game.Empty, watchdog.Reason, g.History, nil
+		}
+
+		state := g.LegalState()
+		if state == game.GameOver {
+			break
+		}
+		if state == game.MustPass {
+			g.Pass()
+			continue
+		}
+
 		var currentModel *Model
 		if g.CurrentPlayer.Color == game.Black {
 			currentModel = model1
@@ -108,27 +140,64 @@ func playMatch(model1, model2 *Model, open []game.Position) game.Piece {
 			currentModel = model2
 		}
 		// Model player's turn
-		if len(game.ValidMoves(g.Board, g.CurrentPlayer.Color)) > 0 {
-			move, err := currentModel.getNextMove(utils.PositionsToAlgebraic(g.History))
+		transcript := utils.TranscriptToAlgebraic(g.History)
+		ff := &forfeit{Engine: currentModel.name, Transcript: transcript, Board: utils.BoardToString(g.Board)}
+		for {
+			raw, err := currentModel.getNextMove(transcript)
+			ff.Raw = raw
 			if err != nil {
-				println("❌ Failed to get move from model :", err.Error(), utils.PositionsToAlgebraic(g.History))
-				return g.GetOtherPlayerMethod().Color
+				println("❌ Failed to get move from model :", err.Error(), transcript)
+				ff.Error = err.Error()
+				return g.GetOtherPlayerMethod().Color, game.NotAborted, g.History, ff
 			}
+			move := strings.TrimSpace(raw)
 			pos := utils.AlgebraicToPosition(move)
-			ok := g.ApplyMove(pos)
-			if !ok {
-				println("❌ Invalid move received from model:", move, "(", currentModel.cmd.Path, ")", "path:", utils.PositionsToAlgebraic(g.History), "color:", g.CurrentPlayer.Color)
-				return g.GetOtherPlayerMethod().Color
+			ff.Move = utils.PositionToAlgebraic(pos)
+			err = g.ApplyMove(pos)
+			if err == nil {
+				break
 			}
-		} else {
-			g.CurrentPlayer = g.GetOtherPlayerMethod()
+			println("❌ Invalid move received from model:", fmt.Sprintf("%q", raw), "(", currentModel.name, ")", "path:", transcript, "color:", g.CurrentPlayer.Color, "error:", err.Error())
+			println(utils.BoardToString(g.Board), "legal moves:", utils.PositionsToAlgebraic(game.ValidMoves(g.Board, g.CurrentPlayer.Color)))
+			ff.Error = err.Error()
+			if !retry || ff.Retried {
+				return g.GetOtherPlayerMethod().Color, game.NotAborted, g.History, ff
+			}
+			ff.Retried = true
+			println("Asking the model again")
 		}
-
 	}
 
 	// Determine winner
 	winner := g.GetWinnerMethod()
-	return winner
+	return winner, game.NotAborted, g.History, nil
+}
+
+// pgnResult converts the outcome of playMatch to a utils game result
+func pgnResult(winner game.Piece, aborted game.AbortReason) int {
+	switch {
+	case aborted != game.NotAborted:
+		return utils.ResultUnfinished
+	case winner == game.Black:
+		return utils.ResultBlackWins
+	case winner == game.White:
+		return utils.ResultWhiteWins
+	default:
+		return utils.ResultDraw
+	}
+}
+
+// writePGN writes the game records to a file, skipping games that were not played
+func writePGN(filename string, records []string) error {
+	var sb strings.Builder
+	for _, record := range records {
+		if record == "" {
+			continue
+		}
+		sb.WriteString(record)
+		sb.WriteString("\n")
+	}
+	return os.WriteFile(filename, []byte(sb.String()), 0644)
 }
 
 func createModels(model1Path, model2Path string) (*Model, *Model, error) {
@@ -151,6 +220,7 @@ func createModels(model1Path, model2Path string) (*Model, *Model, error) {
 	}
 
 	model1Instance := &Model{
+		name:   model1Path,
 		cmd:    exec1,
 		stdin:  stdin1,
 		stdout: stdout1,
@@ -181,6 +251,7 @@ func createModels(model1Path, model2Path string) (*Model, *Model, error) {
 	}
 
 	model2Instance := &Model{
+		name:   model2Path,
 		cmd:    exec2,
 		stdin:  stdin2,
 		stdout: stdout2,
@@ -195,100 +266,220 @@ func createModels(model1Path, model2Path string) (*Model, *Model, error) {
 	return model1Instance, model2Instance, nil
 }
 
-func main() {
-	// Parse command-line flags
-	model1 := flag.String("model1", "", "CLI Executable path to first model")
-	model2 := flag.String("model2", "", "CLI Executable path to second model")
-	numMatches := flag.Int("matches", 100, "Number of matches to play between models (2 games per match)")
-	threads := flag.Int("threads", runtime.NumCPU(), "Number of threads to use")
-	flag.Parse()
+// options are the settings of the command line
+type options struct {
+	cfg           config.Config
+	model1        string
+	model2        string
+	matches       int
+	exportPGN     string
+	recordFile    string
+	replayFile    string
+	replayEngines bool
+	retryIllegal  bool
+	worstOpenings int
+	pgnInput      string
+	variant       game.Variant
+}
 
-	*numMatches = min(*numMatches, len(opening.KNOWN_OPENINGS))
+// parseFlags parses the command line arguments, errors included, into options
+func parseFlags(fs *flag.FlagSet, args []string) (options, error) {
+	opts := options{cfg: config.Default()}
+	config.RegisterFlags(fs, &opts.cfg, config.ThreadsFlag)
+	fs.StringVar(&opts.model1, "model1", "", "CLI Executable path to first model")
+	fs.StringVar(&opts.model2, "model2", "", "CLI Executable path to second model")
+	fs.IntVar(&opts.matches, "matches", 100, "Number of matches to play between models (2 games per match)")
+	fs.StringVar(&opts.exportPGN, "export-pgn", "", "Save the games to this file in a PGN-like format")
+	fs.StringVar(&opts.recordFile, "record", "", "Save the games to this file so they can be replayed")
+	fs.StringVar(&opts.replayFile, "replay", "", "Print the games recorded in this file instead of playing")
+	fs.BoolVar(&opts.replayEngines, "replay-engines", false, "With -replay, play each recorded game again with its engines")
+	fs.BoolVar(&opts.retryIllegal, "retry-illegal", false, "Ask an engine once more before forfeiting on an illegal move")
+	fs.IntVar(&opts.worstOpenings, "worst-openings", 5, "Print the openings the winning model lost the most (0 = disabled)")
+	fs.StringVar(&opts.pgnInput, "pgn-input", "", "Replay the games of this PGN file with each model taking over the losing side instead of playing")
+	variantName := fs.String("variant", "standard", "Rules deciding the winner: standard or misere (fewest discs wins)")
+	if err := config.Parse(fs, args, &opts.cfg); err != nil {
+		return opts, err
+	}
 
-	// Set max parallelism
-	runtime.GOMAXPROCS(*threads)
+	var err error
+	if opts.variant, err = game.ParseVariant(*variantName); err != nil {
+		return opts, err
+	}
+	opts.matches = min(opts.matches, len(opening.KNOWN_OPENINGS))
+	return opts, nil
+}
 
-	println("Running with", *threads, "threads")
+// Results of the games of a comparison, from the point of view of the models
+const (
+	resultDraw = iota
+	resultModel1
+	resultModel2
+	resultAborted
+)
 
-	test1, test2, err := createModels(*model1, *model2)
-	if err != nil {
-		println("❌ Failed to create models:", err.Error())
-		return
+// matchOutcome returns the result of a game where black is model 1 when model1Black is
+// true, the model 2 otherwise
+func matchOutcome(winner game.Piece, aborted game.AbortReason, model1Black bool) int {
+	switch {
+	case aborted != game.NotAborted:
+		return resultAborted
+	case winner == game.Empty:
+		return resultDraw
+	case (winner == game.Black) == model1Black:
+		return resultModel1
+	default:
+		return resultModel2
 	}
+}
 
-	test1.sendLine("exit")
-	test2.sendLine("exit")
-
-	test1.cmd.Process.Kill()
-	test2.cmd.Process.Kill()
+// comparison holds the games of the matches, games 2*i and 2*i+1 being played from
+// opening.KNOWN_OPENINGS[i], model 1 playing black in the first
+type comparison struct {
+	results []int // resultDraw, resultModel1, resultModel2 or resultAborted
+	pgn     []string
+	records []matchRecord
+}
 
-	println("Models initialized successfully")
-	println("Starting game comparison...")
+// playMatches plays opts.matches matches at once, each with its own engines.
+// The games of a match whose engines do not start are left empty.
+func playMatches(start modelFactory, opts options) comparison {
 	var wg sync.WaitGroup
-	results := make([]int, *numMatches*2) // 0: draw, 1: model1 wins, 2: model2 wins
-	var lock sync.Mutex
+	c := comparison{
+		results: make([]int, opts.matches*2),
+		pgn:     make([]string, opts.matches*2),
+		records: make([]matchRecord, opts.matches*2),
+	}
 
-	for i := 0; i < *numMatches; i++ {
+	for i := 0; i < opts.matches; i++ {
 		wg.Add(1)
 		go func(gameNum int) {
 			defer wg.Done()
 
-			model1Instance, model2Instance, err := createModels(*model1, *model2)
+			model1Instance, model2Instance, err := start(opts.model1, opts.model2)
 			if err != nil {
 				println("❌ Failed to create models for game", gameNum, ":", err.Error())
 				return
 			}
 
-			open := utils.AlgebraicToPositions(opening.KNOWN_OPENINGS[gameNum].Transcript)
-
-			tmp := playMatch(model1Instance, model2Instance, open)
-			res2 := 0
-			if tmp == game.White {
-				res2 = 2
-			} else if tmp == game.Black {
-				res2 = 1
+			op := opening.KNOWN_OPENINGS[gameNum]
+			for g, black := range []*Model{model1Instance, model2Instance} {
+				white := model2Instance
+				if black == model2Instance {
+					white = model1Instance
+				}
+				winner, aborted, history, ff := playMatch(black, white, op.Transcript, opts.variant, opts.retryIllegal)
+				round := fmt.Sprintf("%d.%d", gameNum+1, g+1)
+				i := 2*gameNum + g
+				// Each goroutine writes its own games
+				c.results[i] = matchOutcome(winner, aborted, g == 0)
+				c.pgn[i] = utils.FormatGameAsPGN(history, pgnResult(winner, aborted), utils.GameMeta{
+					Round: round, Black: black.name, White: white.name, Opening: op.Name,
+				})
+				c.records[i] = newMatchRecord(round, gameNum, black.name, white.name, opts.variant, winner, aborted, history)
+				c.records[i].Forfeit = ff
 			}
-			res := playMatch(model2Instance, model1Instance, open)
-
-			model1Instance.sendLine("exit")
-			model2Instance.sendLine("exit")
 
-			err = model1Instance.cmd.Process.Kill()
-			if err != nil {
+			if err := model1Instance.stop(); err != nil {
 				println("❌ Failed to kill model 1 process:", err.Error())
 			}
-			err = model2Instance.cmd.Process.Kill()
-			if err != nil {
+			if err := model2Instance.stop(); err != nil {
 				println("❌ Failed to kill model 2 process:", err.Error())
 			}
-
-			lock.Lock()
-			results[2*gameNum] = int(res)
-			results[2*gameNum+1] = res2
-			lock.Unlock()
 		}(i)
 	}
 
 	wg.Wait()
+	return c
+}
 
-	// Count results
-	model1Wins := 0
-	model2Wins := 0
-	draws := 0
+// tally counts the results of the games
+func tally(results []int) (model1Wins, model2Wins, draws, aborted int) {
 	for _, result := range results {
 		switch result {
-		case 0:
+		case resultDraw:
 			draws++
-		case 1:
+		case resultModel1:
 			model1Wins++
-		case 2:
+		case resultModel2:
 			model2Wins++
+		case resultAborted:
+			aborted++
+		}
+	}
+	return model1Wins, model2Wins, draws, aborted
+}
+
+func main() {
+	opts, err := parseFlags(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		println("❌", err.Error())
+		return
+	}
+
+	if opts.replayFile != "" {
+		var start modelFactory
+		if opts.replayEngines {
+			start = createModels
+		}
+		if err := replayRecords(os.Stdout, opts.replayFile, start); err != nil {
+			println("❌ Failed to replay games:", err.Error())
+		}
+		return
+	}
+
+	if opts.pgnInput != "" {
+		if err := commentPGN(opts.pgnInput, opts.model1, opts.model2, opts.variant, opts.retryIllegal); err != nil {
+			println("❌ Failed to comment games:", err.Error())
 		}
+		return
+	}
+
+	// Set max parallelism
+	runtime.GOMAXPROCS(opts.cfg.Threads)
+
+	println("Running with", opts.cfg.Threads, "threads")
+
+	test1, test2, err := createModels(opts.model1, opts.model2)
+	if err != nil {
+		println("❌ Failed to create models:", err.Error())
+		return
 	}
+	test1.stop()
+	test2.stop()
+
+	println("Models initialized successfully")
+	println("Starting game comparison...")
+	c := playMatches(createModels, opts)
+	model1Wins, model2Wins, draws, aborted := tally(c.results)
 
 	println("Results:")
 	println("Model 1 wins:", model1Wins)
 	println("Model 2 wins:", model2Wins)
 	println("Draws:", draws)
+	println("Aborted:", aborted)
+
+	if opts.worstOpenings > 0 && model1Wins != model2Wins {
+		winner := resultModel1
+		if model2Wins > model1Wins {
+			winner = resultModel2
+		}
+		println("Worst openings for model", winner, ":")
+		printWorstOpenings(os.Stderr, openingBreakdown(c.results, winner), opts.worstOpenings)
+	}
 
+	if opts.exportPGN != "" {
+		if err := writePGN(opts.exportPGN, c.pgn); err != nil {
+			println("❌ Failed to export games:", err.Error())
+			return
+		}
+		println("Games saved to", opts.exportPGN)
+	}
+
+	if opts.recordFile != "" {
+		if err := writeRecords(opts.recordFile, c.records); err != nil {
+			println("❌ Failed to record games:", err.Error())
+			return
+		}
+		println("Games recorded to", opts.recordFile)
+	}
 }
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// fakeEngine returns a model speaking the protocol of cmd/cli, answering each transcript
+// with answer of the position it leads to
+func fakeEngine(t *testing.T, name string, answer func(g *game.Game) string) *Model {
+	t.Helper()
+	// Pipes with kernel buffers, as those of a process: the prompt written after the last
+	// move does not block the engine
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		stdinW.Close()
+		stdinR.Close()
+		stdoutR.Close()
+	})
+	go func() {
+		defer stdoutW.Close()
+		in := bufio.NewReader(stdinR)
+		for {
+			if _, err := io.WriteString(stdoutW, "Board > "); err != nil {
+				return
+			}
+			line, err := in.ReadString('\n')
+			line = strings.TrimSpace(line)
+			if err != nil || line == "exit" {
+				return
+			}
+			g := game.NewGame("Black", "White")
+			if _, err := utils.ApplyTranscript(g, line); err != nil {
+				t.Errorf("engine %s received the invalid transcript %q: %v", name, line, err)
+				return
+			}
+			if _, err := io.WriteString(stdoutW, answer(g)+"\n"); err != nil {
+				return
+			}
+		}
+	}()
+	return &Model{name: name, stdin: stdinW, stdout: stdoutR}
+}
+
+// firstMove answers the first valid move in row-major order
+func firstMove(g *game.Game) string {
+	return utils.PositionToAlgebraic(g.GetValidMovesForCurrentPlayer()[0])
+}
+
+// lastMove answers the last valid move in row-major order
+func lastMove(g *game.Game) string {
+	moves := g.GetValidMovesForCurrentPlayer()
+	return utils.PositionToAlgebraic(moves[len(moves)-1])
+}
+
+// fakeFactory starts engines playing firstMove for "first" and lastMove otherwise
+func fakeFactory(t *testing.T) modelFactory {
+	return func(black, white string) (*Model, *Model, error) {
+		engine := func(name string) *Model {
+			if name == "first" {
+				return fakeEngine(t, name, firstMove)
+			}
+			return fakeEngine(t, name, lastMove)
+		}
+		return engine(black), engine(white), nil
+	}
+}
+
+// parse parses args as the command line, without a config file in the home directory
+func parse(t *testing.T, args ...string) (options, error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return parseFlags(fs, args)
+}
+
+func TestParseFlags(t *testing.T) {
+	opts, err := parse(t, "-model1", "a", "-model2", "b", "-matches", "1000", "-variant", "misere",
+		"-retry-illegal", "-replay", "games.jsonl", "-replay-engines", "-worst-openings", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.model1 != "a" || opts.model2 != "b" || opts.variant != game.Misere || !opts.retryIllegal ||
+		opts.replayFile != "games.jsonl" || !opts.replayEngines || opts.worstOpenings != 2 {
+		t.Errorf("options %+v", opts)
+	}
+	if opts.matches != len(opening.KNOWN_OPENINGS) {
+		t.Errorf("%d matches, want at most one per known opening, %d", opts.matches, len(opening.KNOWN_OPENINGS))
+	}
+
+	if _, err := parse(t, "-variant", "reversi"); err == nil {
+		t.Error("unknown variant: no error")
+	}
+}
+
+func TestPlayMatches(t *testing.T) {
+	opts, err := parse(t, "-model1", "first", "-model2", "last", "-matches", "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := playMatches(fakeFactory(t), opts)
+	if len(c.results) != 6 || len(c.records) != 6 || len(c.pgn) != 6 {
+		t.Fatalf("%d results, %d records, %d games, want 6", len(c.results), len(c.records), len(c.pgn))
+	}
+
+	for i, rec := range c.records {
+		black, white := "first", "last"
+		if i%2 == 1 {
+			black, white = white, black
+		}
+		if rec.Black != black || rec.White != white || rec.Opening != i/2 || rec.Forfeit != nil {
+			t.Errorf("game %d: %s vs %s on opening %d, forfeit %v, want %s vs %s on %d",
+				i, rec.Black, rec.White, rec.Opening, rec.Forfeit, black, white, i/2)
+		}
+		if !strings.HasPrefix(rec.Moves, opening.KNOWN_OPENINGS[i/2].Transcript) {
+			t.Errorf("game %d: moves %s do not start with the opening", i, rec.Moves)
+		}
+		// The result is the one of the recorded game, for the model playing each color
+		var winner game.Piece
+		switch rec.Winner {
+		case "black":
+			winner = game.Black
+		case "white":
+			winner = game.White
+		}
+		if want := matchOutcome(winner, game.NotAborted, i%2 == 0); c.results[i] != want {
+			t.Errorf("game %d: result %d, want %d for a %s win", i, c.results[i], want, rec.Winner)
+		}
+		if !strings.Contains(c.pgn[i], "[Black \""+black+"\"]") {
+			t.Errorf("game %d: PGN without its black player:\n%s", i, c.pgn[i])
+		}
+	}
+
+	model1, model2, draws, aborted := tally(c.results)
+	if model1+model2+draws != 6 || aborted != 0 {
+		t.Errorf("tally %d/%d/%d, %d aborted, want 6 finished games", model1, model2, draws, aborted)
+	}
+}
+
+func TestMatchOutcome(t *testing.T) {
+	tests := []struct {
+		winner      game.Piece
+		aborted     game.AbortReason
+		model1Black bool
+		want        int
+	}{
+		{game.Black, game.NotAborted, true, resultModel1},
+		{game.Black, game.NotAborted, false, resultModel2},
+		{game.White, game.NotAborted, true, resultModel2},
+		{game.White, game.NotAborted, false, resultModel1},
+		{game.Empty, game.NotAborted, true, resultDraw},
+		{game.Empty, game.TooManyPlies, false, resultAborted},
+	}
+	for _, tt := range tests {
+		if got := matchOutcome(tt.winner, tt.aborted, tt.model1Black); got != tt.want {
+			t.Errorf("winner %v, aborted %v, model 1 black %v: result %d, want %d",
+				tt.winner, tt.aborted, tt.model1Black, got, tt.want)
+		}
+	}
+}
+
+// illegalOnce answers a1, never legal, to its first request then plays firstMove
+func illegalOnce() func(g *game.Game) string {
+	asked := false
+	return func(g *game.Game) string {
+		if !asked {
+			asked = true
+			return "a1"
+		}
+		return firstMove(g)
+	}
+}
+
+func TestPlayMatchIllegalMove(t *testing.T) {
+	op := opening.KNOWN_OPENINGS[0]
+
+	// Without retry, the engine forfeits with the context of its answer
+	winner, aborted, history, ff := playMatch(fakeEngine(t, "broken", illegalOnce()), fakeEngine(t, "last", lastMove), op.Transcript, game.Standard, false)
+	if winner != game.White || aborted != game.NotAborted {
+		t.Errorf("forfeit of black: winner %v, aborted %v, want white", winner, aborted)
+	}
+	if ff == nil {
+		t.Fatal("no forfeit recorded")
+	}
+	board := game.NewGame("Black", "White")
+	utils.ApplyTranscript(board, op.Transcript)
+	want := forfeit{
+		Engine:     "broken",
+		Transcript: op.Transcript,
+		Raw:        " a1\n", // The rest of the prompt and the line
+
+		Move:  "a1",
+		Board: utils.BoardToString(board.Board),
+		Error: game.ErrIllegalMove.Error(),
+	}
+	if *ff != want {
+		t.Errorf("forfeit %+v, want %+v", *ff, want)
+	}
+	if got := utils.TranscriptToAlgebraic(history); got != op.Transcript {
+		t.Errorf("history %s, want the opening %s", got, op.Transcript)
+	}
+
+	// With retry, the legal second answer is played and the game goes on
+	winner, aborted, history, ff = playMatch(fakeEngine(t, "broken", illegalOnce()), fakeEngine(t, "last", lastMove), op.Transcript, game.Standard, true)
+	if ff != nil || aborted != game.NotAborted {
+		t.Fatalf("retried engine forfeited: %+v, aborted %v", ff, aborted)
+	}
+	g, err := replayMoves(utils.TranscriptToAlgebraic(history))
+	if err != nil || g.LegalState() != game.GameOver {
+		t.Fatalf("retried game %s not played to the end: %v", utils.TranscriptToAlgebraic(history), err)
+	}
+	if winner != g.GetWinnerMethod() {
+		t.Errorf("winner %v, final position won by %v", winner, g.GetWinnerMethod())
+	}
+
+	// An engine answering illegal moves twice forfeits after the retry
+	always := func(*game.Game) string { return "a1" }
+	_, _, _, ff = playMatch(fakeEngine(t, "first", firstMove), fakeEngine(t, "broken", always), op.Transcript, game.Standard, true)
+	if ff == nil || ff.Engine != "broken" || !ff.Retried {
+		t.Errorf("forfeit %+v, want a retried forfeit of broken", ff)
+	}
+}
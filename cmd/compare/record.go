@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// matchRecord is a played game, enough to replay it exactly
+type matchRecord struct {
+	Round      string   `json:"round"`   // Match number and game of the match, like "3.2"
+	Opening    int      `json:"opening"` // Index in opening.KNOWN_OPENINGS
+	Black      string   `json:"black"`   // Engine executables
+	White      string   `json:"white"`
+	Moves      string   `json:"moves"` // Full transcript, opening included
+	Winner     string   `json:"winner"`
+	BlackDiscs int      `json:"black_discs"`
+	WhiteDiscs int      `json:"white_discs"`
+	Aborted    string   `json:"aborted,omitempty"`
+	Variant    string   `json:"variant,omitempty"` // Empty for standard games
+	Forfeit    *forfeit `json:"forfeit,omitempty"` // Why the loser forfeited, if it did
+}
+
+// newMatchRecord builds the record of a game returned by playMatch
+func newMatchRecord(round string, openingIdx int, black, white string, variant game.Variant, winner game.Piece, aborted game.AbortReason, history []game.Position) matchRecord {
+	rec := matchRecord{
+		Round:   round,
+		Opening: openingIdx,
+		Black:   black,
+		White:   white,
+		Moves:   utils.TranscriptToAlgebraic(history),
+		Winner:  colorName(winner),
+	}
+	if variant != game.Standard {
+		rec.Variant = variant.String()
+	}
+	if aborted != game.NotAborted {
+		rec.Aborted = aborted.String()
+		rec.Winner = ""
+	}
+	if g, err := replayMoves(rec.Moves); err == nil {
+		rec.BlackDiscs, rec.WhiteDiscs = game.CountPieces(g.Board)
+	}
+	return rec
+}
+
+// colorName returns the name of a winner color
+func colorName(p game.Piece) string {
+	switch p {
+	case game.Black:
+		return "black"
+	case game.White:
+		return "white"
+	default:
+		return "draw"
+	}
+}
+
+// replayMoves plays a transcript from the initial position, passing when needed
+func replayMoves(transcript string) (*game.Game, error) {
+	g := game.NewGame("Black", "White")
+	if _, err := utils.ApplyTranscript(g, transcript); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// writeRecords writes the records as JSON lines, skipping games that were not played
+func writeRecords(filename string, records []matchRecord) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	for _, rec := range records {
+		if rec.Round == "" {
+			continue
+		}
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readRecords reads records written by writeRecords
+func readRecords(filename string) ([]matchRecord, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var records []matchRecord
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec matchRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, line, err)
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// replayRecords prints the recorded games to w after checking that their moves lead to the
+// recorded score. With start, each game is played again by its engines and compared.
+func replayRecords(w io.Writer, filename string, start modelFactory) error {
+	records, err := readRecords(filename)
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		fmt.Fprintf(w, "Game %s: %s (black) vs %s (white), opening %d\n", rec.Round, rec.Black, rec.White, rec.Opening)
+		fmt.Fprintf(w, "  Moves: %s\n", rec.Moves)
+
+		g, err := replayMoves(rec.Moves)
+		if err != nil {
+			fmt.Fprintf(w, "  ❌ Transcript does not replay: %v\n", err)
+			continue
+		}
+		black, white := game.CountPieces(g.Board)
+		status := "ok"
+		if black != rec.BlackDiscs || white != rec.WhiteDiscs {
+			status = fmt.Sprintf("MISMATCH, recorded %d-%d", rec.BlackDiscs, rec.WhiteDiscs)
+		}
+		result := rec.Winner
+		if rec.Aborted != "" {
+			result = "aborted (" + rec.Aborted + ")"
+		}
+		fmt.Fprintf(w, "  Result: %s, score %d-%d (%s)\n", result, black, white, status)
+		if ff := rec.Forfeit; ff != nil {
+			fmt.Fprintf(w, "  Forfeit of %s after %q: received %q (%s), %s\n", ff.Engine, ff.Transcript, ff.Raw, ff.Move, ff.Error)
+		}
+
+		if start == nil {
+			continue
+		}
+		if rec.Opening < 0 || rec.Opening >= len(opening.KNOWN_OPENINGS) {
+			fmt.Fprintf(w, "  ❌ Unknown opening %d\n", rec.Opening)
+			continue
+		}
+		variant, err := game.ParseVariant(rec.Variant)
+		if err != nil {
+			fmt.Fprintf(w, "  ❌ %v\n", err)
+			continue
+		}
+		blackModel, whiteModel, err := start(rec.Black, rec.White)
+		if err != nil {
+			fmt.Fprintf(w, "  ❌ Failed to start engines: %v\n", err)
+			continue
+		}
+		_, _, history, _ := playMatch(blackModel, whiteModel, opening.KNOWN_OPENINGS[rec.Opening].Transcript, variant, false)
+		blackModel.stop()
+		whiteModel.stop()
+
+		replayed := utils.TranscriptToAlgebraic(history)
+		if replayed == rec.Moves {
+			fmt.Fprintln(w, "  Engines replayed the same game")
+			continue
+		}
+		ply := 0
+		for ply*2 < min(len(replayed), len(rec.Moves)) && replayed[ply*2:ply*2+2] == rec.Moves[ply*2:ply*2+2] {
+			ply++
+		}
+		fmt.Fprintf(w, "  Engines diverge at ply %d: %s\n", ply+1, replayed)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+func TestRecordRoundTrip(t *testing.T) {
+	op := opening.KNOWN_OPENINGS[3]
+	winner, aborted, history, _ := playMatch(fakeEngine(t, "first", firstMove), fakeEngine(t, "last", lastMove), op.Transcript, game.Standard, false)
+	rec := newMatchRecord("4.1", 3, "first", "last", game.Standard, winner, aborted, history)
+
+	// The recorded score is the one of the final position of the moves
+	g, err := replayMoves(rec.Moves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if black, white := game.CountPieces(g.Board); black != rec.BlackDiscs || white != rec.WhiteDiscs || black+white == 4 {
+		t.Errorf("recorded score %d-%d, final position %d-%d", rec.BlackDiscs, rec.WhiteDiscs, black, white)
+	}
+	if rec.Winner != colorName(g.GetWinnerMethod()) {
+		t.Errorf("recorded winner %s, final position won by %s", rec.Winner, colorName(g.GetWinnerMethod()))
+	}
+
+	// Games not played are skipped
+	filename := filepath.Join(t.TempDir(), "games.jsonl")
+	if err := writeRecords(filename, []matchRecord{rec, {}}); err != nil {
+		t.Fatal(err)
+	}
+	records, err := readRecords(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || !reflect.DeepEqual(records[0], rec) {
+		t.Errorf("read %+v, want %+v", records, rec)
+	}
+}
+
+func TestReplayRecords(t *testing.T) {
+	opts, err := parse(t, "-model1", "first", "-model2", "last", "-matches", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := playMatches(fakeFactory(t), opts)
+	// A record whose score does not match its moves
+	c.records[1].BlackDiscs++
+	filename := filepath.Join(t.TempDir(), "games.jsonl")
+	if err := writeRecords(filename, c.records); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := replayRecords(&out, filename, nil); err != nil {
+		t.Fatal(err)
+	}
+	games := strings.Split(out.String(), "Game ")[1:]
+	if len(games) != 4 {
+		t.Fatalf("replayed %d games, want 4:\n%s", len(games), out.String())
+	}
+	for i, text := range games {
+		if !strings.Contains(text, "Moves: "+c.records[i].Moves) {
+			t.Errorf("game %d: moves not printed:\n%s", i, text)
+		}
+		if mismatch := strings.Contains(text, "MISMATCH"); mismatch != (i == 1) {
+			t.Errorf("game %d: mismatch reported %v:\n%s", i, mismatch, text)
+		}
+	}
+
+	// The same engines replay the same games
+	out.Reset()
+	if err := replayRecords(&out, filename, fakeFactory(t)); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out.String(), "Engines replayed the same game"); n != 4 {
+		t.Errorf("%d games replayed identically, want 4:\n%s", n, out.String())
+	}
+
+	// Other engines diverge
+	out.Reset()
+	swapped := func(black, white string) (*Model, *Model, error) {
+		return fakeFactory(t)(white, black)
+	}
+	if err := replayRecords(&out, filename, swapped); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Engines diverge at ply") {
+		t.Errorf("engines swapped, no divergence reported:\n%s", out.String())
+	}
+}
+
+func TestReadRecordsInvalid(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "games.jsonl")
+	if err := os.WriteFile(filename, []byte("{\"round\": \"1.1\"}\n{\"round\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readRecords(filename); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("invalid second line: error %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/ai/learning"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+// generationFile matches the files holding the best model of a generation: a model saved
+// as best_model_gen_N.json, or the best_model of the statistics of generation N
+var generationFile = regexp.MustCompile(`^(best_model|stats)_gen_(\d+)\.json$`)
+
+// player is a model of the tournament, a generation of the run or a baseline
+type player struct {
+	name       string
+	generation int // -1 for a baseline
+	// newSearcher returns the searcher of a game, seed drawing the moves of the random
+	// baseline
+	newSearcher func(seed int64, depth int8) evaluation.Searcher
+}
+
+// modelPlayer returns the player searching at the depth of the tournament with eval
+func modelPlayer(name string, generation int, eval evaluation.Evaluation) player {
+	return player{name: name, generation: generation, newSearcher: func(_ int64, depth int8) evaluation.Searcher {
+		return &evaluation.AlphaBetaSearcher{Depth: depth, Eval: eval}
+	}}
+}
+
+// baselinePlayer returns the built-in baseline of name: Random, Greedy or the name of
+// coefficients
+func baselinePlayer(name string) (player, bool) {
+	switch name {
+	case randomBaseline:
+		return player{name: name, generation: -1, newSearcher: func(seed int64, _ int8) evaluation.Searcher {
+			return evaluation.NewRandomPlayer(seed)
+		}}, true
+	case "Greedy":
+		return player{name: name, generation: -1, newSearcher: func(int64, int8) evaluation.Searcher {
+			return evaluation.GreedyPlayer{}
+		}}, true
+	}
+	coeffs, ok := evaluation.GetCoefficientsByName(name)
+	if !ok {
+		return player{}, false
+	}
+	return modelPlayer(name, -1, evaluation.NewMixedEvaluation(coeffs)), true
+}
+
+// record is the outcome of the games of a player against an opponent
+type record struct {
+	Wins   int
+	Losses int
+	Draws  int
+}
+
+func (r record) games() int {
+	return r.Wins + r.Losses + r.Draws
+}
+
+func main() {
+	dir := flag.String("dir", ".", "Directory of the best model of each generation, best_model_gen_N.json or stats_gen_N.json")
+	games := flag.Int("games", 20, "Games of each pair of models, half of them with each color")
+	depth := flag.Int("depth", 5, "Search depth of the games")
+	baselines := flag.String("baselines", "V2", "Comma-separated built-in models rated with the generations, Random, Greedy or coefficients, empty for none")
+	out := flag.String("out", "elo_history.json", "File receiving the rating of each generation")
+	plot := flag.Bool("plot", false, "Plot the rating of each generation as ASCII")
+	workers := flag.Int("workers", 0, "Games played in parallel, the number of CPUs when 0")
+	flag.Parse()
+
+	players, err := loadGenerations(*dir)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for _, name := range strings.Split(*baselines, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		p, ok := baselinePlayer(name)
+		if !ok {
+			fmt.Printf("unknown baseline model %q\n", name)
+			os.Exit(1)
+		}
+		players = append(players, p)
+	}
+	if len(players) < 2 {
+		fmt.Printf("need at least two models to rate, found %d in %s\n", len(players), *dir)
+		os.Exit(1)
+	}
+
+	records := playTournament(players, *games, int8(*depth), *workers)
+	ratings := bradleyTerry(records)
+	anchorRatings(players, ratings)
+
+	history := newHistory(players, records, ratings, *depth, *games)
+	printLeaderboard(history)
+	if *plot {
+		fmt.Println()
+		fmt.Print(plotHistory(history, 16))
+	}
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("\nRatings written to %s\n", *out)
+}
+
+// loadGenerations reads the best model of each generation of dir, in generation order. A
+// generation with both files is read from best_model_gen_N.json.
+func loadGenerations(dir string) ([]player, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[int]string)
+	for _, entry := range entries {
+		m := generationFile.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		gen, _ := strconv.Atoi(m[2])
+		if _, ok := files[gen]; !ok || m[1] == "best_model" {
+			files[gen] = entry.Name()
+		}
+	}
+
+	var players []player
+	for gen, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		var model learning.EvaluationModel
+		if strings.HasPrefix(name, "stats") {
+			var stats struct {
+				BestModel learning.EvaluationModel `json:"best_model"`
+			}
+			err = json.Unmarshal(data, &stats)
+			model = stats.BestModel
+		} else {
+			err = json.Unmarshal(data, &model)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := model.Coeffs.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		players = append(players, modelPlayer(fmt.Sprintf("gen %d", gen), gen, evaluation.NewMixedEvaluation(model.Coeffs)))
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].generation < players[j].generation })
+	return players, nil
+}
+
+// playTournament plays games games between each pair of players, on the same openings for
+// every pair, each opening with both colors. records[i][j] is the outcome of the games of
+// i against j. Games stopped by the watchdog are not counted.
+func playTournament(players []player, games int, depth int8, workers int) [][]record {
+	openings := learning.DedupeOpenings(opening.KNOWN_OPENINGS)
+	openings = openings[:min(max(games/2, 1), len(openings))]
+
+	type match struct {
+		i, j    int
+		opening opening.Opening
+		color   int // 0: i plays black, 1: white
+	}
+	var matches []match // The index of a match seeds its random moves
+	for i := range players {
+		for j := i + 1; j < len(players); j++ {
+			for _, op := range openings {
+				for color := range 2 {
+					matches = append(matches, match{i, j, op, color})
+				}
+			}
+		}
+	}
+
+	records := make([][]record, len(players))
+	for i := range records {
+		records[i] = make([]record, len(players))
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	jobs := make(chan int)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	played := 0
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				m, seed := matches[index], int64(2*index)
+				win, loss, draw, _, aborted := learning.PlaySearcherMatch(
+					players[m.i].newSearcher(seed, depth), players[m.j].newSearcher(seed+1, depth), m.opening, m.color)
+
+				mutex.Lock()
+				switch {
+				case aborted != game.NotAborted:
+				case win:
+					records[m.i][m.j].Wins++
+					records[m.j][m.i].Losses++
+				case loss:
+					records[m.i][m.j].Losses++
+					records[m.j][m.i].Wins++
+				case draw:
+					records[m.i][m.j].Draws++
+					records[m.j][m.i].Draws++
+				}
+				played++
+				fmt.Fprintf(os.Stderr, "\rPlayed %d/%d games", played, len(matches))
+				mutex.Unlock()
+			}
+		}()
+	}
+	for index := range matches {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+	fmt.Fprintln(os.Stderr)
+	return records
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// plotHistory draws the rating of each generation, one column per generation, on height
+// rows, a baseline being a horizontal line of dashes labelled with its name
+func plotHistory(h History, height int) string {
+	if len(h.Generations) == 0 {
+		return "No generation to plot\n"
+	}
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, r := range append(append([]Rating{}, h.Generations...), h.Baselines...) {
+		low, high = min(low, r.Elo), max(high, r.Elo)
+	}
+	if high-low < 1 {
+		low, high = low-1, high+1
+	}
+	row := func(elo float64) int {
+		return int(math.Round((high - elo) / (high - low) * float64(height-1)))
+	}
+
+	const columnWidth = 2
+	grid := make([][]byte, height)
+	labels := make([]string, height)
+	for i := range grid {
+		grid[i] = []byte(strings.Repeat(" ", len(h.Generations)*columnWidth))
+	}
+	for _, b := range h.Baselines {
+		r := row(b.Elo)
+		for x := range grid[r] {
+			grid[r][x] = '-'
+		}
+		labels[r] = strings.TrimSpace(labels[r] + " " + b.Name)
+	}
+	for x, g := range h.Generations {
+		grid[row(g.Elo)][x*columnWidth] = '*'
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Elo by generation (%d to %d)\n", h.Generations[0].Generation, h.Generations[len(h.Generations)-1].Generation)
+	for i, line := range grid {
+		elo := high - (high-low)*float64(i)/float64(height-1)
+		fmt.Fprintf(&sb, "%7.1f |%s %s\n", elo, line, labels[i])
+	}
+	fmt.Fprintf(&sb, "        +%s\n", strings.Repeat("-", len(h.Generations)*columnWidth))
+	return sb.String()
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Parameters of bradleyTerry
+const (
+	baseRating     = 1500.0 // Average rating of the players
+	priorDraws     = 1.0    // Virtual draws added to each pair that played
+	maxIterations  = 10000
+	ratingEpsilon  = 1e-9 // Largest relative change of a strength once converged
+	eloScaleFactor = 400.0
+)
+
+// randomBaseline is the baseline playing random moves. Its rating is fixed to
+// randomRating when it plays, so the ratings of different runs are comparable.
+const (
+	randomBaseline = "Random"
+	randomRating   = 1000.0
+)
+
+// Rating is the rating of a player in the history file
+type Rating struct {
+	Name       string  `json:"name"`
+	Generation int     `json:"generation"` // -1 for a baseline
+	Elo        float64 `json:"elo"`
+	Wins       int     `json:"wins"`
+	Losses     int     `json:"losses"`
+	Draws      int     `json:"draws"`
+}
+
+// History is the content of the history file: the rating of each generation in
+// generation order, the ratings of the baselines, and the first generation rated above
+// each baseline
+type History struct {
+	Depth        int            `json:"depth"`
+	GamesPerPair int            `json:"games_per_pair"`
+	Generations  []Rating       `json:"generations"`
+	Baselines    []Rating       `json:"baselines"`
+	FirstAbove   map[string]int `json:"first_above,omitempty"`
+}
+
+// bradleyTerry returns the Elo ratings of the players by maximum likelihood on the
+// Bradley-Terry model, draws counting half a win for each side, with the iterations of
+// Hunter's MM algorithm. Each pair that played also shares priorDraws virtual draws, so a
+// player without a win keeps a finite rating. The ratings average baseRating.
+func bradleyTerry(records [][]record) []float64 {
+	n := len(records)
+	strengths := make([]float64, n)
+	for i := range strengths {
+		strengths[i] = 1
+	}
+
+	for range maxIterations {
+		next := make([]float64, n)
+		for i := range records {
+			var wins, denominator float64
+			for j, r := range records[i] {
+				if j == i || r.games() == 0 {
+					continue
+				}
+				wins += float64(r.Wins) + (float64(r.Draws)+priorDraws)/2
+				denominator += (float64(r.games()) + priorDraws) / (strengths[i] + strengths[j])
+			}
+			next[i] = strengths[i]
+			if denominator > 0 {
+				next[i] = wins / denominator
+			}
+		}
+
+		// Scale the strengths to a geometric mean of 1, the average rating
+		var logSum float64
+		for _, s := range next {
+			logSum += math.Log(s)
+		}
+		scale := math.Exp(logSum / float64(n))
+		converged := true
+		for i := range next {
+			next[i] /= scale
+			if math.Abs(next[i]-strengths[i]) > ratingEpsilon*strengths[i] {
+				converged = false
+			}
+		}
+		strengths = next
+		if converged {
+			break
+		}
+	}
+
+	ratings := make([]float64, n)
+	for i, s := range strengths {
+		ratings[i] = baseRating + eloScaleFactor*math.Log10(s)
+	}
+	return ratings
+}
+
+// anchorRatings shifts the ratings so the random baseline, when it plays, is rated
+// randomRating. The differences between ratings are kept.
+func anchorRatings(players []player, ratings []float64) {
+	for i, p := range players {
+		if p.generation < 0 && p.name == randomBaseline {
+			shift := randomRating - ratings[i]
+			for j := range ratings {
+				ratings[j] += shift
+			}
+			return
+		}
+	}
+}
+
+// newHistory gathers the ratings and records of the players
+func newHistory(players []player, records [][]record, ratings []float64, depth, games int) History {
+	h := History{Depth: depth, GamesPerPair: games}
+	for i, p := range players {
+		r := Rating{Name: p.name, Generation: p.generation, Elo: math.Round(ratings[i]*10) / 10}
+		for _, rec := range records[i] {
+			r.Wins += rec.Wins
+			r.Losses += rec.Losses
+			r.Draws += rec.Draws
+		}
+		if p.generation < 0 {
+			h.Baselines = append(h.Baselines, r)
+		} else {
+			h.Generations = append(h.Generations, r)
+		}
+	}
+
+	for _, b := range h.Baselines {
+		for _, g := range h.Generations {
+			if g.Elo > b.Elo {
+				if h.FirstAbove == nil {
+					h.FirstAbove = make(map[string]int)
+				}
+				h.FirstAbove[b.Name] = g.Generation
+				break
+			}
+		}
+	}
+	return h
+}
+
+// printLeaderboard prints the players from the best rated
+func printLeaderboard(h History) {
+	all := append(append([]Rating{}, h.Generations...), h.Baselines...)
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Elo > all[j].Elo })
+
+	fmt.Printf("=== Elo leaderboard: %d games per pair at depth %d ===\n", h.GamesPerPair, h.Depth)
+	fmt.Printf("%-4s | %-10s | %-7s | %-6s | %-6s | %-6s | %-6s\n", "Rank", "Model", "Elo", "Wins", "Losses", "Draws", "Score")
+	fmt.Println(strings.Repeat("-", 62))
+	for rank, r := range all {
+		score := 0.0
+		if games := r.Wins + r.Losses + r.Draws; games > 0 {
+			score = (float64(r.Wins) + float64(r.Draws)*0.5) / float64(games) * 100
+		}
+		fmt.Printf("%-4d | %-10s | %7.1f | %-6d | %-6d | %-6d | %5.1f%%\n", rank+1, r.Name, r.Elo, r.Wins, r.Losses, r.Draws, score)
+	}
+
+	for _, b := range h.Baselines {
+		if gen, ok := h.FirstAbove[b.Name]; ok {
+			fmt.Printf("First generation rated above %s: %d\n", b.Name, gen)
+		} else {
+			fmt.Printf("No generation is rated above %s\n", b.Name)
+		}
+	}
+}
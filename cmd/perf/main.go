@@ -4,78 +4,55 @@ import (
 	"flag"
 	"fmt"
 	"math/rand"
+	"os"
 	"runtime"
 	"sort"
 	"time"
 
 	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
 	"github.com/Coloc3G/othello-engine/models/ai/stats"
+	"github.com/Coloc3G/othello-engine/models/config"
 	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
 	"github.com/Coloc3G/othello-engine/models/utils"
 )
 
 func applyPosition(g *game.Game, pos []game.Position) (err error) {
 	for _, move := range pos {
-		if !game.IsValidMove(g.Board, g.CurrentPlayer.Color, move) {
-			return fmt.Errorf("invalid move %s for player %s", utils.PositionToAlgebraic(move), g.CurrentPlayer.Name)
+		if err := g.ApplyMove(move); err != nil {
+			return fmt.Errorf("invalid move %s for player %s: %w", utils.PositionToAlgebraic(move), g.CurrentPlayer.Name, err)
 		}
-		// Apply the move
-		g.Board, _ = game.GetNewBoardAfterMove(g.Board, move, g.CurrentPlayer.Color)
-		g.CurrentPlayer = game.GetOtherPlayer(g.CurrentPlayer.Color)
-		if !game.HasAnyMoves(g.Board, g.CurrentPlayer.Color) {
-			g.CurrentPlayer = game.GetOtherPlayer(g.CurrentPlayer.Color)
+		if g.LegalState() == game.MustPass {
+			g.Pass()
 		}
 	}
 	return
 }
 
-func generateRandomBoard(numMoves int) (*game.Game, error) {
-	g := game.NewGame("random", "v4")
-
-	for i := 0; i < numMoves; i++ {
-		validMoves := game.ValidMoves(g.Board, g.CurrentPlayer.Color)
-		if len(validMoves) == 0 {
-			// No valid moves, switch player
-			g.CurrentPlayer = game.GetOtherPlayer(g.CurrentPlayer.Color)
-			validMoves = game.ValidMoves(g.Board, g.CurrentPlayer.Color)
-			if len(validMoves) == 0 {
-				// Game is over
-				break
-			}
-		}
-
-		// Choose a random valid move
-		randomMove := validMoves[rand.Intn(len(validMoves))]
-
-		// Apply the move
-		g.Board, _ = game.GetNewBoardAfterMove(g.Board, randomMove, g.CurrentPlayer.Color)
-		g.CurrentPlayer = game.GetOtherPlayer(g.CurrentPlayer.Color)
-	}
-
-	return g, nil
-}
+// runBenchmarkWithRandomBoards searches random boards with alpha-beta and opts, or with searcher when it is not nil
+func runBenchmarkWithRandomBoards(rng *rand.Rand, depth int8, eval evaluation.Evaluation, opts evaluation.SearchOptions, searcher evaluation.Searcher, numBoards int, numMoves int, showStats bool) {
 
-func runBenchmarkWithRandomBoards(depth int8, eval evaluation.Evaluation, numBoards int, numMoves int, showStats bool) {
-
-	totalStats := make(map[string]*stats.OperationStats)
+	totalStats := stats.NewPerformanceStats()
 	totalTime := time.Duration(0)
+	var totalTT evaluation.TTStats
+	searches := 0
 
 	fmt.Printf("Running benchmark with %d random boards (%d moves each)...\n", numBoards, numMoves)
 
 	for i := 0; i < numBoards; i++ {
-		g, err := generateRandomBoard(numMoves)
+		g, err := game.RandomReachableBoard(rng, numMoves)
 		if err != nil {
 			fmt.Printf("Error generating random board %d: %v\n", i+1, err)
 			continue
 		}
 
 		// Check if current player has valid moves
-		if !game.HasAnyMoves(g.Board, g.CurrentPlayer.Color) {
-			g.CurrentPlayer = game.GetOtherPlayer(g.CurrentPlayer.Color)
-			if !game.HasAnyMoves(g.Board, g.CurrentPlayer.Color) {
-				fmt.Printf("Board %d: Game is over, skipping\n", i+1)
-				continue
-			}
+		switch g.LegalState() {
+		case game.GameOver:
+			fmt.Printf("Board %d: Game is over, skipping\n", i+1)
+			continue
+		case game.MustPass:
+			g.Pass()
 		}
 
 		var boardStats *stats.PerformanceStats
@@ -90,7 +67,13 @@ func runBenchmarkWithRandomBoards(depth int8, eval evaluation.Evaluation, numBoa
 		runtime.ReadMemStats(&memBefore)
 
 		start := time.Now()
-		bestMoves, score := evaluation.SolveWithStats(g.Board, g.CurrentPlayer.Color, depth, eval, boardStats)
+		var bestMoves []game.Position
+		var score int16
+		if searcher != nil {
+			bestMoves, score = searcher.Search(g.Board, g.CurrentPlayer.Color)
+		} else {
+			bestMoves, score = evaluation.SolveWithOptions(g.Board, g.CurrentPlayer.Color, depth, eval, opts, boardStats)
+		}
 		elapsed := time.Since(start)
 
 		fmt.Printf("Board %d: Best move: %s, Score: %d, Time: %v\n",
@@ -113,22 +96,11 @@ func runBenchmarkWithRandomBoards(depth int8, eval evaluation.Evaluation, numBoa
 
 		// Accumulate stats
 		if showStats {
-			for opName, opStats := range boardStats.Operations {
-				if totalStats[opName] == nil {
-					totalStats[opName] = &stats.OperationStats{
-						Count: 0,
-						Time:  0,
-						Cache: make(map[string]int64),
-					}
-				}
-				totalStats[opName].Count += opStats.Count
-				totalStats[opName].Time += opStats.Time
-
-				for hash, hits := range opStats.Cache {
-					totalStats[opName].Cache[hash] += hits
-				}
-				boardStats.Reset()
-			}
+			totalStats.MergeFrom(boardStats)
+		}
+		if opts.TTStats != nil && searcher == nil {
+			totalTT.Add(*opts.TTStats)
+			searches++
 		}
 
 	}
@@ -137,7 +109,7 @@ func runBenchmarkWithRandomBoards(depth int8, eval evaluation.Evaluation, numBoa
 	fmt.Printf("Average time: %v\n", totalTime/time.Duration(numBoards))
 	fmt.Printf("Total time: %v\n", totalTime)
 	if showStats {
-		for opName, opStats := range totalStats {
+		for opName, opStats := range totalStats.Operations {
 			fmt.Printf("\nOperation: %s\n", opName)
 			fmt.Printf("  Average count: %.1f\n", float64(opStats.Count)/float64(numBoards))
 			fmt.Printf("  Average time: %v\n", opStats.Time/time.Duration(numBoards))
@@ -163,34 +135,149 @@ func runBenchmarkWithRandomBoards(depth int8, eval evaluation.Evaluation, numBoa
 			}
 		}
 	}
+	if opts.TTStats != nil && searcher == nil {
+		printTTStats(totalTT, searches)
+	}
+}
+
+// checkDeterminism searches the position of each known opening runs times with
+// evaluation.AssertDeterministic and reports whether every search agreed
+func checkDeterminism(depth int8, eval evaluation.Evaluation, runs int) bool {
+	ok := true
+	for _, op := range opening.KNOWN_OPENINGS {
+		g := game.NewGame("Black", "White")
+		if _, err := utils.ApplyTranscript(g, op.Transcript); err != nil || g.LegalState() != game.HasMoves {
+			continue
+		}
+		if err := evaluation.AssertDeterministic(g.Board, g.CurrentPlayer.Color, depth, eval, runs); err != nil {
+			fmt.Printf("%s (%s): %v\n", op.Name, op.Transcript, err)
+			ok = false
+		}
+	}
+	if ok {
+		fmt.Printf("%d openings searched %d times at depth %d with identical results\n", len(opening.KNOWN_OPENINGS), runs, depth)
+	}
+	return ok
+}
+
+// runEvaluationBenchmark prints the speed of the evaluations over numPositions positions.
+// There is no GPU or ensemble evaluation in this build, the table compares the CPU ones.
+func runEvaluationBenchmark(model string, mixed *evaluation.MixedEvaluation, numPositions int) {
+	evals := []struct {
+		name string
+		eval evaluation.Evaluation
+	}{
+		{"Mixed (" + model + ")", mixed},
+		{"Misere", evaluation.MisereWrapper(mixed)},
+		{"Fast", evaluation.NewFastEvaluation()},
+		{"Material", evaluation.NewMaterialEvaluation()},
+		{"Mobility", evaluation.NewMobilityEvaluation()},
+		{"Corners", evaluation.NewCornersEvaluation()},
+		{"Stability", evaluation.NewStabilityEvaluation()},
+		{"Frontier", evaluation.NewFrontierEvaluation()},
+	}
+
+	fmt.Printf("Evaluating %d positions with each evaluation (CPU, PECEvaluate only)\n\n", numPositions)
+	fmt.Printf("%-20s %14s %10s %12s\n", "Evaluation", "Positions/s", "ns/call", "allocs/call")
+	for _, e := range evals {
+		res := evaluation.BenchmarkEvaluation(e.eval, numPositions)
+		fmt.Printf("%-20s %14.0f %10.1f %12.2f\n", e.name, res.PositionsPerSecond, res.NsPerCall, res.AllocsPerCall)
+	}
 }
 
 func main() {
-	d := flag.Int("depth", 10, "Search depth for evaluation")
-	showStats := flag.Bool("stats", false, "Show perf stats")
+	cfg := config.Default()
+	cfg.Depth = 10
+	cfg.Model = "V4"
+	config.RegisterFlags(flag.CommandLine, &cfg, config.DepthFlag|config.ModelFlag)
+	var mode statsMode
+	flag.Var(&mode, "stats", "Show perf stats: perf (the default of a bare -stats) for the operation timings, tt for the transposition table histograms")
 	randomBoards := flag.Int("random", 0, "Number of random boards to test (0 = use fixed board)")
 	randomMoves := flag.Int("moves", 20, "Number of random moves for random board generation")
-	flag.Parse()
+	algo := flag.String("algo", "alphabeta", "Search algorithm: alphabeta or mcts (experimental)")
+	iterations := flag.Int("iterations", evaluation.DefaultMCTSIterations, "Iteration budget for mcts, each allocating at most one node")
+	futility := flag.Int("futility", 0, "Futility margin per ply for alphabeta (0 = disabled)")
+	quiescence := flag.Int("quiescence", 0, "Plies of corner capture extension at the alphabeta leaves (0 = disabled)")
+	noisyQuiescence := flag.Bool("noisy-quiescence", false, "Extend the alphabeta leaves with every noisy move, not only the corner captures, see -quiescence")
+	hashMB := flag.Int("hash-mb", evaluation.DefaultHashMB, "Size of the transposition table of each search in megabytes")
+	fastLeaves := flag.Bool("fast-leaves", false, "Score the alphabeta leaves with the fast heuristic instead of the model, for depth 1-2 searches")
+	ttVerify := flag.Bool("tt-verify", true, "Check a second 64-bit hash on transposition table hits")
+	seed := flag.Int64("seed", 1, "Seed of the random boards, the same seed gives the same boards")
+	determinism := flag.Int("determinism", 0, "Search each known opening this many times concurrently and fail when the results differ, best run with go run -race (0 = disabled)")
+	benchEval := flag.Int("bench-eval", 0, "Time the evaluations on this many positions instead of searching (0 = disabled)")
+	profile := flag.String("profile", "", "Profile the benchmark: cpu or mem, written to cpu.prof or mem.prof and viewed with go tool pprof cpu.prof")
+	profileOut := flag.String("profile-out", "", "File of the -profile profile instead of cpu.prof or mem.prof")
+	if err := config.Parse(flag.CommandLine, os.Args[1:], &cfg); err != nil {
+		fmt.Println(err)
+		return
+	}
 
-	depth := int8(*d)
-	eval := evaluation.NewMixedEvaluation(evaluation.V4Coeff)
+	coeffs, err := cfg.Coefficients()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	depth := int8(cfg.Depth)
+	eval := evaluation.NewMixedEvaluation(coeffs)
+
+	stopProfile, err := startProfile(*profile, *profileOut)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer stopProfile()
+
+	if *benchEval > 0 {
+		runEvaluationBenchmark(coeffs.Name, eval, *benchEval)
+		return
+	}
+	if *determinism > 0 {
+		if !checkDeterminism(depth, eval, *determinism) {
+			os.Exit(1)
+		}
+		return
+	}
+	opts := evaluation.SearchOptions{FutilityMargin: int16(*futility), TTVerify: *ttVerify, QuiescenceDepth: int8(*quiescence), HashMB: *hashMB, FastLeaves: *fastLeaves}
+	if *noisyQuiescence {
+		opts.QuiescenceNoise = evaluation.MoveNoise
+	}
+	showStats := mode == "perf"
+	if mode == "tt" {
+		opts.TTStats = &evaluation.TTStats{}
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	var searcher evaluation.Searcher
+	switch *algo {
+	case "alphabeta":
+	case "mcts":
+		searcher = &evaluation.MCTSSearcher{Eval: eval, MaxIterations: *iterations}
+		if mode != "" {
+			fmt.Println("Perf stats are only recorded by alphabeta")
+			showStats = false
+			opts.TTStats = nil
+		}
+	default:
+		fmt.Printf("Unknown algorithm '%s', expected alphabeta or mcts\n", *algo)
+		return
+	}
 
 	if *randomBoards > 0 {
-		runBenchmarkWithRandomBoards(depth, eval, *randomBoards, *randomMoves, *showStats)
+		runBenchmarkWithRandomBoards(rng, depth, eval, opts, searcher, *randomBoards, *randomMoves, showStats)
 		return
 	}
 
 	// Original fixed board logic
-	g, err := generateRandomBoard(*randomMoves)
+	g, err := game.RandomReachableBoard(rng, *randomMoves)
 	if err != nil {
 		fmt.Println("Error generating random board:", err)
 		return
 	}
 
 	start := time.Now()
-	if *showStats {
+	if showStats {
 		stats := stats.NewPerformanceStats()
-		bestMoves, score := evaluation.SolveWithStats(g.Board, g.CurrentPlayer.Color, depth, eval, stats)
+		bestMoves, score := evaluation.SolveWithOptions(g.Board, g.CurrentPlayer.Color, depth, eval, opts, stats)
 		if len(bestMoves) == 0 || (len(bestMoves) == 1 && bestMoves[0].Row == -1 && bestMoves[0].Col == -1) {
 			fmt.Println("No valid moves found")
 			return
@@ -221,12 +308,18 @@ func main() {
 			}
 		}
 	} else {
-		bestMoves, score := evaluation.Solve(g.Board, g.CurrentPlayer.Color, depth, eval)
+		if searcher == nil {
+			searcher = &evaluation.AlphaBetaSearcher{Depth: depth, Eval: eval, Options: opts}
+		}
+		bestMoves, score := searcher.Search(g.Board, g.CurrentPlayer.Color)
 		if len(bestMoves) == 0 || (len(bestMoves) == 1 && bestMoves[0].Row == -1 && bestMoves[0].Col == -1) {
 			fmt.Println("No valid moves found")
 			return
 		}
 		fmt.Println("Evaluation completed in:", time.Since(start))
 		fmt.Printf("Best moves: %s, Score: %d\n", utils.PositionsToAlgebraic(bestMoves), score)
+		if opts.TTStats != nil {
+			printTTStats(*opts.TTStats, 1)
+		}
 	}
 }
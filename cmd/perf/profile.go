@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startProfile starts the profile of kind, cpu or mem, written to out or to cpu.prof or
+// mem.prof when out is empty. The returned function ends it: the CPU profile covers the
+// time in between, the heap profile is taken when it is called. An empty kind profiles nothing.
+func startProfile(kind, out string) (stop func(), err error) {
+	if kind == "" {
+		return func() {}, nil
+	}
+	if kind != "cpu" && kind != "mem" {
+		return nil, fmt.Errorf("unknown profile %q, expected cpu or mem", kind)
+	}
+	if out == "" {
+		out = kind + ".prof"
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == "cpu" {
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		return func() {
+			pprof.StopCPUProfile()
+			f.Close()
+			fmt.Printf("CPU profile written to %s, view it with: go tool pprof %s\n", out, out)
+		}, nil
+	}
+	return func() {
+		runtime.GC() // Up to date statistics of the live heap
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			fmt.Println("Failed to write the heap profile:", err)
+		}
+		f.Close()
+		fmt.Printf("Heap profile written to %s, view it with: go tool pprof %s\n", out, out)
+	}, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+)
+
+// Width of the longest histogram bar
+const ttBarWidth = 40
+
+// statsMode is the value of the -stats flag: "perf" for the operation timings, also set
+// by a bare -stats, or "tt" for the transposition table histograms
+type statsMode string
+
+func (m *statsMode) String() string {
+	return string(*m)
+}
+
+// IsBoolFlag lets -stats be given without a value, as before it took one
+func (m *statsMode) IsBoolFlag() bool {
+	return true
+}
+
+func (m *statsMode) Set(value string) error {
+	switch value {
+	case "true", "perf":
+		*m = "perf"
+	case "false", "":
+		*m = ""
+	case "tt":
+		*m = "tt"
+	default:
+		return fmt.Errorf("expected perf or tt, got %q", value)
+	}
+	return nil
+}
+
+// printTTStats prints the transposition table statistics of searches as ASCII histograms
+func printTTStats(s evaluation.TTStats, searches int) {
+	fmt.Printf("\n=== TRANSPOSITION TABLE OVER %d SEARCHES ===\n", searches)
+
+	// Rows from the shallowest to the deepest depth with entries or hits
+	lowest, highest := -1, -1
+	var largest int64
+	for depth := range s.DepthHistogram {
+		if s.DepthHistogram[depth] > 0 || s.HitDepthHistogram[depth] > 0 {
+			if lowest < 0 {
+				lowest = depth
+			}
+			highest = depth
+		}
+		largest = max(largest, s.DepthHistogram[depth], s.HitDepthHistogram[depth])
+	}
+	if lowest < 0 {
+		fmt.Println("No entries stored")
+		return
+	}
+
+	for _, h := range []struct {
+		title  string
+		counts [32]int64
+	}{
+		{"Entries by depth", s.DepthHistogram},
+		{"Hits by entry depth", s.HitDepthHistogram},
+	} {
+		fmt.Printf("\n%s\n", h.title)
+		for depth := lowest; depth <= highest; depth++ {
+			fmt.Printf("  %2d | %-*s %d\n", depth, ttBarWidth, ttBar(h.counts[depth], largest), h.counts[depth])
+		}
+	}
+
+	var entries int64
+	for _, n := range s.FlagHistogram {
+		entries += n
+	}
+	fmt.Printf("\nEntries by flag\n")
+	for flag, name := range []string{"exact", "lower", "upper"} {
+		n := s.FlagHistogram[flag]
+		fmt.Printf("  %-5s | %-*s %d (%.1f%%)\n", name, ttBarWidth, ttBar(n, entries), n, 100*float64(n)/float64(max(entries, 1)))
+	}
+	fmt.Printf("\nOverwrites: %d, evicted: %d, collisions: %d\n", s.OverwriteCount, s.EvictedCount, s.CollisionCount)
+}
+
+// ttBar returns a bar of n out of largest, at least one character for a count above 0
+func ttBar(n, largest int64) string {
+	if n <= 0 || largest <= 0 {
+		return ""
+	}
+	return strings.Repeat("#", max(1, int(n*ttBarWidth/largest)))
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/config"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// datasetVersion is bumped whenever the record format changes
+const datasetVersion = 1
+
+// Record is one line of the dataset, written as JSON.
+//
+//	version  format version, currently 1
+//	game     index of the game the position comes from
+//	ply      number of moves played before the position (opening moves included)
+//	black    black pieces bitboard, bit i is square row i/8, column i%8 (a1 is bit 0)
+//	white    white pieces bitboard, same layout
+//	player   side to move, "black" or "white"
+//	move     move chosen by the engine in algebraic notation
+//	score    engine evaluation of the position at the search depth, positive for White
+//
+// Positions reached by the random opening are not recorded, only engine choices are.
+type Record struct {
+	Version int    `json:"version"`
+	Game    int    `json:"game"`
+	Ply     int    `json:"ply"`
+	Black   uint64 `json:"black"`
+	White   uint64 `json:"white"`
+	Player  string `json:"player"`
+	Move    string `json:"move"`
+	Score   int16  `json:"score"`
+}
+
+func main() {
+	cfg := config.Default()
+	config.RegisterFlags(flag.CommandLine, &cfg, config.DepthFlag|config.ThreadsFlag|config.ModelFlag)
+	games := flag.Int("games", 100, "Number of games to play")
+	output := flag.String("output", "data.jsonl", "Output dataset file (JSON lines)")
+	useOpenings := flag.Bool("openings", true, "Start each game from a random known opening to diversify positions")
+	if err := config.Parse(flag.CommandLine, os.Args[1:], &cfg); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	coeffs, err := cfg.Coefficients()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		fmt.Println("Error creating output file:", err)
+		return
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+	encoder := json.NewEncoder(writer)
+
+	start := time.Now()
+	records := make(chan []Record)
+	jobs := make(chan int, *games)
+	for i := range *games {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for range max(1, cfg.Threads) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			eval := evaluation.NewMixedEvaluation(coeffs)
+			for gameIndex := range jobs {
+				records <- playGame(gameIndex, eval, int8(cfg.Depth), *useOpenings)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(records)
+	}()
+
+	// Only this goroutine writes to the file
+	played, total := 0, 0
+	for gameRecords := range records {
+		for _, record := range gameRecords {
+			if err := encoder.Encode(record); err != nil {
+				fmt.Println("Error writing record:", err)
+				return
+			}
+		}
+		played++
+		total += len(gameRecords)
+		fmt.Printf("\rGames %d/%d, %d positions", played, *games, total)
+	}
+
+	fmt.Printf("\nWrote %d positions from %d games to %s in %s\n", total, played, *output, time.Since(start))
+}
+
+// playGame plays one game with the engine on both sides and records every engine move
+func playGame(gameIndex int, eval evaluation.Evaluation, depth int8, useOpening bool) []Record {
+	g := game.NewGame("Black", "White")
+	if useOpening {
+		utils.ApplyTranscript(g, opening.SelectRandomOpening().Transcript)
+	}
+
+	// The game is played on a bitboard from the end of the opening
+	bb := utils.BoardToBits(g.Board)
+	player := g.CurrentPlayer.Color
+	ply := g.NbMoves
+	var records []Record
+	for {
+		finished, blackCanMove, whiteCanMove := game.GameStatusBitBoard(bb)
+		if finished {
+			break
+		}
+		if (player == game.Black && !blackCanMove) || (player == game.White && !whiteCanMove) {
+			player = game.GetOpponentColor(player)
+			continue
+		}
+
+		moves, score := evaluation.SolveBitBoard(bb, player, depth, eval)
+		records = append(records, Record{
+			Version: datasetVersion,
+			Game:    gameIndex,
+			Ply:     ply,
+			Black:   bb.BlackPieces,
+			White:   bb.WhitePieces,
+			Player:  playerName(player),
+			Move:    utils.PositionToAlgebraic(moves[0]),
+			Score:   score,
+		})
+
+		next, ok := game.GetNewBitBoardAfterMove(bb, moves[0], player)
+		if !ok {
+			fmt.Printf("\nSearch returned an illegal move in game %d: %s\n", gameIndex, utils.PositionToAlgebraic(moves[0]))
+			break
+		}
+		bb = next
+		ply++
+		player = game.GetOpponentColor(player)
+	}
+
+	return records
+}
+
+func playerName(p game.Piece) string {
+	if p == game.White {
+		return "white"
+	}
+	return "black"
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestPlayGameRecords(t *testing.T) {
+	eval := evaluation.NewMixedEvaluation(evaluation.V1Coeff)
+	for _, useOpening := range []bool{false, true} {
+		records := playGame(3, eval, 2, useOpening)
+		if len(records) == 0 {
+			t.Fatalf("opening %v: no record", useOpening)
+		}
+
+		for i, record := range records {
+			if record.Version != datasetVersion || record.Game != 3 {
+				t.Errorf("opening %v, record %d: version %d, game %d", useOpening, i, record.Version, record.Game)
+			}
+			if i > 0 && record.Ply != records[i-1].Ply+1 {
+				t.Errorf("opening %v, record %d: ply %d after %d", useOpening, i, record.Ply, records[i-1].Ply)
+			}
+
+			// The bitboard search chooses the move and score of the array-board search
+			board := utils.BitsToBoard(game.BitBoard{BlackPieces: record.Black, WhitePieces: record.White})
+			player := game.Black
+			if record.Player == "white" {
+				player = game.White
+			}
+			moves, score := evaluation.Solve(board, player, 2, eval)
+			if utils.PositionToAlgebraic(moves[0]) != record.Move || score != record.Score {
+				t.Errorf("opening %v, record %d: %s (%d), board search %s (%d)",
+					useOpening, i, record.Move, record.Score, utils.PositionToAlgebraic(moves[0]), score)
+			}
+
+			// The next record is the position after the move, the opponent to move unless it passes
+			next, ok := game.ApplyMoveToBoard(board, player, utils.AlgebraicToPosition(record.Move))
+			if !ok {
+				t.Fatalf("opening %v, record %d: illegal move %s", useOpening, i, record.Move)
+			}
+			bb := utils.BoardToBits(next)
+			if i+1 < len(records) {
+				if records[i+1].Black != bb.BlackPieces || records[i+1].White != bb.WhitePieces {
+					t.Errorf("opening %v, record %d: not the position after %s", useOpening, i+1, record.Move)
+				}
+				opponent := game.GetOpponentColor(player)
+				if want := playerName(opponent); game.HasAnyMoves(next, opponent) && records[i+1].Player != want {
+					t.Errorf("opening %v, record %d: %s to move, want %s", useOpening, i+1, records[i+1].Player, want)
+				}
+			} else if !game.IsGameFinished(next) {
+				t.Errorf("opening %v: last move %s does not end the game", useOpening, record.Move)
+			}
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/engine"
+)
+
+// server answers engine requests over HTTP. Every request forks its own engine from
+// the base one, so handlers can run concurrently.
+type server struct {
+	engine *engine.Engine
+	// Depth and time budget used when a request does not give them
+	defaultDepth  int8
+	defaultTimeMs int
+	maxDepth      int8
+}
+
+// searchRequest is the body of /move and /analyze. Board is a 64 characters string
+// as written by utils.BoardToString, Player is "black" or "white".
+type searchRequest struct {
+	Board  string `json:"board"`
+	Player string `json:"player"`
+	Depth  int8   `json:"depth,omitempty"`
+	// Optional time budget, the search deepens until it is spent (checked between depths)
+	TimeMs int `json:"time_ms,omitempty"`
+	// Number of lines returned by /analyze, all root moves when 0
+	Lines int `json:"lines,omitempty"`
+	// Stream makes /analyze answer JSON lines, the best line of each depth once completed,
+	// instead of the lines of the deepest depth
+	Stream bool `json:"stream,omitempty"`
+}
+
+type lineResponse struct {
+	Move  string   `json:"move"`
+	Score int16    `json:"score"`
+	PV    []string `json:"pv"`
+	Book  bool     `json:"book,omitempty"` // The move comes from the book, without search
+}
+
+type analyzeResponse struct {
+	Lines []lineResponse `json:"lines"`
+}
+
+// depthResponse is a line of the /analyze stream, the best line of a completed depth
+type depthResponse struct {
+	lineResponse
+	Depth int   `json:"depth"`
+	Nodes int64 `json:"nodes"`
+}
+
+// positionRequest is the body of /legal-moves, /apply, /influence and /heatmap, Move is
+// only used by /apply
+type positionRequest struct {
+	Board  string `json:"board"`
+	Player string `json:"player"`
+	Move   string `json:"move,omitempty"`
+}
+
+type legalMovesResponse struct {
+	Moves []string `json:"moves"`
+}
+
+type applyResponse struct {
+	Board    string `json:"board"`
+	GameOver bool   `json:"game_over"`
+	// Player to move next, after a possible pass, empty when the game is over
+	NextPlayer string `json:"next_player,omitempty"`
+	Black      int    `json:"black"`
+	White      int    `json:"white"`
+	// Evaluation of the new position, positive for White
+	Score int16 `json:"score"`
+}
+
+type influenceResponse struct {
+	// Influence of each square on the evaluation, positive for White, rows from rank 1
+	Influence [8][8]int `json:"influence"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *server) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /move", s.handleMove)
+	mux.HandleFunc("POST /analyze", s.handleAnalyze)
+	mux.HandleFunc("POST /legal-moves", s.handleLegalMoves)
+	mux.HandleFunc("POST /apply", s.handleApply)
+	mux.HandleFunc("POST /influence", s.handleInfluence)
+	mux.HandleFunc("POST /heatmap", s.handleHeatmap)
+	return mux
+}
+
+func (s *server) handleMove(w http.ResponseWriter, r *http.Request) {
+	var req searchRequest
+	e, err := s.decodeSearch(r, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, analysis, err := e.BestMove(r.Context())
+	if err != nil {
+		writeError(w, searchErrorStatus(err), err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newLineResponse(analysis))
+}
+
+func (s *server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+	var req searchRequest
+	e, err := s.decodeSearch(r, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Stream {
+		streamAnalysis(w, r, e)
+		return
+	}
+
+	lines, err := e.Analyze(r.Context(), req.Lines)
+	if err != nil {
+		writeError(w, searchErrorStatus(err), err)
+		return
+	}
+
+	resp := analyzeResponse{Lines: make([]lineResponse, len(lines))}
+	for i, line := range lines {
+		resp.Lines[i] = newLineResponse(line)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// streamAnalysis answers the best line of each depth of the search as a JSON line, sent
+// as soon as the depth completes. A search failing before the first depth answers an error.
+func streamAnalysis(w http.ResponseWriter, r *http.Request, e *engine.Engine) {
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	_, err := e.AnalyzeProgressive(r.Context(), func(a engine.Analysis) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc.Encode(depthResponse{lineResponse: newLineResponse(a), Depth: a.Depth, Nodes: a.Nodes})
+		if flusher != nil {
+			flusher.Flush()
+		}
+	})
+	if err != nil {
+		writeError(w, searchErrorStatus(err), err)
+	}
+}
+
+func (s *server) handleLegalMoves(w http.ResponseWriter, r *http.Request) {
+	var req positionRequest
+	e, err := s.decodePosition(r, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp := legalMovesResponse{Moves: make([]string, 0)}
+	for _, move := range e.LegalMoves() {
+		resp.Moves = append(resp.Moves, string(move))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *server) handleApply(w http.ResponseWriter, r *http.Request) {
+	var req positionRequest
+	e, err := s.decodePosition(r, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := e.Play(req.Move); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	black, white := e.Discs()
+	resp := applyResponse{
+		Board:    e.Board(),
+		GameOver: e.GameOver(),
+		Black:    black,
+		White:    white,
+		Score:    int16(e.Evaluate().Score),
+	}
+	if !resp.GameOver {
+		resp.NextPlayer = e.ToMove().String()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *server) handleInfluence(w http.ResponseWriter, r *http.Request) {
+	var req positionRequest
+	e, err := s.decodePosition(r, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, influenceResponse{Influence: e.Influence()})
+}
+
+// handleHeatmap answers the influence of /influence drawn over the board, as a PNG
+func (s *server) handleHeatmap(w http.ResponseWriter, r *http.Request) {
+	var req positionRequest
+	e, err := s.decodePosition(r, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := e.WriteHeatmap(&buf); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(buf.Bytes())
+}
+
+// searchErrorStatus returns the status of a failed search: a position without move to
+// search, or a request cancelled by the client
+func searchErrorStatus(err error) int {
+	if errors.Is(err, engine.ErrMustPass) || errors.Is(err, engine.ErrGameOver) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusServiceUnavailable
+}
+
+// decodePosition reads a position request and returns an engine set to its position
+func (s *server) decodePosition(r *http.Request, req *positionRequest) (*engine.Engine, error) {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, err
+	}
+
+	player, err := parsePlayer(req.Player)
+	if err != nil {
+		return nil, err
+	}
+
+	e := s.engine.Fork()
+	return e, e.SetBoard(req.Board, player)
+}
+
+// decodeSearch reads a search request and returns an engine set to its position, depth
+// and time budget
+func (s *server) decodeSearch(r *http.Request, req *searchRequest) (*engine.Engine, error) {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, err
+	}
+
+	player, err := parsePlayer(req.Player)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Depth <= 0 {
+		req.Depth = s.defaultDepth
+	}
+	if req.TimeMs <= 0 {
+		req.TimeMs = s.defaultTimeMs
+	}
+	if req.Depth > s.maxDepth {
+		req.Depth = s.maxDepth
+	}
+
+	e := s.engine.Fork(engine.WithDepth(int(req.Depth)), engine.WithTime(time.Duration(req.TimeMs)*time.Millisecond))
+	return e, e.SetBoard(req.Board, player)
+}
+
+func parsePlayer(s string) (engine.Color, error) {
+	switch strings.ToLower(s) {
+	case "black", "b", "x":
+		return engine.Black, nil
+	case "white", "w", "o":
+		return engine.White, nil
+	}
+	return engine.None, errors.New("player must be \"black\" or \"white\"")
+}
+
+func newLineResponse(a engine.Analysis) lineResponse {
+	pv := make([]string, len(a.PV))
+	for i, move := range a.PV {
+		pv[i] = string(move)
+	}
+	return lineResponse{Move: pv[0], Score: int16(a.Score), PV: pv, Book: a.Book}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
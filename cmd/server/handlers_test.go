@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/engine"
+)
+
+const (
+	startBoard = "---------------------------OX------XO---------------------------"
+	// Black to move has no move, White has c1
+	mustPassBoard = "OX" + "--------------------------------------------------------------"
+	fullBoard     = "XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+)
+
+func newTestServer() *server {
+	return &server{
+		engine:        engine.New(engine.WithBook(false), engine.WithHashMB(1)),
+		defaultDepth:  2,
+		defaultTimeMs: 0,
+		maxDepth:      4,
+	}
+}
+
+// post sends body to path and returns the recorded response
+func post(t *testing.T, s *server, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+	return rec
+}
+
+// decode reads the JSON response of rec into v, failing the test on a status other than want
+func decode(t *testing.T, rec *httptest.ResponseRecorder, want int, v any) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status %d, want %d: %s", rec.Code, want, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type %q, want application/json", ct)
+	}
+	if err := json.NewDecoder(rec.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var openingMoves = []string{"c4", "d3", "e6", "f5"}
+
+func TestStartBoardIsInitialPosition(t *testing.T) {
+	if board := engine.New().Board(); board != startBoard {
+		t.Fatalf("initial board %s, want %s", board, startBoard)
+	}
+}
+
+func TestMove(t *testing.T) {
+	var resp lineResponse
+	decode(t, post(t, newTestServer(), "/move", `{"board":"`+startBoard+`","player":"black","depth":3}`), http.StatusOK, &resp)
+	if !slices.Contains(openingMoves, resp.Move) {
+		t.Errorf("move %q is not an opening move", resp.Move)
+	}
+	if len(resp.PV) == 0 || resp.PV[0] != resp.Move {
+		t.Errorf("pv %v does not start with the move %q", resp.PV, resp.Move)
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"malformed json", `{"board":`, http.StatusBadRequest},
+		{"unknown player", `{"board":"` + startBoard + `","player":"red"}`, http.StatusBadRequest},
+		{"short board", `{"board":"XO","player":"black"}`, http.StatusBadRequest},
+		{"invalid square", `{"board":"` + strings.Replace(startBoard, "-", "?", 1) + `","player":"black"}`, http.StatusBadRequest},
+		{"must pass", `{"board":"` + mustPassBoard + `","player":"black"}`, http.StatusUnprocessableEntity},
+		{"game over", `{"board":"` + fullBoard + `","player":"white"}`, http.StatusUnprocessableEntity},
+	}
+	for _, path := range []string{"/move", "/analyze"} {
+		for _, tt := range tests {
+			t.Run(path+" "+tt.name, func(t *testing.T) {
+				var resp errorResponse
+				decode(t, post(t, newTestServer(), path, tt.body), tt.want, &resp)
+				if resp.Error == "" {
+					t.Error("no error message")
+				}
+			})
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	var resp analyzeResponse
+	decode(t, post(t, newTestServer(), "/analyze", `{"board":"`+startBoard+`","player":"black","depth":3}`), http.StatusOK, &resp)
+	if len(resp.Lines) != len(openingMoves) {
+		t.Fatalf("%d lines, want one per opening move", len(resp.Lines))
+	}
+	var moves []string
+	for i, line := range resp.Lines {
+		moves = append(moves, line.Move)
+		if len(line.PV) == 0 || line.PV[0] != line.Move {
+			t.Errorf("line %d: pv %v does not start with the move %q", i, line.PV, line.Move)
+		}
+		// Lines are sorted best first for the player to move, Black, so by ascending score
+		if i > 0 && line.Score < resp.Lines[i-1].Score {
+			t.Errorf("line %d scores %d, better for Black than the line before, %d", i, line.Score, resp.Lines[i-1].Score)
+		}
+	}
+	slices.Sort(moves)
+	if !slices.Equal(moves, openingMoves) {
+		t.Errorf("lines for %v, want %v", moves, openingMoves)
+	}
+
+	decode(t, post(t, newTestServer(), "/analyze", `{"board":"`+startBoard+`","player":"black","depth":3,"lines":2}`), http.StatusOK, &resp)
+	if len(resp.Lines) != 2 {
+		t.Errorf("%d lines, want the 2 asked", len(resp.Lines))
+	}
+}
+
+func TestAnalyzeStream(t *testing.T) {
+	rec := post(t, newTestServer(), "/analyze", `{"board":"`+startBoard+`","player":"black","depth":3,"stream":true}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Fatalf("Content-Type %q, want application/x-ndjson", ct)
+	}
+
+	scanner := bufio.NewScanner(rec.Body)
+	depth := 0
+	for scanner.Scan() {
+		var line depthResponse
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		depth++
+		if line.Depth != depth {
+			t.Errorf("line of depth %d, want %d", line.Depth, depth)
+		}
+		if !slices.Contains(openingMoves, line.Move) || line.PV[0] != line.Move {
+			t.Errorf("depth %d: move %q with pv %v", line.Depth, line.Move, line.PV)
+		}
+		if line.Nodes <= 0 {
+			t.Errorf("depth %d: %d nodes", line.Depth, line.Nodes)
+		}
+	}
+	if depth != 3 {
+		t.Errorf("%d lines, want one per depth up to 3", depth)
+	}
+
+	// A search failing before its first depth answers a single JSON error
+	var resp errorResponse
+	decode(t, post(t, newTestServer(), "/analyze", `{"board":"`+fullBoard+`","player":"black","stream":true}`), http.StatusUnprocessableEntity, &resp)
+}
+
+func TestSearchDepthIsCapped(t *testing.T) {
+	rec := post(t, newTestServer(), "/analyze", `{"board":"`+startBoard+`","player":"black","depth":60,"stream":true}`)
+	if n := strings.Count(rec.Body.String(), "\n"); n != 4 {
+		t.Errorf("%d depths searched, want the maximum depth 4", n)
+	}
+}
+
+func TestLegalMoves(t *testing.T) {
+	tests := []struct {
+		name   string
+		board  string
+		player string
+		want   []string
+	}{
+		{"opening black", startBoard, "black", openingMoves},
+		{"opening white", startBoard, "white", []string{"c5", "d6", "e3", "f4"}},
+		{"must pass", mustPassBoard, "black", []string{}},
+		{"game over", fullBoard, "white", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp legalMovesResponse
+			decode(t, post(t, newTestServer(), "/legal-moves", `{"board":"`+tt.board+`","player":"`+tt.player+`"}`), http.StatusOK, &resp)
+			// An empty list, not null
+			if resp.Moves == nil {
+				t.Fatal("moves is null")
+			}
+			slices.Sort(resp.Moves)
+			if !slices.Equal(resp.Moves, tt.want) {
+				t.Errorf("moves %v, want %v", resp.Moves, tt.want)
+			}
+		})
+	}
+
+	for _, body := range []string{`not json`, `{"board":"` + startBoard + `"}`, `{"board":"","player":"black"}`} {
+		var resp errorResponse
+		decode(t, post(t, newTestServer(), "/legal-moves", body), http.StatusBadRequest, &resp)
+	}
+}
+
+func TestWrongMethod(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/move", nil)
+	rec := httptest.NewRecorder()
+	newTestServer().routes().ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /move: status %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		board  string
+		player string
+		move   string
+		want   applyResponse
+	}{
+		{
+			name: "opening", board: startBoard, player: "black", move: "f5",
+			want: applyResponse{
+				Board:      "---------------------------OX------XXX--------------------------",
+				NextPlayer: "white", Black: 4, White: 1,
+			},
+		},
+		{
+			name: "upper case", board: startBoard, player: "black", move: "F5",
+			want: applyResponse{
+				Board:      "---------------------------OX------XXX--------------------------",
+				NextPlayer: "white", Black: 4, White: 1,
+			},
+		},
+		{
+			// White has no move after c1 and passes
+			name: "opponent passes", board: "---XO----X-O------XX-------XX------XXX--------------------------", player: "white", move: "c1",
+			want: applyResponse{
+				Board:      "--OOO----X-O------XX-------XX------XXX--------------------------",
+				NextPlayer: "white", Black: 8, White: 4,
+			},
+		},
+		{
+			name: "game over", board: "XO" + strings.Repeat("-", 62), player: "black", move: "c1",
+			want: applyResponse{Board: "XXX" + strings.Repeat("-", 61), GameOver: true, Black: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp applyResponse
+			decode(t, post(t, newTestServer(), "/apply", `{"board":"`+tt.board+`","player":"`+tt.player+`","move":"`+tt.move+`"}`), http.StatusOK, &resp)
+			score := resp.Score
+			resp.Score = 0
+			if resp != tt.want {
+				t.Errorf("got %+v, want %+v", resp, tt.want)
+			}
+			if tt.want.GameOver && score >= 0 {
+				t.Errorf("black wins the game with a score of %d, want a negative one", score)
+			}
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"malformed json", `{"board":"` + startBoard, http.StatusBadRequest},
+		{"unknown player", `{"board":"` + startBoard + `","player":"","move":"f5"}`, http.StatusBadRequest},
+		{"invalid board", `{"board":"` + startBoard[:63] + `","player":"black","move":"f5"}`, http.StatusBadRequest},
+		{"illegal move", `{"board":"` + startBoard + `","player":"black","move":"a1"}`, http.StatusUnprocessableEntity},
+		{"occupied square", `{"board":"` + startBoard + `","player":"black","move":"d4"}`, http.StatusUnprocessableEntity},
+		{"column out of the board", `{"board":"` + startBoard + `","player":"black","move":"i5"}`, http.StatusUnprocessableEntity},
+		{"row out of the board", `{"board":"` + startBoard + `","player":"black","move":"f9"}`, http.StatusUnprocessableEntity},
+		{"too long", `{"board":"` + startBoard + `","player":"black","move":"f55"}`, http.StatusUnprocessableEntity},
+		{"no move", `{"board":"` + startBoard + `","player":"black"}`, http.StatusUnprocessableEntity},
+		{"pass is not a move", `{"board":"` + mustPassBoard + `","player":"black","move":"pass"}`, http.StatusUnprocessableEntity},
+		{"game over", `{"board":"` + fullBoard + `","player":"black","move":"a1"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp errorResponse
+			decode(t, post(t, newTestServer(), "/apply", tt.body), tt.want, &resp)
+			if resp.Error == "" {
+				t.Error("no error message")
+			}
+		})
+	}
+}
+
+func TestInfluence(t *testing.T) {
+	var resp influenceResponse
+	decode(t, post(t, newTestServer(), "/influence", `{"board":"`+startBoard+`","player":"black"}`), http.StatusOK, &resp)
+	// The four opening moves are images of each other by the symmetries of the board
+	moves := [][2]int{{3, 2}, {2, 3}, {5, 4}, {4, 5}} // c4 d3 e6 f5
+	want := resp.Influence[4][5]
+	if want == 0 {
+		t.Error("the opening moves have no influence")
+	}
+	for _, m := range moves {
+		if got := resp.Influence[m[0]][m[1]]; got != want {
+			t.Errorf("influence of the opening moves %v, want them equal", resp.Influence)
+			break
+		}
+	}
+	for row := range 8 {
+		for col := range 8 {
+			if startBoard[row*8+col] != '-' || slices.Contains(moves, [2]int{row, col}) {
+				continue
+			}
+			if v := resp.Influence[row][col]; v != 0 {
+				t.Errorf("empty square %c%d without move has an influence of %d", 'a'+col, row+1, v)
+			}
+		}
+	}
+
+	decode(t, post(t, newTestServer(), "/influence", `{"board":"`+fullBoard+`","player":"black"}`), http.StatusOK, &resp)
+	if resp.Influence != [8][8]int{} {
+		t.Errorf("finished game has an influence %v", resp.Influence)
+	}
+
+	var errResp errorResponse
+	decode(t, post(t, newTestServer(), "/influence", `{"board":"`+startBoard+`","player":"none"}`), http.StatusBadRequest, &errResp)
+}
+
+func TestHeatmap(t *testing.T) {
+	for _, board := range []string{startBoard, mustPassBoard, fullBoard} {
+		rec := post(t, newTestServer(), "/heatmap", `{"board":"`+board+`","player":"black"}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("board %s: status %d: %s", board, rec.Code, rec.Body)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+			t.Errorf("Content-Type %q, want image/png", ct)
+		}
+		if !strings.HasPrefix(rec.Body.String(), "\x89PNG\r\n\x1a\n") {
+			t.Errorf("board %s: body is not a PNG", board)
+		}
+	}
+
+	var resp errorResponse
+	decode(t, post(t, newTestServer(), "/heatmap", `{"board":"`+strings.Repeat("Z", 64)+`","player":"black"}`), http.StatusBadRequest, &resp)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/Coloc3G/othello-engine/engine"
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/config"
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+func main() {
+	cfg := config.Default()
+	cfg.Depth = 6
+	config.RegisterFlags(flag.CommandLine, &cfg, config.DepthFlag|config.TimeFlag|config.ModelFlag)
+	addr := flag.String("addr", ":8080", "Address to listen on")
+	maxDepth := flag.Int("max-depth", 12, "Maximum search depth a request may ask for")
+	hashMB := flag.Int("hash-mb", evaluation.DefaultHashMB, "Size of the transposition table of each request in megabytes")
+	bookFile := flag.String("book-file", "", "Games of a position book, one \"transcript result\" per line, whose moves are played without search")
+	bookGames := flag.Int("book-min-games", opening.DefaultBookMinGames, "Games a position of -book-file needs to be played")
+	bookWinRate := flag.Float64("book-min-win-rate", opening.DefaultBookMinWinRate, "Win rate a position of -book-file needs to be played")
+	if err := config.Parse(flag.CommandLine, os.Args[1:], &cfg); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	coeffs, err := cfg.Coefficients()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var book *opening.Book
+	if *bookFile != "" {
+		if book, err = opening.LoadBook(*bookFile); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	s := &server{
+		engine:        engine.New(engine.WithModel(cfg.Model), engine.WithPositionBook(book, *bookGames, *bookWinRate), engine.WithHashMB(*hashMB)),
+		defaultDepth:  int8(cfg.Depth),
+		defaultTimeMs: cfg.TimeMs,
+		maxDepth:      int8(*maxDepth),
+	}
+
+	log.Printf("Othello engine server listening on %s with model %s", *addr, coeffs.Name)
+	log.Fatal(http.ListenAndServe(*addr, s.routes()))
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/config"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func main() {
+	cfg := config.Default()
+	cfg.Depth = 4
+	config.RegisterFlags(flag.CommandLine, &cfg, config.DepthFlag|config.ModelFlag)
+	position := flag.String("position", "", "Transcript of the position to search, such as f5d6c3, the initial position when empty")
+	out := flag.String("out", "trace.json", "File receiving the search tree, as JSON")
+	maxNodes := flag.Int("max-nodes", 0, "Nodes recorded in the tree (0 = no limit)")
+	if err := config.Parse(flag.CommandLine, os.Args[1:], &cfg); err != nil {
+		fmt.Println(err)
+		return
+	}
+	// The whole tree is recorded, the leaves included
+	if cfg.Depth > evaluation.MaxTraceDepth {
+		fmt.Printf("Depth %d is too deep to trace, searching at depth %d\n", cfg.Depth, evaluation.MaxTraceDepth)
+		cfg.Depth = evaluation.MaxTraceDepth
+	}
+
+	coeffs, err := cfg.Coefficients()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	g := game.NewGame("Black", "White")
+	if _, err := utils.ApplyTranscript(g, *position); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if g.LegalState() == game.GameOver {
+		fmt.Println("The game is over, there is nothing to search")
+		return
+	}
+
+	eval := evaluation.NewMixedEvaluation(coeffs)
+	moves, score, tree := evaluation.SolveWithTrace(g.Board, g.CurrentPlayer.Color, int8(cfg.Depth), eval, evaluation.TraceOptions{MaxNodes: *maxNodes})
+
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := tree.WriteJSON(f); err != nil {
+		f.Close()
+		fmt.Println(err)
+		return
+	}
+	if err := f.Close(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Best line %s, score %d, %d nodes written to %s\n", utils.PositionsToAlgebraic(moves), score, tree.Nodes, *out)
+}
@@ -3,49 +3,195 @@ package main
 import (
 	"flag"
 	"fmt"
+	"log/slog"
+	"os"
 	"runtime"
+	"time"
 
 	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
 	"github.com/Coloc3G/othello-engine/models/ai/learning"
+	"github.com/Coloc3G/othello-engine/models/config"
 )
 
+// options are the settings of the command line
+type options struct {
+	cfg                config.Config
+	generations        int
+	populationSize     int
+	numGames           int
+	modelName          string
+	dedupeOpenings     bool
+	mutatePhases       bool
+	outDir             string
+	timestamp          bool
+	watch              string
+	standingsEvery     int
+	trainerKind        string
+	layers             string
+	promotionGames     int
+	promotionThreshold float64
+	dataset            string
+}
+
+// parseFlags parses the command line arguments, errors included, into options
+func parseFlags(fs *flag.FlagSet, args []string) (options, error) {
+	opts := options{cfg: config.Default()}
+	opts.cfg.Model = "V1"
+	config.RegisterFlags(fs, &opts.cfg, config.DepthFlag|config.ThreadsFlag|config.ModelFlag|config.LogLevelFlag)
+	config.Alias(fs, "base", "model")
+	fs.IntVar(&opts.generations, "generations", 50, "Number of generations to run")
+	fs.IntVar(&opts.populationSize, "population", 50, "Population size")
+	fs.IntVar(&opts.numGames, "games", 20, "Number of games per model evaluation")
+	fs.StringVar(&opts.modelName, "name", "", "Name of the model to save after training")
+	fs.BoolVar(&opts.dedupeOpenings, "dedupe-openings", false, "Play a single opening of those leading to symmetric positions")
+	fs.BoolVar(&opts.mutatePhases, "mutate-phases", false, "Also mutate the game phase boundaries")
+	fs.StringVar(&opts.outDir, "out", learning.DefaultRunRoot, "Directory holding the training runs")
+	fs.BoolVar(&opts.timestamp, "timestamp", false, "Append the start time to the run directory name")
+	fs.StringVar(&opts.watch, "watch", "", "Show the live standings of the run in this directory instead of training")
+	fs.IntVar(&opts.standingsEvery, "standings-every", learning.DefaultStandingsEvery, "Number of matches between two writes of the standings file")
+	fs.StringVar(&opts.trainerKind, "trainer", "genetic", "Trainer to run: genetic evolves coefficients, neuroevolution evolves a neural network, fit fits coefficients to the scores of -dataset")
+	fs.Var(fs.Lookup("trainer").Value, "method", "Alias of -trainer")
+	fs.StringVar(&opts.layers, "layers", fmt.Sprintf("%d,16,8,1", evaluation.NeuralFeatures), "Layer sizes of the network evolved by the neuroevolution trainer")
+	fs.IntVar(&opts.promotionGames, "promotion-games", learning.DefaultPromotionGames, "Openings of the head-to-head match a new best model must win, 0 promotes on the fitness alone")
+	fs.Float64Var(&opts.promotionThreshold, "promotion-threshold", learning.DefaultPromotionThreshold, "Share of the points of the head-to-head match needed to promote a new best model")
+	fs.StringVar(&opts.dataset, "dataset", "", "JSON lines dataset of scored positions, written by cmd/selfplay, for the fit trainer")
+	if err := config.Parse(fs, args, &opts.cfg); err != nil {
+		return opts, err
+	}
+
+	switch opts.trainerKind {
+	case "genetic", "neuroevolution", "fit":
+	default:
+		return opts, fmt.Errorf("unknown trainer %q, expected genetic, neuroevolution or fit", opts.trainerKind)
+	}
+	return opts, nil
+}
+
 func main() {
-	// Parse command-line flags
-	generations := flag.Int("generations", 50, "Number of generations to run")
-	populationSize := flag.Int("population", 50, "Population size")
-	numGames := flag.Int("games", 20, "Number of games per model evaluation")
-	depth := flag.Int("depth", 5, "Search depth for AI")
-	threads := flag.Int("threads", runtime.NumCPU(), "Number of threads to use")
-	baseModel := flag.String("base", "V1", "Base model to use for training (default: V1)")
-	modelName := flag.String("name", "", "Name of the model to save after training")
-	flag.Parse()
-
-	if *modelName == "" {
+	opts, err := parseFlags(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	cfg := opts.cfg
+
+	if opts.watch != "" {
+		watchStandings(os.Stdout, opts.watch, time.Second)
+		return
+	}
+
+	if opts.modelName == "" {
 		fmt.Println("Please provide a name for the model using the -name flag.")
 		flag.Usage()
 		return
 	}
 
+	level, err := cfg.SlogLevel()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// Logs go to stdout, the progress bar to stderr
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: level}))
+
 	// Set max parallelism
-	runtime.GOMAXPROCS(*threads)
-	fmt.Printf("Running with %d threads\n", *threads)
-
-	baseModelCoeffs, found := evaluation.GetCoefficientsByName(*baseModel)
-	if !found {
-		fmt.Printf("Base model '%s' not found. Available models: ", *baseModel)
-		for _, model := range evaluation.Models {
-			fmt.Printf("%s ", model.Name)
+	runtime.GOMAXPROCS(cfg.Threads)
+
+	var promotion *learning.PromotionGate
+	if opts.promotionGames > 0 {
+		promotion = &learning.PromotionGate{Games: opts.promotionGames, Threshold: opts.promotionThreshold}
+	}
+
+	baseModelCoeffs, err := cfg.Coefficients()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	store := learning.NewRunStore(opts.outDir, opts.modelName, opts.timestamp)
+	slog.SetDefault(logger)
+
+	switch opts.trainerKind {
+	case "genetic":
+	case "neuroevolution":
+		networkLayers, err := evaluation.ParseLayers(opts.layers)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		trainer := learning.NewNeuroEvolutionTrainer(opts.modelName, networkLayers, opts.populationSize, opts.numGames, int8(cfg.Depth), baseModelCoeffs)
+		trainer.Workers = cfg.Threads
+		trainer.DedupeOpenings = opts.dedupeOpenings
+		trainer.Promotion = promotion
+		trainer.Store = store
+		trainer.Logger = logger
+		logger.Info("starting neuroevolution",
+			"name", opts.modelName,
+			"run_dir", store.Dir,
+			"opponent", baseModelCoeffs.Name,
+			"layers", networkLayers,
+			"generations", opts.generations,
+			"population", opts.populationSize,
+			"games", opts.numGames,
+			"depth", cfg.Depth,
+			"threads", cfg.Threads)
+		trainer.StartTraining(opts.generations)
+		return
+	case "fit":
+		if opts.dataset == "" {
+			fmt.Println("Please provide the positions to fit using the -dataset flag.")
+			return
+		}
+		samples, err := learning.LoadDataset(opts.dataset)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		logger.Info("starting fit",
+			"name", opts.modelName,
+			"run_dir", store.Dir,
+			"base", baseModelCoeffs.Name,
+			"dataset", opts.dataset,
+			"positions", len(samples))
+		coeffs, report := learning.FitCoefficients(samples, learning.FitOptions{Base: baseModelCoeffs, Name: opts.modelName})
+		for _, phase := range report.Phases {
+			logger.Info("phase fitted",
+				"phase", phase.Phase,
+				"samples", phase.Samples,
+				"fitted", phase.Fitted,
+				"rmse", phase.RMSE,
+				"base_rmse", phase.BaseRMSE)
+		}
+		if err := store.SaveModel("best_model.json", learning.EvaluationModel{Coeffs: coeffs}); err != nil {
+			logger.Error("failed to save model", "error", err)
+			return
+		}
+		if err := store.SaveCheckpoint("fit_report.json", report); err != nil {
+			logger.Error("failed to save report", "error", err)
+			return
 		}
-		fmt.Println()
+		logger.Info("fit done", "samples", report.Samples, "coeffs", coeffs)
 		return
 	}
 
 	// Create appropriate trainer
-	trainer := learning.NewTrainer(*modelName, *populationSize, *numGames, int8(*depth), baseModelCoeffs)
+	trainer := learning.NewTrainer(opts.modelName, opts.populationSize, opts.numGames, int8(cfg.Depth), baseModelCoeffs)
+	trainer.MutatePhaseBoundaries = opts.mutatePhases
+	trainer.DedupeOpenings = opts.dedupeOpenings
+	trainer.Promotion = promotion
+	trainer.Workers = cfg.Threads
+	trainer.Store = store
+	trainer.Logger = logger
+	trainer.StandingsEvery = opts.standingsEvery
 
-	// Print training configuration
-	fmt.Println("Othello AI Trainer")
-	fmt.Printf("Starting training for %d generations with population size %d, playing %d matches\n\n",
-		*generations, *populationSize, *numGames)
-	trainer.StartTraining(*generations)
+	logger.Info("starting training",
+		"name", opts.modelName,
+		"run_dir", store.Dir,
+		"base", baseModelCoeffs.Name,
+		"generations", opts.generations,
+		"population", opts.populationSize,
+		"games", opts.numGames,
+		"depth", cfg.Depth,
+		"threads", cfg.Threads)
+	trainer.StartTraining(opts.generations)
 }
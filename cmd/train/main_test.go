@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/ai/learning"
+)
+
+func parse(t *testing.T, args ...string) (options, error) {
+	t.Setenv("HOME", t.TempDir())
+	fs := flag.NewFlagSet("train", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return parseFlags(fs, args)
+}
+
+func TestParseFlags(t *testing.T) {
+	opts, err := parse(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.cfg.Model != "V1" || opts.trainerKind != "genetic" || opts.watch != "" || opts.standingsEvery != learning.DefaultStandingsEvery {
+		t.Errorf("default options %+v", opts)
+	}
+
+	opts, err = parse(t, "-base", "V3", "-method", "fit", "-watch", "runs/a", "-standings-every", "5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.cfg.Model != "V3" || opts.trainerKind != "fit" || opts.watch != "runs/a" || opts.standingsEvery != 5 {
+		t.Errorf("aliases and watch flags: %+v", opts)
+	}
+
+	for _, args := range [][]string{{"-trainer", "random"}, {"-standings-every", "x"}, {"-unknown"}} {
+		if _, err := parse(t, args...); err == nil {
+			t.Errorf("%v: no error", args)
+		}
+	}
+}
+
+func TestRefreshStandings(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "run")
+	var out bytes.Buffer
+
+	// Before the first write the watcher waits, once
+	last := refreshStandings(&out, dir, time.Time{})
+	if !last.IsZero() || !strings.Contains(out.String(), "Waiting for") {
+		t.Errorf("missing standings: last %v, output %q", last, out.String())
+	}
+
+	updated := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	standings := &learning.Standings{Generation: 4, Updated: updated, Played: 3, Remaining: 5, Models: []learning.Standing{
+		{Model: 0, Name: "low", Losses: 2, Score: 0},
+		{Model: 1, Name: "high", Wins: 2, Draws: 1, Score: 2.5},
+		{Model: 2, Score: 1},
+	}}
+	if err := learning.NewFileStore(dir).SaveStandings(standings); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if last = refreshStandings(&out, dir, last); !last.Equal(updated) {
+		t.Errorf("last update %v, want %v", last, updated)
+	}
+	lines := strings.Split(out.String(), "\n")
+	if !strings.Contains(lines[0], "generation 4 - 3 played, 5 remaining - updated 15:04:05") {
+		t.Errorf("header %q", lines[0])
+	}
+	// The models are ranked by score, a model without a name shown as -
+	for i, want := range []string{"1    1 high", "2    2 -", "3    0 low"} {
+		if row := lines[3+i]; !strings.HasPrefix(strings.TrimSpace(row), want) {
+			t.Errorf("rank %d: %q, want %q", i+1, row, want)
+		}
+	}
+
+	// Unchanged standings are not redrawn
+	out.Reset()
+	if refreshStandings(&out, dir, last); out.Len() != 0 {
+		t.Errorf("unchanged standings redrawn: %q", out.String())
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, learning.StandingsFile), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if got := refreshStandings(&out, dir, last); !got.Equal(last) || !strings.Contains(out.String(), "reading standings") {
+		t.Errorf("invalid standings: last %v, output %q", got, out.String())
+	}
+}
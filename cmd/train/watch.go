@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/ai/learning"
+)
+
+// watchStandings redraws the standings of the run in dir each time they change, until interrupted
+func watchStandings(w io.Writer, dir string, interval time.Duration) {
+	var last time.Time
+	for {
+		last = refreshStandings(w, dir, last)
+		time.Sleep(interval)
+	}
+}
+
+// refreshStandings reads the standings of the run in dir and prints them when they were
+// updated after last. It returns the update time of the standings printed last.
+func refreshStandings(w io.Writer, dir string, last time.Time) time.Time {
+	standings, err := learning.ReadStandings(dir)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if last.IsZero() {
+			fmt.Fprintf(w, "\033[H\033[2JWaiting for %s/%s...\n", dir, learning.StandingsFile)
+		}
+	case err != nil:
+		// The file is replaced atomically, an error is worth reporting
+		fmt.Fprintln(w, "reading standings:", err)
+	case !standings.Updated.Equal(last):
+		printStandings(w, dir, standings)
+		return standings.Updated
+	}
+	return last
+}
+
+// printStandings clears the terminal and prints the models sorted by score
+func printStandings(w io.Writer, dir string, standings *learning.Standings) {
+	models := append([]learning.Standing(nil), standings.Models...)
+	sort.SliceStable(models, func(i, j int) bool { return models[i].Score > models[j].Score })
+
+	fmt.Fprint(w, "\033[H\033[2J")
+	fmt.Fprintf(w, "Run %s - generation %d - %d played, %d remaining - updated %s\n\n",
+		dir, standings.Generation, standings.Played, standings.Remaining, standings.Updated.Format(time.TimeOnly))
+	fmt.Fprintf(w, "%4s  %-24s %6s %6s %6s %7s %7s\n", "Rank", "Model", "Wins", "Losses", "Draws", "Aborted", "Score")
+	for i, st := range models {
+		name := st.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(w, "%4d  %3d %-20.20s %6d %6d %6d %7d %7.1f\n",
+			i+1, st.Model, name, st.Wins, st.Losses, st.Draws, st.Aborted, st.Score)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
 	"github.com/Coloc3G/othello-engine/models/ai/learning"
+	"github.com/Coloc3G/othello-engine/models/game"
 	"github.com/Coloc3G/othello-engine/models/opening"
 	"github.com/schollz/progressbar/v3"
 )
@@ -61,7 +62,7 @@ func CompareCoefficients(coeff1, coeff2 evaluation.EvaluationCoefficients, numGa
 			defer wg.Done()
 			for i := range jobsCh {
 				for index := range 2 {
-					win1, win2, draw, _ := learning.PlayMatchWithOpening(eval1, eval2, selectedOpenings[i], index, searchDepth)
+					win1, win2, draw, _, aborted := learning.PlayMatchWithOpening(eval1, eval2, selectedOpenings[i], index, searchDepth)
 
 					bar.Add(1)
 					if win1 {
@@ -70,6 +71,8 @@ func CompareCoefficients(coeff1, coeff2 evaluation.EvaluationCoefficients, numGa
 						resultsCh <- 2
 					} else if draw {
 						resultsCh <- 0
+					} else if aborted != game.NotAborted {
+						resultsCh <- 3
 					}
 				}
 			}
@@ -89,6 +92,8 @@ func CompareCoefficients(coeff1, coeff2 evaluation.EvaluationCoefficients, numGa
 			stats.Draws++
 		case 1:
 			stats.Version1Wins++
+		case 3:
+			stats.Aborted++
 		default:
 			stats.Version2Wins++
 		}
@@ -109,6 +114,9 @@ func PrintComparison(stats PerformanceResult) {
 	fmt.Printf("%s wins: %d (%.1f%%)\n", stats.Version1Name, stats.Version1Wins, stats.Version1WinPct)
 	fmt.Printf("%s wins: %d (%.1f%%)\n", stats.Version2Name, stats.Version2Wins, stats.Version2WinPct)
 	fmt.Printf("Draws: %d (%.1f%%)\n", stats.Draws, stats.DrawPct)
+	if stats.Aborted > 0 {
+		fmt.Printf("Aborted: %d\n", stats.Aborted)
+	}
 
 	// Print summary judgment
 	fmt.Print("Conclusion: ")
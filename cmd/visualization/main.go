@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Coloc3G/othello-engine/models/config"
 	"github.com/Coloc3G/othello-engine/models/opening"
 	"github.com/go-echarts/go-echarts/v2/charts"
 	"github.com/go-echarts/go-echarts/v2/components"
@@ -21,6 +22,7 @@ type PerformanceResult struct {
 	Version1Wins   int
 	Version2Wins   int
 	Draws          int
+	Aborted        int
 	TotalGames     int
 	Version1WinPct float64
 	Version2WinPct float64
@@ -29,17 +31,21 @@ type PerformanceResult struct {
 
 func main() {
 	// Parse command line flags
+	cfg := config.Default()
+	config.RegisterFlags(flag.CommandLine, &cfg, config.DepthFlag)
 	numGames := flag.Int("games", 200, "Number of games to run for each comparison")
-	searchDepth := flag.Int("depth", 5, "Search depth for AI")
 	generateHTML := flag.Bool("html", false, "Generate HTML visualization files")
 	showASCII := flag.Bool("ascii", true, "Show ASCII visualization in terminal")
-	flag.Parse()
+	if err := config.Parse(flag.CommandLine, os.Args[1:], &cfg); err != nil {
+		fmt.Println(err)
+		return
+	}
 
 	if *numGames > len(opening.KNOWN_OPENINGS) {
 		*numGames = len(opening.KNOWN_OPENINGS)
 	}
 
-	searchDepth8 := int8(*searchDepth)
+	searchDepth8 := int8(cfg.Depth)
 
 	fmt.Println("Othello AI Performance Visualization")
 	fmt.Printf("Running with %d matches (2 matches/game) at depth %d\n", *numGames*2, searchDepth8)
@@ -0,0 +1,20 @@
+// Package engine embeds the Othello engine in other Go programs. It wraps the game
+// state, the alpha-beta search with its transposition table and the opening book behind
+// a small API meant to stay stable: moves and positions are strings, and no type of the
+// models packages is part of it.
+//
+// A game against the engine:
+//
+//	e := engine.New(engine.WithDepth(8), engine.WithTime(time.Second))
+//	if err := e.SetPosition("f5d6c3"); err != nil {
+//		log.Fatal(err)
+//	}
+//	move, analysis, err := e.BestMove(context.Background())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(move, analysis.Score, analysis.PV)
+//	e.Play(string(move))
+//
+// cmd/cli and cmd/server are built on this package.
+package engine
@@ -0,0 +1,597 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/config"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// Errors returned by the engine
+var (
+	ErrGameOver    = errors.New("engine: game is over")
+	ErrIllegalMove = errors.New("engine: illegal move")
+	ErrMustPass    = errors.New("engine: the player to move has no move and must pass")
+	ErrInvalid     = errors.New("engine: invalid position")
+)
+
+// Color is the color of a player
+type Color int
+
+const (
+	None Color = iota // No player, the game is over
+	Black
+	White
+)
+
+func (c Color) String() string {
+	switch c {
+	case Black:
+		return "black"
+	case White:
+		return "white"
+	}
+	return "none"
+}
+
+// ScoreFromPerspective converts a score of an Analysis, positive when White is ahead, to
+// the point of view of c: positive when c is ahead
+func ScoreFromPerspective(score int, c Color) int {
+	if c == Black {
+		return -score
+	}
+	return score
+}
+
+// Move is a square in algebraic notation, column letter then row number, such as "f5"
+type Move string
+
+// Analysis describes a position as seen by the engine. Scores are positive when White is
+// ahead, finished games scoring beyond every other position.
+type Analysis struct {
+	Score int
+	// Principal variation, starting with the best move. Passes are left out.
+	PV []Move
+	// Depth of the search, 0 for a static evaluation or a book move
+	Depth int
+	// Positions visited by the search
+	Nodes int64
+	// Occupancy of the transposition table after the search, in permille
+	HashFull int
+	// Weighted score of each term of the evaluation of the position, nil when the game is over
+	Components map[string]int
+	// Name of the opening when the move comes from the opening book
+	Opening string
+	// Book is set when the move comes from a book, named in Opening or the book of
+	// WithPositionBook, rather than from a search
+	Book bool
+	// Changes of the ranking of the moves between the depths of the search, set by
+	// BestMove with WithDeepeningStats
+	Deepening *DeepeningStats
+}
+
+// DeepeningStats records how the ranking of the moves of the player to move changed from
+// one depth of the search to the next
+type DeepeningStats struct {
+	// Moves are the moves of the player to move, in the order of MoveOrderChanges
+	Moves []Move
+	// MoveOrderChanges counts, for each move, the depths at which it fell in the ranking
+	MoveOrderChanges []int
+	// Depths is the number of depths searched, BestMoveChanges the number of them whose
+	// best move differs from the one of the previous depth
+	Depths          int
+	BestMoveChanges int
+}
+
+// Stability returns the share of the depths, after the first one, that kept the best move
+// of the previous depth, 1 when fewer than two depths were searched
+func (s *DeepeningStats) Stability() float64 {
+	if s.Depths < 2 {
+		return 1
+	}
+	return 1 - float64(s.BestMoveChanges)/float64(s.Depths-1)
+}
+
+// Engine plays a game of Othello and searches its positions. The transposition table is
+// kept from one search to the next. An Engine is not safe for concurrent use, see Fork.
+type Engine struct {
+	model          string
+	depth          int
+	budget         time.Duration
+	nodes          int64
+	endgameDepth   int
+	book           bool
+	bookBias       int
+	misere         bool
+	deepeningStats bool
+	positionBook   *opening.Book
+	bookMinGames   int
+	bookMinWinRate float64
+	hashMB         int
+
+	eval  evaluation.Evaluation
+	cache *evaluation.Cache
+	game  *game.Game
+	// Whether the moves of the game are known, false after SetBoard
+	transcript bool
+}
+
+// New creates an engine at the start of a game. Without options it uses the latest built-in
+// model at DefaultDepth with the opening book.
+func New(opts ...Option) *Engine {
+	e := &Engine{
+		model: evaluation.Models[len(evaluation.Models)-1].Name,
+		depth: DefaultDepth,
+		book:  true,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.setEvaluation()
+	e.NewGame()
+	return e
+}
+
+// Fork creates an engine with the settings of e changed by opts, at the start of a game
+// and with its own transposition table. The evaluation is shared when the model and the
+// variant are unchanged.
+func (e *Engine) Fork(opts ...Option) *Engine {
+	f := &Engine{
+		model:          e.model,
+		depth:          e.depth,
+		budget:         e.budget,
+		nodes:          e.nodes,
+		endgameDepth:   e.endgameDepth,
+		book:           e.book,
+		bookBias:       e.bookBias,
+		misere:         e.misere,
+		deepeningStats: e.deepeningStats,
+		positionBook:   e.positionBook,
+		bookMinGames:   e.bookMinGames,
+		bookMinWinRate: e.bookMinWinRate,
+		hashMB:         e.hashMB,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if f.model == e.model && f.misere == e.misere {
+		f.eval = e.eval
+	} else {
+		f.setEvaluation()
+	}
+	f.NewGame()
+	return f
+}
+
+// setEvaluation builds the evaluation of the model for the variant
+func (e *Engine) setEvaluation() {
+	coeffs, err := config.Config{Model: e.model}.Coefficients()
+	if err != nil {
+		panic(fmt.Sprintf("engine: %v", err))
+	}
+	variant := game.Standard
+	if e.misere {
+		variant = game.Misere
+	}
+	e.eval = evaluation.ForVariant(evaluation.NewMixedEvaluation(coeffs), variant)
+}
+
+// NewGame starts a new game from the initial position and clears the transposition table
+func (e *Engine) NewGame() {
+	e.game = e.newGame()
+	e.transcript = true
+	e.cache = evaluation.NewCacheMB(e.hashMB)
+}
+
+// WarmCache searches the positions of the known openings at depth, so that the next
+// searches of these positions find them in the transposition table. NewGame clears it.
+func (e *Engine) WarmCache(depth int) {
+	evaluation.WarmCache(opening.KNOWN_OPENINGS, int8(depth), e.eval, e.cache)
+}
+
+// newGame returns a game at the initial position with the variant of the engine
+func (e *Engine) newGame() *game.Game {
+	g := game.NewGame("Black", "White")
+	if e.misere {
+		g.Variant = game.Misere
+	}
+	return g
+}
+
+// Play plays a move for the player to move. When the other player then has no move,
+// the turn comes back without a move to play.
+func (e *Engine) Play(move string) error {
+	if e.GameOver() {
+		return ErrGameOver
+	}
+	return play(e.game, move)
+}
+
+// Pass hands the turn to the other player, only allowed when the player to move has no
+// move, which Play avoids but SetBoard may not
+func (e *Engine) Pass() error {
+	switch e.game.LegalState() {
+	case game.GameOver:
+		return ErrGameOver
+	case game.HasMoves:
+		return fmt.Errorf("engine: %w", game.ErrIllegalPass)
+	}
+	e.game.Pass()
+	return nil
+}
+
+// play applies a move in algebraic notation and the pass that may follow it
+func play(g *game.Game, move string) error {
+	pos := utils.AlgebraicToPosition(strings.ToLower(move))
+	if len(move) != 2 || pos.Row < 0 {
+		return fmt.Errorf("%w: %q", ErrIllegalMove, move)
+	}
+	if err := g.ApplyMove(pos); err != nil {
+		return fmt.Errorf("%w: %q", ErrIllegalMove, move)
+	}
+	if g.LegalState() == game.MustPass {
+		g.Pass()
+	}
+	return nil
+}
+
+// SetPosition replaces the game with the one of a transcript such as "f5d6c3", passes
+// being left out. The game is unchanged when the transcript is not valid.
+func (e *Engine) SetPosition(transcript string) error {
+	if len(transcript)%2 != 0 {
+		return fmt.Errorf("%w: odd transcript length", ErrInvalid)
+	}
+	g := e.newGame()
+	for i := 0; i < len(transcript); i += 2 {
+		if g.LegalState() == game.GameOver {
+			return fmt.Errorf("%w: moves after the end of the game", ErrInvalid)
+		}
+		if err := play(g, transcript[i:i+2]); err != nil {
+			return fmt.Errorf("move %d: %w", i/2+1, err)
+		}
+	}
+	e.game = g
+	e.transcript = true
+	return nil
+}
+
+// SetBoard replaces the game with a position without its moves. board has 64 characters,
+// row by row from a1, "X" or "B" for black, "O" or "W" for white and "-" or "." for an
+// empty square. The player to move may have to pass, see Pass. The opening book is not
+// used for such a position.
+func (e *Engine) SetBoard(board string, toMove Color) error {
+	b, err := utils.StringToBoard(board)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if toMove != Black && toMove != White {
+		return fmt.Errorf("%w: no player to move", ErrInvalid)
+	}
+	g := e.newGame()
+	g.Board = b
+	g.RecountPieces()
+	g.CurrentPlayer = g.Players[toMove-1]
+	e.game = g
+	e.transcript = false
+	return nil
+}
+
+// Board returns the position in the format of SetBoard
+func (e *Engine) Board() string {
+	return utils.BoardToString(e.game.Board)
+}
+
+// Transcript returns the moves of the game, empty after SetBoard
+func (e *Engine) Transcript() string {
+	if !e.transcript {
+		return ""
+	}
+	return utils.TranscriptToAlgebraic(e.game.History)
+}
+
+// ToMove returns the player to move, None when the game is over
+func (e *Engine) ToMove() Color {
+	if e.GameOver() {
+		return None
+	}
+	return colorOf(e.game.CurrentPlayer.Color)
+}
+
+// GameOver reports whether neither player can move
+func (e *Engine) GameOver() bool {
+	return game.IsGameFinished(e.game.Board)
+}
+
+// CacheSize returns the number of positions in the transposition table and the number
+// replaced by other positions since NewGame
+func (e *Engine) CacheSize() (entries int, evicted int64) {
+	return e.cache.Len(), e.cache.DumpStats().EvictedCount
+}
+
+// Discs returns the number of discs of each player
+func (e *Engine) Discs() (black, white int) {
+	return e.game.CountPiecesMethod()
+}
+
+// Winner returns the player with the most discs, or the fewest in the misère variant,
+// None for a draw or a game not over
+func (e *Engine) Winner() Color {
+	if !e.GameOver() {
+		return None
+	}
+	return colorOf(e.game.GetWinnerMethod())
+}
+
+// LegalMoves returns the moves of the player to move
+func (e *Engine) LegalMoves() []Move {
+	if e.GameOver() {
+		return nil
+	}
+	return toMoves(e.game.GetValidMovesForCurrentPlayer())
+}
+
+// Evaluate returns the static evaluation of the position, without search
+func (e *Engine) Evaluate() Analysis {
+	return e.evaluate(e.game.Board)
+}
+
+// evaluate returns the static evaluation of a board
+func (e *Engine) evaluate(board game.Board) Analysis {
+	bb := utils.BoardToBits(board)
+	a := Analysis{Score: int(e.eval.Evaluate(bb))}
+	if c, ok := e.eval.(interface {
+		Components(game.BitBoard) map[string]int16
+	}); ok {
+		if components := c.Components(bb); components != nil {
+			a.Components = make(map[string]int, len(components))
+			for name, score := range components {
+				a.Components[name] = int(score)
+			}
+		}
+	}
+	return a
+}
+
+// Influence returns how each square moves the evaluation of the position, positive for
+// White, indexed by row then column, row 0 being rank 1: the score a disc brings, or the
+// change of score of a legal move of the player to move. See evaluation.SquareInfluence.
+func (e *Engine) Influence() [8][8]int {
+	var influence [8][8]int
+	for square, v := range e.influence() {
+		influence[square/8][square%8] = int(v)
+	}
+	return influence
+}
+
+// WriteHeatmap writes the board tinted by Influence as a PNG, blue for the squares good
+// for White and red for those good for Black
+func (e *Engine) WriteHeatmap(w io.Writer) error {
+	return utils.WriteHeatmapPNG(w, e.game.Board, e.influence())
+}
+
+// influence returns the square influence of the position, for the player to move
+func (e *Engine) influence() [64]int16 {
+	return evaluation.SquareInfluence(utils.BoardToBits(e.game.Board), e.game.CurrentPlayer.Color, e.eval)
+}
+
+// BestMove returns the move of the book or of the search for the player to move, without
+// playing it. ctx is checked between the depths of the search: once it is done, the result
+// of the deepest completed depth is returned, or ctx.Err() when none is.
+func (e *Engine) BestMove(ctx context.Context) (Move, Analysis, error) {
+	if e.GameOver() {
+		return "", Analysis{}, ErrGameOver
+	}
+	if e.game.LegalState() == game.MustPass {
+		return "", Analysis{}, ErrMustPass
+	}
+
+	if move, name, ok := e.bookMove(); ok {
+		board, _ := game.ApplyMoveToBoard(e.game.Board, e.game.CurrentPlayer.Color, move)
+		a := e.evaluate(board)
+		a.PV = toMoves([]game.Position{move})
+		a.Opening = name
+		a.Book = true
+		return a.PV[0], a, nil
+	}
+	if move, ok := e.positionBookMove(); ok {
+		board, _ := game.ApplyMoveToBoard(e.game.Board, e.game.CurrentPlayer.Color, move)
+		a := e.evaluate(board)
+		a.PV = toMoves([]game.Position{move})
+		a.Book = true
+		return a.PV[0], a, nil
+	}
+
+	var moves []game.Position
+	var score int16
+	var nodes int64
+	opts := evaluation.SearchOptions{Cache: e.cache, Nodes: &nodes, MaxNodes: e.nodes}
+	if !e.misere && e.transcript {
+		opts.BookBias = int16(e.bookBias)
+		opts.Transcript = e.Transcript()
+	}
+	if e.deepeningStats {
+		opts.Deepening = &evaluation.IterativeDeepeningStats{}
+	}
+	depth, err := e.deepen(ctx, func(depth int8) bool {
+		m, s := evaluation.SolveWithOptions(e.game.Board, e.game.CurrentPlayer.Color, depth, e.eval, opts, nil)
+		// A depth cut by the node budget only stands in for a first depth
+		if opts.NodeLimitReached() && moves != nil {
+			return false
+		}
+		moves, score = m, s
+		return !opts.NodeLimitReached()
+	})
+	if err != nil {
+		return "", Analysis{}, err
+	}
+
+	a := e.evaluate(e.game.Board)
+	a.Score = int(score)
+	a.PV = toMoves(moves)
+	a.Depth = depth
+	a.Nodes = nodes
+	a.HashFull = e.cache.HashFull()
+	if d := opts.Deepening; d != nil {
+		a.Deepening = &DeepeningStats{
+			Moves:            toMoves(d.Moves),
+			MoveOrderChanges: append([]int(nil), d.MoveOrderChanges...),
+			Depths:           d.Depths,
+			BestMoveChanges:  d.BestMoveChanges,
+		}
+	}
+	return a.PV[0], a, nil
+}
+
+// Analyze searches every move of the player to move and returns the best lines, best
+// first, at most lines of them or all when lines <= 0. ctx is used as in BestMove.
+func (e *Engine) Analyze(ctx context.Context, lines int) ([]Analysis, error) {
+	if e.GameOver() {
+		return nil, ErrGameOver
+	}
+	if e.game.LegalState() == game.MustPass {
+		return nil, ErrMustPass
+	}
+
+	var pvs []evaluation.PVLine
+	depth, err := e.deepen(ctx, func(depth int8) bool {
+		pvs = evaluation.SolveMultiPV(e.game.Board, e.game.CurrentPlayer.Color, depth, e.eval, lines)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Analysis, len(pvs))
+	for i, pv := range pvs {
+		result[i] = Analysis{Score: int(pv.Score), PV: toMoves(pv.Moves), Depth: depth}
+	}
+	return result, nil
+}
+
+// AnalyzeProgressive searches the player to move at increasing depths up to the search
+// depth, calling onDepth, when not nil, with the best line of each completed depth. The
+// book is not used. ctx and the time budget also stop the depth in progress. It returns
+// the analysis of the deepest completed depth, or ctx.Err() when none completed.
+func (e *Engine) AnalyzeProgressive(ctx context.Context, onDepth func(Analysis)) (Analysis, error) {
+	if e.GameOver() {
+		return Analysis{}, ErrGameOver
+	}
+	if e.game.LegalState() == game.MustPass {
+		return Analysis{}, ErrMustPass
+	}
+	if err := ctx.Err(); err != nil {
+		return Analysis{}, err
+	}
+	if e.budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.budget)
+		defer cancel()
+	}
+
+	analysis := func(r evaluation.IterationResult) Analysis {
+		return Analysis{Score: int(r.Score), PV: toMoves(r.PV), Depth: int(r.Depth), Nodes: r.Nodes, HashFull: e.cache.HashFull()}
+	}
+	opts := evaluation.SearchOptions{Cache: e.cache}
+	r := evaluation.SolveIterativeWithOptions(ctx, e.game.Board, e.game.CurrentPlayer.Color, int8(e.targetDepth()), e.eval, opts, func(r evaluation.IterationResult) {
+		if onDepth != nil {
+			onDepth(analysis(r))
+		}
+	})
+	if r.Depth == 0 {
+		return Analysis{}, ctx.Err()
+	}
+	return analysis(r), nil
+}
+
+// deepen runs search at the search depth, or at increasing depths while the time budget
+// lasts or with WithDeepeningStats, and returns the deepest completed depth. search returns false when the node budget
+// cut it, which ends the deepening, the first depth counting as completed.
+func (e *Engine) deepen(ctx context.Context, search func(depth int8) bool) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	target := e.targetDepth()
+	if e.budget <= 0 && e.nodes <= 0 && !e.deepeningStats {
+		search(int8(target))
+		return target, nil
+	}
+
+	deadline := time.Now().Add(e.budget)
+	for depth := 1; ; depth++ {
+		if !search(int8(depth)) {
+			return max(depth-1, 1), nil
+		}
+		if depth >= target || ctx.Err() != nil || (e.budget > 0 && time.Now().After(deadline)) {
+			return depth, nil
+		}
+	}
+}
+
+// targetDepth returns the depth of the searches of the position: the search depth, or the
+// endgame depth once few enough squares are empty
+func (e *Engine) targetDepth() int {
+	black, white := e.game.CountPiecesMethod()
+	if e.endgameDepth > 0 && 64-black-white <= e.endgameDepth-4 {
+		return e.endgameDepth
+	}
+	return e.depth
+}
+
+// bookMove returns the next move of the longest known opening the game follows
+func (e *Engine) bookMove() (game.Position, string, bool) {
+	if !e.book || e.misere || !e.transcript {
+		return game.Position{}, "", false
+	}
+	transcript := e.Transcript()
+	best := opening.Opening{}
+	for _, op := range opening.MatchOpening(transcript) {
+		if len(op.Transcript) > len(best.Transcript) {
+			best = op
+		}
+	}
+	if len(best.Transcript) <= len(transcript) {
+		return game.Position{}, "", false
+	}
+	move := utils.AlgebraicToPosition(best.Transcript[len(transcript) : len(transcript)+2])
+	return move, best.Name, true
+}
+
+// positionBookMove returns the move of the book of WithPositionBook for the position
+func (e *Engine) positionBookMove() (game.Position, bool) {
+	if !e.book || e.misere || e.positionBook == nil {
+		return game.Position{}, false
+	}
+	move, _, ok := e.positionBook.Probe(utils.BoardToBits(e.game.Board), e.game.CurrentPlayer.Color, e.bookMinGames, e.bookMinWinRate)
+	return move, ok
+}
+
+// colorOf converts a piece to a color
+func colorOf(p game.Piece) Color {
+	switch p {
+	case game.Black:
+		return Black
+	case game.White:
+		return White
+	}
+	return None
+}
+
+// toMoves converts positions to moves, leaving out the passes
+func toMoves(positions []game.Position) []Move {
+	moves := make([]Move, 0, len(positions))
+	for _, pos := range positions {
+		if pos.Row >= 0 {
+			moves = append(moves, Move(utils.PositionToAlgebraic(pos)))
+		}
+	}
+	return moves
+}
@@ -0,0 +1,174 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestBestMoveDeepeningStats(t *testing.T) {
+	e := New(WithDepth(4), WithBook(false), WithDeepeningStats(true))
+	move, a, err := e.BestMove(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := a.Deepening
+	if d == nil {
+		t.Fatal("no deepening stats with WithDeepeningStats")
+	}
+	if d.Depths != 4 || len(d.Moves) != 4 || len(d.MoveOrderChanges) != len(d.Moves) {
+		t.Fatalf("deepening stats %+v, want 4 depths of the 4 opening moves", d)
+	}
+	found := false
+	for _, m := range d.Moves {
+		found = found || m == move
+	}
+	if !found {
+		t.Errorf("best move %s not among the moves %v", move, d.Moves)
+	}
+	if s := d.Stability(); s < 0 || s > 1 {
+		t.Errorf("stability %v out of [0, 1]", s)
+	}
+
+	_, a, err = New(WithDepth(4), WithBook(false)).BestMove(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Deepening != nil {
+		t.Errorf("deepening stats %+v without WithDeepeningStats", a.Deepening)
+	}
+}
+
+func TestAnalyzeKnownLine(t *testing.T) {
+	// Black a1, White b1 and c2: Black c1 is the only move, White must pass, then Black c3
+	// takes the last white disc
+	e := New(WithDepth(4), WithBook(false))
+	board := "XO--------O" + strings.Repeat("-", 53)
+	if err := e.SetBoard(board, Black); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := e.Analyze(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 1 || !slices.Equal(lines[0].PV, []Move{"c1", "c3"}) || lines[0].Depth != 4 {
+		t.Fatalf("lines %+v, want the single line c1 c3 at depth 4", lines)
+	}
+
+	// The score is the one of the wipeout at the end of the line
+	final := New()
+	if err := final.SetBoard("XXX-------XX"+strings.Repeat("-", 52), White); err != nil {
+		t.Fatal(err)
+	}
+	if !final.GameOver() {
+		t.Fatal("wipeout not over")
+	}
+	if want := final.Evaluate().Score; lines[0].Score != want || want >= 0 {
+		t.Errorf("score %d, want the final score %d of the black win", lines[0].Score, want)
+	}
+
+	// BestMove plays a single legal move without searching it
+	if move, _, err := e.BestMove(context.Background()); err != nil || move != "c1" {
+		t.Errorf("best move %s (%v), want c1", move, err)
+	}
+}
+
+func TestAnalyzeLines(t *testing.T) {
+	e := New(WithDepth(4), WithBook(false))
+	if err := e.SetPosition("f5d6c3"); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := e.Analyze(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legal := e.LegalMoves()
+	if len(lines) != len(legal) {
+		t.Fatalf("%d lines, want one per legal move %v", len(lines), legal)
+	}
+
+	// The best line is the one of BestMove, the example line
+	if !slices.Equal(lines[0].PV, []Move{"f4", "f6", "f3", "d3"}) {
+		t.Errorf("best line %v, want f4 f6 f3 d3", lines[0].PV)
+	}
+	_, best, err := e.BestMove(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines[0].Score != best.Score {
+		t.Errorf("best line score %d, BestMove %d", lines[0].Score, best.Score)
+	}
+
+	// White to move: best first means the highest score first. Each line starts with a
+	// different legal move and is played out legally.
+	seen := make(map[Move]bool)
+	for i, line := range lines {
+		if i > 0 && line.Score > lines[i-1].Score {
+			t.Errorf("line %d scores %d, above line %d at %d", i, line.Score, i-1, lines[i-1].Score)
+		}
+		if line.Depth != 4 || len(line.PV) == 0 || seen[line.PV[0]] {
+			t.Errorf("line %d: %+v", i, line)
+			continue
+		}
+		seen[line.PV[0]] = true
+		replay := New()
+		if err := replay.SetPosition("f5d6c3"); err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range line.PV {
+			if err := replay.Play(string(m)); err != nil {
+				t.Errorf("line %v: %s: %v", line.PV, m, err)
+				break
+			}
+		}
+	}
+
+	top, err := e.Analyze(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0].Score != lines[0].Score || top[1].Score != lines[1].Score {
+		t.Errorf("2 best lines %+v, want the first lines of the full analysis", top)
+	}
+}
+
+func TestInfluence(t *testing.T) {
+	// Black a1, White b1 and c2, Black to move with c1
+	e := New()
+	if err := e.SetBoard("XO--------O"+strings.Repeat("-", 53), Black); err != nil {
+		t.Fatal(err)
+	}
+	b, err := utils.StringToBoard(e.Board())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := evaluation.SquareInfluence(utils.BoardToBits(b), game.Black, evaluation.NewMixedEvaluation(evaluation.Models[len(evaluation.Models)-1]))
+
+	influence := e.Influence()
+	for row := range influence {
+		for col, v := range influence[row] {
+			if v != int(want[row*8+col]) {
+				t.Errorf("row %d, column %d: influence %d, want %d", row, col, v, want[row*8+col])
+			}
+		}
+	}
+	// Rows start from rank 1: a1 is the black disc, c1 its move and c2 a white disc
+	if influence[0][0] != int(evaluation.MIN_EVAL) || influence[0][2] == 0 || influence[1][2] == 0 || influence[2][2] != 0 {
+		t.Errorf("influence of a1 %d, c1 %d, c2 %d, c3 %d", influence[0][0], influence[0][2], influence[1][2], influence[2][2])
+	}
+
+	var buf bytes.Buffer
+	if err := e.WriteHeatmap(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := png.Decode(&buf); err != nil {
+		t.Errorf("heatmap is not a PNG: %v", err)
+	}
+}
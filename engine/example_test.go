@@ -0,0 +1,25 @@
+package engine_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Coloc3G/othello-engine/engine"
+)
+
+func ExampleEngine_BestMove() {
+	e := engine.New(engine.WithDepth(4), engine.WithBook(false))
+	if err := e.SetPosition("f5d6c3"); err != nil {
+		log.Fatal(err)
+	}
+	move, analysis, err := e.BestMove(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(e.ToMove(), "plays", move)
+	fmt.Println("depth", analysis.Depth, "pv", analysis.PV)
+	// Output:
+	// white plays f4
+	// depth 4 pv [f4 f6 f3 d3]
+}
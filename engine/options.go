@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/config"
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+// Option configures an Engine
+type Option func(*Engine)
+
+// Default settings of an Engine
+const (
+	DefaultDepth = 8
+)
+
+// WithModel sets the evaluation model: a built-in model name or "run:NAME[@genN]" for a
+// model of a training run. New panics on an unknown model, check names with ValidateModel.
+func WithModel(name string) Option {
+	return func(e *Engine) {
+		e.model = name
+	}
+}
+
+// WithDepth sets the search depth in plies
+func WithDepth(depth int) Option {
+	return func(e *Engine) {
+		e.depth = depth
+	}
+}
+
+// WithTime sets a time budget for BestMove: the search deepens until the budget is spent,
+// up to the search depth. The budget is checked between depths. 0 searches at the depth only.
+func WithTime(budget time.Duration) Option {
+	return func(e *Engine) {
+		e.budget = budget
+	}
+}
+
+// WithNodes sets a node budget for BestMove: the search deepens until it has visited that
+// many positions, up to the search depth, and the depth cut by the budget is discarded.
+// Unlike the time budget, it gives the same moves on every machine. See the difficulty
+// presets of evaluation.NodeBudgets. 0 disables it.
+func WithNodes(budget int64) Option {
+	return func(e *Engine) {
+		e.nodes = budget
+	}
+}
+
+// WithDeepeningStats makes BestMove search every depth up to the search depth and report
+// in Analysis.Deepening how the ranking of the moves changed from one depth to the next
+func WithDeepeningStats(enabled bool) Option {
+	return func(e *Engine) {
+		e.deepeningStats = enabled
+	}
+}
+
+// WithHashMB sets the size of the transposition table in megabytes, allocated by New and
+// NewGame. 0, the default, uses evaluation.DefaultHashMB.
+func WithHashMB(mb int) Option {
+	return func(e *Engine) {
+		e.hashMB = mb
+	}
+}
+
+// WithEndgameDepth searches at depth once the game is that close to its end, the search
+// then reaching the final position: with at most depth-4 empty squares, the 4 extra plies
+// covering passes. 0 disables it.
+func WithEndgameDepth(depth int) Option {
+	return func(e *Engine) {
+		e.endgameDepth = depth
+	}
+}
+
+// WithBook enables or disables the opening book, used by BestMove while the game follows
+// a known opening, and the book of WithPositionBook. It is enabled by default and never
+// used for the misère variant.
+func WithBook(enabled bool) Option {
+	return func(e *Engine) {
+		e.book = enabled
+	}
+}
+
+// WithPositionBook plays the moves of book without search while the opening book is
+// enabled: the move leading to the best win rate among the book positions reached by at
+// least minGames games with a win rate above minWinRate, see opening.Book.Probe. Unlike
+// the named openings of WithBook, positions are looked up up to the symmetries of the
+// square, and after SetBoard too. nil, the default, disables it.
+func WithPositionBook(book *opening.Book, minGames int, minWinRate float64) Option {
+	return func(e *Engine) {
+		e.positionBook = book
+		e.bookMinGames = minGames
+		e.bookMinWinRate = minWinRate
+	}
+}
+
+// WithBookBias adds bias to the score of the moves continuing a known opening during the
+// first plies, so the search prefers them over moves scored about as well. Unlike the book,
+// which plays its moves without search, it only breaks near ties. 0, the default, disables it.
+func WithBookBias(bias int) Option {
+	return func(e *Engine) {
+		e.bookBias = bias
+	}
+}
+
+// WithMisere plays the misère variant, where the player with the fewest discs wins
+func WithMisere(misere bool) Option {
+	return func(e *Engine) {
+		e.misere = misere
+	}
+}
+
+// ValidateModel returns an error when WithModel does not accept name
+func ValidateModel(name string) error {
+	_, err := config.Config{Model: name}.Coefficients()
+	if err != nil {
+		return fmt.Errorf("engine: %w", err)
+	}
+	return nil
+}
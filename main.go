@@ -5,13 +5,21 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Coloc3G/othello-engine/models/config"
 	"github.com/Coloc3G/othello-engine/ui"
 )
 
 func main() {
 	// Define minimal command line flags
+	cfg := config.Default()
+	config.RegisterFlags(flag.CommandLine, &cfg, 0)
 	helpPtr := flag.Bool("help", false, "Show help information")
-	flag.Parse()
+	modelsPtr := flag.String("models", "", "Directory of JSON models to offer in the AI selection screens")
+	bookPtr := flag.String("book-file", "", "Games of a position book, one \"transcript result\" per line, whose moves the AIs play without search")
+	if err := config.Parse(flag.CommandLine, os.Args[1:], &cfg); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 
 	// Show help information if requested
 	if *helpPtr {
@@ -25,5 +33,5 @@ func main() {
 
 	// Launch the UI-based game
 	fmt.Println("Starting Othello game...")
-	ui.RunUI()
+	ui.RunUI(*modelsPtr, *bookPtr)
 }
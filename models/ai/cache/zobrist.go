@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"math/bits"
+	"math/rand"
+	"sync"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// ZobristSeed seeds the global table so hashes are identical across runs
+const ZobristSeed int64 = 0x0711e110
+
+// ZobristTable holds the random keys of every (square, piece) pair and of the side to move
+type ZobristTable struct {
+	squares [3][64]uint64 // Indexed by game.Piece, then by row*8+col
+	players [2]uint64     // Indexed by player - 1
+}
+
+var (
+	globalZobristOnce sync.Once
+	// Table of GlobalZobrist, created on first use
+	globalZobrist *ZobristTable
+)
+
+// GlobalZobrist returns the table shared by every hash of the engine, the table of
+// ZobristSeed created on first use. It is read-only once created.
+func GlobalZobrist() *ZobristTable {
+	globalZobristOnce.Do(func() {
+		globalZobrist = NewZobristTable(ZobristSeed)
+	})
+	return globalZobrist
+}
+
+// NewZobristTable creates a table with keys drawn from seed
+func NewZobristTable(seed int64) *ZobristTable {
+	r := rand.New(rand.NewSource(seed))
+	t := &ZobristTable{}
+	for p := range t.squares {
+		for sq := range t.squares[p] {
+			t.squares[p][sq] = r.Uint64()
+		}
+	}
+	for p := range t.players {
+		t.players[p] = r.Uint64()
+	}
+	return t
+}
+
+// Key returns the key of a piece on a square
+func (t *ZobristTable) Key(row, col int, piece game.Piece) uint64 {
+	return t.squares[piece][row*8+col]
+}
+
+// PlayerKey returns the key of the side to move
+func (t *ZobristTable) PlayerKey(player game.Piece) uint64 {
+	return t.players[player-1]
+}
+
+// Hash returns the key of a position, empty squares do not change the hash
+func (t *ZobristTable) Hash(bb game.BitBoard, player game.Piece) uint64 {
+	h := t.PlayerKey(player)
+	for b := bb.BlackPieces; b != 0; b &= b - 1 {
+		h ^= t.squares[game.Black][bits.TrailingZeros64(b)]
+	}
+	for w := bb.WhitePieces; w != 0; w &= w - 1 {
+		h ^= t.squares[game.White][bits.TrailingZeros64(w)]
+	}
+	return h
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestZobristKeysDistinct(t *testing.T) {
+	seen := make(map[uint64]string, 3*64+2)
+	add := func(key uint64, name string) {
+		if key == 0 {
+			t.Errorf("key of %s is 0", name)
+		}
+		if other, ok := seen[key]; ok {
+			t.Errorf("keys of %s and %s are equal", name, other)
+		}
+		seen[key] = name
+	}
+	for _, piece := range []game.Piece{game.Empty, game.White, game.Black} {
+		for row := range 8 {
+			for col := range 8 {
+				add(GlobalZobrist().Key(row, col, piece), fmt.Sprintf("%s on %c%d", pieceName(piece), 'a'+col, row+1))
+			}
+		}
+	}
+	add(GlobalZobrist().PlayerKey(game.Black), "black to move")
+	add(GlobalZobrist().PlayerKey(game.White), "white to move")
+	if len(seen) != 3*64+2 {
+		t.Errorf("%d distinct keys, want %d", len(seen), 3*64+2)
+	}
+}
+
+func pieceName(p game.Piece) string {
+	switch p {
+	case game.White:
+		return "white"
+	case game.Black:
+		return "black"
+	}
+	return "empty"
+}
+
+func TestZobristTableReproducible(t *testing.T) {
+	a, b := NewZobristTable(ZobristSeed), NewZobristTable(ZobristSeed)
+	if *a != *b {
+		t.Error("two tables of the same seed differ")
+	}
+	if *a != *GlobalZobrist() {
+		t.Error("GlobalZobrist is not the table of ZobristSeed")
+	}
+	if c := NewZobristTable(ZobristSeed + 1); *a == *c {
+		t.Error("tables of different seeds are equal")
+	}
+}
+
+func TestZobristHashIncremental(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	hashes := make(map[uint64]game.BitBoard)
+	for range 50 {
+		bb, player := game.BitBoard{BlackPieces: 0x0000000810000000, WhitePieces: 0x0000001008000000}, game.Black
+		for !game.IsGameFinishedBitBoard(bb) {
+			moves := game.ValidMovesBitBoard(bb, player)
+			if len(moves) == 0 {
+				player = game.GetOpponentColor(player)
+				continue
+			}
+			after, _ := game.ApplyMoveToBitBoard(bb, player, moves[rng.Intn(len(moves))])
+			opponent := game.GetOpponentColor(player)
+
+			// The hash after the move is the one before with the changed squares and the
+			// side to move swapped
+			want := GlobalZobrist().Hash(bb, player) ^ GlobalZobrist().PlayerKey(player) ^ GlobalZobrist().PlayerKey(opponent)
+			for changed := (bb.BlackPieces ^ after.BlackPieces) | (bb.WhitePieces ^ after.WhitePieces); changed != 0; changed &= changed - 1 {
+				sq := bits.TrailingZeros64(changed)
+				if bb.BlackPieces&(1<<sq) != 0 {
+					want ^= GlobalZobrist().Key(sq/8, sq%8, game.Black)
+				}
+				if bb.WhitePieces&(1<<sq) != 0 {
+					want ^= GlobalZobrist().Key(sq/8, sq%8, game.White)
+				}
+				if after.BlackPieces&(1<<sq) != 0 {
+					want ^= GlobalZobrist().Key(sq/8, sq%8, game.Black)
+				}
+				if after.WhitePieces&(1<<sq) != 0 {
+					want ^= GlobalZobrist().Key(sq/8, sq%8, game.White)
+				}
+			}
+			got := GlobalZobrist().Hash(after, opponent)
+			if got != want {
+				t.Fatalf("hash of %+v is %x, want %x", after, got, want)
+			}
+			if other, ok := hashes[got]; ok && other != after {
+				t.Fatalf("positions %+v and %+v share the hash %x", other, after, got)
+			}
+			hashes[got] = after
+			bb, player = after, opponent
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package evaluation
+
+import (
+	"math/bits"
+	"math/rand"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// RandomPlayer is the Searcher playing a uniformly random valid move, the weakest baseline
+// of tournaments and ratings. It is not safe for concurrent use.
+type RandomPlayer struct {
+	rng *rand.Rand
+}
+
+// NewRandomPlayer returns a RandomPlayer whose moves are drawn from seed, so a game
+// between the same players replays the same moves
+func NewRandomPlayer(seed int64) *RandomPlayer {
+	return &RandomPlayer{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Search returns a random valid move and the static score of the position it leads to
+func (p *RandomPlayer) Search(b game.Board, player game.Piece) ([]game.Position, int16) {
+	bb := utils.BoardToBits(b)
+	moves := game.ValidMovesBitBoard(bb, player)
+	if len(moves) == 0 {
+		return []game.Position{{Row: -1, Col: -1}}, -1
+	}
+	move := moves[p.rng.Intn(len(moves))]
+	next, _ := game.GetNewBitBoardAfterMove(bb, move, player)
+	return []game.Position{move}, game.BitBoardFastScore(next)
+}
+
+// GreedyPlayer is the Searcher playing the move flipping the most discs, a corner first
+// between moves flipping as many, then the first in row-major order: a1 to h1, then a2 to
+// h8. It looks one ply ahead and evaluates nothing, a baseline any search should beat.
+type GreedyPlayer struct{}
+
+// Search returns the greedy move and the static score of the position it leads to
+func (GreedyPlayer) Search(b game.Board, player game.Piece) ([]game.Position, int16) {
+	bb := utils.BoardToBits(b)
+	mask := game.ValidMovesMask(bb, player)
+	if mask == 0 {
+		return []game.Position{{Row: -1, Col: -1}}, -1
+	}
+
+	own := func(bb game.BitBoard) uint64 {
+		if player == game.White {
+			return bb.WhitePieces
+		}
+		return bb.BlackPieces
+	}
+	var best game.Position
+	var bestNext game.BitBoard
+	bestFlips, bestCorner := -1, false
+	// Squares in row-major order, a1 to h1 then a2 to h8, the first best move staying
+	for ; mask != 0; mask &= mask - 1 {
+		square := bits.TrailingZeros64(mask)
+		move := game.Position{Row: int8(square / 8), Col: int8(square % 8)}
+		next, _ := game.GetNewBitBoardAfterMove(bb, move, player)
+		flips := bits.OnesCount64(own(next)) - bits.OnesCount64(own(bb)) - 1
+		corner := isCornerMove(move)
+		if flips > bestFlips || (flips == bestFlips && corner && !bestCorner) {
+			best, bestNext, bestFlips, bestCorner = move, next, flips, corner
+		}
+	}
+	return []game.Position{best}, game.BitBoardFastScore(bestNext)
+}
@@ -0,0 +1,69 @@
+package evaluation
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// discs returns the bitboard of squares in algebraic notation
+func discs(squares ...string) uint64 {
+	var mask uint64
+	for _, square := range squares {
+		pos := utils.AlgebraicToPosition(square)
+		mask |= 1 << (pos.Row*8 + pos.Col)
+	}
+	return mask
+}
+
+func TestGreedyPlayer(t *testing.T) {
+	tests := []struct {
+		name         string
+		white, black uint64
+		want         string
+	}{
+		// d1 flips d2, f1 flips f2 and f3
+		{"most flips", discs("d3", "f4"), discs("d2", "f2", "f3"), "f1"},
+		// d1 flips d2, h8 flips g7
+		{"corner between equal flips", discs("d3", "f6"), discs("d2", "g7"), "h8"},
+		// d1 flips d2, f1 flips f2
+		{"row-major between equal flips", discs("d3", "f3"), discs("d2", "f2"), "d1"},
+		// d1 flips d2, a2 flips b2
+		{"first rank before the second", discs("d3", "c2"), discs("d2", "b2"), "d1"},
+		// c3 flips c4, a5 flips b5
+		{"inner square before a later edge", discs("c5"), discs("c4", "b5"), "c3"},
+	}
+	for _, tt := range tests {
+		bb := game.BitBoard{WhitePieces: tt.white, BlackPieces: tt.black}
+		moves, score := GreedyPlayer{}.Search(utils.BitsToBoard(bb), game.White)
+		if got := utils.PositionToAlgebraic(moves[0]); got != tt.want {
+			t.Errorf("%s: %s among %v, want %s", tt.name, got, game.ValidMovesBitBoard(bb, game.White), tt.want)
+			continue
+		}
+		next, _ := game.GetNewBitBoardAfterMove(bb, moves[0], game.White)
+		if want := game.BitBoardFastScore(next); score != want {
+			t.Errorf("%s: score %d, want the fast score %d of the position after the move", tt.name, score, want)
+		}
+	}
+
+	bb := game.BitBoard{WhitePieces: discs("a1"), BlackPieces: discs("b1")}
+	if moves, _ := (GreedyPlayer{}).Search(utils.BitsToBoard(bb), game.Black); len(moves) != 1 || moves[0] != game.PassMove {
+		t.Errorf("move %v without a legal move, want a pass", moves)
+	}
+}
+
+func TestRandomPlayer(t *testing.T) {
+	boards, players := randomBitBoards(t, 23, 20)
+	first, second := NewRandomPlayer(4), NewRandomPlayer(4)
+	for i, bb := range boards {
+		moves, _ := first.Search(utils.BitsToBoard(bb), players[i])
+		if !slices.Contains(game.ValidMovesBitBoard(bb, players[i]), moves[0]) {
+			t.Errorf("board %d: move %v is not legal", i, moves[0])
+		}
+		if again, _ := second.Search(utils.BitsToBoard(bb), players[i]); again[0] != moves[0] {
+			t.Errorf("board %d: moves %v and %v from the same seed", i, moves[0], again[0])
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package evaluation
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// EvaluateBatchCPU evaluates many boards at once using a pool of worker goroutines.
+// It is the CPU counterpart of a GPU batch evaluation: results[i] is eval.Evaluate
+// applied to boards[i]. Scores are from White's perspective like every Evaluation,
+// players is only checked for length so callers can pass the same arguments they
+// would give to a batch API that needs the side to move.
+func EvaluateBatchCPU(boards []game.Board, players []game.Piece, eval Evaluation) []int16 {
+	if players != nil && len(players) != len(boards) {
+		panic("EvaluateBatchCPU: boards and players must have the same length")
+	}
+
+	results := make([]int16, len(boards))
+	if len(boards) == 0 {
+		return results
+	}
+
+	numWorkers := min(runtime.NumCPU(), len(boards))
+	jobsCh := make(chan int, len(boards))
+	for i := range boards {
+		jobsCh <- i
+	}
+	close(jobsCh)
+
+	var wg sync.WaitGroup
+	for range numWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobsCh {
+				// Each index is written by exactly one worker, no locking needed
+				results[i] = eval.Evaluate(utils.BoardToBits(boards[i]))
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,40 @@
+package evaluation
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestEvaluateBatchCPUMatchesEvaluate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var boards []game.Board
+	var players []game.Piece
+	for i := range 200 {
+		g, err := game.RandomReachableBoard(rng, i%60)
+		if err != nil {
+			t.Fatal(err)
+		}
+		boards = append(boards, g.Board)
+		players = append(players, g.CurrentPlayer.Color)
+	}
+
+	eval := NewMixedEvaluation(V7Coeff)
+	results := EvaluateBatchCPU(boards, players, eval)
+	if len(results) != len(boards) {
+		t.Fatalf("got %d results for %d boards", len(results), len(boards))
+	}
+	for i, b := range boards {
+		if want := eval.Evaluate(utils.BoardToBits(b)); results[i] != want {
+			t.Errorf("board %d: batch score %d, Evaluate %d", i, results[i], want)
+		}
+	}
+}
+
+func TestEvaluateBatchCPUEmpty(t *testing.T) {
+	if results := EvaluateBatchCPU(nil, nil, NewMixedEvaluation(V7Coeff)); len(results) != 0 {
+		t.Errorf("got %d results for no board", len(results))
+	}
+}
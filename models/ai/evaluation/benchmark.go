@@ -0,0 +1,78 @@
+package evaluation
+
+import (
+	"math/rand"
+	"runtime"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// EvalBenchmarkResult is the speed of an Evaluation measured by BenchmarkEvaluation
+type EvalBenchmarkResult struct {
+	Positions          int
+	PositionsPerSecond float64
+	NsPerCall          float64
+	AllocsPerCall      float64
+}
+
+// benchmarkSeed makes every benchmark run on the same positions
+const benchmarkSeed = 1
+
+// BenchmarkEvaluation times eval.PECEvaluate over numPositions positions reached by random
+// games from the start. The positions and their precomputations are built before the timed
+// loop, so only the evaluation itself is measured.
+func BenchmarkEvaluation(eval Evaluation, numPositions int) EvalBenchmarkResult {
+	boards, pecs := benchmarkPositions(numPositions)
+	result := EvalBenchmarkResult{Positions: len(boards)}
+	if len(boards) == 0 {
+		return result
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	var sink int16
+	for i := range boards {
+		sink ^= eval.PECEvaluate(boards[i], pecs[i])
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	_ = sink
+
+	calls := float64(len(boards))
+	result.NsPerCall = float64(elapsed.Nanoseconds()) / calls
+	result.PositionsPerSecond = calls / elapsed.Seconds()
+	result.AllocsPerCall = float64(after.Mallocs-before.Mallocs) / calls
+	return result
+}
+
+// benchmarkPositions returns n positions of random games with their precomputations.
+// The final position of a game is kept, the search evaluates those too.
+func benchmarkPositions(n int) ([]game.BitBoard, []PreEvaluationComputation) {
+	rng := rand.New(rand.NewSource(benchmarkSeed))
+	boards := make([]game.BitBoard, 0, n)
+	for len(boards) < n {
+		g := game.NewGame("Black", "White")
+		for len(boards) < n {
+			state := g.LegalState()
+			if state == game.GameOver {
+				break
+			}
+			if state == game.MustPass {
+				g.Pass()
+				continue
+			}
+			moves := g.GetValidMovesForCurrentPlayer()
+			g.ApplyMove(moves[rng.Intn(len(moves))])
+			boards = append(boards, game.BoardToBitBoard(g.Board))
+		}
+	}
+
+	pecs := make([]PreEvaluationComputation, len(boards))
+	for i, b := range boards {
+		pecs[i] = PrecomputeEvaluationBitBoard(b)
+	}
+	return boards, pecs
+}
@@ -0,0 +1,28 @@
+package evaluation
+
+import (
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// BookBiasPlies is the number of plies from the start of the game during which
+// SearchOptions.BookBias applies
+const BookBiasPlies = 12
+
+// bookBonuses returns the bonus of each root move for player, positive for White, or nil
+// when the book bias does not apply
+func bookBonuses(opts *SearchOptions, player game.Piece) map[game.Position]int16 {
+	if opts == nil || opts.BookBias == 0 || len(opts.Transcript)/2 >= BookBiasPlies {
+		return nil
+	}
+	bonus := opts.BookBias
+	if player == game.Black {
+		bonus = -bonus
+	}
+	bonuses := make(map[game.Position]int16)
+	for _, move := range opening.Continuations(opts.Transcript) {
+		bonuses[utils.AlgebraicToPosition(move)] = bonus
+	}
+	return bonuses
+}
@@ -0,0 +1,128 @@
+package evaluation
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// transcriptPosition plays a transcript from the initial position and returns the
+// position reached with its player to move
+func transcriptPosition(t *testing.T, transcript string) (game.BitBoard, game.Piece) {
+	t.Helper()
+	g := game.NewGame("Black", "White")
+	for _, move := range utils.AlgebraicToPositions(transcript) {
+		if err := g.ApplyMove(move); err != nil {
+			t.Fatalf("transcript %s: %v", transcript, err)
+		}
+		if g.LegalState() == game.MustPass {
+			g.Pass()
+		}
+	}
+	return utils.BoardToBits(g.Board), g.CurrentPlayer.Color
+}
+
+// rootScores returns the score of each move of player, each searched with a full window
+func rootScores(bb game.BitBoard, player game.Piece, depth int8, eval Evaluation) map[game.Position]int16 {
+	scores := make(map[game.Position]int16)
+	for _, move := range game.ValidMovesBitBoard(bb, player) {
+		child, _ := game.GetNewBitBoardAfterMove(bb, move, player)
+		scores[move], _ = mmab(child, game.GetOpponentColor(player), depth-1, MIN_EVAL-65, MAX_EVAL+65, eval, nil, nil, nil, &SearchOptions{DisableTT: true}, 0)
+	}
+	return scores
+}
+
+func TestBookBiasPrefersBookMove(t *testing.T) {
+	const depth = 3
+	eval := NewMixedEvaluation(V7Coeff)
+
+	// Find an opening position where the search leaves the book by a few points
+	tested := 0
+	seen := make(map[string]bool)
+	for _, op := range opening.KNOWN_OPENINGS {
+		for plies := 1; plies < len(op.Transcript)/2 && plies < BookBiasPlies; plies++ {
+			transcript := op.Transcript[:2*plies]
+			if seen[transcript] {
+				continue
+			}
+			seen[transcript] = true
+			bb, player := transcriptPosition(t, transcript)
+			var book []game.Position
+			for _, move := range opening.Continuations(transcript) {
+				book = append(book, utils.AlgebraicToPosition(move))
+			}
+			moves, score := solve(bb, player, depth, eval, nil, nil, &SearchOptions{Transcript: transcript})
+			if slices.Contains(book, moves[0]) {
+				continue
+			}
+
+			// The book move closest to the best move, for player
+			scores := rootScores(bb, player, depth, eval)
+			bookMove, gap := book[0], int16(MAX_EVAL)
+			for _, move := range book {
+				g := score - scores[move]
+				if player == game.Black {
+					g = -g
+				}
+				if g < gap {
+					bookMove, gap = move, g
+				}
+			}
+			if gap <= 1 || gap > 200 {
+				continue
+			}
+			tested++
+
+			biased, biasedScore := solve(bb, player, depth, eval, nil, nil, &SearchOptions{Transcript: transcript, BookBias: gap + 1})
+			if biased[0] != bookMove {
+				t.Errorf("%s: bias %d plays %v, want the book move %v scoring %d below %v", transcript, gap+1, biased[0], bookMove, gap, moves[0])
+			}
+			// The bias chooses the move but is not part of the score
+			if biasedScore != scores[bookMove] {
+				t.Errorf("%s: biased search scores %d, the book move %d", transcript, biasedScore, scores[bookMove])
+			}
+
+			small, smallScore := solve(bb, player, depth, eval, nil, nil, &SearchOptions{Transcript: transcript, BookBias: gap - 1})
+			if small[0] != moves[0] || smallScore != score {
+				t.Errorf("%s: bias %d below the gap plays %v %d, want %v %d", transcript, gap-1, small[0], smallScore, moves[0], score)
+			}
+		}
+	}
+	if tested == 0 {
+		t.Fatal("no opening position where the search leaves the book")
+	}
+	t.Logf("%d opening positions leaving the book", tested)
+}
+
+func TestBookBiasOnlyEarly(t *testing.T) {
+	if bonuses := bookBonuses(&SearchOptions{Transcript: "f5", BookBias: 10}, game.White); len(bonuses) == 0 {
+		t.Error("no bonus after f5")
+	}
+	if bonuses := bookBonuses(&SearchOptions{Transcript: "f5"}, game.White); bonuses != nil {
+		t.Errorf("bonuses %v without a bias", bonuses)
+	}
+	if bonuses := bookBonuses(nil, game.White); bonuses != nil {
+		t.Errorf("bonuses %v without options", bonuses)
+	}
+	late := "f5d6c3d3c4f4f6f3e6e7d7c5"
+	if len(late)/2 != BookBiasPlies {
+		t.Fatalf("transcript of %d plies, want %d", len(late)/2, BookBiasPlies)
+	}
+	if bonuses := bookBonuses(&SearchOptions{Transcript: late, BookBias: 10}, game.Black); bonuses != nil {
+		t.Errorf("bonuses %v after %d plies", bonuses, BookBiasPlies)
+	}
+	// In favor of the player to move, positive for White
+	for move, bonus := range bookBonuses(&SearchOptions{Transcript: "f5", BookBias: 10}, game.White) {
+		if bonus != 10 {
+			t.Errorf("bonus %d of %v for White, want 10", bonus, move)
+		}
+	}
+	for move, bonus := range bookBonuses(&SearchOptions{Transcript: "f5d6", BookBias: 10}, game.Black) {
+		if bonus != -10 {
+			t.Errorf("bonus %d of %v for Black, want -10", bonus, move)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package evaluation
+
+import (
+	"sync/atomic"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// Node budgets of the difficulty presets, each ten times the previous one. The strength
+// grows more smoothly with the budget than with the depth, where one more ply often
+// multiplies the work by five or more.
+const (
+	NodesEasy   int64 = 2_000
+	NodesMedium int64 = 20_000
+	NodesHard   int64 = 200_000
+	NodesExpert int64 = 2_000_000
+)
+
+// NodeBudgets lists the difficulty presets, weakest first
+var NodeBudgets = []int64{NodesEasy, NodesMedium, NodesHard, NodesExpert}
+
+// SolveNodes searches at increasing depths, up to maxDepth, until budget positions are
+// visited, and returns the result of the deepest completed depth with that depth. The depth
+// cut by the budget is discarded, unless it is the first one. The transposition table is
+// shared between the depths.
+func SolveNodes(b game.Board, player game.Piece, maxDepth int8, eval Evaluation, budget int64) ([]game.Position, int16, int8) {
+	return solveNodes(b, player, maxDepth, eval, budget, nil)
+}
+
+// solveNodes is SolveNodes cut once stop, when not nil, holds true, as the budget cuts it
+func solveNodes(b game.Board, player game.Piece, maxDepth int8, eval Evaluation, budget int64, stop *atomic.Bool) ([]game.Position, int16, int8) {
+	bb := utils.BoardToBits(b)
+	var nodes int64
+	opts := SearchOptions{Cache: NewCache(), Nodes: &nodes, MaxNodes: budget, Stop: stop}
+
+	var moves []game.Position
+	var score int16
+	var completed int8
+	for depth := int8(1); depth <= maxDepth; depth++ {
+		m, s := solve(bb, player, depth, eval, nil, nil, &opts)
+		if opts.NodeLimitReached() && completed > 0 {
+			break
+		}
+		moves, score, completed = m, s, depth
+		if opts.NodeLimitReached() {
+			break
+		}
+	}
+	return moves, score, completed
+}
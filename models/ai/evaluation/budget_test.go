@@ -0,0 +1,125 @@
+package evaluation
+
+import (
+	"math/bits"
+	"slices"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestSolveNodesReturnsLegalMove(t *testing.T) {
+	boards, players := randomBitBoards(t, 31, 50)
+	eval := NewMixedEvaluation(V7Coeff)
+	for i, bb := range boards {
+		moves, _, depth := SolveNodes(utils.BitsToBoard(bb), players[i], 20, eval, 1_000)
+		if !slices.Contains(game.ValidMovesBitBoard(bb, players[i]), moves[0]) {
+			t.Errorf("board %d: illegal move %v", i, moves[0])
+		}
+		if depth < 1 {
+			t.Errorf("board %d: no completed depth", i)
+		}
+	}
+}
+
+func TestMaxNodesBoundsNodes(t *testing.T) {
+	boards, players := randomBitBoards(t, 32, 30)
+	eval := NewMixedEvaluation(V7Coeff)
+	for _, budget := range []int64{1, 10, 1_000} {
+		for i, bb := range boards {
+			_, _, nodes := searchNodes(bb, players[i], 8, eval, SearchOptions{MaxNodes: budget})
+			if nodes > budget {
+				t.Errorf("board %d: %d nodes with a budget of %d", i, nodes, budget)
+			}
+		}
+	}
+}
+
+func TestMaxNodesDisabled(t *testing.T) {
+	boards, players := randomBitBoards(t, 33, 20)
+	eval := NewMixedEvaluation(V7Coeff)
+	for i, bb := range boards {
+		want, wantScore, wantNodes := searchNodes(bb, players[i], 4, eval, SearchOptions{})
+		// A budget larger than the search does not change it
+		for _, budget := range []int64{0, wantNodes + 1} {
+			move, score, nodes := searchNodes(bb, players[i], 4, eval, SearchOptions{MaxNodes: budget})
+			if move != want || score != wantScore || nodes != wantNodes {
+				t.Errorf("board %d, budget %d: %v %d in %d nodes, want %v %d in %d nodes", i, budget, move, score, nodes, want, wantScore, wantNodes)
+			}
+		}
+	}
+}
+
+func TestMaxNodesKeepsTableExact(t *testing.T) {
+	boards, players := randomBitBoards(t, 34, 20)
+	eval := NewMixedEvaluation(V7Coeff)
+	for i, bb := range boards {
+		want, wantScore := solve(bb, players[i], 5, eval, nil, nil, &SearchOptions{DisableTT: true})
+
+		// A search cut by the budget leaves only complete results in the shared table
+		cache := NewCache()
+		var nodes int64
+		solve(bb, players[i], 5, eval, nil, nil, &SearchOptions{Cache: cache, Nodes: &nodes, MaxNodes: 200})
+		moves, score := solve(bb, players[i], 5, eval, nil, nil, &SearchOptions{Cache: cache})
+		if score != wantScore {
+			t.Errorf("board %d: %v %d after a cut search, want %v %d", i, moves[0], score, want[0], wantScore)
+		}
+	}
+}
+
+// playNodesGame plays a game from bb, player to move, between two node budgets, player
+// searching with budgets[0], and returns the final disc margin of player
+func playNodesGame(bb game.BitBoard, player game.Piece, budgets [2]int64, eval Evaluation) int {
+	first := player
+	turn := 0
+	for !game.IsGameFinishedBitBoard(bb) {
+		if len(game.ValidMovesBitBoard(bb, player)) > 0 {
+			moves, _, _ := SolveNodes(utils.BitsToBoard(bb), player, 60, eval, budgets[turn])
+			bb, _ = game.GetNewBitBoardAfterMove(bb, moves[0], player)
+		}
+		player, turn = game.GetOpponentColor(player), 1-turn
+	}
+	margin := bits.OnesCount64(bb.BlackPieces) - bits.OnesCount64(bb.WhitePieces)
+	if first == game.White {
+		return -margin
+	}
+	return margin
+}
+
+func TestNodeBudgetsStrengthOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("plays full games")
+	}
+	budgets := []int64{50, 500, 5_000}
+	eval := NewMixedEvaluation(V7Coeff)
+	openings, players := randomBitBoards(t, 35, 8)
+
+	// points[i] counts the wins of budgets[i] in a round-robin, each pair playing both
+	// sides of every opening
+	points := make([]float64, len(budgets))
+	for i := range budgets {
+		for j := i + 1; j < len(budgets); j++ {
+			for k, bb := range openings {
+				for _, order := range [][2]int{{i, j}, {j, i}} {
+					margin := playNodesGame(bb, players[k], [2]int64{budgets[order[0]], budgets[order[1]]}, eval)
+					switch {
+					case margin > 0:
+						points[order[0]]++
+					case margin < 0:
+						points[order[1]]++
+					default:
+						points[order[0]] += 0.5
+						points[order[1]] += 0.5
+					}
+				}
+			}
+		}
+	}
+	t.Logf("points of budgets %v: %v", budgets, points)
+	for i := 1; i < len(budgets); i++ {
+		if points[i] <= points[i-1] {
+			t.Errorf("budget %d scores %v points, not more than budget %d with %v", budgets[i], points[i], budgets[i-1], points[i-1])
+		}
+	}
+}
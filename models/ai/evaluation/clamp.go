@@ -0,0 +1,28 @@
+package evaluation
+
+import "github.com/Coloc3G/othello-engine/models/game"
+
+// MaxNonTerminalScore bounds the score ScoreClamp gives a position that is not finished,
+// well below the scores of a finished game, which start at MAX_EVAL
+const MaxNonTerminalScore int16 = 10000
+
+// ScoreClamp limits the scores of an evaluation to [-MaxNonTerminalScore,
+// MaxNonTerminalScore] for the positions that are not finished, so a large bonus, such as
+// for a corner capture, is not mistaken by the search for a won game. Finished positions
+// keep their score. See SearchOptions.ClampScore.
+type ScoreClamp struct {
+	Inner Evaluation
+}
+
+func (c ScoreClamp) Evaluate(b game.BitBoard) int16 {
+	pec := PrecomputeEvaluationBitBoard(b)
+	return c.PECEvaluate(b, pec)
+}
+
+func (c ScoreClamp) PECEvaluate(b game.BitBoard, pec PreEvaluationComputation) int16 {
+	score := c.Inner.PECEvaluate(b, pec)
+	if pec.IsGameOver {
+		return score
+	}
+	return min(max(score, -MaxNonTerminalScore), MaxNonTerminalScore)
+}
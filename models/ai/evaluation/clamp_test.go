@@ -0,0 +1,72 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// constEvaluation scores every position the same
+type constEvaluation int16
+
+func (c constEvaluation) Evaluate(game.BitBoard) int16 { return int16(c) }
+func (c constEvaluation) PECEvaluate(game.BitBoard, PreEvaluationComputation) int16 {
+	return int16(c)
+}
+
+// cornerRush scores a corner as much as a won game, the case ScoreClamp guards against
+type cornerRush struct{}
+
+func (cornerRush) Evaluate(b game.BitBoard) int16 {
+	return 15000 * NewCornersEvaluation().Evaluate(b)
+}
+func (e cornerRush) PECEvaluate(b game.BitBoard, _ PreEvaluationComputation) int16 {
+	return e.Evaluate(b)
+}
+
+func TestScoreClamp(t *testing.T) {
+	boards, _ := randomBitBoards(t, 51, 30)
+	tests := []struct {
+		score, want int16
+	}{
+		{MIN_EVAL - 64, -MaxNonTerminalScore},
+		{MAX_EVAL + 64, MaxNonTerminalScore},
+		{-MaxNonTerminalScore - 1, -MaxNonTerminalScore},
+		{MaxNonTerminalScore, MaxNonTerminalScore},
+		{123, 123},
+	}
+	for _, tt := range tests {
+		clamp := ScoreClamp{Inner: constEvaluation(tt.score)}
+		for i, bb := range boards {
+			if got := evaluateBoth(t, clamp, bb); got != tt.want {
+				t.Errorf("board %d: %d clamped to %d, want %d", i, tt.score, got, tt.want)
+			}
+		}
+		// A finished game keeps its score
+		full := game.BitBoard{WhitePieces: ^uint64(0)}
+		if got := evaluateBoth(t, clamp, full); got != tt.score {
+			t.Errorf("finished game: %d clamped to %d", tt.score, got)
+		}
+	}
+}
+
+func TestClampScoreSearch(t *testing.T) {
+	bb := cornerBoard(t)
+	for depth := int8(1); depth <= 3; depth++ {
+		_, score := solve(bb, game.White, depth, cornerRush{}, nil, nil, &SearchOptions{})
+		if score <= MaxNonTerminalScore {
+			t.Fatalf("depth %d: score %d without the clamp, want the corner beyond MaxNonTerminalScore", depth, score)
+		}
+		_, defaultScore := solve(bb, game.White, depth, cornerRush{}, nil, nil, nil)
+		if defaultScore != score {
+			t.Errorf("depth %d: score %d with ClampScore off, %d by default", depth, score, defaultScore)
+		}
+		moves, clamped := solve(bb, game.White, depth, cornerRush{}, nil, nil, &SearchOptions{ClampScore: true})
+		if clamped != MaxNonTerminalScore {
+			t.Errorf("depth %d: score %d with ClampScore, want %d", depth, clamped, MaxNonTerminalScore)
+		}
+		if moves[0] != (game.Position{Row: 0, Col: 0}) {
+			t.Errorf("depth %d: plays %v with ClampScore, want the corner a1", depth, moves[0])
+		}
+	}
+}
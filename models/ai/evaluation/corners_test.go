@@ -0,0 +1,45 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// Discs of the initial position, two of each color in the center
+const (
+	initialWhite uint64 = 0x0000001008000000
+	initialBlack uint64 = 0x0000000810000000
+)
+
+// evaluateBoth returns the score of e on bb, failing the test when Evaluate and
+// PECEvaluate disagree
+func evaluateBoth(t *testing.T, e Evaluation, bb game.BitBoard) int16 {
+	t.Helper()
+	score := e.Evaluate(bb)
+	if pec := e.PECEvaluate(bb, PrecomputeEvaluationBitBoard(bb)); pec != score {
+		t.Errorf("board %+v: Evaluate %d, PECEvaluate %d", bb, score, pec)
+	}
+	return score
+}
+
+func TestCornersEvaluation(t *testing.T) {
+	const a1, h1, a8, h8 = uint64(1) << 0, uint64(1) << 7, uint64(1) << 56, uint64(1) << 63
+	tests := []struct {
+		name         string
+		white, black uint64
+		want         int16
+	}{
+		{"no corner", 0, 0, 0},
+		{"white 3-1", a1 | h1 | a8, h8, 2},
+		{"black 3-1", h8, a1 | h1 | a8, -2},
+		{"all white", a1 | h1 | a8 | h8, 0, 4},
+		{"split 2-2", a1 | h8, h1 | a8, 0},
+	}
+	for _, tt := range tests {
+		bb := game.BitBoard{WhitePieces: tt.white | initialWhite, BlackPieces: tt.black | initialBlack}
+		if got := evaluateBoth(t, NewCornersEvaluation(), bb); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package evaluation
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// DebugEvaluationResult is the breakdown of the mixed evaluation of a position, from the
+// point of view of a player: positive scores are good for Player
+type DebugEvaluationResult struct {
+	Player   game.Piece
+	Phase    int
+	GameOver bool // The score is the final one and the components are not set
+	// Per component, in the order of ComponentNames
+	RawScores     [numComponents]int16
+	Coefficients  [numComponents]int16
+	Contributions [numComponents]int16 // Raw score times coefficient, they sum to Score
+	Score         int16
+}
+
+// DebugEvaluateCPU evaluates a position with coeffs like MixedEvaluation and returns the
+// raw score, coefficient and contribution of each component, from the point of view of player
+func DebugEvaluateCPU(b game.Board, player game.Piece, coeffs EvaluationCoefficients) DebugEvaluationResult {
+	eval := NewMixedEvaluation(coeffs)
+	bb := utils.BoardToBits(b)
+	pec := PrecomputeEvaluationBitBoard(bb)
+	result := DebugEvaluationResult{Player: player, Phase: eval.phase(pec)}
+
+	sign := int16(1)
+	if player == game.Black {
+		sign = -1
+	}
+	if pec.IsGameOver || pec.WhitePieces == 0 || pec.BlackPieces == 0 {
+		result.GameOver = true
+		result.Score = sign * eval.PECEvaluate(bb, pec)
+		return result
+	}
+
+	// Same terms as weightedScores, kept apart so the search does not pay for the breakdown
+	raw := [numComponents]int16{
+		componentMaterial:  eval.MaterialEvaluation.PECEvaluate(bb, pec),
+		componentMobility:  eval.MobilityEvaluation.PECEvaluate(bb, pec),
+		componentCorners:   eval.CornersEvaluation.PECEvaluate(bb, pec),
+		componentParity:    eval.ParityEvaluation.PECEvaluate(bb, pec),
+		componentStability: eval.StabilityEvaluation.PECEvaluate(bb, pec),
+		componentFrontier:  eval.FrontierEvaluation.PECEvaluate(bb, pec),
+	}
+	c := &result.Coefficients
+	c[componentMaterial], c[componentMobility], c[componentCorners], c[componentParity], c[componentStability], c[componentFrontier] = eval.ComputeGamePhaseCoefficients(pec)
+	if len(eval.ThreatCoeff) > 0 {
+		c[componentThreat] = eval.ThreatCoeff[result.Phase]
+		raw[componentThreat] = eval.ThreatEvaluation.PECEvaluate(bb, pec)
+	}
+	if len(eval.TempoCoeff) > 0 {
+		c[componentTempo] = eval.TempoCoeff[result.Phase]
+		raw[componentTempo] = eval.TempoEvaluation.PECEvaluate(bb, pec)
+	}
+	if len(eval.EdgeCoeff) > 0 {
+		c[componentEdge] = eval.EdgeCoeff[result.Phase]
+		raw[componentEdge] = eval.EdgeEvaluation.PECEvaluate(bb, pec)
+	}
+
+	for i := range raw {
+		result.RawScores[i] = sign * raw[i]
+		result.Contributions[i] = c[i] * result.RawScores[i]
+		result.Score += result.Contributions[i]
+	}
+	return result
+}
+
+// WriteTable writes the breakdown as a table, one component per line
+func (r DebugEvaluationResult) WriteTable(w io.Writer) error {
+	player := "white"
+	if r.Player == game.Black {
+		player = "black"
+	}
+	if r.GameOver {
+		_, err := fmt.Fprintf(w, "Evaluation for %s: game over, score %d\n", player, r.Score)
+		return err
+	}
+	fmt.Fprintf(w, "Evaluation for %s, phase %d\n", player, r.Phase)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "component\traw\tcoeff\tcontribution")
+	for i, name := range ComponentNames {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", name, r.RawScores[i], r.Coefficients[i], r.Contributions[i])
+	}
+	fmt.Fprintf(tw, "total\t\t\t%d\n", r.Score)
+	return tw.Flush()
+}
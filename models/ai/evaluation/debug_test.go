@@ -0,0 +1,105 @@
+package evaluation
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestDebugEvaluateCPU(t *testing.T) {
+	coeffs := V4Coeff
+	coeffs.ThreatCoeffs = []int16{3, 3, 3, 3, 3, 3}
+	coeffs.TempoCoeffs = []int16{5, 5, 5, 5, 5, 5}
+	coeffs.EdgeCoeffs = []int16{2, 2, 2, 2, 2, 2}
+	eval := NewMixedEvaluation(coeffs)
+
+	for _, transcript := range []string{"", "f5d6c3", "f5d6c3d3c4f4c5b3c2e6c6b4b5d2e3a6c1b1"} {
+		g := game.NewGame("Black", "White")
+		if _, err := utils.ApplyTranscript(g, transcript); err != nil {
+			t.Fatal(err)
+		}
+		bb := utils.BoardToBits(g.Board)
+		components := eval.Components(bb)
+
+		for _, player := range []game.Piece{game.White, game.Black} {
+			r := DebugEvaluateCPU(g.Board, player, coeffs)
+			sign := int16(1)
+			if player == game.Black {
+				sign = -1
+			}
+			if r.GameOver || r.Player != player {
+				t.Fatalf("%q: game over %v, player %d", transcript, r.GameOver, r.Player)
+			}
+			if want := sign * eval.Evaluate(bb); r.Score != want {
+				t.Errorf("%q, player %d: score %d, want %d", transcript, player, r.Score, want)
+			}
+			var sum int16
+			for i, name := range ComponentNames {
+				if r.Contributions[i] != r.RawScores[i]*r.Coefficients[i] {
+					t.Errorf("%q: %s contribution %d, want %d * %d", transcript, name, r.Contributions[i], r.RawScores[i], r.Coefficients[i])
+				}
+				// The contributions are the weighted components of the search
+				if r.Contributions[i] != sign*components[name] {
+					t.Errorf("%q, player %d: %s contribution %d, component %d", transcript, player, name, r.Contributions[i], components[name])
+				}
+				sum += r.Contributions[i]
+			}
+			if sum != r.Score {
+				t.Errorf("%q: contributions sum to %d, score %d", transcript, sum, r.Score)
+			}
+		}
+	}
+}
+
+func TestDebugEvaluateCPUGameOver(t *testing.T) {
+	// Black has wiped out White
+	var board game.Board
+	board[0][0], board[0][1] = game.Black, game.Black
+	eval := NewMixedEvaluation(V4Coeff)
+	want := eval.Evaluate(utils.BoardToBits(board))
+
+	r := DebugEvaluateCPU(board, game.Black, V4Coeff)
+	if !r.GameOver || r.Score != -want || r.Contributions != [numComponents]int16{} {
+		t.Errorf("game over %v, score %d, contributions %v, want the final score %d alone", r.GameOver, r.Score, r.Contributions, -want)
+	}
+
+	var out bytes.Buffer
+	if err := r.WriteTable(&out); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), fmt.Sprintf("Evaluation for black: game over, score %d\n", -want); got != want {
+		t.Errorf("table %q, want %q", got, want)
+	}
+}
+
+func TestDebugEvaluationWriteTable(t *testing.T) {
+	g := game.NewGame("Black", "White")
+	utils.ApplyTranscript(g, "f5d6c3")
+	r := DebugEvaluateCPU(g.Board, game.White, V4Coeff)
+
+	var out bytes.Buffer
+	if err := r.WriteTable(&out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 3+len(ComponentNames) {
+		t.Fatalf("%d lines, want a title, a header, a line per component and the total:\n%s", len(lines), out.String())
+	}
+	if want := fmt.Sprintf("Evaluation for white, phase %d", r.Phase); lines[0] != want {
+		t.Errorf("title %q, want %q", lines[0], want)
+	}
+	for i, name := range ComponentNames {
+		fields := strings.Fields(lines[2+i])
+		want := []string{name, fmt.Sprint(r.RawScores[i]), fmt.Sprint(r.Coefficients[i]), fmt.Sprint(r.Contributions[i])}
+		if strings.Join(fields, " ") != strings.Join(want, " ") {
+			t.Errorf("line %q, want %q", lines[2+i], want)
+		}
+	}
+	if fields := strings.Fields(lines[len(lines)-1]); len(fields) != 2 || fields[0] != "total" || fields[1] != fmt.Sprint(r.Score) {
+		t.Errorf("total line %q, want the score %d", lines[len(lines)-1], r.Score)
+	}
+}
@@ -0,0 +1,88 @@
+package evaluation
+
+import (
+	"sort"
+
+	"github.com/Coloc3G/othello-engine/models/ai/stats"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// IterativeDeepeningStats records how the ranking of the root moves changes from one depth
+// to the next of an iterative deepening, set as SearchOptions.Deepening for each depth.
+// A best move that changes with the depth means the shallower search was misleading, which
+// is also where aspiration windows would need re-searches.
+type IterativeDeepeningStats struct {
+	// Moves are the root moves, in the order of MoveOrderChanges
+	Moves []game.Position
+	// MoveOrderChanges counts, for each root move, the depths at which it fell in the
+	// ranking of the root moves
+	MoveOrderChanges []int
+	// Depths is the number of depths recorded, BestMoveChanges the number of them whose
+	// best move differs from the one of the previous depth
+	Depths          int
+	BestMoveChanges int
+
+	rank []int // Rank of each root move at the previous depth, 0 for the best move
+}
+
+// Stability returns the share of the depths, after the first one, that kept the best move
+// of the previous depth, 1 when fewer than two depths are recorded
+func (s *IterativeDeepeningStats) Stability() float64 {
+	if s.Depths < 2 {
+		return 1
+	}
+	return 1 - float64(s.BestMoveChanges)/float64(s.Depths-1)
+}
+
+// record ranks the root moves by their score at a depth and counts the moves that fell in
+// the ranking, each fall being recorded as "move_order_changes" in perfStats. A position
+// with other root moves than the previous depth starts the statistics over.
+func (s *IterativeDeepeningStats) record(moves []game.Position, scores []int16, player game.Piece, perfStats *stats.PerformanceStats) {
+	order := make([]int, len(moves))
+	for i := range order {
+		order[i] = i
+	}
+	// The first move of equal scores is the one the search keeps
+	sort.SliceStable(order, func(i, j int) bool {
+		if player == game.White {
+			return scores[order[i]] > scores[order[j]]
+		}
+		return scores[order[i]] < scores[order[j]]
+	})
+	rank := make([]int, len(moves))
+	for r, i := range order {
+		rank[i] = r
+	}
+
+	if !sameMoves(s.Moves, moves) {
+		*s = IterativeDeepeningStats{Moves: moves, MoveOrderChanges: make([]int, len(moves))}
+	}
+	s.Depths++
+	if s.rank != nil {
+		if s.rank[order[0]] != 0 {
+			s.BestMoveChanges++
+		}
+		for i := range moves {
+			if rank[i] > s.rank[i] {
+				s.MoveOrderChanges[i]++
+				if perfStats != nil {
+					perfStats.RecordOperation("move_order_changes", 0, "")
+				}
+			}
+		}
+	}
+	s.rank = rank
+}
+
+// sameMoves reports whether a and b are the same moves in the same order
+func sameMoves(a, b []game.Position) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
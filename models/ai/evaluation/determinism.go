@@ -0,0 +1,39 @@
+package evaluation
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// AssertDeterministic runs Solve runs times on a position, concurrently so that state the
+// searches share, such as the buffers of an evaluation, is exercised, and returns an error
+// when the best move or the score of a run differs from the first one. Run under the race
+// detector, it also reports the data races behind such differences. Every evaluation of
+// this build runs on the CPU, there is no GPU path to skip.
+func AssertDeterministic(b game.Board, player game.Piece, depth int8, eval Evaluation, runs int) error {
+	if runs <= 0 {
+		return nil
+	}
+	moves := make([][]game.Position, runs)
+	scores := make([]int16, runs)
+	var wg sync.WaitGroup
+	for i := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			moves[i], scores[i] = Solve(b, player, depth, eval)
+		}()
+	}
+	wg.Wait()
+
+	for i := 1; i < runs; i++ {
+		if moves[i][0] != moves[0][0] || scores[i] != scores[0] {
+			return fmt.Errorf("run %d played %s with score %d, run 1 played %s with score %d",
+				i+1, utils.PositionToAlgebraic(moves[i][0]), scores[i], utils.PositionToAlgebraic(moves[0][0]), scores[0])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// TestAssertDeterministic searches the known openings concurrently, run it with go test -race
+// to also catch the data races between the searches
+func TestAssertDeterministic(t *testing.T) {
+	depth, runs := int8(4), 4
+	if testing.Short() {
+		depth = 2
+	}
+	eval := NewMixedEvaluation(V7Coeff)
+	searched := 0
+	for _, op := range opening.KNOWN_OPENINGS {
+		g := game.NewGame("Black", "White")
+		if _, err := utils.ApplyTranscript(g, op.Transcript); err != nil || g.LegalState() != game.HasMoves {
+			continue
+		}
+		if err := AssertDeterministic(g.Board, g.CurrentPlayer.Color, depth, eval, runs); err != nil {
+			t.Errorf("%s (%s): %v", op.Name, op.Transcript, err)
+		}
+		searched++
+	}
+	if searched == 0 {
+		t.Fatal("no known opening searched")
+	}
+}
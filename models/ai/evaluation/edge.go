@@ -0,0 +1,142 @@
+package evaluation
+
+import (
+	"math/bits"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// Scores of an edge disc in edgeTable: a disc no sequence of moves along the edge can flip
+// is an asset, one that can be flipped is a liability the opponent may wedge into
+const (
+	edgeStableScore   = 2
+	edgeUnstableScore = -1
+)
+
+// edgeStates is the number of configurations of an edge, each square being empty, black or white
+const edgeStates = 6561
+
+// Masks of the first and last rows and columns
+const (
+	edgeTop    uint64 = 0x00000000000000ff
+	edgeBottom uint64 = 0xff00000000000000
+	edgeLeft   uint64 = 0x0101010101010101
+	edgeRight  uint64 = 0x8080808080808080
+)
+
+var (
+	// base3 maps the discs of a player on an edge, one bit per square, to the base 3 index
+	// of the configuration with those discs only, digit 1 for each disc
+	base3 [256]uint16
+	// edgeTable scores each edge configuration, indexed by base3[black] + 2*base3[white],
+	// positive for White
+	edgeTable [edgeStates]int16
+)
+
+func init() {
+	for discs := range 256 {
+		var index, power uint16 = 0, 1
+		for square := range 8 {
+			if discs&(1<<square) != 0 {
+				index += power
+			}
+			power *= 3
+		}
+		base3[discs] = index
+	}
+
+	var stable [edgeStates]int16 // Stable discs of each configuration, -1 when not computed
+	for i := range stable {
+		stable[i] = -1
+	}
+	for black := range 256 {
+		for white := range 256 {
+			if black&white != 0 {
+				continue
+			}
+			s := edgeStable(uint8(black), uint8(white), &stable)
+			edgeTable[edgeIndex(uint8(black), uint8(white))] = edgeDiscsScore(uint8(white), s) - edgeDiscsScore(uint8(black), s)
+		}
+	}
+}
+
+// EdgeEvaluation is an evaluation function that scores the discs of the four edges by
+// the safety of their configuration, looked up in a table of every edge configuration
+type EdgeEvaluation struct {
+}
+
+func NewEdgeEvaluation() *EdgeEvaluation {
+	return &EdgeEvaluation{}
+}
+
+func (e *EdgeEvaluation) Evaluate(b game.BitBoard) int16 {
+	pec := PrecomputeEvaluationBitBoard(b)
+	return e.PECEvaluate(b, pec)
+}
+
+func (e *EdgeEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputation) int16 {
+	black, white := b.BlackPieces, b.WhitePieces
+	return edgeTable[edgeIndex(uint8(black&edgeTop), uint8(white&edgeTop))] +
+		edgeTable[edgeIndex(uint8((black&edgeBottom)>>56), uint8((white&edgeBottom)>>56))] +
+		edgeTable[edgeIndex(columnToByte(black&edgeLeft), columnToByte(white&edgeLeft))] +
+		edgeTable[edgeIndex(columnToByte((black&edgeRight)>>7), columnToByte((white&edgeRight)>>7))]
+}
+
+// edgeIndex returns the index of an edge configuration in edgeTable
+func edgeIndex(black, white uint8) uint16 {
+	return base3[black] + 2*base3[white]
+}
+
+// columnToByte gathers the discs of the first column into a byte, the bottom square
+// first. The order of an edge does not matter, edgeTable being symmetric.
+func columnToByte(column uint64) uint8 {
+	return uint8((column * 0x0102040810204080) >> 56)
+}
+
+// edgeDiscsScore scores the discs of a player given the stable discs of the edge
+func edgeDiscsScore(discs, stable uint8) int16 {
+	return edgeStableScore*int16(bits.OnesCount8(discs&stable)) + edgeUnstableScore*int16(bits.OnesCount8(discs&^stable))
+}
+
+// edgeStable returns the discs of an edge configuration that no sequence of moves along
+// the edge can flip. Any empty square may be played by either player, since the move can
+// be legal through another line of the board. A disc is stable when no move flips it and
+// it stays stable in the configurations the moves lead to, which have fewer empty squares.
+func edgeStable(black, white uint8, memo *[edgeStates]int16) uint8 {
+	index := edgeIndex(black, white)
+	if memo[index] >= 0 {
+		return uint8(memo[index])
+	}
+
+	stable := black | white
+	for empty := ^(black | white); empty != 0; empty &= empty - 1 {
+		square := uint8(bits.TrailingZeros8(empty))
+		// Black plays the square, then White does
+		flips := edgeFlips(black, white, square)
+		stable &= ^flips & edgeStable(black|1<<square|flips, white&^flips, memo)
+		flips = edgeFlips(white, black, square)
+		stable &= ^flips & edgeStable(black&^flips, white|1<<square|flips, memo)
+	}
+	memo[index] = int16(stable)
+	return stable
+}
+
+// edgeFlips returns the discs of opponent flipped along the edge when player plays square
+func edgeFlips(player, opponent, square uint8) uint8 {
+	var flips uint8
+	for _, step := range []int{-1, 1} {
+		var line uint8
+		for s := int(square) + step; s >= 0 && s < 8; s += step {
+			bit := uint8(1) << s
+			if opponent&bit != 0 {
+				line |= bit
+				continue
+			}
+			if player&bit != 0 {
+				flips |= line
+			}
+			break
+		}
+	}
+	return flips
+}
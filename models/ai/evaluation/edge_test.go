@@ -0,0 +1,98 @@
+package evaluation
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestEdgeTableFullEdgeIsMaximal(t *testing.T) {
+	full := edgeTable[edgeIndex(0, 0xff)]
+	if full != 8*edgeStableScore {
+		t.Errorf("full white edge scores %d, want %d", full, 8*edgeStableScore)
+	}
+	if got := edgeTable[edgeIndex(0xff, 0)]; got != -full {
+		t.Errorf("full black edge scores %d, want %d", got, -full)
+	}
+	for black := range 256 {
+		for white := range 256 {
+			if black&white != 0 {
+				continue
+			}
+			score := edgeTable[edgeIndex(uint8(black), uint8(white))]
+			if score > full || score < -full {
+				t.Fatalf("edge %08b/%08b scores %d, beyond the full edges", black, white, score)
+			}
+			// Reading the edge from the other end does not change it
+			if mirror := edgeTable[edgeIndex(bits.Reverse8(uint8(black)), bits.Reverse8(uint8(white)))]; mirror != score {
+				t.Fatalf("edge %08b/%08b scores %d, its mirror %d", black, white, score, mirror)
+			}
+			// Swapping the colors negates it
+			if swapped := edgeTable[edgeIndex(uint8(white), uint8(black))]; swapped != -score {
+				t.Fatalf("edge %08b/%08b scores %d, with the colors swapped %d", black, white, score, swapped)
+			}
+		}
+	}
+}
+
+func TestEdgeTable(t *testing.T) {
+	tests := []struct {
+		name         string
+		black, white uint8
+		want         int16
+	}{
+		{"empty", 0, 0, 0},
+		{"white corner", 0, 0b00000001, edgeStableScore},
+		{"white b1, Black can take a1 and flip it", 0, 0b00000010, edgeUnstableScore},
+		{"white a1-c1 anchored on the corner", 0, 0b00000111, 3 * edgeStableScore},
+		// White d1 flips Black c1, then Black e1 flips back b1 to d1: only the corner is safe
+		{"black a1 c1 around white b1", 0b00000101, 0b00000010, edgeUnstableScore - (edgeStableScore + edgeUnstableScore)},
+		// Black c1 can be flipped by White d1, Black cannot flip a1 nor b1
+		{"white a1 b1 black c1", 0b00000100, 0b00000011, 2*edgeStableScore - edgeUnstableScore},
+	}
+	for _, tt := range tests {
+		if got := edgeTable[edgeIndex(tt.black, tt.white)]; got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEdgeEvaluationEdges(t *testing.T) {
+	// A full white edge scores the edge and the two corners on the perpendicular edges
+	want := 8*edgeStableScore + 2*edgeStableScore
+	for name, edge := range map[string]uint64{"top": edgeTop, "bottom": edgeBottom, "left": edgeLeft, "right": edgeRight} {
+		bb := game.BitBoard{WhitePieces: edge | initialWhite, BlackPieces: initialBlack}
+		if got := evaluateBoth(t, NewEdgeEvaluation(), bb); got != int16(want) {
+			t.Errorf("full white %s edge: %d, want %d", name, got, want)
+		}
+		bb = game.BitBoard{WhitePieces: initialWhite, BlackPieces: edge | initialBlack}
+		if got := evaluateBoth(t, NewEdgeEvaluation(), bb); got != -int16(want) {
+			t.Errorf("full black %s edge: %d, want %d", name, got, -want)
+		}
+	}
+	if got := evaluateBoth(t, NewEdgeEvaluation(), game.BitBoard{WhitePieces: initialWhite, BlackPieces: initialBlack}); got != 0 {
+		t.Errorf("initial position: %d, want 0", got)
+	}
+}
+
+func TestMixedEvaluationEdgeCoeff(t *testing.T) {
+	bb := game.BitBoard{WhitePieces: edgeTop | initialWhite, BlackPieces: initialBlack | 1<<20}
+	edge := NewEdgeEvaluation().Evaluate(bb)
+
+	coeffs := V7Coeff
+	coeffs.EdgeCoeffs = nil
+	without := NewMixedEvaluation(coeffs)
+	if got := without.Components(bb)["edge"]; got != 0 {
+		t.Errorf("edge component %d without EdgeCoeffs", got)
+	}
+
+	coeffs.EdgeCoeffs = []int16{3, 3, 3, 3, 3, 3}
+	with := NewMixedEvaluation(coeffs)
+	if got := with.Components(bb)["edge"]; got != 3*edge {
+		t.Errorf("edge component %d, want 3 * %d", got, edge)
+	}
+	if got, want := with.Evaluate(bb)-without.Evaluate(bb), 3*edge; got != want {
+		t.Errorf("EdgeCoeffs change the score by %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package evaluation
+
+import (
+	"math"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// EnsembleEvaluation combines several evaluations, such as models trained separately that
+// make different mistakes, into their weighted average. The search then scores each
+// position, the root moves included, with the combined score of the models.
+type EnsembleEvaluation struct {
+	Evaluations []Evaluation
+	// Weights of the evaluations, normalized to sum to 1
+	Weights []float64
+}
+
+// NewEnsembleEvaluation returns the weighted average of evals. Weights are relative: they
+// are normalized to sum to 1, so an ensemble of identical evaluations scores as each of
+// them. It panics when the lengths differ or the weights do not have a positive sum.
+func NewEnsembleEvaluation(evals []Evaluation, weights []float64) *EnsembleEvaluation {
+	if len(evals) != len(weights) {
+		panic("NewEnsembleEvaluation: evals and weights must have the same length")
+	}
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	if !(sum > 0) {
+		panic("NewEnsembleEvaluation: the weights must have a positive sum")
+	}
+	normalized := make([]float64, len(weights))
+	for i, w := range weights {
+		normalized[i] = w / sum
+	}
+	return &EnsembleEvaluation{Evaluations: evals, Weights: normalized}
+}
+
+func (e *EnsembleEvaluation) Evaluate(b game.BitBoard) int16 {
+	pec := PrecomputeEvaluationBitBoard(b)
+	return e.PECEvaluate(b, pec)
+}
+
+func (e *EnsembleEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputation) int16 {
+	var total float64
+	for i, eval := range e.Evaluations {
+		total += e.Weights[i] * float64(eval.PECEvaluate(b, pec))
+	}
+	return int16(max(math.MinInt16, min(math.MaxInt16, math.Round(total))))
+}
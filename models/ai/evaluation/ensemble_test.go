@@ -0,0 +1,61 @@
+package evaluation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEnsembleOfIdenticalModels(t *testing.T) {
+	boards, players := randomBitBoards(t, 41, 30)
+	single := NewMixedEvaluation(V7Coeff)
+	ensemble := NewEnsembleEvaluation([]Evaluation{NewMixedEvaluation(V7Coeff), NewMixedEvaluation(V7Coeff)}, []float64{2, 5})
+	for i, bb := range boards {
+		if got, want := evaluateBoth(t, ensemble, bb), single.Evaluate(bb); got != want {
+			t.Errorf("board %d: ensemble %d, single model %d", i, got, want)
+		}
+		moves, score := SolveBitBoard(bb, players[i], 3, ensemble)
+		wantMoves, wantScore := SolveBitBoard(bb, players[i], 3, single)
+		if moves[0] != wantMoves[0] || score != wantScore {
+			t.Errorf("board %d: ensemble search %v %d, single model %v %d", i, moves[0], score, wantMoves[0], wantScore)
+		}
+	}
+}
+
+func TestEnsembleWeights(t *testing.T) {
+	boards, _ := randomBitBoards(t, 42, 30)
+	material, mobility := NewMaterialEvaluation(), NewMobilityEvaluation()
+	ensemble := NewEnsembleEvaluation([]Evaluation{material, mobility}, []float64{3, 1})
+	materialOnly := NewEnsembleEvaluation([]Evaluation{material, mobility}, []float64{1, 0})
+	for i, bb := range boards {
+		want := int16(math.Round(0.75*float64(material.Evaluate(bb)) + 0.25*float64(mobility.Evaluate(bb))))
+		if got := evaluateBoth(t, ensemble, bb); got != want {
+			t.Errorf("board %d: ensemble %d, want %d", i, got, want)
+		}
+		if got, want := evaluateBoth(t, materialOnly, bb), material.Evaluate(bb); got != want {
+			t.Errorf("board %d: ensemble with a zero weight %d, want the material %d", i, got, want)
+		}
+	}
+}
+
+func TestNewEnsembleEvaluationPanics(t *testing.T) {
+	tests := []struct {
+		name    string
+		evals   []Evaluation
+		weights []float64
+	}{
+		{"lengths differ", []Evaluation{NewMaterialEvaluation()}, []float64{1, 1}},
+		{"zero sum", []Evaluation{NewMaterialEvaluation()}, []float64{0}},
+		{"negative sum", []Evaluation{NewMaterialEvaluation(), NewMobilityEvaluation()}, []float64{1, -2}},
+		{"NaN", []Evaluation{NewMaterialEvaluation()}, []float64{math.NaN()}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", tt.name)
+				}
+			}()
+			NewEnsembleEvaluation(tt.evals, tt.weights)
+		}()
+	}
+}
@@ -0,0 +1,58 @@
+package evaluation
+
+import (
+	"math/bits"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// DefaultMaxExtensionDepth is a reasonable SearchOptions.MaxExtensionDepth
+const DefaultMaxExtensionDepth int8 = 4
+
+// Reasons returned by ShouldExtend
+const (
+	ExtendPass        = "pass"        // The player to move must pass
+	ExtendSingleMove  = "single move" // The player to move has a single move
+	ExtendCorner      = "corner"      // The player to move can take a corner
+	ExtendConstrained = "two moves"   // The player to move has only two moves
+)
+
+// ShouldExtend reports whether the search should look one ply deeper at a position, the
+// moves of player being forced or decisive, and why. A finished game is never extended.
+func ShouldExtend(pec PreEvaluationComputation, player game.Piece) (extend bool, reason string) {
+	own, opponent := pec.BlackMoveMask, pec.WhiteMoveMask
+	if player == game.White {
+		own, opponent = opponent, own
+	}
+	reason = extensionReason(own, opponent)
+	return reason != "", reason
+}
+
+// extensionReason is ShouldExtend on the move masks of the player to move and of the
+// opponent, empty when the position is not extended
+func extensionReason(own, opponent uint64) string {
+	switch moves := bits.OnesCount64(own); {
+	case moves == 0 && opponent != 0:
+		return ExtendPass
+	case moves == 1:
+		return ExtendSingleMove
+	case own&cornerMask != 0:
+		return ExtendCorner
+	case moves == 2:
+		return ExtendConstrained
+	}
+	return ""
+}
+
+// extend reports whether mmab searches node one ply deeper, the line leading to it
+// having already been extended extended times, at most MaxExtensionDepth
+func (o *SearchOptions) extend(node game.BitBoard, player game.Piece, extended int8) bool {
+	if o == nil || !o.SearchExtension || extended >= o.MaxExtensionDepth {
+		return false
+	}
+	own, opponent := computeBothValidMoves(node)
+	if player == game.White {
+		own, opponent = opponent, own
+	}
+	return extensionReason(own, opponent) != ""
+}
@@ -0,0 +1,57 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// cornerBoard returns the initial position where White, to move, can take a1 by flipping b2
+func cornerBoard(t *testing.T) game.BitBoard {
+	t.Helper()
+	g, err := game.NewGameBuilder().WithPieceAt("b2", game.Black).WithPieceAt("c3", game.White).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return utils.BoardToBits(g.Board)
+}
+
+func TestShouldExtendCorner(t *testing.T) {
+	bb := cornerBoard(t)
+	extend, reason := ShouldExtend(PrecomputeEvaluationBitBoard(bb), game.White)
+	if !extend || reason != ExtendCorner {
+		t.Fatalf("ShouldExtend = %v %q, want the corner extension", extend, reason)
+	}
+}
+
+func TestExtensionLimitPerLine(t *testing.T) {
+	bb := cornerBoard(t)
+	opts := &SearchOptions{SearchExtension: true, MaxExtensionDepth: 2}
+	for extended, want := range []bool{true, true, false} {
+		if got := opts.extend(bb, game.White, int8(extended)); got != want {
+			t.Errorf("extend after %d extensions = %v, want %v", extended, got, want)
+		}
+	}
+}
+
+func TestForcedCornerExtension(t *testing.T) {
+	bb := cornerBoard(t)
+	eval := NewMixedEvaluation(V7Coeff)
+	window := func(depth int8, opts *SearchOptions) (int16, []game.Position) {
+		return mmab(bb, game.White, depth, MIN_EVAL-65, MAX_EVAL+65, eval, nil, nil, nil, opts, 0)
+	}
+
+	// A leaf where White can take a corner is searched one ply deeper, as a depth 1 search
+	extended, extendedPath := window(0, &SearchOptions{SearchExtension: true, MaxExtensionDepth: 1})
+	deeper, deeperPath := window(1, nil)
+	if extended != deeper || len(extendedPath) == 0 || extendedPath[0] != deeperPath[0] {
+		t.Errorf("extended leaf %d %v, depth 1 search %d %v", extended, extendedPath, deeper, deeperPath)
+	}
+
+	// Without extensions left, the leaf keeps its static evaluation
+	static, path := window(0, &SearchOptions{SearchExtension: true})
+	if static != eval.Evaluate(bb) || len(path) != 0 {
+		t.Errorf("unextended leaf %d %v, static evaluation %d", static, path, eval.Evaluate(bb))
+	}
+}
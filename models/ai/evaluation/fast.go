@@ -0,0 +1,73 @@
+package evaluation
+
+import (
+	"math/bits"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// FastEvaluation scores boards with game.BitBoardFastScore. Searches using it should set
+// SearchOptions.FastLeaves, so the leaves skip the PreEvaluationComputation.
+type FastEvaluation struct {
+}
+
+func NewFastEvaluation() *FastEvaluation {
+	return &FastEvaluation{}
+}
+
+func (e *FastEvaluation) Evaluate(b game.BitBoard) int16 {
+	return game.BitBoardFastScore(b)
+}
+
+func (e *FastEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputation) int16 {
+	return game.BitBoardFastScore(b)
+}
+
+// fastShallow searches a node of depth 0 or 1 with SearchOptions.FastLeaves: a node of
+// depth 0 is its own fast score, a node of depth 1 the best fast score of its children,
+// the opponent's moves being the leaves of a pass
+func fastShallow(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16, trace *TraceNode, opts *SearchOptions) (int16, []game.Position) {
+	moves := game.ValidMovesMask(node, player)
+	if depth <= 0 || moves == 0 {
+		return game.BitBoardFastScore(node), nil
+	}
+
+	opponent := game.GetOpponentColor(player)
+	best := MIN_EVAL - 65
+	if player == game.Black {
+		best = MAX_EVAL + 65
+	}
+	var bestMove []game.Position
+	for moves != 0 {
+		square := bits.TrailingZeros64(moves)
+		moves &= moves - 1
+		move := squareMoves[square][0]
+		child, _ := game.GetNewBitBoardAfterMove(node, move, player)
+		if opts.Nodes != nil {
+			*opts.Nodes++
+		}
+		score := game.BitBoardFastScore(child)
+		leaf := trace.child(move)
+		leaf.enter(child, opponent, 0, alpha, beta)
+		leaf.leave(score, alpha, beta)
+
+		if player == game.White {
+			if score > best {
+				best, bestMove = score, squareMoves[square]
+			}
+			alpha = max(alpha, score)
+		} else {
+			if score < best {
+				best, bestMove = score, squareMoves[square]
+			}
+			beta = min(beta, score)
+		}
+		if beta <= alpha {
+			if trace != nil {
+				trace.prune(node, player, game.MaskToPositions(moves))
+			}
+			break
+		}
+	}
+	return best, bestMove
+}
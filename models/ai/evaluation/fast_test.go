@@ -0,0 +1,73 @@
+package evaluation
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// randomBitBoards returns count random positions reached by 10 to 50 moves, with their
+// player to move, the same ones for the same seed
+func randomBitBoards(tb testing.TB, seed int64, count int) ([]game.BitBoard, []game.Piece) {
+	tb.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	boards := make([]game.BitBoard, 0, count)
+	players := make([]game.Piece, 0, count)
+	for len(boards) < count {
+		g, err := game.RandomReachableBoard(rng, 10+rng.Intn(40))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if g.LegalState() != game.HasMoves {
+			continue
+		}
+		boards = append(boards, utils.BoardToBits(g.Board))
+		players = append(players, g.CurrentPlayer.Color)
+	}
+	return boards, players
+}
+
+func TestFastLeavesMatchesSearch(t *testing.T) {
+	boards, players := randomBitBoards(t, 2, 40)
+	eval := NewFastEvaluation()
+	for depth := int8(1); depth <= 3; depth++ {
+		for i, bb := range boards {
+			_, want := solve(bb, players[i], depth, eval, nil, nil, &SearchOptions{DisableTT: true})
+			_, got := solve(bb, players[i], depth, eval, nil, nil, &SearchOptions{DisableTT: true, FastLeaves: true})
+			if got != want {
+				t.Errorf("depth %d, board %d: fast leaves score %d, search score %d", depth, i, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkPrecomputeEvaluationBitBoard(b *testing.B) {
+	boards, _ := randomBitBoards(b, 1, 256)
+	b.ReportAllocs()
+	for i := range b.N {
+		PrecomputeEvaluationBitBoard(boards[i%len(boards)])
+	}
+}
+
+func BenchmarkBitBoardFastScore(b *testing.B) {
+	boards, _ := randomBitBoards(b, 1, 256)
+	b.ReportAllocs()
+	for i := range b.N {
+		game.BitBoardFastScore(boards[i%len(boards)])
+	}
+}
+
+func benchmarkShallowSearch(b *testing.B, depth int8, fastLeaves bool) {
+	boards, players := randomBitBoards(b, 1, 64)
+	eval := NewFastEvaluation()
+	b.ReportAllocs()
+	for i := range b.N {
+		j := i % len(boards)
+		solve(boards[j], players[j], depth, eval, nil, nil, &SearchOptions{FastLeaves: fastLeaves})
+	}
+}
+
+func BenchmarkSearchDepth2(b *testing.B)           { benchmarkShallowSearch(b, 2, false) }
+func BenchmarkSearchDepth2FastLeaves(b *testing.B) { benchmarkShallowSearch(b, 2, true) }
@@ -33,11 +33,14 @@ func (e *FrontierEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationCompu
 		notBottomEdge = 0xFFFFFFFFFFFFFF00
 	)
 
-	// Calculate adjacent squares using optimized bit operations
+	// Calculate adjacent squares using optimized bit operations. A shift towards row 8
+	// (<<7, <<9) must drop the empty squares of row 8 and a shift towards row 1 (>>7, >>9)
+	// those of row 1; the swapped masks of earlier versions lost diagonal neighbors, see
+	// the note on the trained models in vars.go.
 	adjacent := emptySquares>>8 | emptySquares<<8 | // North & South
 		(emptySquares&notLeftEdge)>>1 | (emptySquares&notRightEdge)<<1 | // East & West
-		(emptySquares&notLeftEdge&notTopEdge)>>9 | (emptySquares&notRightEdge&notTopEdge)>>7 | // NE & NW
-		(emptySquares&notLeftEdge&notBottomEdge)<<7 | (emptySquares&notRightEdge&notBottomEdge)<<9 // SE & SW
+		(emptySquares&notLeftEdge&notBottomEdge)>>9 | (emptySquares&notRightEdge&notBottomEdge)>>7 | // NE & NW
+		(emptySquares&notLeftEdge&notTopEdge)<<7 | (emptySquares&notRightEdge&notTopEdge)<<9 // SE & SW
 
 	// Find frontier pieces: pieces that are adjacent to empty squares
 	whiteFrontierMask := whitePieces & adjacent
@@ -0,0 +1,92 @@
+package evaluation
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// The frontier evaluation counts the discs next to an empty square, Black's minus White's,
+// each disc once however many empty neighbors it has
+func TestFrontierEvaluation(t *testing.T) {
+	const d4 = uint64(1) << 27
+	const d4Neighbors = uint64(7)<<18 | 5<<26 | 7<<34 // c3-e3, c4, e4, c5-e5
+	const a1, a1Neighbors = uint64(1), uint64(1)<<1 | 1<<8 | 1<<9
+	tests := []struct {
+		name         string
+		white, black uint64
+		want         int16
+	}{
+		{"center disc with 8 empty neighbors", d4, 0, -1},
+		{"corner disc with 3 empty neighbors", a1, 0, -1},
+		{"center disc surrounded", d4, d4Neighbors, 8},
+		{"corner disc surrounded", a1, a1Neighbors, 3},
+		{"initial position", initialWhite, initialBlack, 0},
+	}
+	for _, tt := range tests {
+		bb := game.BitBoard{WhitePieces: tt.white, BlackPieces: tt.black}
+		if got := evaluateBoth(t, NewFrontierEvaluation(), bb); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// bruteForceFrontier counts the discs of pieces next to an empty square, square by square
+func bruteForceFrontier(pieces, empty uint64) int16 {
+	count := int16(0)
+	for sq := range 64 {
+		if pieces&(1<<sq) == 0 {
+			continue
+		}
+		row, col := sq/8, sq%8
+	neighbors:
+		for dr := -1; dr <= 1; dr++ {
+			for dc := -1; dc <= 1; dc++ {
+				r, c := row+dr, col+dc
+				if (dr != 0 || dc != 0) && r >= 0 && r < 8 && c >= 0 && c < 8 && empty&(1<<(r*8+c)) != 0 {
+					count++
+					break neighbors
+				}
+			}
+		}
+	}
+	return count
+}
+
+// transposeBits mirrors a bitboard along the a1-h8 diagonal
+func transposeBits(b uint64) uint64 {
+	var t uint64
+	for sq := range 64 {
+		if b&(1<<sq) != 0 {
+			t |= 1 << ((sq%8)*8 + sq/8)
+		}
+	}
+	return t
+}
+
+// Regression test of the diagonal shifts, whose masks once dropped the empty squares of
+// the wrong edge row: the counts match a square by square count and do not change when
+// the board is mirrored
+func TestFrontierEvaluationMatchesBruteForce(t *testing.T) {
+	// An empty h8 makes g7 a frontier disc, which the swapped masks missed
+	if got := evaluateBoth(t, NewFrontierEvaluation(), game.BitBoard{BlackPieces: ^uint64(0) &^ (1 << 63)}); got != 3 {
+		t.Errorf("black board but h8: %d, want the frontier discs g8, h7 and g7", got)
+	}
+
+	rng := rand.New(rand.NewSource(3))
+	for range 2000 {
+		occupied := rng.Uint64() | rng.Uint64() // About three squares out of four
+		white := occupied & rng.Uint64()
+		bb := game.BitBoard{WhitePieces: white, BlackPieces: occupied &^ white}
+		empty := ^occupied
+		want := bruteForceFrontier(bb.BlackPieces, empty) - bruteForceFrontier(bb.WhitePieces, empty)
+		if got := evaluateBoth(t, NewFrontierEvaluation(), bb); got != want {
+			t.Fatalf("white %016x black %016x: %d, want %d", bb.WhitePieces, bb.BlackPieces, got, want)
+		}
+		mirrored := game.BitBoard{WhitePieces: transposeBits(bb.WhitePieces), BlackPieces: transposeBits(bb.BlackPieces)}
+		if got := evaluateBoth(t, NewFrontierEvaluation(), mirrored); got != want {
+			t.Fatalf("white %016x black %016x mirrored: %d, want %d", bb.WhitePieces, bb.BlackPieces, got, want)
+		}
+	}
+}
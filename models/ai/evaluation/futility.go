@@ -0,0 +1,33 @@
+package evaluation
+
+import "github.com/Coloc3G/othello-engine/models/game"
+
+// FutilityDepth is the deepest remaining depth at which futility pruning applies
+const FutilityDepth = 2
+
+// DefaultFutilityMargin is the futility margin per remaining ply of DefaultSearchOptions
+const DefaultFutilityMargin int16 = 150
+
+// edgeMask holds the squares of the board border, corners included
+const edgeMask uint64 = 0xff818181818181ff
+
+// isQuietMove reports whether a move is quiet. Every Othello move flips discs, so captures
+// cannot define it as in chess: a move is quiet when it is not played on an edge or a corner,
+// the squares whose ownership changes the evaluation the most and rarely flips back.
+func isQuietMove(move game.Position) bool {
+	return edgeMask&(1<<(uint(move.Row)*8+uint(move.Col))) == 0
+}
+
+// isFutile reports whether quiet moves can be skipped at a node, the static evaluation
+// being too far below alpha for white or above beta for black to matter
+func isFutile(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16, eval Evaluation, opts *SearchOptions) bool {
+	if opts == nil || opts.FutilityMargin <= 0 || depth > FutilityDepth {
+		return false
+	}
+	static := eval.PECEvaluate(node, PrecomputeEvaluationBitBoard(node))
+	margin := opts.FutilityMargin * int16(depth)
+	if player == game.White {
+		return static+margin <= alpha
+	}
+	return static-margin >= beta
+}
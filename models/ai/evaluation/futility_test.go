@@ -0,0 +1,90 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// searchNodes searches bb with opts and returns the best move, its score and the number
+// of positions visited
+func searchNodes(bb game.BitBoard, player game.Piece, depth int8, eval Evaluation, opts SearchOptions) (game.Position, int16, int64) {
+	var nodes int64
+	opts.Nodes = &nodes
+	moves, score := solve(bb, player, depth, eval, nil, nil, &opts)
+	return moves[0], score, nodes
+}
+
+func TestIsQuietMove(t *testing.T) {
+	tests := []struct {
+		move string
+		want bool
+	}{
+		{"a1", false}, {"h8", false}, {"a4", false}, {"d1", false}, {"h5", false}, {"e8", false},
+		{"b2", true}, {"g7", true}, {"d3", true}, {"c6", true},
+	}
+	for _, tt := range tests {
+		move := game.Position{Row: int8(tt.move[1] - '1'), Col: int8(tt.move[0] - 'a')}
+		if got := isQuietMove(move); got != tt.want {
+			t.Errorf("isQuietMove(%s) = %v, want %v", tt.move, got, tt.want)
+		}
+	}
+}
+
+func TestFutilityPruning(t *testing.T) {
+	const depth = 4
+	boards, players := randomBitBoards(t, 21, 40)
+	eval := NewMixedEvaluation(V7Coeff)
+
+	var nodesWithout, nodesWith int64
+	same := 0
+	for i, bb := range boards {
+		move, score, nodes := searchNodes(bb, players[i], depth, eval, SearchOptions{})
+		nodesWithout += nodes
+
+		// A zero margin is the default search
+		defaultMoves, defaultScore := SolveBitBoard(bb, players[i], depth, eval)
+		if defaultMoves[0] != move || defaultScore != score {
+			t.Errorf("board %d: %v %d without futility pruning, %v %d by default", i, move, score, defaultMoves[0], defaultScore)
+		}
+
+		pruned, _, nodes := searchNodes(bb, players[i], depth, eval, SearchOptions{FutilityMargin: DefaultFutilityMargin})
+		nodesWith += nodes
+		if pruned == move {
+			same++
+		}
+	}
+
+	if nodesWith >= nodesWithout {
+		t.Errorf("futility pruning visits %d nodes, %d without", nodesWith, nodesWithout)
+	}
+	if same < len(boards)*8/10 {
+		t.Errorf("futility pruning keeps the best move on %d of %d boards, want at least 80%%", same, len(boards))
+	}
+	t.Logf("%d nodes without futility pruning, %d with, same move on %d of %d boards", nodesWithout, nodesWith, same, len(boards))
+}
+
+func TestIsFutileOnlyAtShallowDepth(t *testing.T) {
+	// alpha is a won game for White, out of reach of the static evaluation
+	bb := game.BitBoard{WhitePieces: initialWhite, BlackPieces: initialBlack}
+	eval := NewMixedEvaluation(V7Coeff)
+	opts := &SearchOptions{FutilityMargin: DefaultFutilityMargin}
+	for depth := int8(1); depth <= FutilityDepth+1; depth++ {
+		if got, want := isFutile(bb, game.White, depth, MAX_EVAL, MAX_EVAL+65, eval, opts), depth <= FutilityDepth; got != want {
+			t.Errorf("depth %d: isFutile = %v, want %v", depth, got, want)
+		}
+	}
+	if isFutile(bb, game.White, 1, MAX_EVAL, MAX_EVAL+65, eval, &SearchOptions{}) {
+		t.Error("futile without a margin")
+	}
+	if isFutile(bb, game.White, 1, MAX_EVAL, MAX_EVAL+65, eval, nil) {
+		t.Error("futile without options")
+	}
+	// Within the margin of the window
+	if isFutile(bb, game.White, 1, 0, MAX_EVAL, eval, opts) {
+		t.Error("futile with the static evaluation inside the window")
+	}
+	if !isFutile(bb, game.Black, 1, MIN_EVAL-65, MIN_EVAL, eval, opts) {
+		t.Error("not futile for Black with beta a lost game for White")
+	}
+}
@@ -0,0 +1,40 @@
+package evaluation
+
+import (
+	"math/bits"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// SquareInfluence returns, for each square indexed by row*8+col, how it moves the evaluation
+// of a position, positive for White. A disc gets the score of the position minus the score
+// without it, a legal move of player the score after the move minus the score before, and
+// other squares 0. Differences are clamped to [MIN_EVAL, MAX_EVAL], so wipeouts and finished
+// games do not dwarf the other squares. A finished game has no influence at all.
+func SquareInfluence(bb game.BitBoard, player game.Piece, eval Evaluation) [64]int16 {
+	var influence [64]int16
+	if game.IsGameFinishedBitBoard(bb) {
+		return influence
+	}
+	score := int32(eval.Evaluate(bb))
+
+	for discs := bb.BlackPieces | bb.WhitePieces; discs != 0; discs &= discs - 1 {
+		square := bits.TrailingZeros64(discs)
+		without := game.BitBoard{
+			BlackPieces: bb.BlackPieces &^ (1 << square),
+			WhitePieces: bb.WhitePieces &^ (1 << square),
+		}
+		influence[square] = clampInfluence(score - int32(eval.Evaluate(without)))
+	}
+
+	for _, move := range game.MaskToPositions(game.ValidMovesMask(bb, player)) {
+		after, _ := game.GetNewBitBoardAfterMove(bb, move, player)
+		influence[int(move.Row)*8+int(move.Col)] = clampInfluence(int32(eval.Evaluate(after)) - score)
+	}
+	return influence
+}
+
+// clampInfluence brings a score difference within the evaluation range
+func clampInfluence(d int32) int16 {
+	return int16(min(max(d, int32(MIN_EVAL)), int32(MAX_EVAL)))
+}
@@ -0,0 +1,62 @@
+package evaluation
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestSquareInfluence(t *testing.T) {
+	// Black a1, White b1 and c2, Black to move: c1 is the only move
+	const a1, b1, c1, c2 = 0, 1, 2, 10
+	bb := game.BitBoard{BlackPieces: 1 << a1, WhitePieces: 1<<b1 | 1<<c2}
+	eval := NewMixedEvaluation(V4Coeff)
+	score := eval.Evaluate(bb)
+	influence := SquareInfluence(bb, game.Black, eval)
+
+	want := map[int]int16{
+		// Without its only disc Black is wiped out, the difference is clamped
+		a1: MIN_EVAL,
+		b1: score - eval.Evaluate(game.BitBoard{BlackPieces: 1 << a1, WhitePieces: 1 << c2}),
+		c2: score - eval.Evaluate(game.BitBoard{BlackPieces: 1 << a1, WhitePieces: 1 << b1}),
+		c1: eval.Evaluate(game.BitBoard{BlackPieces: 1<<a1 | 1<<b1 | 1<<c1, WhitePieces: 1 << c2}) - score,
+	}
+	for square, v := range influence {
+		if v != want[square] {
+			t.Errorf("square %d: influence %d, want %d", square, v, want[square])
+		}
+	}
+
+	// White's moves are measured when White is to move, Black's move c1 is then empty
+	white := SquareInfluence(bb, game.White, eval)
+	if white[c1] != 0 || white[b1] != influence[b1] {
+		t.Errorf("white to move: c1 %d, b1 %d, want 0 and %d", white[c1], white[b1], influence[b1])
+	}
+
+	// A finished game has no influence
+	if over := SquareInfluence(game.BitBoard{BlackPieces: 1<<a1 | 1<<b1}, game.White, eval); over != [64]int16{} {
+		t.Errorf("finished game influence %v", over)
+	}
+}
+
+func TestSquareInfluenceSymmetric(t *testing.T) {
+	eval := NewMixedEvaluation(V4Coeff)
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 200; i++ {
+		g, err := game.RandomReachableBoard(rng, rng.Intn(50))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bb := game.BoardToBitBoard(g.Board)
+		player := g.CurrentPlayer.Color
+		influence := SquareInfluence(bb, player, eval)
+		mirrored := SquareInfluence(bb.MirrorDiagonal(), player, eval)
+		for square, v := range influence {
+			row, col := square/8, square%8
+			if m := mirrored[col*8+row]; m != v {
+				t.Fatalf("position %d, square %d: influence %d, %d in the mirrored position", i, square, v, m)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package evaluation
+
+import "fmt"
+
+// Interpolate blends two coefficient sets linearly, t = 0 giving ec and t = 1 giving other.
+// t is clamped to [0, 1]. Optional coefficients missing on one side count as zeros and
+// phase boundaries are blended as well, staying strictly increasing.
+func (ec EvaluationCoefficients) Interpolate(other EvaluationCoefficients, t float64) EvaluationCoefficients {
+	t = min(max(t, 0), 1)
+
+	result := EvaluationCoefficients{
+		Name:            fmt.Sprintf("%s~%s@%.2f", ec.Name, other.Name, t),
+		MaterialCoeffs:  interpolateSlice(ec.MaterialCoeffs, other.MaterialCoeffs, t),
+		MobilityCoeffs:  interpolateSlice(ec.MobilityCoeffs, other.MobilityCoeffs, t),
+		CornersCoeffs:   interpolateSlice(ec.CornersCoeffs, other.CornersCoeffs, t),
+		ParityCoeffs:    interpolateSlice(ec.ParityCoeffs, other.ParityCoeffs, t),
+		StabilityCoeffs: interpolateSlice(ec.StabilityCoeffs, other.StabilityCoeffs, t),
+		FrontierCoeffs:  interpolateSlice(ec.FrontierCoeffs, other.FrontierCoeffs, t),
+		ThreatCoeffs:    interpolateSlice(ec.ThreatCoeffs, other.ThreatCoeffs, t),
+		TempoCoeffs:     interpolateSlice(ec.TempoCoeffs, other.TempoCoeffs, t),
+		EdgeCoeffs:      interpolateSlice(ec.EdgeCoeffs, other.EdgeCoeffs, t),
+	}
+
+	if len(ec.PhaseBoundaries) > 0 || len(other.PhaseBoundaries) > 0 {
+		boundaries := interpolateSlice(ec.Boundaries(), other.Boundaries(), t)
+		for i := 1; i < len(boundaries); i++ {
+			boundaries[i] = max(boundaries[i], boundaries[i-1]+1)
+		}
+		result.PhaseBoundaries = boundaries
+	}
+
+	return result
+}
+
+// interpolateSlice blends a and b element-wise, missing elements count as zeros.
+// It returns nil when both are empty so optional coefficients stay disabled.
+func interpolateSlice(a, b []int16, t float64) []int16 {
+	n := max(len(a), len(b))
+	if n == 0 {
+		return nil
+	}
+
+	result := make([]int16, n)
+	for i := range result {
+		var va, vb float64
+		if i < len(a) {
+			va = float64(a[i])
+		}
+		if i < len(b) {
+			vb = float64(b[i])
+		}
+		result[i] = int16(va*(1-t) + vb*t)
+	}
+	return result
+}
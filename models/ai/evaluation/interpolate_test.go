@@ -0,0 +1,50 @@
+package evaluation
+
+import (
+	"slices"
+	"testing"
+)
+
+// sameCoeffs reports whether two coefficient sets have the same values, names aside
+func sameCoeffs(a, b EvaluationCoefficients) bool {
+	for i, c := range a.namedCoeffs() {
+		if !slices.Equal(c.values, b.namedCoeffs()[i].values) {
+			return false
+		}
+	}
+	return slices.Equal(a.Boundaries(), b.Boundaries())
+}
+
+func TestInterpolateBoundaries(t *testing.T) {
+	tests := []struct {
+		name string
+		t    float64
+		want EvaluationCoefficients
+	}{
+		{"t=0", 0, V1Coeff},
+		{"t=1", 1, V4Coeff},
+		{"below 0", -0.5, V1Coeff},
+		{"above 1", 2, V4Coeff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := V1Coeff.Interpolate(V4Coeff, tt.t)
+			if !sameCoeffs(got, tt.want) {
+				t.Errorf("got %+v, want the coefficients of %s", got, tt.want.Name)
+			}
+		})
+	}
+}
+
+func TestInterpolateMidpoint(t *testing.T) {
+	got := V1Coeff.Interpolate(V4Coeff, 0.5)
+	for phase := range V1Coeff.MobilityCoeffs {
+		want := int16((float64(V1Coeff.MobilityCoeffs[phase]) + float64(V4Coeff.MobilityCoeffs[phase])) / 2)
+		if got.MobilityCoeffs[phase] != want {
+			t.Errorf("mobility phase %d: got %d, want %d", phase, got.MobilityCoeffs[phase], want)
+		}
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("blend of valid coefficients is invalid: %v", err)
+	}
+}
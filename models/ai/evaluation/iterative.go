@@ -0,0 +1,73 @@
+package evaluation
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// IterationResult is the result of a depth completed by SolveIterative
+type IterationResult struct {
+	Depth   int8
+	Score   int16           // From White's perspective
+	PV      []game.Position // Best line, starting with {-1, -1} when the player must pass, empty when neither player can move
+	Nodes   int64           // Positions visited since the first depth
+	Elapsed time.Duration   // Time since the start of the search
+}
+
+// SolveIterative searches b at increasing depths, from 1 up to maxDepth, calling
+// onIteration, when not nil, with the result of each completed depth. The depths share
+// their transposition table, so each one mostly costs its own last ply. Once ctx is done,
+// the depth in progress stops and is discarded. It returns the result of the deepest
+// completed depth, a zero Depth when none completed.
+func SolveIterative(ctx context.Context, b game.Board, player game.Piece, maxDepth int8, eval Evaluation, onIteration func(IterationResult)) IterationResult {
+	return SolveIterativeWithOptions(ctx, b, player, maxDepth, eval, SearchOptions{}, onIteration)
+}
+
+// SolveIterativeWithOptions is SolveIterative with search options. A new table is used
+// when opts has no Cache. Its Nodes and Stop fields are replaced by those of the search,
+// and the options of the root moves, BookBias, Deepening and RootMoveOrder, do not apply.
+func SolveIterativeWithOptions(ctx context.Context, b game.Board, player game.Piece, maxDepth int8, eval Evaluation, opts SearchOptions, onIteration func(IterationResult)) IterationResult {
+	start := time.Now()
+	bb := utils.BoardToBits(b)
+
+	var nodes int64
+	var stop atomic.Bool
+	opts.Nodes, opts.Stop = &nodes, &stop
+	if opts.Cache == nil && !opts.DisableTT {
+		opts.Cache = NewCacheMB(opts.HashMB)
+		opts.Cache.Verify = opts.TTVerify
+	}
+	if opts.ClampScore {
+		eval = ScoreClamp{Inner: eval}
+	}
+	defer context.AfterFunc(ctx, func() { stop.Store(true) })()
+
+	// A player who must pass plays the pass marker, as with Solve, and the search
+	// continues with the opponent
+	root, rootPlayer, pass := bb, player, int8(0)
+	if game.ValidMovesMask(bb, player) == 0 && game.ValidMovesMask(bb, game.GetOpponentColor(player)) != 0 {
+		rootPlayer, pass = game.GetOpponentColor(player), 1
+	}
+
+	var last IterationResult
+	for depth := int8(1); depth <= maxDepth && ctx.Err() == nil; depth++ {
+		opts.Cache.newSearch()
+		score, pv := mmab(root, rootPlayer, depth-pass, MIN_EVAL-65, MAX_EVAL+65, eval, opts.Cache, nil, nil, &opts, 0)
+		if opts.NodeLimitReached() {
+			break
+		}
+		pv = opts.Cache.extendPV(root, rootPlayer, pv, depth-pass)
+		if pass != 0 {
+			pv = append([]game.Position{{Row: -1, Col: -1}}, pv...)
+		}
+		last = IterationResult{Depth: depth, Score: score, PV: pv, Nodes: nodes, Elapsed: time.Since(start)}
+		if onIteration != nil {
+			onIteration(last)
+		}
+	}
+	return last
+}
@@ -0,0 +1,75 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestSolveIterativeDepths(t *testing.T) {
+	boards, players := randomBitBoards(t, 13, 10)
+	eval := NewMixedEvaluation(V7Coeff)
+	const maxDepth = 5
+	for i, bb := range boards {
+		var depths []int8
+		var nodes int64
+		last := SolveIterative(context.Background(), utils.BitsToBoard(bb), players[i], maxDepth, eval, func(r IterationResult) {
+			if r.Nodes < nodes {
+				t.Errorf("board %d, depth %d: %d nodes after %d", i, r.Depth, r.Nodes, nodes)
+			}
+			depths = append(depths, r.Depth)
+			nodes = r.Nodes
+		})
+		for j, depth := range depths {
+			if depth != int8(j+1) {
+				t.Fatalf("board %d: depths %v, want 1 to %d", i, depths, maxDepth)
+			}
+		}
+		if len(depths) != maxDepth || last.Depth != maxDepth {
+			t.Errorf("board %d: depths %v, last %d", i, depths, last.Depth)
+		}
+	}
+}
+
+func TestSolveIterativeMatchesSolve(t *testing.T) {
+	boards, players := randomBitBoards(t, 14, 20)
+	eval := NewMixedEvaluation(V7Coeff)
+	for depth := int8(1); depth <= 5; depth++ {
+		for i, bb := range boards {
+			b := utils.BitsToBoard(bb)
+			_, want := Solve(b, players[i], depth, eval)
+			r := SolveIterative(context.Background(), b, players[i], depth, eval, nil)
+			if r.Score != want {
+				t.Errorf("depth %d, board %d: iterative score %d, Solve %d", depth, i, r.Score, want)
+			}
+			if _, _, plies, ok := replayLine(bb, players[i], r.PV); !ok || plies > depth {
+				t.Errorf("depth %d, board %d: line %v", depth, i, r.PV)
+			}
+		}
+	}
+}
+
+func TestSolveIterativeRootPass(t *testing.T) {
+	// Black cannot flip the White corner, White can flip b1 from c1
+	bb := game.BitBoard{WhitePieces: 1 << 0, BlackPieces: 1 << 1}
+	r := SolveIterative(context.Background(), utils.BitsToBoard(bb), game.Black, 3, NewMixedEvaluation(V7Coeff), nil)
+	if len(r.PV) < 2 || r.PV[0] != (game.Position{Row: -1, Col: -1}) || r.PV[1] != (game.Position{Row: 0, Col: 2}) {
+		t.Errorf("line %v, want the pass then c1", r.PV)
+	}
+}
+
+func TestSolveIterativeCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	r := SolveIterative(ctx, game.NewGame("Black", "White").Board, game.Black, 30, NewMixedEvaluation(V7Coeff), nil)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("search stopped %v after the start, 50ms deadline", elapsed)
+	}
+	if r.Depth == 0 || r.Depth >= 30 {
+		t.Errorf("deepest completed depth %d", r.Depth)
+	}
+}
@@ -0,0 +1,41 @@
+package evaluation
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestMaterialEvaluation(t *testing.T) {
+	tests := []struct {
+		name         string
+		white, black uint64
+		want         int16
+	}{
+		{"initial position", initialWhite, initialBlack, 0},
+		{"5 against 3", initialWhite | 0x7, initialBlack | 0x100, 2},
+		{"full board for Black", 0, ^uint64(0), -64},
+	}
+	for _, tt := range tests {
+		bb := game.BitBoard{WhitePieces: tt.white, BlackPieces: tt.black}
+		if got := evaluateBoth(t, NewMaterialEvaluation(), bb); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(15))
+	for i := 0; i < 50; i++ {
+		g, err := game.RandomReachableBoard(rng, 10+rng.Intn(40))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bb := utils.BoardToBits(g.Board)
+		want := int16(bits.OnesCount64(bb.WhitePieces) - bits.OnesCount64(bb.BlackPieces))
+		if got := evaluateBoth(t, NewMaterialEvaluation(), bb); got != want {
+			t.Errorf("random board %d: %d, want %d", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package evaluation
+
+import (
+	"math"
+	"time"
+
+	zobrist "github.com/Coloc3G/othello-engine/models/ai/cache"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+const (
+	// DefaultMCTSIterations is the iteration budget used when neither MaxIterations nor
+	// MaxTime is set
+	DefaultMCTSIterations = 10000
+	// MCTSValueScale is the evaluation difference that moves the squashed value from 0.5 to ~0.73
+	MCTSValueScale = 200.0
+)
+
+// MCTSSearcher is an experimental Monte Carlo Tree Search using Eval as the leaf value
+// function instead of random rollouts. Nodes are shared between transpositions, found by
+// the Zobrist hash of their position.
+type MCTSSearcher struct {
+	Eval Evaluation
+	// UCT exploration constant, sqrt(2) when 0
+	Exploration float64
+	// Number of iterations, each evaluating one leaf and allocating at most one node,
+	// DefaultMCTSIterations when both budgets are 0
+	MaxIterations int
+	// Time budget, no limit when 0
+	MaxTime time.Duration
+}
+
+// mctsTree holds the nodes of a search by the Zobrist hash of their position
+type mctsTree struct {
+	nodes     map[uint64]*mctsNode
+	allocated int // Nodes created, those of colliding hashes included
+}
+
+// mctsNode accumulates values in [0,1] from White's perspective
+type mctsNode struct {
+	board    game.BitBoard
+	player   game.Piece
+	moves    []game.Position // A single {-1, -1} move when the player must pass
+	children []*mctsNode
+	terminal bool
+	visits   int
+	valueSum float64
+}
+
+func (n *mctsNode) value() float64 {
+	return n.valueSum / float64(n.visits)
+}
+
+func (s *MCTSSearcher) Search(b game.Board, player game.Piece) ([]game.Position, int16) {
+	bb := utils.BoardToBits(b)
+	if len(game.ValidMovesBitBoard(bb, player)) == 0 {
+		return []game.Position{{Row: -1, Col: -1}}, -1
+	}
+	pv, score, _ := s.search(bb, player)
+	return pv, score
+}
+
+// search runs the search and returns its principal variation, its score and its tree
+func (s *MCTSSearcher) search(bb game.BitBoard, player game.Piece) ([]game.Position, int16, *mctsTree) {
+	exploration := s.Exploration
+	if exploration == 0 {
+		exploration = math.Sqrt2
+	}
+	maxIterations := s.MaxIterations
+	if maxIterations == 0 && s.MaxTime == 0 {
+		maxIterations = DefaultMCTSIterations
+	}
+	var deadline time.Time
+	if s.MaxTime > 0 {
+		deadline = time.Now().Add(s.MaxTime)
+	}
+
+	tree := &mctsTree{nodes: make(map[uint64]*mctsNode)}
+	root := s.node(tree, bb, player)
+	path := make([]*mctsNode, 0, 64)
+
+	for iteration := 0; maxIterations == 0 || iteration < maxIterations; iteration++ {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+
+		// Selection, stopping at the first node never evaluated
+		node := root
+		path = append(path[:0], node)
+		for !node.terminal && node.visits > 0 {
+			node = s.selectChild(tree, node, exploration)
+			path = append(path, node)
+		}
+
+		// Evaluation of the leaf and backpropagation
+		value := s.leafValue(node)
+		for _, n := range path {
+			n.visits++
+			n.valueSum += value
+		}
+	}
+
+	// Principal variation along the most visited children
+	var pv []game.Position
+	best := root
+	for node := root; !node.terminal; {
+		idx := -1
+		for i, child := range node.children {
+			if child != nil && child.visits > 0 && (idx == -1 || child.visits > node.children[idx].visits) {
+				idx = i
+			}
+		}
+		if idx == -1 {
+			break
+		}
+		if node == root {
+			best = node.children[idx]
+		}
+		pv = append(pv, node.moves[idx])
+		node = node.children[idx]
+	}
+
+	if len(pv) == 0 && !root.terminal {
+		// The budget ended before a child of the root was visited
+		return root.moves[:1], unsquash(s.leafValue(root)), tree
+	}
+	return pv, unsquash(best.value()), tree
+}
+
+// node returns the node of a position, creating it the first time it is reached, or an
+// unshared node when another position has its hash
+func (s *MCTSSearcher) node(tree *mctsTree, bb game.BitBoard, player game.Piece) *mctsNode {
+	key := zobrist.GlobalZobrist().Hash(bb, player)
+	n, found := tree.nodes[key]
+	if found && n.board == bb && n.player == player {
+		return n
+	}
+
+	n = &mctsNode{board: bb, player: player}
+	tree.allocated++
+	n.moves = game.ValidMovesBitBoard(bb, player)
+	if len(n.moves) == 0 {
+		if len(game.ValidMovesBitBoard(bb, game.GetOpponentColor(player))) > 0 {
+			n.moves = []game.Position{{Row: -1, Col: -1}}
+		} else {
+			n.terminal = true
+		}
+	}
+	n.children = make([]*mctsNode, len(n.moves))
+	if !found {
+		tree.nodes[key] = n
+	}
+	return n
+}
+
+// selectChild picks the child maximizing UCT for the player to move, unvisited children first
+func (s *MCTSSearcher) selectChild(tree *mctsTree, n *mctsNode, exploration float64) *mctsNode {
+	bestIdx := 0
+	bestUCT := math.Inf(-1)
+	logVisits := math.Log(float64(n.visits))
+
+	for i, child := range n.children {
+		if child == nil || child.visits == 0 {
+			bestIdx = i
+			break
+		}
+		q := child.value()
+		if n.player == game.Black {
+			q = 1 - q
+		}
+		uct := q + exploration*math.Sqrt(logVisits/float64(child.visits))
+		if uct > bestUCT {
+			bestUCT = uct
+			bestIdx = i
+		}
+	}
+
+	if n.children[bestIdx] == nil {
+		next := n.board
+		if move := n.moves[bestIdx]; move.Row != -1 {
+			next, _ = game.GetNewBitBoardAfterMove(n.board, move, n.player)
+		}
+		n.children[bestIdx] = s.node(tree, next, game.GetOpponentColor(n.player))
+	}
+	return n.children[bestIdx]
+}
+
+// leafValue returns the value of a node in [0,1] from White's perspective
+func (s *MCTSSearcher) leafValue(n *mctsNode) float64 {
+	if n.terminal {
+		switch game.WinnerBitBoard(n.board) {
+		case game.White:
+			return 1
+		case game.Black:
+			return 0
+		}
+		return 0.5
+	}
+	pec := PrecomputeEvaluationBitBoard(n.board)
+	return squash(s.Eval.PECEvaluate(n.board, pec))
+}
+
+func squash(score int16) float64 {
+	return 1 / (1 + math.Exp(-float64(score)/MCTSValueScale))
+}
+
+// unsquash converts a value back to the evaluation scale
+func unsquash(v float64) int16 {
+	const eps = 1e-9
+	v = math.Min(math.Max(v, eps), 1-eps)
+	score := MCTSValueScale * math.Log(v/(1-v))
+	return int16(math.Max(math.Min(score, float64(MAX_EVAL)), float64(MIN_EVAL)))
+}
@@ -0,0 +1,147 @@
+package evaluation
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	zobrist "github.com/Coloc3G/othello-engine/models/ai/cache"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// exactMargin returns the black minus white margin of the game under perfect play
+func exactMargin(bb game.BitBoard, player game.Piece) int {
+	moves := game.ValidMovesBitBoard(bb, player)
+	opponent := game.GetOpponentColor(player)
+	if len(moves) == 0 {
+		if !game.HasAnyMovesBitBoard(bb, opponent) {
+			return game.FinalMarginBitBoard(bb)
+		}
+		return exactMargin(bb, opponent)
+	}
+	best := 0
+	for i, move := range moves {
+		next, _ := game.GetNewBitBoardAfterMove(bb, move, player)
+		margin := exactMargin(next, opponent)
+		if i == 0 || (player == game.Black && margin > best) || (player == game.White && margin < best) {
+			best = margin
+		}
+	}
+	return best
+}
+
+// forcedWins returns endgame positions where the player to move has both winning and
+// not winning moves, with the winning ones
+func forcedWins(t *testing.T, seed int64, count int) ([]game.BitBoard, []game.Piece, [][]game.Position) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	var boards []game.BitBoard
+	var players []game.Piece
+	var wins [][]game.Position
+	for tries := 0; len(boards) < count && tries < 10000; tries++ {
+		g, err := game.RandomReachableBoard(rng, 54+rng.Intn(4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.LegalState() != game.HasMoves {
+			continue
+		}
+		bb, player := utils.BoardToBits(g.Board), g.CurrentPlayer.Color
+		var winning []game.Position
+		moves := game.ValidMovesBitBoard(bb, player)
+		for _, move := range moves {
+			next, _ := game.GetNewBitBoardAfterMove(bb, move, player)
+			if margin := exactMargin(next, game.GetOpponentColor(player)); (player == game.Black && margin > 0) || (player == game.White && margin < 0) {
+				winning = append(winning, move)
+			}
+		}
+		if len(winning) > 0 && len(winning) < len(moves) {
+			boards, players, wins = append(boards, bb), append(players, player), append(wins, winning)
+		}
+	}
+	if len(boards) < count {
+		t.Fatalf("%d forced wins found, want %d", len(boards), count)
+	}
+	return boards, players, wins
+}
+
+func TestMCTSLegalMove(t *testing.T) {
+	boards, players := randomBitBoards(t, 21, 20)
+	searcher := &MCTSSearcher{Eval: NewMixedEvaluation(V7Coeff), MaxIterations: 200}
+	for i, bb := range boards {
+		pv, _ := searcher.Search(utils.BitsToBoard(bb), players[i])
+		if len(pv) == 0 || !slices.Contains(game.ValidMovesBitBoard(bb, players[i]), pv[0]) {
+			t.Errorf("board %d: move %v is not legal", i, pv)
+		}
+	}
+
+	// a1 and b1 only, Black to move
+	pv, _ := searcher.Search(utils.BitsToBoard(game.BitBoard{WhitePieces: 1, BlackPieces: 2}), game.Black)
+	if len(pv) != 1 || pv[0] != game.PassMove {
+		t.Errorf("move %v without a legal move, want a pass", pv)
+	}
+}
+
+func TestMCTSForcedWin(t *testing.T) {
+	boards, players, wins := forcedWins(t, 5, 10)
+	searcher := &MCTSSearcher{Eval: NewMixedEvaluation(V7Coeff), MaxIterations: 2000}
+	for i, bb := range boards {
+		pv, _ := searcher.Search(utils.BitsToBoard(bb), players[i])
+		if !slices.Contains(wins[i], pv[0]) {
+			t.Errorf("board %d: move %v, want one of the winning moves %v", i, pv[0], wins[i])
+		}
+	}
+}
+
+func TestMCTSIterationBudget(t *testing.T) {
+	boards, players := randomBitBoards(t, 22, 10)
+	for _, budget := range []int{1, 2, 10, 500} {
+		searcher := &MCTSSearcher{Eval: NewMixedEvaluation(V7Coeff), MaxIterations: budget}
+		for i, bb := range boards {
+			pv, _, tree := searcher.search(bb, players[i])
+			root := tree.nodes[zobrist.GlobalZobrist().Hash(bb, players[i])]
+			if root.visits != budget {
+				t.Errorf("budget %d, board %d: %d iterations", budget, i, root.visits)
+			}
+			if tree.allocated > budget || len(tree.nodes) > tree.allocated {
+				t.Errorf("budget %d, board %d: %d nodes allocated, %d in the table", budget, i, tree.allocated, len(tree.nodes))
+			}
+			if len(pv) == 0 || !slices.Contains(game.ValidMovesBitBoard(bb, players[i]), pv[0]) {
+				t.Errorf("budget %d, board %d: move %v is not legal", budget, i, pv)
+			}
+		}
+	}
+
+}
+
+func TestMCTSNodeInvariants(t *testing.T) {
+	bb := utils.BoardToBits(game.NewGame("Black", "White").Board)
+	searcher := &MCTSSearcher{Eval: NewMixedEvaluation(V7Coeff), MaxIterations: 1000}
+	_, score, tree := searcher.search(bb, game.Black)
+	root := tree.nodes[zobrist.GlobalZobrist().Hash(bb, game.Black)]
+	if score < MIN_EVAL || score > MAX_EVAL {
+		t.Errorf("score %d out of the evaluation bounds", score)
+	}
+	// The children of the root cannot be reached from another node
+	childVisits := 0
+	for _, child := range root.children {
+		if child != nil {
+			childVisits += child.visits
+		}
+	}
+	if childVisits != root.visits-1 {
+		t.Errorf("children of the root visited %d times, root %d times", childVisits, root.visits)
+	}
+	for key, n := range tree.nodes {
+		if n.visits < 0 || n.visits > root.visits {
+			t.Errorf("node %x: %d visits, root %d", key, n.visits, root.visits)
+		}
+		if n.visits > 0 && (n.value() < 0 || n.value() > 1) {
+			t.Errorf("node %x: value %v out of [0, 1]", key, n.value())
+		}
+		if len(n.children) != len(n.moves) {
+			t.Errorf("node %x: %d children for %d moves", key, len(n.children), len(n.moves))
+		}
+	}
+}
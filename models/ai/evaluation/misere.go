@@ -0,0 +1,104 @@
+package evaluation
+
+import "github.com/Coloc3G/othello-engine/models/game"
+
+// MisereFlips selects the components of a MixedEvaluation whose sign is flipped
+// for the misère variant, where the player with the fewest discs wins
+type MisereFlips struct {
+	Material  bool
+	Mobility  bool
+	Corners   bool
+	Parity    bool
+	Stability bool
+	Frontier  bool
+	Threat    bool
+	Tempo     bool
+	Edge      bool
+}
+
+// DefaultMisereFlips flips the components counting discs: having more discs and
+// getting the last move, which flips discs, are bad in misère
+var DefaultMisereFlips = MisereFlips{Material: true, Parity: true}
+
+// MisereEvaluation adapts a MixedEvaluation to the misère variant. Terminal positions
+// are scored with the misère result, so the exact endgame search plays for the fewest discs.
+type MisereEvaluation struct {
+	Inner *MixedEvaluation
+	Flips MisereFlips
+}
+
+// MisereWrapper wraps an evaluation for the misère variant with DefaultMisereFlips
+func MisereWrapper(inner *MixedEvaluation) *MisereEvaluation {
+	return &MisereEvaluation{Inner: inner, Flips: DefaultMisereFlips}
+}
+
+// ForVariant returns the evaluation to use for a variant
+func ForVariant(eval *MixedEvaluation, variant game.Variant) Evaluation {
+	if variant == game.Misere {
+		return MisereWrapper(eval)
+	}
+	return eval
+}
+
+func (e *MisereEvaluation) Evaluate(b game.BitBoard) int16 {
+	pec := PrecomputeEvaluationBitBoard(b)
+	return e.PECEvaluate(b, pec)
+}
+
+func (e *MisereEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputation) int16 {
+	// A player without discs has the fewest possible and wins
+	if pec.WhitePieces == 0 {
+		return MAX_EVAL + 64
+	}
+	if pec.BlackPieces == 0 {
+		return MIN_EVAL - 64
+	}
+	if pec.IsGameOver {
+		if pec.WhitePieces < pec.BlackPieces {
+			return MAX_EVAL + pec.BlackPieces - pec.WhitePieces
+		} else if pec.WhitePieces > pec.BlackPieces {
+			return MIN_EVAL - pec.WhitePieces + pec.BlackPieces
+		}
+		return 0
+	}
+
+	flips := e.flipped()
+	var total int16
+	for i, score := range e.Inner.weightedScores(b, pec) {
+		if flips[i] {
+			score = -score
+		}
+		total += score
+	}
+	return total
+}
+
+// Components returns the weighted score of each component of a position, by name, with
+// the sign of the flipped components reversed, and nil when the game is over
+func (e *MisereEvaluation) Components(b game.BitBoard) map[string]int16 {
+	components := e.Inner.Components(b)
+	if components == nil {
+		return nil
+	}
+	for i, flip := range e.flipped() {
+		if flip {
+			components[ComponentNames[i]] = -components[ComponentNames[i]]
+		}
+	}
+	return components
+}
+
+// flipped tells, for each component in the order of weightedScores, whether its sign is reversed
+func (e *MisereEvaluation) flipped() [numComponents]bool {
+	return [numComponents]bool{
+		componentMaterial:  e.Flips.Material,
+		componentMobility:  e.Flips.Mobility,
+		componentCorners:   e.Flips.Corners,
+		componentParity:    e.Flips.Parity,
+		componentStability: e.Flips.Stability,
+		componentFrontier:  e.Flips.Frontier,
+		componentThreat:    e.Flips.Threat,
+		componentTempo:     e.Flips.Tempo,
+		componentEdge:      e.Flips.Edge,
+	}
+}
@@ -0,0 +1,65 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestMisereEvaluationFinishedGames(t *testing.T) {
+	tests := []struct {
+		name         string
+		white, black uint64
+		want         int16
+	}{
+		{"White wiped out wins", 0, initialBlack, MAX_EVAL + 64},
+		{"Black wiped out wins", initialWhite, 0, MIN_EVAL - 64},
+		{"White fewer discs wins", 0x0f, ^uint64(0x0f), MAX_EVAL + 60 - 4},
+		{"Black fewer discs wins", ^uint64(0x0f), 0x0f, MIN_EVAL - 60 + 4},
+		{"draw", 0x00000000ffffffff, 0xffffffff00000000, 0},
+	}
+	for _, tt := range tests {
+		bb := game.BitBoard{WhitePieces: tt.white, BlackPieces: tt.black}
+		if got := evaluateBoth(t, MisereWrapper(NewMixedEvaluation(V7Coeff)), bb); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMisereFlipsComponents(t *testing.T) {
+	boards, _ := randomBitBoards(t, 71, 30)
+	mixed := NewMixedEvaluation(V7Coeff)
+	misere := MisereWrapper(mixed)
+	noFlip := &MisereEvaluation{Inner: mixed}
+	for i, bb := range boards {
+		standard := mixed.Components(bb)
+		flipped := misere.Components(bb)
+		var want int16
+		for name, score := range standard {
+			if name == "material" || name == "parity" {
+				score = -score
+			}
+			if flipped[name] != score {
+				t.Errorf("board %d: misère %s %d, want %d", i, name, flipped[name], score)
+			}
+			want += score
+		}
+		if got := evaluateBoth(t, misere, bb); got != want {
+			t.Errorf("board %d: misère score %d, want the sum of its components %d", i, got, want)
+		}
+		// Without flips, only the finished games change
+		if got := evaluateBoth(t, noFlip, bb); got != mixed.Evaluate(bb) {
+			t.Errorf("board %d: misère without flips %d, standard %d", i, got, mixed.Evaluate(bb))
+		}
+	}
+}
+
+func TestForVariant(t *testing.T) {
+	mixed := NewMixedEvaluation(V7Coeff)
+	if eval := ForVariant(mixed, game.Standard); eval != Evaluation(mixed) {
+		t.Errorf("standard variant evaluation %T, want the mixed evaluation", eval)
+	}
+	if eval, ok := ForVariant(mixed, game.Misere).(*MisereEvaluation); !ok || eval.Inner != mixed || eval.Flips != DefaultMisereFlips {
+		t.Errorf("misère variant evaluation %+v, want the misère wrapper of the mixed evaluation", eval)
+	}
+}
@@ -1,6 +1,10 @@
 package evaluation
 
 import (
+	"fmt"
+	"slices"
+	"sort"
+
 	"github.com/Coloc3G/othello-engine/models/game"
 )
 
@@ -18,6 +22,12 @@ type MixedEvaluation struct {
 	StabilityEvaluation *StabilityEvaluation
 	// The evaluation of the board state using the frontier evaluation function
 	FrontierEvaluation *FrontierEvaluation
+	// The evaluation of the board state using the threat evaluation function
+	ThreatEvaluation *ThreatEvaluation
+	// The evaluation of the board state using the tempo evaluation function
+	TempoEvaluation *TempoEvaluation
+	// The evaluation of the board state using the edge evaluation function
+	EdgeEvaluation *EdgeEvaluation
 	// Coefficients for different game phases
 	MaterialCoeff  []int16
 	MobilityCoeff  []int16
@@ -25,6 +35,14 @@ type MixedEvaluation struct {
 	ParityCoeff    []int16
 	StabilityCoeff []int16
 	FrontierCoeff  []int16
+	// Optional, the threat, tempo and edge evaluations are skipped when empty
+	ThreatCoeff []int16
+	TempoCoeff  []int16
+	EdgeCoeff   []int16
+	// Piece count boundaries between game phases
+	PhaseBoundaries []int16
+	// customPhases is set when PhaseBoundaries differ from the ones used for pec.Phase
+	customPhases bool
 }
 
 // Coefficients structure for serialization
@@ -36,6 +54,12 @@ type EvaluationCoefficients struct {
 	ParityCoeffs    []int16 `json:"parity_coeff"`
 	StabilityCoeffs []int16 `json:"stability_coeff"`
 	FrontierCoeffs  []int16 `json:"frontier_coeff"`
+	ThreatCoeffs    []int16 `json:"threat_coeff,omitempty"`
+	TempoCoeffs     []int16 `json:"tempo_coeff,omitempty"`
+	EdgeCoeffs      []int16 `json:"edge_coeff,omitempty"`
+	// Phase i is used while the piece count is <= PhaseBoundaries[i], the last phase after that.
+	// Empty means DefaultPhaseBoundaries.
+	PhaseBoundaries []int16 `json:"phase_boundaries,omitempty"`
 	// Name of the coefficients set
 	Name string `json:"name"`
 }
@@ -47,13 +71,58 @@ func NewMixedEvaluation(coeffs EvaluationCoefficients) *MixedEvaluation {
 		CornersEvaluation:   NewCornersEvaluation(),
 		StabilityEvaluation: NewStabilityEvaluation(),
 		FrontierEvaluation:  NewFrontierEvaluation(),
+		ThreatEvaluation:    NewThreatEvaluation(),
+		TempoEvaluation:     NewTempoEvaluation(),
+		EdgeEvaluation:      NewEdgeEvaluation(),
 		MaterialCoeff:       coeffs.MaterialCoeffs,
 		MobilityCoeff:       coeffs.MobilityCoeffs,
 		CornersCoeff:        coeffs.CornersCoeffs,
 		ParityCoeff:         coeffs.ParityCoeffs,
 		StabilityCoeff:      coeffs.StabilityCoeffs,
 		FrontierCoeff:       coeffs.FrontierCoeffs,
+		ThreatCoeff:         coeffs.ThreatCoeffs,
+		TempoCoeff:          coeffs.TempoCoeffs,
+		EdgeCoeff:           coeffs.EdgeCoeffs,
+		PhaseBoundaries:     coeffs.Boundaries(),
+		customPhases:        !slices.Equal(coeffs.Boundaries(), DefaultPhaseBoundaries),
+	}
+}
+
+// Boundaries returns the phase boundaries of the coefficients, falling back to DefaultPhaseBoundaries
+func (ec EvaluationCoefficients) Boundaries() []int16 {
+	if len(ec.PhaseBoundaries) == 0 {
+		return DefaultPhaseBoundaries
 	}
+	return ec.PhaseBoundaries
+}
+
+// ValidatePhases checks that the phase boundaries are strictly increasing and that
+// every coefficient slice has exactly one value per phase
+func (ec EvaluationCoefficients) ValidatePhases() error {
+	boundaries := ec.Boundaries()
+	for i := 1; i < len(boundaries); i++ {
+		if boundaries[i] <= boundaries[i-1] {
+			return fmt.Errorf("phase boundaries must be strictly increasing, got %v", boundaries)
+		}
+	}
+
+	phases := len(boundaries) + 1
+	for _, c := range ec.namedCoeffs() {
+		if len(c.values) == 0 && c.optional {
+			continue
+		}
+		if len(c.values) != phases {
+			return fmt.Errorf("%s coefficients have %d phases, expected %d", c.name, len(c.values), phases)
+		}
+	}
+	return nil
+}
+
+// PhaseForPieceCount returns the game phase index for a piece count given the phase boundaries
+func PhaseForPieceCount(piecesCount int16, boundaries []int16) int {
+	return sort.Search(len(boundaries), func(i int) bool {
+		return piecesCount <= boundaries[i]
+	})
 }
 
 func (e *MixedEvaluation) Evaluate(b game.BitBoard) int16 {
@@ -78,6 +147,57 @@ func (e *MixedEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputat
 		return 0
 	}
 
+	var total int16
+	for _, score := range e.weightedScores(b, pec) {
+		total += score
+	}
+	return total
+}
+
+// Indexes of the components in the scores returned by weightedScores
+const (
+	componentMaterial = iota
+	componentMobility
+	componentCorners
+	componentParity
+	componentStability
+	componentFrontier
+	componentThreat
+	componentTempo
+	componentEdge
+	numComponents
+)
+
+// ComponentNames names the components of the mixed evaluation, in the order of weightedScores
+var ComponentNames = [numComponents]string{
+	componentMaterial:  "material",
+	componentMobility:  "mobility",
+	componentCorners:   "corners",
+	componentParity:    "parity",
+	componentStability: "stability",
+	componentFrontier:  "frontier",
+	componentThreat:    "threat",
+	componentTempo:     "tempo",
+	componentEdge:      "edge",
+}
+
+// Components returns the weighted score of each component of a position, by name,
+// and nil when the game is over
+func (e *MixedEvaluation) Components(b game.BitBoard) map[string]int16 {
+	pec := PrecomputeEvaluationBitBoard(b)
+	if pec.IsGameOver || pec.WhitePieces == 0 || pec.BlackPieces == 0 {
+		return nil
+	}
+	components := make(map[string]int16, numComponents)
+	for i, score := range e.weightedScores(b, pec) {
+		components[ComponentNames[i]] = score
+	}
+	return components
+}
+
+// weightedScores returns the score of each component multiplied by its coefficient
+// for the phase of a position that is not over
+func (e *MixedEvaluation) weightedScores(b game.BitBoard, pec PreEvaluationComputation) (scores [numComponents]int16) {
 	materialCoeff, mobilityCoeff, cornersCoeff, parityCoeff, stabilityCoeff, frontierCoeff := e.ComputeGamePhaseCoefficients(pec)
 
 	// Get all raw evaluation scores without normalization to match CUDA implementation
@@ -88,6 +208,40 @@ func (e *MixedEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputat
 	stabilityScore := e.StabilityEvaluation.PECEvaluate(b, pec)
 	frontierScore := e.FrontierEvaluation.PECEvaluate(b, pec)
 
+	// The threat and tempo evaluations play every move, only run them when they are weighted
+	var threatCoeff, threatScore, tempoCoeff, tempoScore int16
+	if len(e.ThreatCoeff) > 0 {
+		threatCoeff = e.ThreatCoeff[e.phase(pec)]
+		if threatCoeff != 0 {
+			threatScore = e.ThreatEvaluation.PECEvaluate(b, pec)
+		}
+	}
+	if len(e.TempoCoeff) > 0 {
+		tempoCoeff = e.TempoCoeff[e.phase(pec)]
+		if tempoCoeff != 0 {
+			tempoScore = e.TempoEvaluation.PECEvaluate(b, pec)
+		}
+	}
+	var edgeCoeff, edgeScore int16
+	if len(e.EdgeCoeff) > 0 {
+		edgeCoeff = e.EdgeCoeff[e.phase(pec)]
+		if edgeCoeff != 0 {
+			edgeScore = e.EdgeEvaluation.PECEvaluate(b, pec)
+		}
+	}
+
+	scores = [numComponents]int16{
+		componentMaterial:  materialCoeff * materialScore,
+		componentMobility:  mobilityCoeff * mobilityScore,
+		componentCorners:   cornersCoeff * cornersScore,
+		componentParity:    parityCoeff * parityScore,
+		componentStability: stabilityCoeff * stabilityScore,
+		componentFrontier:  frontierCoeff * frontierScore,
+		componentThreat:    threatCoeff * threatScore,
+		componentTempo:     tempoCoeff * tempoScore,
+		componentEdge:      edgeCoeff * edgeScore,
+	}
+
 	if pec.Debug {
 		println("materialCoeff:", materialCoeff, "\tmaterialScore:", materialScore)
 		println("mobilityCoeff:", mobilityCoeff, "\tmobilityScore:", mobilityScore)
@@ -95,39 +249,21 @@ func (e *MixedEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputat
 		println("parityCoeff:", parityCoeff, "\tparityScore:", parityScore)
 		println("stabilityCoeff:", stabilityCoeff, "\tstabilityScore:", stabilityScore)
 		println("frontierCoeff:", frontierCoeff, "\tfrontierScore:", frontierScore)
-		println("Resulting score:", materialCoeff*materialScore+
-			mobilityCoeff*mobilityScore+
-			cornersCoeff*cornersScore+
-			parityCoeff*parityScore+
-			stabilityCoeff*stabilityScore+
-			frontierCoeff*frontierScore)
-	}
-
-	return materialCoeff*materialScore +
-		mobilityCoeff*mobilityScore +
-		cornersCoeff*cornersScore +
-		parityCoeff*parityScore +
-		stabilityCoeff*stabilityScore +
-		frontierCoeff*frontierScore
+		println("threatCoeff:", threatCoeff, "\tthreatScore:", threatScore)
+		println("tempoCoeff:", tempoCoeff, "\ttempoScore:", tempoScore)
+		println("edgeCoeff:", edgeCoeff, "\tedgeScore:", edgeScore)
+		var total int16
+		for _, score := range scores {
+			total += score
+		}
+		println("Resulting score:", total)
+	}
+	return scores
 }
 
 // ComputeGamePhaseCoefficients computes the coefficients for the evaluation functions based on the number of pieces on the board
 func (e *MixedEvaluation) ComputeGamePhaseCoefficients(pec PreEvaluationComputation) (int16, int16, int16, int16, int16, int16) {
-	piecesCount := pec.WhitePieces + pec.BlackPieces
-	var phase int
-	if piecesCount < 10 {
-		phase = 0 // Early game
-	} else if piecesCount <= 20 {
-		phase = 1 // Mid game
-	} else if piecesCount <= 35 {
-		phase = 2 // Mid game
-	} else if piecesCount <= 50 {
-		phase = 3 // Mid game
-	} else if piecesCount <= 55 {
-		phase = 4 // Mid game
-	} else {
-		phase = 5 // Late game
-	}
+	phase := e.phase(pec)
 
 	return e.MaterialCoeff[phase],
 		e.MobilityCoeff[phase],
@@ -136,3 +272,11 @@ func (e *MixedEvaluation) ComputeGamePhaseCoefficients(pec PreEvaluationComputat
 		e.StabilityCoeff[phase],
 		e.FrontierCoeff[phase]
 }
+
+// phase returns the game phase of the position for this evaluation's boundaries
+func (e *MixedEvaluation) phase(pec PreEvaluationComputation) int {
+	if e.customPhases {
+		return PhaseForPieceCount(pec.WhitePieces+pec.BlackPieces, e.PhaseBoundaries)
+	}
+	return pec.Phase
+}
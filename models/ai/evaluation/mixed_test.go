@@ -0,0 +1,87 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// goldenPhases is the phase of each piece count from 4 to 64 with DefaultPhaseBoundaries
+var goldenPhases = [61]int{
+	0, 0, 0, 0, 0, 0, // 4-9
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, // 10-20
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, // 21-35
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, // 36-50
+	4, 4, 4, 4, 4, // 51-55
+	5, 5, 5, 5, 5, 5, 5, 5, 5, // 56-64
+}
+
+func TestComputeGamePhaseCoefficientsGolden(t *testing.T) {
+	// Each component gets its own coefficient per phase, so the phase can be read back
+	coeffs := EvaluationCoefficients{
+		MaterialCoeffs:  []int16{100, 101, 102, 103, 104, 105},
+		MobilityCoeffs:  []int16{200, 201, 202, 203, 204, 205},
+		CornersCoeffs:   []int16{300, 301, 302, 303, 304, 305},
+		ParityCoeffs:    []int16{400, 401, 402, 403, 404, 405},
+		StabilityCoeffs: []int16{500, 501, 502, 503, 504, 505},
+		FrontierCoeffs:  []int16{600, 601, 602, 603, 604, 605},
+	}
+	eval := NewMixedEvaluation(coeffs)
+	for i, phase := range goldenPhases {
+		count := i + 4
+		// The discs fill the board from a1, the colors do not change the phase
+		discs := ^uint64(0) >> (64 - count)
+		bb := game.BitBoard{WhitePieces: discs & 0x5555555555555555, BlackPieces: discs & 0xaaaaaaaaaaaaaaaa}
+		for _, pec := range []PreEvaluationComputation{PrecomputeEvaluationBitBoard(bb), PrecomputeEvaluation(utils.BitsToBoard(bb))} {
+			material, mobility, corners, parity, stability, frontier := eval.ComputeGamePhaseCoefficients(pec)
+			got := []int16{material, mobility, corners, parity, stability, frontier}
+			for j, coeff := range got {
+				if want := int16(100*(j+1) + phase); coeff != want {
+					t.Errorf("%d pieces: coefficients %v, want phase %d", count, got, phase)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestCustomPhaseBoundaries(t *testing.T) {
+	// Three phases split at 20 and 40 discs, the material coefficient names the phase
+	coeffs := EvaluationCoefficients{
+		MaterialCoeffs:  []int16{1, 2, 3},
+		MobilityCoeffs:  []int16{0, 0, 0},
+		CornersCoeffs:   []int16{0, 0, 0},
+		ParityCoeffs:    []int16{0, 0, 0},
+		StabilityCoeffs: []int16{0, 0, 0},
+		FrontierCoeffs:  []int16{0, 0, 0},
+		PhaseBoundaries: []int16{20, 40},
+	}
+	if err := coeffs.ValidatePhases(); err != nil {
+		t.Fatal(err)
+	}
+	eval := NewMixedEvaluation(coeffs)
+	tests := []struct {
+		count, phase int
+	}{
+		{4, 0}, {20, 0}, {21, 1}, {40, 1}, {41, 2}, {63, 2},
+	}
+	for _, tt := range tests {
+		discs := ^uint64(0) >> (64 - tt.count)
+		bb := game.BitBoard{WhitePieces: discs & 0x5555555555555555, BlackPieces: discs & 0xaaaaaaaaaaaaaaaa}
+		material, _, _, _, _, _ := eval.ComputeGamePhaseCoefficients(PrecomputeEvaluationBitBoard(bb))
+		if int(material) != tt.phase+1 {
+			t.Errorf("%d discs: phase %d, want %d", tt.count, material-1, tt.phase)
+		}
+	}
+
+	// The default boundaries need six coefficients per component
+	coeffs.PhaseBoundaries = nil
+	if err := coeffs.ValidatePhases(); err == nil {
+		t.Error("three coefficients per component validated with the default boundaries")
+	}
+	coeffs.PhaseBoundaries = []int16{40, 20}
+	if err := coeffs.ValidatePhases(); err == nil {
+		t.Error("decreasing boundaries validated")
+	}
+}
@@ -1,6 +1,10 @@
 package evaluation
 
-import "github.com/Coloc3G/othello-engine/models/game"
+import (
+	"math/bits"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
 
 // MobilityEvaluation is an evaluation function that scores a board based on the number of possible moves for each player
 type MobilityEvaluation struct {
@@ -10,11 +14,16 @@ func NewMobilityEvaluation() *MobilityEvaluation {
 	return &MobilityEvaluation{}
 }
 
+// Evaluate counts the moves on the move masks, without building the move lists
 func (e *MobilityEvaluation) Evaluate(b game.BitBoard) int16 {
-	pec := PrecomputeEvaluationBitBoard(b)
-	return e.PECEvaluate(b, pec)
+	return mobility(game.ValidMovesMask(b, game.White), game.ValidMovesMask(b, game.Black))
 }
 
 func (e *MobilityEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputation) int16 {
-	return int16(len(pec.WhiteValidMoves) - len(pec.BlackValidMoves))
+	return mobility(pec.WhiteMoveMask, pec.BlackMoveMask)
+}
+
+// mobility is the difference between the number of White and Black moves
+func mobility(whiteMoves, blackMoves uint64) int16 {
+	return int16(bits.OnesCount64(whiteMoves) - bits.OnesCount64(blackMoves))
 }
@@ -0,0 +1,57 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestMobilityEvaluation(t *testing.T) {
+	tests := []struct {
+		name         string
+		white, black uint64
+		want         int16
+	}{
+		{"initial position, 4 moves each", initialWhite, initialBlack, 0},
+		{"only White can move, c1", 1 << 0, 1 << 1, 1},
+		{"only Black can move, c1", 1 << 1, 1 << 0, -1},
+		{"nobody can move", ^uint64(0), 0, 0},
+	}
+	for _, tt := range tests {
+		bb := game.BitBoard{WhitePieces: tt.white, BlackPieces: tt.black}
+		if got := evaluateBoth(t, NewMobilityEvaluation(), bb); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// listMobility is the mobility counted on the move lists, before the move masks
+func listMobility(bb game.BitBoard) int16 {
+	return int16(len(game.ValidMovesBitBoard(bb, game.White)) - len(game.ValidMovesBitBoard(bb, game.Black)))
+}
+
+func TestMobilityMatchesMoveLists(t *testing.T) {
+	boards, _ := randomBitBoards(t, 3, 500)
+	for i, bb := range boards {
+		if got, want := evaluateBoth(t, NewMobilityEvaluation(), bb), listMobility(bb); got != want {
+			t.Errorf("board %d: mobility %d, %d on the move lists", i, got, want)
+		}
+	}
+}
+
+func BenchmarkMobilityMasks(b *testing.B) {
+	boards, _ := randomBitBoards(b, 1, 256)
+	e := NewMobilityEvaluation()
+	b.ReportAllocs()
+	for i := range b.N {
+		e.Evaluate(boards[i%len(boards)])
+	}
+}
+
+func BenchmarkMobilityMoveLists(b *testing.B) {
+	boards, _ := randomBitBoards(b, 1, 256)
+	b.ReportAllocs()
+	for i := range b.N {
+		listMobility(boards[i%len(boards)])
+	}
+}
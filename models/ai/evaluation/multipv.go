@@ -0,0 +1,50 @@
+package evaluation
+
+import (
+	"sort"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// PVLine is a principal variation starting with one of the root moves
+type PVLine struct {
+	Moves []game.Position
+	Score int16
+}
+
+// SolveMultiPV searches every root move with a full window and returns the best lines
+// for player, best first. At most lines variations are returned, all of them when lines <= 0.
+func SolveMultiPV(b game.Board, player game.Piece, depth int8, eval Evaluation, lines int) []PVLine {
+	bb := utils.BoardToBits(b)
+	validMoves := game.ValidMovesBitBoard(bb, player)
+	if len(validMoves) == 0 {
+		return nil
+	}
+
+	opponent := game.GetOtherPlayer(player).Color
+	cache := NewCache()
+	result := make([]PVLine, 0, len(validMoves))
+
+	for _, move := range validMoves {
+		newBoard, _ := game.GetNewBitBoardAfterMove(bb, move, player)
+		score, childMoves := MMAB(newBoard, opponent, depth-1, MIN_EVAL-65, MAX_EVAL+65, eval, cache, nil)
+		result = append(result, PVLine{
+			Moves: append([]game.Position{move}, childMoves...),
+			Score: score,
+		})
+	}
+
+	// Scores are from White's perspective
+	sort.SliceStable(result, func(i, j int) bool {
+		if player == game.White {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Score < result[j].Score
+	})
+
+	if lines > 0 && lines < len(result) {
+		result = result[:lines]
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package evaluation
+
+import (
+	"math/bits"
+
+	"github.com/Coloc3G/othello-engine/models/ai"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// PositionalOrderMask marks the squares weighted positively by ai.StabilityMap. Their discs
+// are not stable discs, which no move can flip, but the discs the stability evaluation
+// favours, so the move ordering using them is positional.
+var PositionalOrderMask = func() (mask uint64) {
+	for row := range 8 {
+		for col := range 8 {
+			if ai.StabilityMap[row][col] > 0 {
+				mask |= 1 << (row*8 + col)
+			}
+		}
+	}
+	return mask
+}()
+
+// PositionalDiscs returns the discs of both colors standing on PositionalOrderMask squares
+func PositionalDiscs(b game.BitBoard) uint64 {
+	return (b.WhitePieces | b.BlackPieces) & PositionalOrderMask
+}
+
+// ComputeMVVScore returns the number of discs of mask that player flips by playing move
+// (Most Valuable Victim), 0 when the move is not valid. Only the discs of the opponent
+// can be flipped, so mask may hold both colors, e.g. PositionalDiscs(b).
+func ComputeMVVScore(board game.BitBoard, move game.Position, player game.Piece, mask uint64) int16 {
+	nb, ok := game.GetNewBitBoardAfterMove(board, move, player)
+	if !ok {
+		return 0
+	}
+	flipped := nb.BlackPieces & board.WhitePieces
+	if player == game.White {
+		flipped = nb.WhitePieces & board.BlackPieces
+	}
+	return int16(bits.OnesCount64(flipped & mask))
+}
+
+// orderMovesPositionally sorts moves by decreasing number of PositionalDiscs flipped by
+// the player, keeping the corners first order of game.ValidMovesBitBoard between moves
+// of equal score
+func orderMovesPositionally(b game.BitBoard, player game.Piece, moves []game.Position) {
+	opponent := b.BlackPieces
+	if player == game.Black {
+		opponent = b.WhitePieces
+	}
+	mask := opponent & PositionalOrderMask
+	if len(moves) < 2 || mask == 0 {
+		return
+	}
+	var buf [32]int16
+	scores := buf[:0]
+	for _, move := range moves {
+		scores = append(scores, ComputeMVVScore(b, move, player, mask))
+	}
+	// Insertion sort, stable and allocation free for the few moves of a position
+	for i := 1; i < len(moves); i++ {
+		for j := i; j > 0 && scores[j] > scores[j-1]; j-- {
+			scores[j], scores[j-1] = scores[j-1], scores[j]
+			moves[j], moves[j-1] = moves[j-1], moves[j]
+		}
+	}
+}
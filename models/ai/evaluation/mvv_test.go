@@ -0,0 +1,83 @@
+package evaluation
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestComputeMVVScoreCountsPlayerFlips(t *testing.T) {
+	boards, players := randomBitBoards(t, 6, 100)
+	both := 0
+	for i, bb := range boards {
+		opponent := game.GetOpponentColor(players[i])
+		for _, move := range game.ValidMovesBitBoard(bb, players[i]) {
+			child, _ := game.GetNewBitBoardAfterMove(bb, move, players[i])
+			own, childOwn := bb.BlackPieces, child.BlackPieces
+			if players[i] == game.White {
+				own, childOwn = bb.WhitePieces, child.WhitePieces
+			}
+			flips := int16(bits.OnesCount64(childOwn) - bits.OnesCount64(own) - 1)
+			if got := ComputeMVVScore(bb, move, players[i], ^uint64(0)); got != flips {
+				t.Errorf("board %d, move %v: score %d, %d discs flipped", i, move, got, flips)
+			}
+			if game.ValidMovesMask(bb, opponent)&(1<<(uint(move.Row)*8+uint(move.Col))) != 0 {
+				both++
+			}
+		}
+		if got := ComputeMVVScore(bb, game.Position{Row: -1, Col: -1}, players[i], ^uint64(0)); got != 0 {
+			t.Errorf("board %d: score %d for an invalid move", i, got)
+		}
+	}
+	if both == 0 {
+		t.Error("no move valid for both players among the positions")
+	}
+}
+
+func TestPositionalOrderingKeepsMoves(t *testing.T) {
+	boards, players := randomBitBoards(t, 7, 100)
+	for i, bb := range boards {
+		moves := game.ValidMovesBitBoard(bb, players[i])
+		ordered := append([]game.Position(nil), moves...)
+		orderMovesPositionally(bb, players[i], ordered)
+		seen := make(map[game.Position]bool)
+		for _, move := range ordered {
+			seen[move] = true
+		}
+		if len(seen) != len(moves) {
+			t.Fatalf("board %d: ordered %v, moves %v", i, ordered, moves)
+		}
+		opponent := bb.BlackPieces
+		if players[i] == game.Black {
+			opponent = bb.WhitePieces
+		}
+		for j := 1; j < len(ordered); j++ {
+			if ComputeMVVScore(bb, ordered[j], players[i], opponent&PositionalOrderMask) > ComputeMVVScore(bb, ordered[j-1], players[i], opponent&PositionalOrderMask) {
+				t.Errorf("board %d: %v ordered after %v with a higher score", i, ordered[j], ordered[j-1])
+			}
+		}
+	}
+}
+
+// benchmarkDepth8Nodes reports the positions visited by depth 8 searches of midgame positions
+func benchmarkDepth8Nodes(b *testing.B, positional bool) {
+	boards, players := randomBitBoards(b, 8, 4)
+	eval := NewMixedEvaluation(V7Coeff)
+	var nodes int64
+	for i := range b.N {
+		bb := boards[i%len(boards)]
+		var searched int64
+		solve(bb, players[i%len(boards)], 8, eval, nil, nil, &SearchOptions{Nodes: &searched, PositionalOrdering: positional})
+		nodes += searched
+	}
+	b.ReportMetric(float64(nodes)/float64(b.N), "nodes/op")
+}
+
+func BenchmarkDepth8PositionalOrdering(b *testing.B) {
+	benchmarkDepth8Nodes(b, true)
+}
+
+func BenchmarkDepth8GeneratorOrdering(b *testing.B) {
+	benchmarkDepth8Nodes(b, false)
+}
@@ -0,0 +1,246 @@
+package evaluation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// NeuralFeatures is the number of inputs of a NeuralEvaluation: the raw scores of the
+// components of the MixedEvaluation, in the order of weightedScores
+const NeuralFeatures = numComponents
+
+const (
+	neuralInputScale  = 1.0 / 64 // Brings the raw component scores around [-1, 1]
+	neuralOutputScale = 2000     // Maps the output of the network to the range of the mixed evaluation
+)
+
+// neuralMagic starts the binary encoding of a network
+var neuralMagic = [4]byte{'O', 'N', 'N', '1'}
+
+// NeuralEvaluation scores positions with a fully connected network. The hidden layers use
+// tanh, the single output is linear and scaled to the range of the mixed evaluation.
+type NeuralEvaluation struct {
+	// Layers holds the size of each layer, NeuralFeatures first and 1 last
+	Layers []int
+	// Weights[l] maps layer l to layer l+1, row major with one row per output
+	Weights [][]float32
+	Biases  [][]float32
+
+	components *MixedEvaluation
+}
+
+// ParseLayers parses a comma separated list of layer sizes such as "9,16,8,1"
+func ParseLayers(s string) ([]int, error) {
+	var layers []int
+	for _, field := range strings.Split(s, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("invalid layer size %q", field)
+		}
+		layers = append(layers, size)
+	}
+	return layers, ValidateLayers(layers)
+}
+
+// ValidateLayers checks that layers describe a network usable as an evaluation
+func ValidateLayers(layers []int) error {
+	if len(layers) < 2 {
+		return fmt.Errorf("a network needs at least 2 layers, got %d", len(layers))
+	}
+	if layers[0] != NeuralFeatures {
+		return fmt.Errorf("the input layer must have %d neurons, got %d", NeuralFeatures, layers[0])
+	}
+	if layers[len(layers)-1] != 1 {
+		return fmt.Errorf("the output layer must have 1 neuron, got %d", layers[len(layers)-1])
+	}
+	for _, size := range layers {
+		if size <= 0 {
+			return fmt.Errorf("layer sizes must be positive, got %v", layers)
+		}
+	}
+	return nil
+}
+
+// NewNeuralEvaluation creates a network with all weights at zero
+func NewNeuralEvaluation(layers []int) *NeuralEvaluation {
+	e := &NeuralEvaluation{
+		Layers:     append([]int(nil), layers...),
+		Weights:    make([][]float32, len(layers)-1),
+		Biases:     make([][]float32, len(layers)-1),
+		components: NewMixedEvaluation(V1Coeff),
+	}
+	for l := range e.Weights {
+		e.Weights[l] = make([]float32, layers[l]*layers[l+1])
+		e.Biases[l] = make([]float32, layers[l+1])
+	}
+	return e
+}
+
+// NewRandomNeuralEvaluation creates a network with weights drawn from a normal distribution
+// scaled by the fan-in of each layer, and biases at zero
+func NewRandomNeuralEvaluation(layers []int, rng *rand.Rand) *NeuralEvaluation {
+	e := NewNeuralEvaluation(layers)
+	for l, weights := range e.Weights {
+		std := 1 / math.Sqrt(float64(layers[l]))
+		for i := range weights {
+			weights[i] = float32(rng.NormFloat64() * std)
+		}
+	}
+	return e
+}
+
+// Clone returns a deep copy of the network
+func (e *NeuralEvaluation) Clone() *NeuralEvaluation {
+	c := NewNeuralEvaluation(e.Layers)
+	for l := range e.Weights {
+		copy(c.Weights[l], e.Weights[l])
+		copy(c.Biases[l], e.Biases[l])
+	}
+	return c
+}
+
+// Params calls fn with every weight and bias of the network
+func (e *NeuralEvaluation) Params(fn func(p *float32)) {
+	for l := range e.Weights {
+		for i := range e.Weights[l] {
+			fn(&e.Weights[l][i])
+		}
+		for i := range e.Biases[l] {
+			fn(&e.Biases[l][i])
+		}
+	}
+}
+
+func (e *NeuralEvaluation) Evaluate(b game.BitBoard) int16 {
+	pec := PrecomputeEvaluationBitBoard(b)
+	return e.PECEvaluate(b, pec)
+}
+
+func (e *NeuralEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputation) int16 {
+	if pec.WhitePieces == 0 {
+		return MIN_EVAL - 64
+	}
+	if pec.BlackPieces == 0 {
+		return MAX_EVAL + 64
+	}
+	if pec.IsGameOver {
+		if pec.WhitePieces > pec.BlackPieces {
+			return MAX_EVAL + pec.WhitePieces - pec.BlackPieces
+		} else if pec.WhitePieces < pec.BlackPieces {
+			return MIN_EVAL - pec.BlackPieces + pec.WhitePieces
+		}
+		return 0
+	}
+
+	features := e.features(b, pec)
+	activations := features[:]
+	for l, weights := range e.Weights {
+		next := make([]float32, e.Layers[l+1])
+		for o := range next {
+			sum := e.Biases[l][o]
+			row := weights[o*len(activations) : (o+1)*len(activations)]
+			for i, a := range activations {
+				sum += row[i] * a
+			}
+			if l < len(e.Weights)-1 {
+				sum = float32(math.Tanh(float64(sum)))
+			}
+			next[o] = sum
+		}
+		activations = next
+	}
+
+	// Keep the score of positions not over inside the range of finished games
+	score := float64(activations[0]) * neuralOutputScale
+	return int16(math.Max(math.Min(score, float64(MAX_EVAL-1)), float64(MIN_EVAL+1)))
+}
+
+// features returns the scaled raw scores of the mixed evaluation components
+func (e *NeuralEvaluation) features(b game.BitBoard, pec PreEvaluationComputation) (features [NeuralFeatures]float32) {
+	c := e.components
+	raw := [NeuralFeatures]int16{
+		componentMaterial:  c.MaterialEvaluation.PECEvaluate(b, pec),
+		componentMobility:  c.MobilityEvaluation.PECEvaluate(b, pec),
+		componentCorners:   c.CornersEvaluation.PECEvaluate(b, pec),
+		componentParity:    c.ParityEvaluation.PECEvaluate(b, pec),
+		componentStability: c.StabilityEvaluation.PECEvaluate(b, pec),
+		componentFrontier:  c.FrontierEvaluation.PECEvaluate(b, pec),
+		componentThreat:    c.ThreatEvaluation.PECEvaluate(b, pec),
+		componentTempo:     c.TempoEvaluation.PECEvaluate(b, pec),
+		componentEdge:      c.EdgeEvaluation.PECEvaluate(b, pec),
+	}
+	for i, score := range raw {
+		features[i] = float32(score) * neuralInputScale
+	}
+	return features
+}
+
+// MarshalBinary encodes the network as the magic "ONN1", the number of layers and their
+// sizes as little endian uint32, then the weights and biases of each layer as float32
+func (e *NeuralEvaluation) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(neuralMagic[:])
+	sizes := []uint32{uint32(len(e.Layers))}
+	for _, size := range e.Layers {
+		sizes = append(sizes, uint32(size))
+	}
+	binary.Write(&buf, binary.LittleEndian, sizes)
+	for l := range e.Weights {
+		binary.Write(&buf, binary.LittleEndian, e.Weights[l])
+		binary.Write(&buf, binary.LittleEndian, e.Biases[l])
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a network written by MarshalBinary
+func (e *NeuralEvaluation) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	var magic [4]byte
+	if _, err := r.Read(magic[:]); err != nil || magic != neuralMagic {
+		return errors.New("not a neural network file")
+	}
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return err
+	}
+	if count > 64 {
+		return fmt.Errorf("invalid number of layers %d", count)
+	}
+	sizes := make([]uint32, count)
+	if err := binary.Read(r, binary.LittleEndian, sizes); err != nil {
+		return err
+	}
+	layers := make([]int, count)
+	for i, size := range sizes {
+		if size > 1<<16 {
+			return fmt.Errorf("invalid layer size %d", size)
+		}
+		layers[i] = int(size)
+	}
+	if err := ValidateLayers(layers); err != nil {
+		return err
+	}
+
+	decoded := NewNeuralEvaluation(layers)
+	for l := range decoded.Weights {
+		if err := binary.Read(r, binary.LittleEndian, decoded.Weights[l]); err != nil {
+			return err
+		}
+		if err := binary.Read(r, binary.LittleEndian, decoded.Biases[l]); err != nil {
+			return err
+		}
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%d trailing bytes after the network", r.Len())
+	}
+	*e = *decoded
+	return nil
+}
@@ -0,0 +1,37 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// The parity evaluation is global: the regions only count through the total number of
+// empty squares, +1 when it is even and -1 when it is odd
+func TestParityEvaluation(t *testing.T) {
+	// Squares in the four quadrants
+	const a1, b1, h1, a8, h8 = 0, 1, 7, 56, 63
+	tests := []struct {
+		name  string
+		empty []int
+		want  int16
+	}{
+		{"full board", nil, 1},
+		{"one empty in one quadrant", []int{a1}, -1},
+		{"two empties in one quadrant", []int{a1, b1}, 1},
+		{"one empty in two quadrants", []int{a1, h1}, 1},
+		{"one empty in three quadrants", []int{a1, h1, a8}, -1},
+		{"one empty in every quadrant", []int{a1, h1, a8, h8}, 1},
+	}
+	for _, tt := range tests {
+		white := ^uint64(0)
+		for _, sq := range tt.empty {
+			white &^= 1 << sq
+		}
+		black := white & 0x00000000ffffffff
+		bb := game.BitBoard{WhitePieces: white &^ black, BlackPieces: black}
+		if got := evaluateBoth(t, NewParityEvaluation(), bb); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package evaluation
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// Ponderer searches in the background the position expected after the opponent's
+// predicted reply, so the engine can answer instantly when the prediction is right
+type Ponderer struct {
+	mu  sync.Mutex
+	job *ponderJob
+}
+
+type ponderJob struct {
+	board  game.Board
+	player game.Piece
+	stop   atomic.Bool // Cancels the search, set when the job is discarded
+	done   chan struct{}
+	moves  []game.Position
+	score  int16
+}
+
+// ponderSearch searches a pondered position, stopping once stop holds true
+type ponderSearch func(b game.Board, player game.Piece, stop *atomic.Bool) ([]game.Position, int16)
+
+// Start ponders the position reached when opponent plays predicted on b, searched for
+// the engine (the other color) with eval at depth. Any previous pondering is cancelled.
+func (p *Ponderer) Start(b game.Board, opponent game.Piece, predicted game.Position, depth int8, eval Evaluation) {
+	p.start(b, opponent, predicted, func(b game.Board, player game.Piece, stop *atomic.Bool) ([]game.Position, int16) {
+		return SolveWithOptions(b, player, depth, eval, SearchOptions{Stop: stop}, nil)
+	})
+}
+
+// StartNodes is Start searching with SolveNodes, up to maxDepth within budget nodes
+func (p *Ponderer) StartNodes(b game.Board, opponent game.Piece, predicted game.Position, maxDepth int8, eval Evaluation, budget int64) {
+	p.start(b, opponent, predicted, func(b game.Board, player game.Piece, stop *atomic.Bool) ([]game.Position, int16) {
+		moves, score, _ := solveNodes(b, player, maxDepth, eval, budget, stop)
+		return moves, score
+	})
+}
+
+// start ponders the position reached when opponent plays predicted on b with search
+func (p *Ponderer) start(b game.Board, opponent game.Piece, predicted game.Position, search ponderSearch) {
+	next, ok := game.ApplyMoveToBoard(b, opponent, predicted)
+	if !ok {
+		p.Stop()
+		return
+	}
+
+	job := &ponderJob{
+		board:  next,
+		player: game.GetOpponentColor(opponent),
+		done:   make(chan struct{}),
+	}
+	go func() {
+		job.moves, job.score = search(job.board, job.player, &job.stop)
+		close(job.done)
+	}()
+
+	p.swap(job)
+}
+
+// Result returns the pondered search when b and player are the pondered position,
+// waiting for the search to finish if needed. It reports false on a ponder miss,
+// whose search is cancelled.
+func (p *Ponderer) Result(b game.Board, player game.Piece) ([]game.Position, int16, bool) {
+	p.mu.Lock()
+	job := p.job
+	p.job = nil
+	p.mu.Unlock()
+
+	if job == nil {
+		return nil, 0, false
+	}
+	if job.board != b || job.player != player {
+		job.stop.Store(true)
+		return nil, 0, false
+	}
+	<-job.done
+	return job.moves, job.score, true
+}
+
+// Stop cancels the current pondering, its search returning at the next node
+func (p *Ponderer) Stop() {
+	p.swap(nil)
+}
+
+// swap replaces the current job, cancelling the previous one
+func (p *Ponderer) swap(job *ponderJob) {
+	p.mu.Lock()
+	previous := p.job
+	p.job = job
+	p.mu.Unlock()
+	if previous != nil {
+		previous.stop.Store(true)
+	}
+}
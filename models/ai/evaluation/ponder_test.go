@@ -0,0 +1,108 @@
+package evaluation
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestPonderHitReturnsCachedMove(t *testing.T) {
+	g := game.NewGame("Black", "White")
+	predicted := game.Position{Row: 2, Col: 3}
+	eval := NewMixedEvaluation(V7Coeff)
+
+	var searches atomic.Int32
+	var p Ponderer
+	p.start(g.Board, game.Black, predicted, func(b game.Board, player game.Piece, stop *atomic.Bool) ([]game.Position, int16) {
+		searches.Add(1)
+		return Solve(b, player, 4, eval)
+	})
+
+	// The human plays the predicted move
+	if err := g.ApplyMove(predicted); err != nil {
+		t.Fatal(err)
+	}
+	moves, score, hit := p.Result(g.Board, g.CurrentPlayer.Color)
+	if !hit {
+		t.Fatal("ponder miss on the predicted move")
+	}
+	if n := searches.Load(); n != 1 {
+		t.Errorf("%d searches, want only the pondered one", n)
+	}
+	wantMoves, wantScore := Solve(g.Board, g.CurrentPlayer.Color, 4, eval)
+	if moves[0] != wantMoves[0] || score != wantScore {
+		t.Errorf("pondered %v %d, search %v %d", moves[0], score, wantMoves[0], wantScore)
+	}
+
+	// The result is handed out once
+	if _, _, hit := p.Result(g.Board, g.CurrentPlayer.Color); hit {
+		t.Error("second ponder hit on the same job")
+	}
+}
+
+// blockingSearch returns a search running until it is stopped, reporting on stopped
+func blockingSearch(stopped chan<- struct{}) ponderSearch {
+	return func(b game.Board, player game.Piece, stop *atomic.Bool) ([]game.Position, int16) {
+		for !stop.Load() {
+			time.Sleep(time.Millisecond)
+		}
+		close(stopped)
+		return nil, 0
+	}
+}
+
+func TestPonderMissCancelsSearch(t *testing.T) {
+	g := game.NewGame("Black", "White")
+	stopped := make(chan struct{})
+	var p Ponderer
+	p.start(g.Board, game.Black, game.Position{Row: 2, Col: 3}, blockingSearch(stopped))
+
+	// The human plays another move
+	if err := g.ApplyMove(game.Position{Row: 5, Col: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, hit := p.Result(g.Board, g.CurrentPlayer.Color); hit {
+		t.Fatal("ponder hit on another move")
+	}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the search of a ponder miss kept running")
+	}
+}
+
+func TestPonderStopAndRestartCancelSearch(t *testing.T) {
+	b := game.NewGame("Black", "White").Board
+	var p Ponderer
+
+	stopped := make(chan struct{})
+	p.start(b, game.Black, game.Position{Row: 2, Col: 3}, blockingSearch(stopped))
+	p.Stop()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop did not cancel the search")
+	}
+
+	stopped = make(chan struct{})
+	p.start(b, game.Black, game.Position{Row: 2, Col: 3}, blockingSearch(stopped))
+	p.start(b, game.Black, game.Position{Row: 3, Col: 2}, blockingSearch(make(chan struct{})))
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a new pondering did not cancel the previous search")
+	}
+	p.Stop()
+}
+
+func TestPonderStopCutsSolve(t *testing.T) {
+	var stop atomic.Bool
+	stop.Store(true)
+	start := time.Now()
+	SolveWithOptions(game.NewGame("Black", "White").Board, game.Black, 20, NewMixedEvaluation(V7Coeff), SearchOptions{Stop: &stop}, nil)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("stopped search took %v", elapsed)
+	}
+}
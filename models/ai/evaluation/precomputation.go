@@ -2,15 +2,20 @@ package evaluation
 
 import (
 	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
 )
 
 func PrecomputeEvaluation(b game.Board) (pec PreEvaluationComputation) {
 	black, white := game.CountPieces(b)
 	pec.BlackPieces = int16(black)
 	pec.WhitePieces = int16(white)
+	pec.Phase = PhaseForPieceCount(pec.BlackPieces+pec.WhitePieces, DefaultPhaseBoundaries)
+	bb := utils.BoardToBits(b)
 
 	pec.BlackValidMoves = game.ValidMoves(b, game.Black)
 	pec.WhiteValidMoves = game.ValidMoves(b, game.White)
+	pec.BlackMoveMask = game.ValidMovesMask(bb, game.Black)
+	pec.WhiteMoveMask = game.ValidMovesMask(bb, game.White)
 
 	if black+white == 64 || game.IsGameFinished(b) {
 		pec.IsGameOver = true
@@ -24,6 +29,7 @@ func PrecomputeEvaluationBitBoard(b game.BitBoard) (pec PreEvaluationComputation
 	black, white := game.CountPiecesBitBoard(b)
 	pec.BlackPieces = int16(black)
 	pec.WhitePieces = int16(white)
+	pec.Phase = PhaseForPieceCount(pec.BlackPieces+pec.WhitePieces, DefaultPhaseBoundaries)
 
 	// Fast path: if board is full, game is over
 	totalPieces := black + white
@@ -35,9 +41,10 @@ func PrecomputeEvaluationBitBoard(b game.BitBoard) (pec PreEvaluationComputation
 		return
 	}
 
-	// Use optimized move generation
-	pec.BlackValidMoves = game.ValidMovesBitBoard(b, game.Black)
-	pec.WhiteValidMoves = game.ValidMovesBitBoard(b, game.White)
+	// Generate the moves of both players in one pass
+	pec.BlackMoveMask, pec.WhiteMoveMask = computeBothValidMoves(b)
+	pec.BlackValidMoves = game.MaskToPositions(pec.BlackMoveMask)
+	pec.WhiteValidMoves = game.MaskToPositions(pec.WhiteMoveMask)
 
 	// Game is over if neither player has valid moves
 	if len(pec.BlackValidMoves)+len(pec.WhiteValidMoves) == 0 {
@@ -45,3 +52,60 @@ func PrecomputeEvaluationBitBoard(b game.BitBoard) (pec PreEvaluationComputation
 	}
 	return
 }
+
+// Edge masks of the shifts moving discs east and west, dropping the discs wrapping to the other side
+const (
+	notAFile = 0xFEFEFEFEFEFEFEFE
+	notHFile = 0x7F7F7F7F7F7F7F7F
+)
+
+// computeBothValidMoves returns the move masks of both players, the same as game.ValidMovesMask,
+// sharing the empty squares and expanding both players' lines in the same pass over each direction
+func computeBothValidMoves(bb game.BitBoard) (blackMoves, whiteMoves uint64) {
+	black, white := bb.BlackPieces, bb.WhitePieces
+	empty := ^(black | white)
+
+	var b, w uint64
+	b, w = bothMovesLeft(black, white, empty, 8, 0xFFFFFFFFFFFFFFFF) // North
+	blackMoves, whiteMoves = blackMoves|b, whiteMoves|w
+	b, w = bothMovesRight(black, white, empty, 8, 0xFFFFFFFFFFFFFFFF) // South
+	blackMoves, whiteMoves = blackMoves|b, whiteMoves|w
+	b, w = bothMovesLeft(black, white, empty, 1, notAFile) // East
+	blackMoves, whiteMoves = blackMoves|b, whiteMoves|w
+	b, w = bothMovesRight(black, white, empty, 1, notHFile) // West
+	blackMoves, whiteMoves = blackMoves|b, whiteMoves|w
+	b, w = bothMovesLeft(black, white, empty, 9, notAFile) // Northeast
+	blackMoves, whiteMoves = blackMoves|b, whiteMoves|w
+	b, w = bothMovesLeft(black, white, empty, 7, notHFile) // Northwest
+	blackMoves, whiteMoves = blackMoves|b, whiteMoves|w
+	b, w = bothMovesRight(black, white, empty, 7, notAFile) // Southeast
+	blackMoves, whiteMoves = blackMoves|b, whiteMoves|w
+	b, w = bothMovesRight(black, white, empty, 9, notHFile) // Southwest
+	blackMoves, whiteMoves = blackMoves|b, whiteMoves|w
+	return
+}
+
+// bothMovesLeft expands the lines of both players in a direction shifting left by shift.
+// A line holds at most 6 opponent discs, the first step and 5 expansions cover it.
+func bothMovesLeft(black, white, empty uint64, shift uint, mask uint64) (blackMoves, whiteMoves uint64) {
+	whiteTargets, blackTargets := white&mask, black&mask
+	fb := (black << shift) & whiteTargets
+	fw := (white << shift) & blackTargets
+	for range 5 {
+		fb |= (fb << shift) & whiteTargets
+		fw |= (fw << shift) & blackTargets
+	}
+	return (fb << shift) & empty & mask, (fw << shift) & empty & mask
+}
+
+// bothMovesRight is bothMovesLeft for the directions shifting right
+func bothMovesRight(black, white, empty uint64, shift uint, mask uint64) (blackMoves, whiteMoves uint64) {
+	whiteTargets, blackTargets := white&mask, black&mask
+	fb := (black >> shift) & whiteTargets
+	fw := (white >> shift) & blackTargets
+	for range 5 {
+		fb |= (fb >> shift) & whiteTargets
+		fw |= (fw >> shift) & blackTargets
+	}
+	return (fb >> shift) & empty & mask, (fw >> shift) & empty & mask
+}
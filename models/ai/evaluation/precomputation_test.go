@@ -0,0 +1,80 @@
+package evaluation
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func FuzzComputeBothValidMoves(f *testing.F) {
+	f.Add(initialBlack, initialWhite)
+	f.Add(uint64(0), uint64(0))
+	f.Add(^uint64(0), uint64(0))
+	f.Add(uint64(0x8100000000000081), uint64(0x0042000000004200))
+	boards, _ := randomBitBoards(f, 4, 20)
+	for _, bb := range boards {
+		f.Add(bb.BlackPieces, bb.WhitePieces)
+	}
+	f.Fuzz(func(t *testing.T, black, white uint64) {
+		// A square holds one disc
+		bb := game.BitBoard{BlackPieces: black &^ white, WhitePieces: white}
+		blackMoves, whiteMoves := computeBothValidMoves(bb)
+		if want := game.ValidMovesMask(bb, game.Black); blackMoves != want {
+			t.Errorf("board %+v: black moves %016x, want %016x", bb, blackMoves, want)
+		}
+		if want := game.ValidMovesMask(bb, game.White); whiteMoves != want {
+			t.Errorf("board %+v: white moves %016x, want %016x", bb, whiteMoves, want)
+		}
+	})
+}
+
+func TestPrecomputeEvaluationBitBoardMatchesBoard(t *testing.T) {
+	boards, _ := randomBitBoards(t, 5, 200)
+	boards = append(boards,
+		game.BitBoard{WhitePieces: ^uint64(0)},
+		game.BitBoard{WhitePieces: 0x5555555555555555, BlackPieces: 0xaaaaaaaaaaaaaaaa},
+		game.BitBoard{WhitePieces: 1 << 0, BlackPieces: 1 << 63},
+	)
+	for i, bb := range boards {
+		got := PrecomputeEvaluationBitBoard(bb)
+		want := PrecomputeEvaluation(utils.BitsToBoard(bb))
+		if got.BlackPieces != want.BlackPieces || got.WhitePieces != want.WhitePieces || got.Phase != want.Phase || got.IsGameOver != want.IsGameOver {
+			t.Errorf("board %d: counts %d/%d phase %d over %v, want %d/%d phase %d over %v", i,
+				got.BlackPieces, got.WhitePieces, got.Phase, got.IsGameOver, want.BlackPieces, want.WhitePieces, want.Phase, want.IsGameOver)
+		}
+		if got.BlackMoveMask != want.BlackMoveMask || got.WhiteMoveMask != want.WhiteMoveMask {
+			t.Errorf("board %d: move masks %016x/%016x, want %016x/%016x", i, got.BlackMoveMask, got.WhiteMoveMask, want.BlackMoveMask, want.WhiteMoveMask)
+		}
+		if !sameMoveSet(got.BlackValidMoves, want.BlackValidMoves) || !sameMoveSet(got.WhiteValidMoves, want.WhiteValidMoves) {
+			t.Errorf("board %d: moves %v/%v, want %v/%v", i, got.BlackValidMoves, got.WhiteValidMoves, want.BlackValidMoves, want.WhiteValidMoves)
+		}
+	}
+}
+
+// sameMoveSet reports whether two move lists hold the same moves, in any order
+func sameMoveSet(a, b []game.Position) bool {
+	cmp := func(p, q game.Position) int { return int(p.Row)*8 + int(p.Col) - int(q.Row)*8 - int(q.Col) }
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.SortFunc(a, cmp)
+	slices.SortFunc(b, cmp)
+	return slices.Equal(a, b)
+}
+
+func TestPrecomputedPhase(t *testing.T) {
+	// Both sides of every default boundary, and the full board of the fast path
+	for _, boundary := range DefaultPhaseBoundaries {
+		for _, count := range []int{int(boundary), int(boundary) + 1, 64} {
+			discs := ^uint64(0) >> (64 - count)
+			bb := game.BitBoard{WhitePieces: discs & 0x5555555555555555, BlackPieces: discs & 0xaaaaaaaaaaaaaaaa}
+			want := goldenPhases[count-4]
+			if got := PrecomputeEvaluationBitBoard(bb).Phase; got != want {
+				t.Errorf("%d discs: bitboard phase %d, want %d", count, got, want)
+			}
+			if got := PrecomputeEvaluation(utils.BitsToBoard(bb)).Phase; got != want {
+				t.Errorf("%d discs: board phase %d, want %d", count, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package evaluation
+
+import (
+	"math/bits"
+
+	zobrist "github.com/Coloc3G/othello-engine/models/ai/cache"
+	"github.com/Coloc3G/othello-engine/models/ai/stats"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// DefaultQuiescenceDepth is a reasonable SearchOptions.QuiescenceDepth
+const DefaultQuiescenceDepth int8 = 4
+
+// Thresholds of a noisy move of MoveNoise
+const (
+	NoisyFlips          = 5 // A move flipping more discs is noisy
+	NoisyMobilitySwing  = 4 // A move changing the mobility difference by more is noisy
+	MaxQuiesceSearchPly = 8 // Plies QuiesceSearch searches at most
+)
+
+// quiesceTTDepth is the depth of the transposition table entries of quiesce, below
+// the leaves of the search so they never stand for a search of depth 0
+const quiesceTTDepth int8 = -1
+
+// cornerMask holds the four corners
+const cornerMask uint64 = 0x8100000000000081
+
+// isCornerMove reports whether a move takes a corner
+func isCornerMove(move game.Position) bool {
+	return cornerMask&(1<<(uint(move.Row)*8+uint(move.Col))) != 0
+}
+
+// QuiescenceNoise selects the moves the quiescence search plays past the leaves
+type QuiescenceNoise uint8
+
+const (
+	// CornerNoise plays the corner captures, and every reply to a corner capture
+	CornerNoise QuiescenceNoise = iota
+	// MoveNoise plays the noisy moves: a move is noisy when it takes a corner, flips more
+	// than NoisyFlips discs or changes the difference between the mobility of the players
+	// by more than NoisyMobilitySwing
+	MoveNoise
+)
+
+// moves returns the moves of player the quiescence search plays at node, afterCorner
+// telling whether the move leading to node took a corner
+func (n QuiescenceNoise) moves(node game.BitBoard, player game.Piece, pec PreEvaluationComputation, afterCorner bool) []game.Position {
+	if n == MoveNoise {
+		return noisyMoves(node, player, pec)
+	}
+	moves := pec.BlackValidMoves
+	if player == game.White {
+		moves = pec.WhiteValidMoves
+	}
+	if afterCorner {
+		return moves
+	}
+	var corners []game.Position
+	for _, move := range moves {
+		if isCornerMove(move) {
+			corners = append(corners, move)
+		}
+	}
+	return corners
+}
+
+// QuiesceSearch scores a position as long as it is noisy, so a search does not stop just
+// before a large swing. The player may stand pat on the static evaluation or play a
+// MoveNoise move, for at most MaxQuiesceSearchPly plies. The scores are stored in cache,
+// which may be nil, below the depth of every search.
+func QuiesceSearch(b game.BitBoard, player game.Piece, alpha, beta int16, eval Evaluation, cache *Cache) int16 {
+	return quiesce(b, player, MaxQuiesceSearchPly, alpha, beta, MoveNoise, false, eval, cache, nil)
+}
+
+// quiesce extends a leaf of the search with the moves noise selects, so the score of a
+// position is not read just before a large swing. The player may stand pat on the static
+// evaluation or play one of these moves. The extension stops after plies plies. The
+// MoveNoise scores are stored in cache, which may be nil, the CornerNoise scores depending
+// on the move leading to the position.
+func quiesce(node game.BitBoard, player game.Piece, plies int8, alpha, beta int16, noise QuiescenceNoise, afterCorner bool, eval Evaluation, cache *Cache, perfStats *stats.PerformanceStats) int16 {
+	if perfStats != nil {
+		perfStats.RecordOperation("quiescence", 0, "")
+	}
+	if noise != MoveNoise {
+		cache = nil
+	}
+	key := zobrist.GlobalZobrist().Hash(node, player)
+	verify := cache.verifyHash(node)
+	if entry, ok := cache.ttEntry(key, verify); ok {
+		switch {
+		case entry.Flag == 0,
+			entry.Flag == 1 && entry.Score >= beta,
+			entry.Flag == 2 && entry.Score <= alpha:
+			cache.recordHit(entry)
+			return entry.Score
+		}
+	}
+
+	pec := PrecomputeEvaluationBitBoard(node)
+	standPat := eval.PECEvaluate(node, pec)
+	if plies <= 0 || pec.IsGameOver {
+		return standPat
+	}
+	moves := noise.moves(node, player, pec, afterCorner)
+	if len(moves) == 0 {
+		return standPat
+	}
+
+	// Standing pat bounds the score, the player is not forced to play a noisy move
+	originalAlpha, originalBeta := alpha, beta
+	best := standPat
+	if player == game.White {
+		if best >= beta {
+			return best
+		}
+		alpha = max(alpha, best)
+	} else {
+		if best <= alpha {
+			return best
+		}
+		beta = min(beta, best)
+	}
+
+	opponent := game.GetOpponentColor(player)
+	for _, move := range moves {
+		child, _ := game.GetNewBitBoardAfterMove(node, move, player)
+		score := quiesce(child, opponent, plies-1, alpha, beta, noise, isCornerMove(move), eval, cache, perfStats)
+		if player == game.White {
+			best = max(best, score)
+			alpha = max(alpha, score)
+		} else {
+			best = min(best, score)
+			beta = min(beta, score)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+
+	var flag int8
+	if best <= originalAlpha {
+		flag = 2
+	} else if best >= originalBeta {
+		flag = 1
+	}
+	cache.cacheTTEntry(key, verify, TTEntry{Score: best, Depth: quiesceTTDepth, Flag: flag})
+	return best
+}
+
+// noisyMoves returns the noisy moves of player, see MoveNoise
+func noisyMoves(node game.BitBoard, player game.Piece, pec PreEvaluationComputation) []game.Position {
+	moves, own := pec.BlackValidMoves, node.BlackPieces
+	if player == game.White {
+		moves, own = pec.WhiteValidMoves, node.WhitePieces
+	}
+	before := mobilityDifference(pec.BlackMoveMask, pec.WhiteMoveMask, player)
+
+	var noisy []game.Position
+	for _, move := range moves {
+		if isCornerMove(move) {
+			noisy = append(noisy, move)
+			continue
+		}
+		child, _ := game.GetNewBitBoardAfterMove(node, move, player)
+		childOwn := child.BlackPieces
+		if player == game.White {
+			childOwn = child.WhitePieces
+		}
+		if bits.OnesCount64(childOwn)-bits.OnesCount64(own)-1 > NoisyFlips {
+			noisy = append(noisy, move)
+			continue
+		}
+		black, white := computeBothValidMoves(child)
+		if swing := mobilityDifference(black, white, player) - before; swing > NoisyMobilitySwing || swing < -NoisyMobilitySwing {
+			noisy = append(noisy, move)
+		}
+	}
+	return noisy
+}
+
+// mobilityDifference returns the moves of player minus the moves of the opponent
+func mobilityDifference(blackMoves, whiteMoves uint64, player game.Piece) int {
+	diff := bits.OnesCount64(blackMoves) - bits.OnesCount64(whiteMoves)
+	if player == game.White {
+		return -diff
+	}
+	return diff
+}
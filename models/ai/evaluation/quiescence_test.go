@@ -0,0 +1,46 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestQuiesceStandPat(t *testing.T) {
+	bb := cornerBoard(t)
+	eval := NewMixedEvaluation(V7Coeff)
+	static := eval.Evaluate(bb)
+	for _, noise := range []QuiescenceNoise{CornerNoise, MoveNoise} {
+		if score := quiesce(bb, game.White, 0, MIN_EVAL, MAX_EVAL, noise, false, eval, nil, nil); score != static {
+			t.Errorf("noise %d: %d after 0 plies, static evaluation %d", noise, score, static)
+		}
+		// White may take a1 but is never forced to
+		if score := quiesce(bb, game.White, DefaultQuiescenceDepth, MIN_EVAL, MAX_EVAL, noise, false, eval, nil, nil); score < static {
+			t.Errorf("noise %d: %d below the static evaluation %d", noise, score, static)
+		}
+	}
+}
+
+func TestCornerNoiseMoves(t *testing.T) {
+	bb := cornerBoard(t)
+	pec := PrecomputeEvaluationBitBoard(bb)
+	corners := CornerNoise.moves(bb, game.White, pec, false)
+	if len(corners) != 1 || corners[0] != (game.Position{Row: 0, Col: 0}) {
+		t.Errorf("corner moves %v, want a1", corners)
+	}
+	if replies := CornerNoise.moves(bb, game.White, pec, true); len(replies) != len(pec.WhiteValidMoves) {
+		t.Errorf("%d replies to a corner capture, want the %d moves", len(replies), len(pec.WhiteValidMoves))
+	}
+}
+
+func TestQuiesceSearchCache(t *testing.T) {
+	boards, players := randomBitBoards(t, 5, 50)
+	eval := NewMixedEvaluation(V7Coeff)
+	cache := NewCacheMB(1)
+	for i, bb := range boards {
+		want := quiesce(bb, players[i], MaxQuiesceSearchPly, MIN_EVAL, MAX_EVAL, MoveNoise, false, eval, nil, nil)
+		if got := QuiesceSearch(bb, players[i], MIN_EVAL, MAX_EVAL, eval, cache); got != want {
+			t.Errorf("board %d: QuiesceSearch %d, without a table %d", i, got, want)
+		}
+	}
+}
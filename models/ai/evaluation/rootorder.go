@@ -0,0 +1,33 @@
+package evaluation
+
+import "github.com/Coloc3G/othello-engine/models/game"
+
+// orderRootMoves returns the root moves in the order of RootMoveOrder, the moves it does
+// not list following in their order in moves. Moves of RootMoveOrder that are not valid
+// or already listed are ignored. Without RootMoveOrder, moves is returned unchanged.
+func (o *SearchOptions) orderRootMoves(moves []game.Position) []game.Position {
+	if o == nil || o.RootMoveOrder == nil {
+		return moves
+	}
+	ordered := make([]game.Position, 0, len(moves))
+	var placed uint64
+	add := func(move game.Position) {
+		bit := uint64(1) << (move.Row*8 + move.Col)
+		if placed&bit == 0 {
+			placed |= bit
+			ordered = append(ordered, move)
+		}
+	}
+	for _, move := range o.RootMoveOrder {
+		for _, valid := range moves {
+			if move == valid {
+				add(move)
+				break
+			}
+		}
+	}
+	for _, move := range moves {
+		add(move)
+	}
+	return ordered
+}
@@ -0,0 +1,76 @@
+package evaluation
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestOrderRootMoves(t *testing.T) {
+	a, b, c, d := game.Position{Row: 2, Col: 3}, game.Position{Row: 3, Col: 2}, game.Position{Row: 4, Col: 5}, game.Position{Row: 5, Col: 4}
+	invalid := game.Position{Row: 0, Col: 0}
+	moves := []game.Position{a, b, c, d}
+	tests := []struct {
+		name  string
+		order []game.Position
+		want  []game.Position
+	}{
+		{"nil keeps the generator order", nil, moves},
+		{"empty keeps the generator order", []game.Position{}, moves},
+		{"reversed", []game.Position{d, c, b, a}, []game.Position{d, c, b, a}},
+		{"missing moves appended", []game.Position{c}, []game.Position{c, a, b, d}},
+		{"invalid moves ignored", []game.Position{invalid, b, invalid}, []game.Position{b, a, c, d}},
+		{"duplicates ignored", []game.Position{d, d, a, d}, []game.Position{d, a, b, c}},
+	}
+	for _, tt := range tests {
+		opts := &SearchOptions{RootMoveOrder: tt.order}
+		if got := opts.orderRootMoves(slices.Clone(moves)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := (*SearchOptions)(nil).orderRootMoves(moves); !slices.Equal(got, moves) {
+		t.Errorf("without options: %v, want %v", got, moves)
+	}
+}
+
+func TestRootMoveOrderReversed(t *testing.T) {
+	boards, players := randomBitBoards(t, 61, 30)
+	eval := NewMixedEvaluation(V7Coeff)
+	changed := 0
+	for i, bb := range boards {
+		moves := game.ValidMovesBitBoard(bb, players[i])
+		if len(moves) < 3 {
+			continue
+		}
+		want, wantScore, wantNodes := searchNodes(bb, players[i], 4, eval, SearchOptions{DisableTT: true})
+
+		// The generator order given explicitly is the default search
+		move, score, nodes := searchNodes(bb, players[i], 4, eval, SearchOptions{DisableTT: true, RootMoveOrder: moves})
+		if move != want || score != wantScore || nodes != wantNodes {
+			t.Errorf("board %d: generator order %v %d in %d nodes, default %v %d in %d nodes", i, move, score, nodes, want, wantScore, wantNodes)
+		}
+
+		reversed := slices.Clone(moves)
+		slices.Reverse(reversed)
+		move, score, nodes = searchNodes(bb, players[i], 4, eval, SearchOptions{DisableTT: true, RootMoveOrder: reversed})
+		if score != wantScore {
+			t.Errorf("board %d: reversed order scores %d, default %d", i, score, wantScore)
+		}
+		// Moves of equal score are kept in the order searched, the first one wins
+		if move != want && !tiedMove(bb, players[i], move, wantScore, eval) {
+			t.Errorf("board %d: reversed order plays %v, default %v", i, move, want)
+		}
+		if nodes != wantNodes {
+			changed++
+		}
+	}
+	if changed == 0 {
+		t.Error("reversing the root moves never changes the pruning")
+	}
+}
+
+// tiedMove reports whether move scores score at depth 4, the best score of the position
+func tiedMove(bb game.BitBoard, player game.Piece, move game.Position, score int16, eval Evaluation) bool {
+	return rootScores(bb, player, 4, eval)[move] == score
+}
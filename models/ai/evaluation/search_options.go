@@ -0,0 +1,89 @@
+package evaluation
+
+import (
+	"sync/atomic"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// SearchOptions tunes the alpha-beta search, the zero value is the default search
+type SearchOptions struct {
+	// FutilityMargin per remaining ply: at depth <= FutilityDepth, when the static evaluation
+	// is further than FutilityMargin*depth from the window, quiet moves are not searched.
+	// 0 disables futility pruning.
+	FutilityMargin int16
+	// TTVerify sets Cache.Verify on the table the search allocates: its entries are then
+	// also checked against the second half of BitBoard.Hash128 and the collisions counted.
+	// Off by default, entries are trusted on the 64-bit key alone. A table given as Cache
+	// keeps its own setting.
+	TTVerify bool
+	// QuiescenceDepth extends the leaves with the moves QuiescenceNoise selects, corner
+	// captures and the replies to them by default, for at most this many plies.
+	// 0 disables the extension.
+	QuiescenceDepth int8
+	QuiescenceNoise QuiescenceNoise
+	// DisableTT skips every transposition table read and write, to rule out
+	// table bugs when searches disagree
+	DisableTT bool
+	// PositionalOrdering searches first the moves flipping the most discs of
+	// PositionalOrderMask, instead of the corners first order of the move generator.
+	// It visits more nodes at depth 8, see BenchmarkDepth8PositionalOrdering.
+	PositionalOrdering bool
+	// TTStats, when set, receives the transposition table statistics of the search
+	TTStats *TTStats
+	// Cache, when set, is the transposition table of the search and keeps its entries
+	// for the next searches. Each search uses a new table of HashMB megabytes otherwise,
+	// sized for the depth when 0, up to DefaultHashMB.
+	Cache  *Cache
+	HashMB int
+	// Nodes, when set, is increased by the number of positions the search visits
+	Nodes *int64
+	// MaxNodes stops the search once the node counter reaches it: the positions left are
+	// given their static evaluation and the unfinished results are not stored in the
+	// transposition table. Nodes, when set, is the counter, shared with the previous searches,
+	// a counter of this search otherwise. 0 disables the limit. See NodeLimitReached.
+	MaxNodes int64
+	// Stop, when set, cuts the search as MaxNodes does once it holds true, so another
+	// goroutine can stop a search in progress
+	Stop *atomic.Bool
+	// BookBias is added, in favor of the player to move, to the score of the root moves
+	// continuing a known opening after Transcript, the moves leading to the position, during
+	// the first BookBiasPlies plies. It only changes the choice of the move, not the returned
+	// score. 0 disables it.
+	BookBias   int16
+	Transcript string
+	// SearchExtension searches one ply deeper at the positions ShouldExtend selects, at
+	// most MaxExtensionDepth extra plies along a line, so 0 extends nothing
+	SearchExtension   bool
+	MaxExtensionDepth int8
+	// ClampScore evaluates the positions with ScoreClamp, so only finished games get the
+	// scores of a won or lost game
+	ClampScore bool
+	// Deepening, when set, records the ranking of the root moves for the iterative
+	// deepening it is shared with. Depths cut by MaxNodes are not recorded.
+	Deepening *IterativeDeepeningStats
+	// RootMoveOrder, when not nil, is the order in which the root moves are searched,
+	// instead of the order of the move generator, so the pruning of a search can be
+	// reproduced and inspected. Invalid moves are ignored, the valid moves it does not
+	// list are searched after it.
+	RootMoveOrder []game.Position
+	// FastLeaves scores the leaves with game.BitBoardFastScore instead of the evaluation of
+	// the search: the nodes of depth 1 score their children directly, without their
+	// precomputation, transposition table lookups nor recursion. Meant for depth 1-2
+	// searches, where this overhead outweighs the evaluation.
+	FastLeaves bool
+}
+
+// DefaultSearchOptions returns the recommended search options
+func DefaultSearchOptions() SearchOptions {
+	return SearchOptions{FutilityMargin: DefaultFutilityMargin}
+}
+
+// NodeLimitReached reports whether the node counter reached MaxNodes, which needs Nodes to
+// be set, or Stop holds true, the result of the search being then incomplete
+func (o *SearchOptions) NodeLimitReached() bool {
+	if o == nil {
+		return false
+	}
+	return (o.MaxNodes > 0 && o.Nodes != nil && *o.Nodes >= o.MaxNodes) || (o.Stop != nil && o.Stop.Load())
+}
@@ -3,36 +3,21 @@ package evaluation
 import (
 	"time"
 
+	zobrist "github.com/Coloc3G/othello-engine/models/ai/cache"
 	"github.com/Coloc3G/othello-engine/models/ai/stats"
 	"github.com/Coloc3G/othello-engine/models/game"
 	"github.com/Coloc3G/othello-engine/models/utils"
 )
 
-type TTEntry struct {
-	Score int16
-	Depth int8
-	Moves []game.Position
-	Flag  int8 // 0: exact, 1: lower bound, 2: upper bound
+// AlphaBetaSearcher is the Searcher running Solve at a fixed depth
+type AlphaBetaSearcher struct {
+	Depth   int8
+	Eval    Evaluation
+	Options SearchOptions
 }
 
-type Cache struct {
-	TTCache    map[string]TTEntry
-	MaxEntries int
-}
-
-// NewCache creates a new cache with max entries limit
-func NewCache() *Cache {
-	return &Cache{
-		TTCache:    make(map[string]TTEntry),
-		MaxEntries: 20000000,
-	}
-}
-
-func (c *Cache) cacheTTEntry(boardHash string, entry TTEntry) {
-	if len(c.TTCache) >= c.MaxEntries {
-		return
-	}
-	c.TTCache[boardHash] = entry
+func (s *AlphaBetaSearcher) Search(b game.Board, player game.Piece) ([]game.Position, int16) {
+	return SolveWithOptions(b, player, s.Depth, s.Eval, s.Options, nil)
 }
 
 func Solve(b game.Board, player game.Piece, depth int8, eval Evaluation) ([]game.Position, int16) {
@@ -41,20 +26,67 @@ func Solve(b game.Board, player game.Piece, depth int8, eval Evaluation) ([]game
 
 // Solve finds the best move for a player using minimax with alpha-beta pruning
 func SolveWithStats(b game.Board, player game.Piece, depth int8, eval Evaluation, perfStats *stats.PerformanceStats) ([]game.Position, int16) {
-	bb := utils.BoardToBits(b)
-	validMoves := game.ValidMovesBitBoard(bb, player)
+	return solve(utils.BoardToBits(b), player, depth, eval, perfStats, nil, nil)
+}
+
+// SolveBitBoard is Solve on a bitboard, for game loops kept on bitboards
+func SolveBitBoard(bb game.BitBoard, player game.Piece, depth int8, eval Evaluation) ([]game.Position, int16) {
+	return solve(bb, player, depth, eval, nil, nil, nil)
+}
+
+// SolveWithOptions is Solve with the given search options
+func SolveWithOptions(b game.Board, player game.Piece, depth int8, eval Evaluation, opts SearchOptions, perfStats *stats.PerformanceStats) ([]game.Position, int16) {
+	return solve(utils.BoardToBits(b), player, depth, eval, perfStats, nil, &opts)
+}
+
+// SolvePureMinimax is Solve without the transposition table, a reference for the other searches
+func SolvePureMinimax(b game.Board, player game.Piece, depth int8, eval Evaluation) ([]game.Position, int16) {
+	return SolveWithOptions(b, player, depth, eval, SearchOptions{DisableTT: true}, nil)
+}
+
+// SolveWithTrace is Solve recording the explored tree within the limits of opts, at most
+// MaxTraceDepth plies below the root. The tree is rooted at TraceTree.Root, a TraceNode
+// holding the position, the window before and after the search and the children of each
+// node, so the TraceNode tree of a search is returned along with its best line rather
+// than by a separate function.
+func SolveWithTrace(b game.Board, player game.Piece, depth int8, eval Evaluation, opts TraceOptions) ([]game.Position, int16, *TraceTree) {
+	tree := newTraceTree(opts)
+	moves, score := solve(utils.BoardToBits(b), player, depth, eval, nil, tree.Root, nil)
+	return moves, score, tree
+}
+
+// solve is the root of the alpha-beta search, trace is nil when the tree is not recorded
+// and opts nil for the default search
+func solve(bb game.BitBoard, player game.Piece, depth int8, eval Evaluation, perfStats *stats.PerformanceStats, trace *TraceNode, opts *SearchOptions) ([]game.Position, int16) {
+	validMoves := opts.orderRootMoves(game.ValidMovesBitBoard(bb, player))
 	if len(validMoves) == 0 {
 		return []game.Position{{Row: -1, Col: -1}}, -1
 	}
+	if opts != nil && opts.ClampScore {
+		eval = ScoreClamp{Inner: eval}
+	}
 
 	// If only one move is available, return it immediately
 	if len(validMoves) == 1 {
 		bestMove := validMoves[0]
 		newBoard, _ := game.GetNewBitBoardAfterMove(bb, bestMove, player)
 		bestScore := eval.Evaluate(newBoard)
+		alpha, beta := MIN_EVAL-65, MAX_EVAL+65
+		child := trace.child(bestMove)
+		child.enter(newBoard, game.GetOpponentColor(player), depth-1, alpha, beta)
+		child.leave(bestScore, alpha, beta)
+		trace.enter(bb, player, depth, alpha, beta)
+		trace.leave(bestScore, alpha, beta)
 		return []game.Position{bestMove}, bestScore
 	}
 
+	// The node limit needs a counter
+	if opts != nil && opts.MaxNodes > 0 && opts.Nodes == nil {
+		local := *opts
+		local.Nodes = new(int64)
+		opts = &local
+	}
+
 	var bestMoves []game.Position
 	bestScore := MIN_EVAL - 65
 	if player == game.Black {
@@ -63,16 +95,43 @@ func SolveWithStats(b game.Board, player game.Piece, depth int8, eval Evaluation
 	alpha := MIN_EVAL - 65
 	beta := MAX_EVAL + 65
 	opponent := game.GetOtherPlayer(player).Color
-	cache := NewCache() // Cache optimisé avec priorité PEC
+	var cache *Cache
+	if opts != nil && opts.Cache != nil {
+		cache = opts.Cache
+		cache.newSearch()
+	} else if opts == nil || !opts.DisableTT {
+		hashMB := searchHashMB(depth)
+		if opts != nil && opts.HashMB > 0 {
+			hashMB = opts.HashMB
+		}
+		cache = NewCacheMB(hashMB)
+		cache.Verify = opts != nil && opts.TTVerify
+	}
+	trace.enter(bb, player, depth, alpha, beta)
+
+	// The bonus of the book moves is removed from the returned score
+	bonuses := bookBonuses(opts, player)
+	var bestBonus int16
+	var scores []int16
+	if opts != nil && opts.Deepening != nil {
+		scores = make([]int16, len(validMoves))
+	}
 
-	for _, move := range validMoves {
+	for i, move := range validMoves {
 		newBoard, _ := game.GetNewBitBoardAfterMove(bb, move, player)
-		childScore, childMoves := MMAB(newBoard, opponent, depth-1, alpha, beta, eval, cache, perfStats)
+		// The window is shifted by the bonus, so a bound of the child stays a bound once biased
+		bonus := bonuses[move]
+		childScore, childMoves := mmab(newBoard, opponent, depth-1, alpha-bonus, beta-bonus, eval, cache, perfStats, trace.child(move), opts, 0)
+		childScore += bonus
+		if scores != nil {
+			scores[i] = childScore
+		}
 
 		if player == game.White {
 			// Maximizing white player
 			if childScore > bestScore {
 				bestScore = childScore
+				bestBonus = bonus
 				bestMoves = []game.Position{move}
 				if childMoves != nil {
 					bestMoves = append(bestMoves, childMoves...)
@@ -86,6 +145,7 @@ func SolveWithStats(b game.Board, player game.Piece, depth int8, eval Evaluation
 			// Minimizing black player
 			if childScore < bestScore {
 				bestScore = childScore
+				bestBonus = bonus
 				bestMoves = []game.Position{move}
 				if childMoves != nil {
 					bestMoves = append(bestMoves, childMoves...)
@@ -97,38 +157,84 @@ func SolveWithStats(b game.Board, player game.Piece, depth int8, eval Evaluation
 			}
 		}
 
+		if opts.NodeLimitReached() {
+			break
+		}
 	}
 
-	cache.TTCache = make(map[string]TTEntry, 0)
+	bestScore -= bestBonus
+	if scores != nil && !opts.NodeLimitReached() {
+		opts.Deepening.record(validMoves, scores, player, perfStats)
+	}
 
-	return bestMoves, bestScore
+	if opts != nil && opts.TTStats != nil {
+		*opts.TTStats = cache.DumpStats()
+	}
+	trace.leave(bestScore, alpha, beta)
+
+	return cache.extendPV(bb, player, bestMoves, depth), bestScore
 }
 
 // MMAB performs minimax search with alpha-beta pruning
 func MMAB(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16, eval Evaluation, cache *Cache, perfStats *stats.PerformanceStats) (score int16, path []game.Position) {
+	return mmab(node, player, depth, alpha, beta, eval, cache, perfStats, nil, nil, 0)
+}
+
+// mmab is MMAB recording the explored tree in trace, which is nil when the tree is not recorded,
+// and applying the search options, nil for the default search. extended is the number of
+// extensions of the line leading to node.
+func mmab(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16, eval Evaluation, cache *Cache, perfStats *stats.PerformanceStats, trace *TraceNode, opts *SearchOptions, extended int8) (score int16, path []game.Position) {
+	trace.enter(node, player, depth, alpha, beta)
+	if opts.NodeLimitReached() {
+		score = eval.Evaluate(node)
+		trace.leave(score, alpha, beta)
+		return score, nil
+	}
+	if opts != nil && opts.Nodes != nil {
+		*opts.Nodes++
+	}
+	if opts.extend(node, player, extended) {
+		if perfStats != nil {
+			perfStats.RecordOperation("extension", 0, "")
+		}
+		depth++
+		extended++
+	}
+
+	// A nil cache is always empty and stores nothing
+	if opts != nil && opts.DisableTT {
+		cache = nil
+	}
 
 	hashStart := time.Now()
-	boardHash := utils.HashBitBoard(node)
+	key := zobrist.GlobalZobrist().Hash(node, player)
+	verify := cache.verifyHash(node)
+	var boardHash string
 	if perfStats != nil {
 		pecTime := time.Since(hashStart)
+		boardHash = utils.HashBitBoard(node)
 		perfStats.RecordOperation("hashBoard", pecTime, boardHash)
 	}
 
 	// Check transposition table first
-	if ttEntry, exists := cache.TTCache[boardHash]; exists && ttEntry.Depth >= depth {
+	if ttEntry, exists := cache.ttEntry(key, verify); exists && ttEntry.Depth >= depth {
 		ttHitStart := time.Now()
+		trace.ttHit()
+		cache.recordHit(ttEntry)
 
 		switch ttEntry.Flag {
 		case 0: // Exact value
 			if perfStats != nil {
 				perfStats.RecordOperation("tt_exact_hit", time.Since(ttHitStart), boardHash)
 			}
+			trace.leave(ttEntry.Score, alpha, beta)
 			return ttEntry.Score, ttEntry.Moves
 		case 1: // Lower bound
 			if ttEntry.Score >= beta {
 				if perfStats != nil {
 					perfStats.RecordOperation("tt_lower_cutoff", time.Since(ttHitStart), boardHash)
 				}
+				trace.leave(ttEntry.Score, alpha, beta)
 				return ttEntry.Score, ttEntry.Moves
 			}
 			if ttEntry.Score > alpha {
@@ -139,6 +245,7 @@ func MMAB(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16,
 				if perfStats != nil {
 					perfStats.RecordOperation("tt_upper_cutoff", time.Since(ttHitStart), boardHash)
 				}
+				trace.leave(ttEntry.Score, alpha, beta)
 				return ttEntry.Score, ttEntry.Moves
 			}
 			if ttEntry.Score < beta {
@@ -154,10 +261,21 @@ func MMAB(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16,
 	originalAlpha := alpha
 	originalBeta := beta
 
+	if depth <= 1 && opts != nil && opts.FastLeaves {
+		score, path = fastShallow(node, player, depth, alpha, beta, trace, opts)
+		trace.leave(score, alpha, beta)
+		return score, path
+	}
+
 	// Base case: leaf node or terminal position
 	if depth == 0 {
+		if opts != nil && opts.QuiescenceDepth > 0 {
+			score = quiesce(node, player, opts.QuiescenceDepth, alpha, beta, opts.QuiescenceNoise, false, eval, cache, perfStats)
+			trace.leave(score, alpha, beta)
+			return score, nil
+		}
+
 		// Evaluate position
-		var score int16
 		pecTimeStart := time.Now()
 		pec := PrecomputeEvaluationBitBoard(node)
 		if perfStats != nil {
@@ -168,6 +286,7 @@ func MMAB(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16,
 		if perfStats != nil {
 			perfStats.RecordOperation("leaf_eval", time.Since(evalStartTime), boardHash)
 		}
+		trace.leave(score, alpha, beta)
 
 		return score, nil
 	}
@@ -178,15 +297,30 @@ func MMAB(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16,
 
 	// If no valid moves, pass turn
 	if len(moves) == 0 {
-		return MMAB(node, opponent, depth-1, alpha, beta, eval, cache, perfStats)
+		score, path = mmab(node, opponent, depth-1, alpha, beta, eval, cache, perfStats, trace.child(game.Position{Row: -1, Col: -1}), opts, extended)
+		trace.leave(score, alpha, beta)
+		return score, path
+	}
+	if opts != nil && opts.PositionalOrdering {
+		orderMovesPositionally(node, player, moves)
 	}
 	bestMoves := []game.Position{moves[0]}
 	bestScore := MIN_EVAL - 65
 	if player == game.Black {
 		bestScore = MAX_EVAL + 65
 	}
+	futile := isFutile(node, player, depth, alpha, beta, eval, opts)
+	searched := false
 
-	for _, move := range moves {
+	for i, move := range moves {
+		if futile && isQuietMove(move) {
+			if perfStats != nil {
+				perfStats.RecordOperation("futility", 0, "")
+			}
+			trace.prune(node, player, moves[i:i+1])
+			continue
+		}
+		searched = true
 		algebraicMove := utils.PositionToAlgebraic(move)
 		moveStart := time.Now()
 		newNode, _ := game.GetNewBitBoardAfterMove(node, move, player)
@@ -194,7 +328,7 @@ func MMAB(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16,
 			perfStats.RecordOperation("move", time.Since(moveStart), algebraicMove+"-"+boardHash)
 		}
 		// Recursive evaluation
-		score, childMoves := MMAB(newNode, opponent, depth-1, alpha, beta, eval, cache, perfStats)
+		score, childMoves := mmab(newNode, opponent, depth-1, alpha, beta, eval, cache, perfStats, trace.child(move), opts, extended)
 
 		if player == game.White {
 			if score > bestScore {
@@ -215,6 +349,7 @@ func MMAB(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16,
 				if perfStats != nil {
 					perfStats.RecordOperation("prune", 0, "")
 				}
+				trace.prune(node, player, moves[i+1:])
 				break
 			}
 		} else {
@@ -236,10 +371,19 @@ func MMAB(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16,
 				if perfStats != nil {
 					perfStats.RecordOperation("prune", 0, "")
 				}
+				trace.prune(node, player, moves[i+1:])
 				break
 			}
 		}
 
+		if opts.NodeLimitReached() {
+			break
+		}
+	}
+
+	// Every move was futile, the node fails low for white or high for black
+	if !searched {
+		bestScore = eval.PECEvaluate(node, PrecomputeEvaluationBitBoard(node))
 	}
 
 	// Store result in transposition table
@@ -252,12 +396,16 @@ func MMAB(node game.BitBoard, player game.Piece, depth int8, alpha, beta int16,
 		flag = 0 // Exact value
 	}
 
-	cache.cacheTTEntry(boardHash, TTEntry{
-		Score: bestScore,
-		Depth: depth,
-		Moves: bestMoves[:1],
-		Flag:  flag,
-	})
+	// Past the node limit, the children may not have been searched
+	if !opts.NodeLimitReached() {
+		cache.cacheTTEntry(key, verify, TTEntry{
+			Score: bestScore,
+			Depth: depth,
+			Moves: bestMoves[:1],
+			Flag:  flag,
+		})
+	}
+	trace.leave(bestScore, alpha, beta)
 
 	return bestScore, bestMoves
 
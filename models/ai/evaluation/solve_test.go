@@ -0,0 +1,25 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestSolveMatchesPureMinimax(t *testing.T) {
+	boards, players := randomBitBoards(t, 9, 30)
+	boards = append(boards, utils.BoardToBits(game.NewGame("Black", "White").Board))
+	players = append(players, game.Black)
+	eval := NewMixedEvaluation(V7Coeff)
+	for depth := int8(1); depth <= 5; depth++ {
+		for i, bb := range boards {
+			b := utils.BitsToBoard(bb)
+			wantMoves, wantScore := SolvePureMinimax(b, players[i], depth, eval)
+			moves, score := Solve(b, players[i], depth, eval)
+			if score != wantScore || moves[0] != wantMoves[0] {
+				t.Errorf("depth %d, board %d: search %v %d, without the table %v %d", depth, i, moves[0], score, wantMoves[0], wantScore)
+			}
+		}
+	}
+}
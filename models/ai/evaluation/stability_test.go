@@ -0,0 +1,28 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestStabilityEvaluation(t *testing.T) {
+	// The weights of an edge: 4 -3 2 2 2 2 -3 4
+	const firstRow, lastRow, firstColumn = uint64(0xff), uint64(0xff) << 56, uint64(0x0101010101010101)
+	tests := []struct {
+		name         string
+		white, black uint64
+		want         int16
+	}{
+		{"initial position", initialWhite, initialBlack, 0},
+		{"full White edge", firstRow | initialWhite, initialBlack, 10},
+		{"full Black edge", initialWhite, lastRow | initialBlack, -10},
+		{"full edges of both", firstColumn | initialWhite, firstRow&^1 | initialBlack, 10 - 6},
+	}
+	for _, tt := range tests {
+		bb := game.BitBoard{WhitePieces: tt.white, BlackPieces: tt.black}
+		if got := evaluateBoth(t, NewStabilityEvaluation(), bb); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package evaluation
+
+import (
+	"math/bits"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// TempoEvaluation is an evaluation function that counts the useful moves of each player,
+// a move being useful when the player still has a larger piece advantage than before it
+// after the opponent's best reply
+type TempoEvaluation struct {
+}
+
+func NewTempoEvaluation() *TempoEvaluation {
+	return &TempoEvaluation{}
+}
+
+func (e *TempoEvaluation) Evaluate(b game.BitBoard) int16 {
+	pec := PrecomputeEvaluationBitBoard(b)
+	return e.PECEvaluate(b, pec)
+}
+
+func (e *TempoEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputation) int16 {
+	return usefulMoves(b, pec.WhiteValidMoves, game.White) - usefulMoves(b, pec.BlackValidMoves, game.Black)
+}
+
+// usefulMoves counts the moves of player that still gain pieces after the opponent's best reply
+func usefulMoves(b game.BitBoard, moves []game.Position, player game.Piece) int16 {
+	opponent := game.GetOpponentColor(player)
+	before := pieceAdvantage(b, player)
+
+	var count int16
+	for _, move := range moves {
+		next, ok := game.ApplyMoveToBitBoard(b, player, move)
+		if !ok {
+			continue
+		}
+
+		// The opponent answers with the reply that hurts player the most, a pass keeps next
+		worst := pieceAdvantage(next, player)
+		for _, reply := range game.ValidMovesBitBoard(next, opponent) {
+			after, _ := game.ApplyMoveToBitBoard(next, opponent, reply)
+			worst = min(worst, pieceAdvantage(after, player))
+		}
+
+		if worst > before {
+			count++
+		}
+	}
+	return count
+}
+
+// pieceAdvantage returns the number of pieces of player minus the opponent's
+func pieceAdvantage(b game.BitBoard, player game.Piece) int {
+	diff := bits.OnesCount64(b.WhitePieces) - bits.OnesCount64(b.BlackPieces)
+	if player == game.Black {
+		return -diff
+	}
+	return diff
+}
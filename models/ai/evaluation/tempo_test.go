@@ -0,0 +1,55 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestTempoEvaluation(t *testing.T) {
+	const a1, b1, c1 = uint64(1) << 0, uint64(1) << 1, uint64(1) << 2
+	tests := []struct {
+		name         string
+		white, black uint64
+		want         int16
+	}{
+		// Every opening move gains 3 discs, every reply flips one back to 3-3
+		{"initial position, no move keeps a gain", initialWhite, initialBlack, 0},
+		{"White takes c1, Black cannot reply", a1, b1, 1},
+		{"Black takes c1, White cannot reply", b1, a1, -1},
+		// White d1 flips c1 but Black e1 then flips the whole rank
+		{"White d1 is answered by Black e1", b1, a1 | c1, 0},
+		{"nobody can move", ^uint64(0), 0, 0},
+	}
+	for _, tt := range tests {
+		bb := game.BitBoard{WhitePieces: tt.white, BlackPieces: tt.black}
+		if got := evaluateBoth(t, NewTempoEvaluation(), bb); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMixedEvaluationTempoCoeff(t *testing.T) {
+	// White has a move keeping its gain, Black none
+	bb := game.BitBoard{WhitePieces: 1 << 0, BlackPieces: 1 << 1}
+	tempo := NewTempoEvaluation().Evaluate(bb)
+	if tempo <= 0 {
+		t.Fatalf("tempo %d, want White ahead", tempo)
+	}
+
+	coeffs := V7Coeff
+	coeffs.TempoCoeffs = nil
+	without := NewMixedEvaluation(coeffs)
+	if got := without.Components(bb)["tempo"]; got != 0 {
+		t.Errorf("tempo component %d without TempoCoeffs", got)
+	}
+
+	coeffs.TempoCoeffs = []int16{7, 7, 7, 7, 7, 7}
+	with := NewMixedEvaluation(coeffs)
+	if got := with.Components(bb)["tempo"]; got != 7*tempo {
+		t.Errorf("tempo component %d, want 7 * %d", got, tempo)
+	}
+	if got, want := with.Evaluate(bb)-without.Evaluate(bb), 7*tempo; got != want {
+		t.Errorf("TempoCoeffs change the score by %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+package evaluation
+
+import "github.com/Coloc3G/othello-engine/models/game"
+
+const (
+	// ThreatThreshold is the number of opponent replies below which a move is forcing
+	ThreatThreshold = 3
+	// ThreatBonus is the score given per reply removed below ThreatThreshold
+	ThreatBonus = 1
+)
+
+// ThreatEvaluation is an evaluation function that rewards forcing moves,
+// i.e. moves leaving the opponent with very few replies
+type ThreatEvaluation struct {
+}
+
+func NewThreatEvaluation() *ThreatEvaluation {
+	return &ThreatEvaluation{}
+}
+
+func (e *ThreatEvaluation) Evaluate(b game.BitBoard) int16 {
+	pec := PrecomputeEvaluationBitBoard(b)
+	return e.PECEvaluate(b, pec)
+}
+
+func (e *ThreatEvaluation) PECEvaluate(b game.BitBoard, pec PreEvaluationComputation) int16 {
+	return forcingScore(b, pec.WhiteValidMoves, game.White) - forcingScore(b, pec.BlackValidMoves, game.Black)
+}
+
+// forcingScore sums the bonus of every move of player that restricts the opponent below ThreatThreshold replies
+func forcingScore(b game.BitBoard, moves []game.Position, player game.Piece) int16 {
+	opponent := game.GetOpponentColor(player)
+	var score int16
+	for _, move := range moves {
+		next, ok := game.ApplyMoveToBitBoard(b, player, move)
+		if !ok {
+			continue
+		}
+		if replies := len(game.ValidMovesBitBoard(next, opponent)); replies < ThreatThreshold {
+			score += int16(ThreatThreshold-replies) * ThreatBonus
+		}
+	}
+	return score
+}
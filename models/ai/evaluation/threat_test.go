@@ -0,0 +1,51 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestThreatEvaluation(t *testing.T) {
+	const a1, b1, c1 = uint64(1) << 0, uint64(1) << 1, uint64(1) << 2
+	tests := []struct {
+		name         string
+		white, black uint64
+		want         int16
+	}{
+		// Every opening move leaves the opponent 3 replies, none is forcing
+		{"initial position", initialWhite, initialBlack, 0},
+		{"White c1 leaves Black no reply", a1, b1, ThreatThreshold * ThreatBonus},
+		{"Black c1 leaves White no reply", b1, a1, -ThreatThreshold * ThreatBonus},
+		// White d1 leaves Black a single reply, e1
+		{"White d1 leaves Black one reply", b1, a1 | c1, (ThreatThreshold - 1) * ThreatBonus},
+		{"nobody can move", ^uint64(0), 0, 0},
+	}
+	for _, tt := range tests {
+		bb := game.BitBoard{WhitePieces: tt.white, BlackPieces: tt.black}
+		if got := evaluateBoth(t, NewThreatEvaluation(), bb); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMixedEvaluationThreatCoeff(t *testing.T) {
+	bb := game.BitBoard{WhitePieces: 1 << 0, BlackPieces: 1 << 1}
+	threat := NewThreatEvaluation().Evaluate(bb)
+
+	coeffs := V7Coeff
+	coeffs.ThreatCoeffs = nil
+	without := NewMixedEvaluation(coeffs)
+	if got := without.Components(bb)["threat"]; got != 0 {
+		t.Errorf("threat component %d without ThreatCoeffs", got)
+	}
+
+	coeffs.ThreatCoeffs = []int16{5, 5, 5, 5, 5, 5}
+	with := NewMixedEvaluation(coeffs)
+	if got := with.Components(bb)["threat"]; got != 5*threat {
+		t.Errorf("threat component %d, want 5 * %d", got, threat)
+	}
+	if got, want := with.Evaluate(bb)-without.Evaluate(bb), 5*threat; got != want {
+		t.Errorf("ThreatCoeffs change the score by %d, want %d", got, want)
+	}
+}
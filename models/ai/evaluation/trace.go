@@ -0,0 +1,177 @@
+package evaluation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// MaxTraceDepth is the most plies below the root SolveWithTrace records, the tree
+// growing exponentially with the depth. A deeper search is traced up to this depth.
+const MaxTraceDepth = 5
+
+// TraceOptions limits the part of the search tree recorded by SolveWithTrace
+type TraceOptions struct {
+	MaxDepth int // Plies recorded below the root, MaxTraceDepth when 0 or deeper
+	MaxNodes int // Nodes recorded, 0 for no limit
+}
+
+// TraceNode is a node of the explored search tree
+type TraceNode struct {
+	Move       string        `json:"move,omitempty"` // Move leading to the node, "pass" for a pass
+	Board      game.BitBoard `json:"-"`              // Position of the node, written as a board string
+	Player     game.Piece    `json:"-"`              // Player to move, written as "black" or "white"
+	Depth      int8          `json:"depth"`          // Plies left to search
+	Alpha      int16         `json:"alpha"`          // Window at entry
+	Beta       int16         `json:"beta"`
+	AlphaAfter int16         `json:"alpha_after"` // Window once the node is searched
+	BetaAfter  int16         `json:"beta_after"`
+	Score      int16         `json:"score"`            // Score returned by the search
+	TTHit      bool          `json:"tt_hit,omitempty"` // The transposition table had an entry deep enough
+	Pruned     bool          `json:"pruned,omitempty"` // The move was cut off and never searched
+	Children   []*TraceNode  `json:"children,omitempty"`
+
+	tree *TraceTree
+	ply  int // Plies below the root
+}
+
+// MarshalJSON writes the node with its board as a utils.BoardToString string and its
+// player by name, JSON numbers not holding 64-bit masks exactly in most viewers
+func (n *TraceNode) MarshalJSON() ([]byte, error) {
+	type node TraceNode // Without the method, to avoid the recursion
+	player := "white"
+	if n.Player == game.Black {
+		player = "black"
+	}
+	return json.Marshal(struct {
+		Board  string `json:"board"`
+		Player string `json:"player"`
+		*node
+	}{utils.BoardToString(utils.BitsToBoard(n.Board)), player, (*node)(n)})
+}
+
+// TraceTree is the search tree recorded by SolveWithTrace
+type TraceTree struct {
+	Root    *TraceNode   `json:"root"`
+	Nodes   int          `json:"nodes"`
+	Options TraceOptions `json:"-"`
+}
+
+// newTraceTree creates a tree with its root node
+func newTraceTree(opts TraceOptions) *TraceTree {
+	if opts.MaxDepth <= 0 || opts.MaxDepth > MaxTraceDepth {
+		opts.MaxDepth = MaxTraceDepth
+	}
+	t := &TraceTree{Options: opts}
+	t.Root = &TraceNode{tree: t}
+	t.Nodes = 1
+	return t
+}
+
+// child records the node reached by a move, or returns nil when the node is
+// not traced or the limits are reached so the subtree is not recorded
+func (n *TraceNode) child(move game.Position) *TraceNode {
+	if n == nil {
+		return nil
+	}
+	t := n.tree
+	if (t.Options.MaxDepth > 0 && n.ply >= t.Options.MaxDepth) ||
+		(t.Options.MaxNodes > 0 && t.Nodes >= t.Options.MaxNodes) {
+		return nil
+	}
+	name := "pass"
+	if move.Row >= 0 {
+		name = utils.PositionToAlgebraic(move)
+	}
+	c := &TraceNode{Move: name, tree: t, ply: n.ply + 1}
+	n.Children = append(n.Children, c)
+	t.Nodes++
+	return c
+}
+
+// enter records the position and the search window at entry
+func (n *TraceNode) enter(board game.BitBoard, player game.Piece, depth int8, alpha, beta int16) {
+	if n != nil {
+		n.Board, n.Player, n.Depth = board, player, depth
+		n.Alpha, n.Beta = alpha, beta
+	}
+}
+
+// leave records the returned score and the final window
+func (n *TraceNode) leave(score, alpha, beta int16) {
+	if n != nil {
+		n.Score = score
+		n.AlphaAfter, n.BetaAfter = alpha, beta
+	}
+}
+
+// ttHit marks a transposition table hit
+func (n *TraceNode) ttHit() {
+	if n != nil {
+		n.TTHit = true
+	}
+}
+
+// prune records the moves of player skipped by a cutoff in board
+func (n *TraceNode) prune(board game.BitBoard, player game.Piece, moves []game.Position) {
+	if n == nil {
+		return
+	}
+	for _, move := range moves {
+		c := n.child(move)
+		if c == nil {
+			return
+		}
+		c.Pruned = true
+		c.Board, _ = game.GetNewBitBoardAfterMove(board, move, player)
+		c.Player = game.GetOpponentColor(player)
+	}
+}
+
+// WriteJSON writes the tree as indented JSON
+func (t *TraceTree) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+// WriteDOT writes the tree in the Graphviz DOT format.
+// Transposition table hits are drawn as boxes and pruned moves dashed.
+func (t *TraceTree) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph search {")
+	fmt.Fprintln(bw, "  node [fontname=\"monospace\"];")
+	id := 0
+	var walk func(n *TraceNode) int
+	walk = func(n *TraceNode) int {
+		nodeID := id
+		id++
+		label := n.Move
+		if label == "" {
+			label = "root"
+		}
+		attrs := ""
+		if n.Pruned {
+			attrs = ", style=dashed, color=gray"
+			fmt.Fprintf(bw, "  n%d [label=%q%s];\n", nodeID, label, attrs)
+			return nodeID
+		}
+		if n.TTHit {
+			attrs = ", shape=box"
+		}
+		fmt.Fprintf(bw, "  n%d [label=%q%s];\n", nodeID, fmt.Sprintf("%s\n%d [%d,%d]", label, n.Score, n.Alpha, n.Beta), attrs)
+		for _, c := range n.Children {
+			fmt.Fprintf(bw, "  n%d -> n%d;\n", nodeID, walk(c))
+		}
+		return nodeID
+	}
+	if t.Root != nil {
+		walk(t.Root)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
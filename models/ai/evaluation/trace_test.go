@@ -0,0 +1,141 @@
+package evaluation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// treeDepth returns the most plies below n in a traced tree
+func treeDepth(n *TraceNode) int {
+	depth := 0
+	for _, c := range n.Children {
+		depth = max(depth, treeDepth(c)+1)
+	}
+	return depth
+}
+
+func TestTraceDepthLimit(t *testing.T) {
+	g := game.NewGame("Black", "White")
+	eval := NewMixedEvaluation(V7Coeff)
+	for _, opts := range []TraceOptions{{}, {MaxDepth: MaxTraceDepth + 3}} {
+		_, _, tree := SolveWithTrace(g.Board, game.Black, MaxTraceDepth+2, eval, opts)
+		if depth := treeDepth(tree.Root); depth != MaxTraceDepth {
+			t.Errorf("options %+v: tree of %d plies, want %d", opts, depth, MaxTraceDepth)
+		}
+	}
+
+	_, _, tree := SolveWithTrace(g.Board, game.Black, 4, eval, TraceOptions{MaxDepth: 2})
+	if depth := treeDepth(tree.Root); depth != 2 {
+		t.Errorf("tree of %d plies, want the 2 of MaxDepth", depth)
+	}
+}
+
+// checkTraceNode checks the structure of a traced subtree: a searched node records one
+// child per move, searched or pruned, and pruned nodes have no children
+func checkTraceNode(t *testing.T, n *TraceNode, path string) (pruned int) {
+	t.Helper()
+	if n.Pruned {
+		if len(n.Children) > 0 {
+			t.Errorf("%s: pruned node has %d children", path, len(n.Children))
+		}
+		return 1
+	}
+	if len(n.Children) > 0 {
+		moves := len(game.ValidMovesBitBoard(n.Board, n.Player))
+		if moves == 0 {
+			moves = 1 // Pass
+		}
+		if len(n.Children) != moves {
+			t.Errorf("%s: %d children for %d moves", path, len(n.Children), moves)
+		}
+	}
+	for _, c := range n.Children {
+		pruned += checkTraceNode(t, c, path+" "+c.Move)
+	}
+	return pruned
+}
+
+func TestTraceTreeStructure(t *testing.T) {
+	boards, players := randomBitBoards(t, 4, 10)
+	eval := NewMixedEvaluation(V7Coeff)
+	pruned := 0
+	for i, bb := range boards {
+		b := utils.BitsToBoard(bb)
+		moves, score, tree := SolveWithTrace(b, players[i], 4, eval, TraceOptions{})
+		wantMoves, wantScore := Solve(b, players[i], 4, eval)
+		if score != wantScore || moves[0] != wantMoves[0] {
+			t.Errorf("board %d: traced search %v %d, search %v %d", i, moves[0], score, wantMoves[0], wantScore)
+		}
+
+		root := tree.Root
+		legal := game.ValidMovesBitBoard(bb, players[i])
+		if len(root.Children) != len(legal) {
+			t.Errorf("board %d: root has %d children for %d moves", i, len(root.Children), len(legal))
+		}
+		if root.Score != score {
+			t.Errorf("board %d: root score %d, search score %d", i, root.Score, score)
+		}
+		for _, c := range root.Children {
+			if c.Pruned {
+				t.Errorf("board %d: root move %s pruned", i, c.Move)
+			}
+		}
+		pruned += checkTraceNode(t, root, "root")
+	}
+	if pruned == 0 {
+		t.Error("no pruned move marked in the traced searches")
+	}
+}
+
+func TestTraceExport(t *testing.T) {
+	g := game.NewGame("Black", "White")
+	_, _, tree := SolveWithTrace(g.Board, game.Black, 3, NewMixedEvaluation(V7Coeff), TraceOptions{})
+
+	var dot strings.Builder
+	if err := tree.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(dot.String(), "digraph search {") || strings.Count(dot.String(), "->") != tree.Nodes-1 {
+		t.Errorf("DOT export of %d nodes:\n%s", tree.Nodes, dot.String())
+	}
+
+	var buf bytes.Buffer
+	if err := tree.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Root struct {
+			Player   string            `json:"player"`
+			Children []json.RawMessage `json:"children"`
+		} `json:"root"`
+		Nodes int `json:"nodes"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Nodes != tree.Nodes || decoded.Root.Player != "black" || len(decoded.Root.Children) != 4 {
+		t.Errorf("JSON export: %d nodes, root of %s with %d children", decoded.Nodes, decoded.Root.Player, len(decoded.Root.Children))
+	}
+}
+
+// The trace is disabled by a nil node, the two benchmarks compare Solve with the traced search
+func BenchmarkSolveUntraced(b *testing.B) {
+	board := game.NewGame("Black", "White").Board
+	eval := NewMixedEvaluation(V7Coeff)
+	for range b.N {
+		Solve(board, game.Black, 6, eval)
+	}
+}
+
+func BenchmarkSolveTraced(b *testing.B) {
+	board := game.NewGame("Black", "White").Board
+	eval := NewMixedEvaluation(V7Coeff)
+	for range b.N {
+		SolveWithTrace(board, game.Black, 6, eval, TraceOptions{})
+	}
+}
@@ -0,0 +1,293 @@
+package evaluation
+
+import (
+	zobrist "github.com/Coloc3G/othello-engine/models/ai/cache"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// DefaultHashMB is the size of the transposition table of NewCache, in megabytes
+const DefaultHashMB = 16
+
+// Layout of the table: buckets of ttBucketSlots entries of 24 bytes. The first slots of a
+// bucket keep the deepest entries, the last one takes any entry, so the positions of the
+// current line always find a place.
+const (
+	ttBucketSlots = 4
+	ttSlotBytes   = 24
+)
+
+type TTEntry struct {
+	Score int16
+	Depth int8
+	Moves []game.Position // Best move, nil when unknown
+	Flag  int8            // 0: exact, 1: lower bound, 2: upper bound
+}
+
+// ttSlot is an entry as stored in the table, packed in ttSlotBytes
+type ttSlot struct {
+	check  uint64 // Zobrist key
+	verify uint64 // Second half of BitBoard.Hash128, 0 when the cache does not verify entries
+	score  int16
+	depth  int8
+	flag   int8  // Flag of the entry plus one, 0 for an empty slot
+	move   int8  // Square of the best move, -1 when unknown
+	age    uint8 // Search that stored the entry
+}
+
+type ttBucket [ttBucketSlots]ttSlot
+
+// Cache is the transposition table, keyed by the Zobrist hash of the position and side to
+// move. Its size is set once: the buckets are allocated by NewCacheMB and a full bucket
+// replaces one of its entries, so the memory used does not grow during a search.
+type Cache struct {
+	// Verify also checks a second, independent 64-bit hash of the position before trusting
+	// an entry, counting the entries of the same key with another second hash as
+	// collisions. It is off by default. Set it before the first store: entries stored
+	// otherwise are not found.
+	Verify bool
+
+	buckets []ttBucket
+	mask    uint64 // Bits of the key indexing the buckets
+	age     uint8  // Search of the new entries, older entries are replaced first
+	used    int    // Slots holding an entry
+	stats   TTStats
+}
+
+// TTStats describes how a search used its transposition table
+type TTStats struct {
+	DepthHistogram    [32]int64 // Entries in the table per remaining depth
+	FlagHistogram     [3]int64  // Entries in the table per flag: exact, lower bound, upper bound
+	HitDepthHistogram [32]int64 // Entries found deep enough for the search, per depth of the entry
+	OverwriteCount    int64     // Stores replacing the entry of the same key
+	EvictedCount      int64     // Stores replacing the entry of another key in a full bucket
+	CollisionCount    int64     // Lookups finding the key of another position, see Cache.Verify
+}
+
+// Add adds the statistics of another search
+func (s *TTStats) Add(o TTStats) {
+	for i := range s.DepthHistogram {
+		s.DepthHistogram[i] += o.DepthHistogram[i]
+		s.HitDepthHistogram[i] += o.HitDepthHistogram[i]
+	}
+	for i := range s.FlagHistogram {
+		s.FlagHistogram[i] += o.FlagHistogram[i]
+	}
+	s.OverwriteCount += o.OverwriteCount
+	s.EvictedCount += o.EvictedCount
+	s.CollisionCount += o.CollisionCount
+}
+
+// ttDepthBucket returns the histogram bucket of a depth, negative depths of the
+// quiescence extension going to 0
+func ttDepthBucket(depth int8) int {
+	return min(max(int(depth), 0), len(TTStats{}.DepthHistogram)-1)
+}
+
+// squareMoves holds the best move of an entry for each square, so reading an entry does
+// not allocate. The slices are shared and must not be modified.
+var squareMoves = func() (moves [64][]game.Position) {
+	for square := range moves {
+		moves[square] = []game.Position{{Row: int8(square / 8), Col: int8(square % 8)}}
+	}
+	return moves
+}()
+
+// NewCache creates a table of DefaultHashMB megabytes
+func NewCache() *Cache {
+	return NewCacheMB(DefaultHashMB)
+}
+
+// NewCacheMB creates a table of at most mb megabytes, DefaultHashMB when mb <= 0: the
+// largest power of two buckets fitting, at least one
+func NewCacheMB(mb int) *Cache {
+	if mb <= 0 {
+		mb = DefaultHashMB
+	}
+	n := 1
+	for 2*n*ttBucketSlots*ttSlotBytes <= mb<<20 {
+		n *= 2
+	}
+	return &Cache{
+		buckets: make([]ttBucket, n),
+		mask:    uint64(n - 1),
+	}
+}
+
+// searchHashMB returns the size of the table a search at depth allocates when it is given
+// neither a table nor a size. Shallow searches, the most frequent in training, would
+// spend more time clearing a large table than searching.
+func searchHashMB(depth int8) int {
+	return min(DefaultHashMB, 1<<max(0, int(depth)-5))
+}
+
+// DumpStats returns the statistics of the table, a nil cache has none
+func (c *Cache) DumpStats() TTStats {
+	if c == nil {
+		return TTStats{}
+	}
+	return c.stats
+}
+
+// Len returns the number of entries in the table, 0 for a nil cache
+func (c *Cache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.used
+}
+
+// CollisionCount returns the number of lookups that found an entry of the same Zobrist key
+// but of another position, 0 for a nil cache or one that does not verify entries
+func (c *Cache) CollisionCount() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.stats.CollisionCount
+}
+
+// HashFull returns the occupancy of the table in permille, 0 for a nil cache
+func (c *Cache) HashFull() int {
+	if c == nil {
+		return 0
+	}
+	return c.used * 1000 / (len(c.buckets) * ttBucketSlots)
+}
+
+// newSearch ages the entries of the previous searches, replaced before the new ones
+func (c *Cache) newSearch() {
+	if c != nil {
+		c.age++
+	}
+}
+
+// recordHit counts an entry used by the search
+func (c *Cache) recordHit(entry TTEntry) {
+	if c != nil {
+		c.stats.HitDepthHistogram[ttDepthBucket(entry.Depth)]++
+	}
+}
+
+// verifyHash returns the second hash of a position, 0 when the cache does not verify entries
+func (c *Cache) verifyHash(node game.BitBoard) uint64 {
+	if c == nil || !c.Verify {
+		return 0
+	}
+	return node.Hash128()[1]
+}
+
+// ttEntry returns the entry of a position, a nil cache is always empty
+func (c *Cache) ttEntry(key, verify uint64) (TTEntry, bool) {
+	if c == nil {
+		return TTEntry{}, false
+	}
+	bucket := &c.buckets[key&c.mask]
+	for i := range bucket {
+		slot := &bucket[i]
+		if slot.flag == 0 || slot.check != key {
+			continue
+		}
+		if c.Verify && slot.verify != verify {
+			c.stats.CollisionCount++
+			continue
+		}
+		entry := TTEntry{Score: slot.score, Depth: slot.depth, Flag: slot.flag - 1}
+		if slot.move >= 0 {
+			entry.Moves = squareMoves[slot.move]
+		}
+		return entry, true
+	}
+	return TTEntry{}, false
+}
+
+func (c *Cache) cacheTTEntry(key, verify uint64, entry TTEntry) {
+	if c == nil {
+		return
+	}
+	bucket := &c.buckets[key&c.mask]
+	slot := &bucket[c.replacement(bucket, key, entry.Depth)]
+	if slot.flag == 0 {
+		c.used++
+	} else {
+		if slot.check == key && slot.verify == verify {
+			c.stats.OverwriteCount++
+		} else {
+			c.stats.EvictedCount++
+		}
+		c.stats.DepthHistogram[ttDepthBucket(slot.depth)]--
+		c.stats.FlagHistogram[slot.flag-1]--
+	}
+
+	move := int8(-1)
+	if len(entry.Moves) > 0 && entry.Moves[0].Row >= 0 {
+		move = entry.Moves[0].Row*8 + entry.Moves[0].Col
+	}
+	*slot = ttSlot{check: key, verify: verify, score: entry.Score, depth: entry.Depth, flag: entry.Flag + 1, move: move, age: c.age}
+	c.stats.DepthHistogram[ttDepthBucket(entry.Depth)]++
+	c.stats.FlagHistogram[entry.Flag]++
+}
+
+// extendPV completes a line cut by a table hit, a slot keeping only the best move of its
+// position and not the line after it: line is played from node, passing when the player
+// to move cannot, and followed by the best moves found in the table, for depth plies at
+// most counting the passes. A nil cache returns line unchanged.
+func (c *Cache) extendPV(node game.BitBoard, player game.Piece, line []game.Position, depth int8) []game.Position {
+	if c == nil {
+		return line
+	}
+	var plies int8
+	for _, move := range line {
+		if game.ValidMovesMask(node, player) == 0 {
+			player = game.GetOpponentColor(player)
+			plies++
+		}
+		next, ok := game.GetNewBitBoardAfterMove(node, move, player)
+		if !ok {
+			return line
+		}
+		node, player = next, game.GetOpponentColor(player)
+		plies++
+	}
+	line = line[:len(line):len(line)]
+	for ; plies < depth; plies++ {
+		if game.ValidMovesMask(node, player) == 0 {
+			player = game.GetOpponentColor(player)
+			continue
+		}
+		entry, ok := c.ttEntry(zobrist.GlobalZobrist().Hash(node, player), c.verifyHash(node))
+		if !ok || len(entry.Moves) == 0 {
+			break
+		}
+		next, ok := game.GetNewBitBoardAfterMove(node, entry.Moves[0], player)
+		if !ok {
+			break
+		}
+		line = append(line, entry.Moves[0])
+		node, player = next, game.GetOpponentColor(player)
+	}
+	return line
+}
+
+// replacement returns the slot of bucket taking an entry of depth: the slot of the same
+// key, else the emptiest of the depth-preferred slots, an entry of an older search
+// or the shallowest entry, when the new entry is as deep, else the always-replace slot
+func (c *Cache) replacement(bucket *ttBucket, key uint64, depth int8) int {
+	for i := range bucket {
+		if bucket[i].flag != 0 && bucket[i].check == key {
+			return i
+		}
+	}
+	victim := 0
+	for i := 0; i < ttBucketSlots-1; i++ {
+		slot, worst := &bucket[i], &bucket[victim]
+		if slot.flag == 0 {
+			return i
+		}
+		if (slot.age != c.age && worst.age == c.age) || (slot.age == c.age) == (worst.age == c.age) && slot.depth < worst.depth {
+			victim = i
+		}
+	}
+	if worst := &bucket[victim]; worst.age != c.age || depth >= worst.depth {
+		return victim
+	}
+	return ttBucketSlots - 1
+}
@@ -0,0 +1,174 @@
+package evaluation
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// replayLine plays a line from node, passing when the player to move cannot, and returns
+// the plies it took, passes included, or false when a move is invalid
+func replayLine(node game.BitBoard, player game.Piece, line []game.Position) (game.BitBoard, game.Piece, int8, bool) {
+	var plies int8
+	for _, move := range line {
+		if game.ValidMovesMask(node, player) == 0 {
+			player = game.GetOpponentColor(player)
+			plies++
+		}
+		next, ok := game.GetNewBitBoardAfterMove(node, move, player)
+		if !ok {
+			return node, player, plies, false
+		}
+		node, player = next, game.GetOpponentColor(player)
+		plies++
+	}
+	return node, player, plies, true
+}
+
+func TestSolvePVNotCutByTableHits(t *testing.T) {
+	boards, players := randomBitBoards(t, 10, 30)
+	eval := NewMixedEvaluation(V7Coeff)
+	const depth = 6
+	for i, bb := range boards {
+		line, _ := solve(bb, players[i], depth, eval, nil, nil, nil)
+		end, player, plies, ok := replayLine(bb, players[i], line)
+		if !ok {
+			t.Errorf("board %d: line %v is not playable", i, line)
+			continue
+		}
+		over := game.ValidMovesMask(end, player)|game.ValidMovesMask(end, game.GetOpponentColor(player)) == 0
+		if plies > depth || (plies < depth && !over) {
+			t.Errorf("board %d: line %v of %d plies for a depth %d search", i, line, plies, depth)
+		}
+	}
+}
+
+// tinyCache returns a table of a single bucket, replacing entries at almost every store
+func tinyCache() *Cache {
+	return &Cache{buckets: make([]ttBucket, 1), Verify: true}
+}
+
+// TestCacheSizesAgree compares the bounded table with the searches without a table, and
+// with a table large enough to keep every position as the former map did
+func TestCacheSizesAgree(t *testing.T) {
+	boards, players := randomBitBoards(t, 11, 20)
+	eval := NewMixedEvaluation(V7Coeff)
+	const depth = 5
+	for i, bb := range boards {
+		wantMoves, want := solve(bb, players[i], depth, eval, nil, nil, &SearchOptions{DisableTT: true})
+		for name, cache := range map[string]*Cache{"tiny": tinyCache(), "default": NewCache(), "large": NewCacheMB(256)} {
+			moves, score := solve(bb, players[i], depth, eval, nil, nil, &SearchOptions{Cache: cache})
+			if score != want || moves[0] != wantMoves[0] {
+				t.Errorf("board %d, %s table: %v %d, without a table %v %d", i, name, moves[0], score, wantMoves[0], want)
+			}
+		}
+	}
+}
+
+// BenchmarkSearchMemoryFlat reports the heap grown by depth 8 searches sharing a table of
+// DefaultHashMB: the table is allocated before, so it stays flat however many positions
+// the searches store
+func BenchmarkSearchMemoryFlat(b *testing.B) {
+	boards, players := randomBitBoards(b, 12, 8)
+	eval := NewMixedEvaluation(V7Coeff)
+	cache := NewCache()
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	for i := range b.N {
+		solve(boards[i%len(boards)], players[i%len(boards)], 8, eval, nil, nil, &SearchOptions{Cache: cache})
+	}
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(int64(after.HeapInuse)-int64(before.HeapInuse)), "heap-B")
+	b.ReportMetric(float64(cache.HashFull()), "hashfull")
+}
+
+// TestCollisionCount stores two positions under the same key, as a Zobrist hash stubbed to
+// collide would, and checks the second hash tells them apart
+func TestCollisionCount(t *testing.T) {
+	first := game.BitBoard{BlackPieces: 0x0000000810000000, WhitePieces: 0x0000001008000000}
+	second := first.MirrorAntiDiagonal()
+	second.BlackPieces |= 1
+	const key = 0x1234
+	if NewCacheMB(1).Verify {
+		t.Error("new tables verify their entries by default")
+	}
+	for _, verify := range []bool{true, false} {
+		cache := NewCacheMB(1)
+		cache.Verify = verify
+		cache.cacheTTEntry(key, cache.verifyHash(first), TTEntry{Score: 7, Depth: 3})
+		entry, ok := cache.ttEntry(key, cache.verifyHash(second))
+		if verify {
+			if ok || cache.CollisionCount() != 1 {
+				t.Errorf("verified table: found %v %v, %d collisions, want a single collision", entry, ok, cache.CollisionCount())
+			}
+			if entry, ok := cache.ttEntry(key, cache.verifyHash(first)); !ok || entry.Score != 7 {
+				t.Errorf("verified table: stored position found %v %v", entry, ok)
+			}
+		} else if !ok || cache.CollisionCount() != 0 {
+			t.Errorf("unverified table: found %v %v, %d collisions, want the entry of the key", entry, ok, cache.CollisionCount())
+		}
+	}
+}
+
+// TestCacheVerifiesFullHash checks that every bit of the second hash is compared, a 0 hash
+// being a hash like any other once the table verifies its entries
+func TestCacheVerifiesFullHash(t *testing.T) {
+	if size := unsafe.Sizeof(ttSlot{}); size != ttSlotBytes {
+		t.Fatalf("slot of %d bytes, want ttSlotBytes = %d", size, ttSlotBytes)
+	}
+	const key = 0x1234
+	tests := []struct {
+		name           string
+		stored, lookup uint64
+	}{
+		{"low bit", 0xfedcba9876543210, 0xfedcba9876543211},
+		{"bit 47", 0xfedcba9876543210, 0xfedc3a9876543210},
+		{"zero hash stored", 0, 1},
+		{"zero hash looked up", 1 << 63, 0},
+	}
+	for _, tt := range tests {
+		cache := NewCacheMB(1)
+		cache.Verify = true
+		cache.cacheTTEntry(key, tt.stored, TTEntry{Score: 7, Depth: 3})
+		if entry, ok := cache.ttEntry(key, tt.lookup); ok || cache.CollisionCount() != 1 {
+			t.Errorf("%s: found %v %v, %d collisions, want a single collision", tt.name, entry, ok, cache.CollisionCount())
+		}
+		if entry, ok := cache.ttEntry(key, tt.stored); !ok || entry.Score != 7 {
+			t.Errorf("%s: stored position found %v %v", tt.name, entry, ok)
+		}
+	}
+}
+
+// TestCollidingPositionsShareSlot stores two positions under one key in a table of a single
+// bucket, so both go to the same slot: each lookup of the position not stored misses and
+// counts a collision, while another key of the bucket misses without one
+func TestCollidingPositionsShareSlot(t *testing.T) {
+	first := game.BitBoard{BlackPieces: 0x0000000810000000, WhitePieces: 0x0000001008000000}
+	second := first.MirrorDiagonal()
+	second.WhitePieces |= 1 << 63
+	const key, otherKey = 0x40, 0x41
+	cache := tinyCache()
+
+	cache.cacheTTEntry(key, cache.verifyHash(first), TTEntry{Score: 1, Depth: 2})
+	if _, ok := cache.ttEntry(key, cache.verifyHash(second)); ok || cache.CollisionCount() != 1 {
+		t.Fatalf("second position found %v with %d collisions, want a miss and 1", ok, cache.CollisionCount())
+	}
+	if _, ok := cache.ttEntry(otherKey, cache.verifyHash(second)); ok || cache.CollisionCount() != 1 {
+		t.Fatalf("other key found %v with %d collisions, want a miss and still 1", ok, cache.CollisionCount())
+	}
+
+	cache.cacheTTEntry(key, cache.verifyHash(second), TTEntry{Score: 2, Depth: 2})
+	if cache.Len() != 1 || cache.DumpStats().EvictedCount != 1 || cache.DumpStats().OverwriteCount != 0 {
+		t.Errorf("%d entries, %+v, want the second position replacing the first in its slot", cache.Len(), cache.DumpStats())
+	}
+	if entry, ok := cache.ttEntry(key, cache.verifyHash(second)); !ok || entry.Score != 2 {
+		t.Errorf("second position found %v %v after its store", entry, ok)
+	}
+	if _, ok := cache.ttEntry(key, cache.verifyHash(first)); ok || cache.CollisionCount() != 2 {
+		t.Errorf("first position found %v with %d collisions, want a miss and 2", ok, cache.CollisionCount())
+	}
+}
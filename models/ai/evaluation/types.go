@@ -7,7 +7,10 @@ type PreEvaluationComputation struct {
 	BlackPieces     int16
 	WhiteValidMoves []game.Position
 	BlackValidMoves []game.Position
+	WhiteMoveMask   uint64 // WhiteValidMoves as a bitboard
+	BlackMoveMask   uint64 // BlackValidMoves as a bitboard
 	IsGameOver      bool
+	Phase           int  // Game phase index computed with DefaultPhaseBoundaries
 	Debug           bool // For debugging purposes, can be set to true to print debug information
 }
 
@@ -16,3 +19,10 @@ type Evaluation interface {
 	Evaluate(bb game.BitBoard) int16
 	PECEvaluate(bb game.BitBoard, pec PreEvaluationComputation) int16
 }
+
+// Searcher picks a move for a player, returning the principal variation and its score
+// from White's perspective. When the player has no valid move it returns a single
+// {-1, -1} position like Solve.
+type Searcher interface {
+	Search(b game.Board, player game.Piece) ([]game.Position, int16)
+}
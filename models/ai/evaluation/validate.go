@@ -0,0 +1,87 @@
+package evaluation
+
+import "fmt"
+
+// Bounds checked by Validate
+const (
+	MinCoeff        = -10000
+	MaxCoeff        = 10000
+	EarlyGamePhases = 2 // Corner coefficients cannot be negative in these first phases
+)
+
+// Validate checks the phases and that all coefficients are within [MinCoeff, MaxCoeff],
+// that mobility is never penalized and that corners are never penalized early in the game
+func (ec EvaluationCoefficients) Validate() error {
+	if err := ec.ValidatePhases(); err != nil {
+		return err
+	}
+
+	for _, c := range ec.namedCoeffs() {
+		for phase, v := range c.values {
+			if v < MinCoeff || v > MaxCoeff {
+				return fmt.Errorf("%s coefficient %d of phase %d is outside [%d, %d]", c.name, v, phase, MinCoeff, MaxCoeff)
+			}
+		}
+	}
+	for phase, v := range ec.MobilityCoeffs {
+		if v < 0 {
+			return fmt.Errorf("mobility coefficient %d of phase %d is negative", v, phase)
+		}
+	}
+	for phase, v := range ec.CornersCoeffs[:min(EarlyGamePhases, len(ec.CornersCoeffs))] {
+		if v < 0 {
+			return fmt.Errorf("corners coefficient %d of early phase %d is negative", v, phase)
+		}
+	}
+	return nil
+}
+
+// Clamp returns a copy of the coefficients brought back within the bounds checked by Validate
+func (ec EvaluationCoefficients) Clamp() EvaluationCoefficients {
+	clamped := ec
+	clamped.MaterialCoeffs = clampSlice(ec.MaterialCoeffs, MinCoeff)
+	clamped.MobilityCoeffs = clampSlice(ec.MobilityCoeffs, 0)
+	clamped.CornersCoeffs = clampSlice(ec.CornersCoeffs, MinCoeff)
+	for i := range min(EarlyGamePhases, len(clamped.CornersCoeffs)) {
+		clamped.CornersCoeffs[i] = max(clamped.CornersCoeffs[i], 0)
+	}
+	clamped.ParityCoeffs = clampSlice(ec.ParityCoeffs, MinCoeff)
+	clamped.StabilityCoeffs = clampSlice(ec.StabilityCoeffs, MinCoeff)
+	clamped.FrontierCoeffs = clampSlice(ec.FrontierCoeffs, MinCoeff)
+	clamped.ThreatCoeffs = clampSlice(ec.ThreatCoeffs, MinCoeff)
+	clamped.TempoCoeffs = clampSlice(ec.TempoCoeffs, MinCoeff)
+	clamped.EdgeCoeffs = clampSlice(ec.EdgeCoeffs, MinCoeff)
+	return clamped
+}
+
+type namedCoeffs struct {
+	name     string
+	values   []int16
+	optional bool // Disabled when empty
+}
+
+// namedCoeffs lists every coefficient slice with its name, in a stable order
+func (ec EvaluationCoefficients) namedCoeffs() []namedCoeffs {
+	return []namedCoeffs{
+		{"material", ec.MaterialCoeffs, false},
+		{"mobility", ec.MobilityCoeffs, false},
+		{"corners", ec.CornersCoeffs, false},
+		{"parity", ec.ParityCoeffs, false},
+		{"stability", ec.StabilityCoeffs, false},
+		{"frontier", ec.FrontierCoeffs, false},
+		{"threat", ec.ThreatCoeffs, true},
+		{"tempo", ec.TempoCoeffs, true},
+		{"edge", ec.EdgeCoeffs, true},
+	}
+}
+
+func clampSlice(values []int16, lower int16) []int16 {
+	if values == nil {
+		return nil
+	}
+	clamped := make([]int16, len(values))
+	for i, v := range values {
+		clamped[i] = min(max(v, lower), MaxCoeff)
+	}
+	return clamped
+}
@@ -0,0 +1,82 @@
+package evaluation
+
+import "testing"
+
+// withCoeffs returns a copy of V4Coeff with the slice selected by set replaced by values
+func withCoeffs(set func(*EvaluationCoefficients, []int16), values ...int16) EvaluationCoefficients {
+	ec := V4Coeff
+	set(&ec, values)
+	return ec
+}
+
+func setMaterial(ec *EvaluationCoefficients, v []int16)   { ec.MaterialCoeffs = v }
+func setMobility(ec *EvaluationCoefficients, v []int16)   { ec.MobilityCoeffs = v }
+func setCorners(ec *EvaluationCoefficients, v []int16)    { ec.CornersCoeffs = v }
+func setParity(ec *EvaluationCoefficients, v []int16)     { ec.ParityCoeffs = v }
+func setFrontier(ec *EvaluationCoefficients, v []int16)   { ec.FrontierCoeffs = v }
+func setThreat(ec *EvaluationCoefficients, v []int16)     { ec.ThreatCoeffs = v }
+func setTempo(ec *EvaluationCoefficients, v []int16)      { ec.TempoCoeffs = v }
+func setEdge(ec *EvaluationCoefficients, v []int16)       { ec.EdgeCoeffs = v }
+func setBoundaries(ec *EvaluationCoefficients, v []int16) { ec.PhaseBoundaries = v }
+
+func TestValidateValid(t *testing.T) {
+	valid := append([]EvaluationCoefficients{}, Models...)
+	valid = append(valid,
+		V1Coeff.Interpolate(V7Coeff, 0.5),
+		V2Coeff.Interpolate(V5Coeff, 0.25),
+		withCoeffs(setMaterial, MinCoeff, MinCoeff, 0, 0, MaxCoeff, MaxCoeff),
+		withCoeffs(setMobility, 0, 0, 0, 0, 0, 0),
+		withCoeffs(setMobility, MaxCoeff, 1, 2, 3, 4, 5),
+		withCoeffs(setCorners, 0, 0, -50, -50, -100, MinCoeff),
+		withCoeffs(setParity, -30, -30, 0, 0, 30, 30),
+		withCoeffs(setFrontier, -10, -10, -10, -10, -10, -10),
+		withCoeffs(setThreat, 1, 2, 3, 4, 5, 6),
+		withCoeffs(setTempo, -5, -5, 5, 5, 0, 0),
+		withCoeffs(setEdge, 10, 10, 20, 20, 30, 30),
+		withCoeffs(setBoundaries, 10, 22, 36, 48, 56),
+		withCoeffs(setBoundaries, 4, 5, 6, 7, 64),
+	)
+	if len(valid) != 20 {
+		t.Fatalf("%d valid sets, want 20", len(valid))
+	}
+	for i, ec := range valid {
+		if err := ec.Validate(); err != nil {
+			t.Errorf("set %d (%s): %v", i, ec.Name, err)
+		}
+	}
+}
+
+func TestValidateInvalid(t *testing.T) {
+	invalid := map[string]EvaluationCoefficients{
+		"material above max":        withCoeffs(setMaterial, 0, 0, 1, 1, MaxCoeff+1, 11),
+		"parity below min":          withCoeffs(setParity, MinCoeff-1, 7, 29, 29, 47, 47),
+		"negative mobility":         withCoeffs(setMobility, 6, 6, 20, -1, 39, 39),
+		"negative early corners":    withCoeffs(setCorners, -1, 100, 100, 100, 100, 100),
+		"negative 2nd phase corner": withCoeffs(setCorners, 100, -100, 100, 100, 100, 100),
+		"missing phase":             withCoeffs(setFrontier, 7, 7, 8, 8, 38),
+		"extra phase":               withCoeffs(setMaterial, 0, 0, 1, 1, 11, 11, 11),
+		"threat phases":             withCoeffs(setThreat, 1, 2),
+		"unsorted boundaries":       withCoeffs(setBoundaries, 9, 35, 20, 50, 55),
+		"repeated boundary":         withCoeffs(setBoundaries, 9, 20, 20, 50, 55),
+	}
+	if len(invalid) != 10 {
+		t.Fatalf("%d invalid sets, want 10", len(invalid))
+	}
+	for name, ec := range invalid {
+		if err := ec.Validate(); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestClampFixesValues(t *testing.T) {
+	ec := withCoeffs(setMobility, -5, 6, 20, 20, 39, MaxCoeff)
+	ec.CornersCoeffs = []int16{-3, -2, -1, 100, 100, 100}
+	ec.MaterialCoeffs = []int16{MinCoeff - 1, 0, 1, 1, 11, MaxCoeff + 1}
+	if err := ec.Validate(); err == nil {
+		t.Fatal("out of bounds coefficients accepted")
+	}
+	if err := ec.Clamp().Validate(); err != nil {
+		t.Errorf("clamped coefficients are invalid: %v", err)
+	}
+}
@@ -6,6 +6,13 @@ const (
 )
 
 var (
+	// DefaultPhaseBoundaries are the piece counts closing each of the six game phases
+	DefaultPhaseBoundaries = []int16{9, 20, 35, 50, 55}
+
+	// The frontier coefficients of the trained models were fitted while the frontier
+	// evaluation missed the diagonal neighbors of the empty squares of rows 1 and 8, so
+	// they weigh frontier counts slightly different from the current ones
+
 	V1Coeff = EvaluationCoefficients{
 		Name:            "V1",
 		MaterialCoeffs:  []int16{0, 0, 1, 1, 50, 50},
@@ -0,0 +1,52 @@
+package evaluation
+
+import (
+	"fmt"
+	"math"
+)
+
+// vectorComponents is the number of coefficient slices in a vector
+const vectorComponents = 6
+
+// ToVector flattens the material, mobility, corners, parity, stability and frontier
+// coefficients, in that order, for optimizers working on float vectors. The threat, tempo and
+// edge coefficients, the phase boundaries and the name are not part of the vector.
+func (ec EvaluationCoefficients) ToVector() []float32 {
+	v := make([]float32, 0, vectorComponents*(len(ec.Boundaries())+1))
+	for _, c := range ec.namedCoeffs()[:vectorComponents] {
+		for _, coeff := range c.values {
+			v = append(v, float32(coeff))
+		}
+	}
+	return v
+}
+
+// EvaluationCoefficientsFromVector rebuilds coefficients with the default phases from a
+// vector written by ToVector. Values are rounded to the nearest integer and must fit in
+// [MinCoeff, MaxCoeff]; the result is not otherwise validated.
+func EvaluationCoefficientsFromVector(v []float32) (EvaluationCoefficients, error) {
+	phases := len(DefaultPhaseBoundaries) + 1
+	if len(v) != vectorComponents*phases {
+		return EvaluationCoefficients{}, fmt.Errorf("coefficient vector has %d values, expected %d", len(v), vectorComponents*phases)
+	}
+
+	var slices [vectorComponents][]int16
+	for i := range slices {
+		slices[i] = make([]int16, phases)
+		for phase := range phases {
+			value := math.Round(float64(v[i*phases+phase]))
+			if math.IsNaN(value) || value < MinCoeff || value > MaxCoeff {
+				return EvaluationCoefficients{}, fmt.Errorf("coefficient %d is %v, outside [%d, %d]", i*phases+phase, v[i*phases+phase], MinCoeff, MaxCoeff)
+			}
+			slices[i][phase] = int16(value)
+		}
+	}
+	return EvaluationCoefficients{
+		MaterialCoeffs:  slices[0],
+		MobilityCoeffs:  slices[1],
+		CornersCoeffs:   slices[2],
+		ParityCoeffs:    slices[3],
+		StabilityCoeffs: slices[4],
+		FrontierCoeffs:  slices[5],
+	}, nil
+}
@@ -0,0 +1,72 @@
+package evaluation
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestVectorRoundTrip(t *testing.T) {
+	for _, coeffs := range []EvaluationCoefficients{V1Coeff, V2Coeff, V3Coeff, V4Coeff} {
+		v := coeffs.ToVector()
+		phases := len(DefaultPhaseBoundaries) + 1
+		if len(v) != vectorComponents*phases {
+			t.Fatalf("%s: vector of %d values, want %d", coeffs.Name, len(v), vectorComponents*phases)
+		}
+		got, err := EvaluationCoefficientsFromVector(v)
+		if err != nil {
+			t.Fatalf("%s: %v", coeffs.Name, err)
+		}
+		for _, pair := range [][2][]int16{
+			{got.MaterialCoeffs, coeffs.MaterialCoeffs},
+			{got.MobilityCoeffs, coeffs.MobilityCoeffs},
+			{got.CornersCoeffs, coeffs.CornersCoeffs},
+			{got.ParityCoeffs, coeffs.ParityCoeffs},
+			{got.StabilityCoeffs, coeffs.StabilityCoeffs},
+			{got.FrontierCoeffs, coeffs.FrontierCoeffs},
+		} {
+			if !slices.Equal(pair[0], pair[1]) {
+				t.Errorf("%s: round trip gives %v, want %v", coeffs.Name, pair[0], pair[1])
+			}
+		}
+		if !slices.Equal(got.ToVector(), v) {
+			t.Errorf("%s: vector changed by a round trip", coeffs.Name)
+		}
+	}
+}
+
+func TestVectorLayout(t *testing.T) {
+	v := V4Coeff.ToVector()
+	phases := len(DefaultPhaseBoundaries) + 1
+	// Material first, frontier last
+	if v[0] != float32(V4Coeff.MaterialCoeffs[0]) || v[len(v)-1] != float32(V4Coeff.FrontierCoeffs[phases-1]) {
+		t.Errorf("vector %v does not start with material and end with frontier", v)
+	}
+	if v[2*phases] != float32(V4Coeff.CornersCoeffs[0]) {
+		t.Errorf("value %d is %v, want the first corners coefficient %d", 2*phases, v[2*phases], V4Coeff.CornersCoeffs[0])
+	}
+}
+
+func TestVectorFromOptimizer(t *testing.T) {
+	v := V4Coeff.ToVector()
+	v[0] += 0.4
+	v[1] -= 0.6
+	got, err := EvaluationCoefficientsFromVector(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.MaterialCoeffs[0] != V4Coeff.MaterialCoeffs[0] || got.MaterialCoeffs[1] != V4Coeff.MaterialCoeffs[1]-1 {
+		t.Errorf("values not rounded to the nearest integer: %v", got.MaterialCoeffs[:2])
+	}
+
+	for name, v := range map[string][]float32{
+		"short":     v[:len(v)-1],
+		"too large": append(slices.Clone(v[:len(v)-1]), MaxCoeff+1),
+		"too small": append(slices.Clone(v[:len(v)-1]), MinCoeff-1),
+		"nan":       append(slices.Clone(v[:len(v)-1]), float32(math.NaN())),
+	} {
+		if _, err := EvaluationCoefficientsFromVector(v); err == nil {
+			t.Errorf("%s vector: no error", name)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package evaluation
+
+import (
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// DefaultWarmDepth is a reasonable depth for WarmCache: shallow enough to warm the known
+// openings in about a second
+const DefaultWarmDepth int8 = 6
+
+// WarmCache searches the position reached by each opening at depth, storing the results in
+// cache, so the next searches of these positions and of their continuations start from a
+// populated transposition table. Openings that do not replay, or end the game, are skipped.
+func WarmCache(openings []opening.Opening, depth int8, eval Evaluation, cache *Cache) {
+	opts := &SearchOptions{Cache: cache}
+	for _, op := range openings {
+		g := game.NewGame("Black", "White")
+		if _, err := utils.ApplyTranscript(g, op.Transcript); err != nil || g.LegalState() != game.HasMoves {
+			continue
+		}
+		solve(utils.BoardToBits(g.Board), g.CurrentPlayer.Color, depth, eval, nil, nil, opts)
+	}
+}
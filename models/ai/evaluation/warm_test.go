@@ -0,0 +1,35 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+func TestWarmCacheSavesNodes(t *testing.T) {
+	const depth = 4
+	eval := NewMixedEvaluation(V7Coeff)
+	openings := opening.KNOWN_OPENINGS[:20]
+	warm := NewCache()
+	WarmCache(openings, depth, eval, warm)
+
+	for _, op := range openings {
+		bb, player := transcriptPosition(t, op.Transcript)
+		cold, coldScore, coldNodes := searchNodes(bb, player, depth, eval, SearchOptions{Cache: NewCache()})
+		move, score, nodes := searchNodes(bb, player, depth, eval, SearchOptions{Cache: warm})
+		if nodes >= coldNodes {
+			t.Errorf("%s: %d nodes with the warm table, %d with a cold one", op.Name, nodes, coldNodes)
+		}
+		if move != cold || score != coldScore {
+			t.Errorf("%s: %v %d with the warm table, %v %d with a cold one", op.Name, move, score, cold, coldScore)
+		}
+	}
+}
+
+func TestWarmCacheSkipsInvalidOpenings(t *testing.T) {
+	cache := NewCache()
+	WarmCache([]opening.Opening{{Name: "odd", Transcript: "f5d"}, {Name: "illegal", Transcript: "a1"}}, 4, NewMixedEvaluation(V7Coeff), cache)
+	if n := cache.Len(); n != 0 {
+		t.Errorf("%d entries stored for openings that do not replay", n)
+	}
+}
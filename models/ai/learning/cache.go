@@ -0,0 +1,105 @@
+package learning
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// matchKey identifies a match whose outcome depends only on its inputs,
+// the search being deterministic
+type matchKey struct {
+	Model    string
+	Opponent string
+	Depth    int8
+	Opening  string
+	Player   int
+}
+
+// matchResult is the stored outcome of a match
+type matchResult struct {
+	Win, Loss, Draw bool
+	Aborted         game.AbortReason
+	History         string
+}
+
+// FitnessCache stores match outcomes so models kept unchanged by elitism
+// do not replay the same games against the same opponent. Mutated models get new
+// fingerprints every generation, so the outcomes neither stored nor looked up during
+// a generation are dropped at the start of the next one, see NextGeneration.
+type FitnessCache struct {
+	mu       sync.Mutex
+	current  map[matchKey]matchResult // Outcomes stored or looked up this generation
+	previous map[matchKey]matchResult // Outcomes of the previous generation not looked up yet
+}
+
+// NewFitnessCache creates an empty fitness cache
+func NewFitnessCache() *FitnessCache {
+	return &FitnessCache{current: make(map[matchKey]matchResult)}
+}
+
+// NextGeneration starts a generation, dropping the outcomes unused during the previous one
+func (c *FitnessCache) NextGeneration() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.previous = c.current
+	c.current = make(map[matchKey]matchResult, len(c.previous))
+}
+
+// get returns the stored outcome of a match, a nil cache never hits
+func (c *FitnessCache) get(key matchKey) (matchResult, bool) {
+	if c == nil {
+		return matchResult{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if res, ok := c.current[key]; ok {
+		return res, true
+	}
+	res, ok := c.previous[key]
+	if ok {
+		// Kept for the next generation
+		c.current[key] = res
+		delete(c.previous, key)
+	}
+	return res, ok
+}
+
+// put stores the outcome of a match
+func (c *FitnessCache) put(key matchKey, res matchResult) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current[key] = res
+}
+
+// Len returns the number of stored matches
+func (c *FitnessCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.current) + len(c.previous)
+}
+
+// coeffsFingerprint returns a short hash of the coefficients, ignoring their name
+func coeffsFingerprint(coeffs evaluation.EvaluationCoefficients) string {
+	coeffs.Name = ""
+	data, err := json.Marshal(coeffs)
+	if err != nil {
+		panic(err)
+	}
+	h := fnv.New64a()
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
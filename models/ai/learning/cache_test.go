@@ -0,0 +1,61 @@
+package learning
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+func TestFitnessCacheSkipsElites(t *testing.T) {
+	openings := opening.KNOWN_OPENINGS[:2]
+	matchesPerModel := 2 * len(openings)
+	cache := NewFitnessCache()
+
+	elite := EvaluationModel{Coeffs: evaluation.V4Coeff}
+	first := []*EvaluationModel{&elite, {Coeffs: evaluation.V2Coeff}}
+	cache.NextGeneration()
+	if hits := evaluateModelsInParallel(first, evaluation.V1Coeff, 1, openings, 2, cache, nil); hits != 0 {
+		t.Fatalf("first generation: %d cache hits, want 0", hits)
+	}
+	fitness := elite.Fitness
+	wins, losses, draws := elite.Wins, elite.Losses, elite.Draws
+
+	// The elite is kept, the other model replaced
+	second := []*EvaluationModel{&elite, {Coeffs: evaluation.V3Coeff}}
+	cache.NextGeneration()
+	if hits := evaluateModelsInParallel(second, evaluation.V1Coeff, 1, openings, 2, cache, nil); hits != matchesPerModel {
+		t.Errorf("second generation: %d cache hits, want the %d matches of the elite", hits, matchesPerModel)
+	}
+	if elite.Fitness != fitness || elite.Wins != wins || elite.Losses != losses || elite.Draws != draws {
+		t.Errorf("elite fitness %v (%d/%d/%d) changed from %v (%d/%d/%d)",
+			elite.Fitness, elite.Wins, elite.Losses, elite.Draws, fitness, wins, losses, draws)
+	}
+}
+
+func TestFitnessCacheEvictsUnusedOutcomes(t *testing.T) {
+	cache := NewFitnessCache()
+	kept := matchKey{Model: "elite", Opening: "a"}
+	dropped := matchKey{Model: "mutated", Opening: "a"}
+
+	cache.NextGeneration()
+	cache.put(kept, matchResult{Win: true})
+	cache.put(dropped, matchResult{Loss: true})
+
+	// Only the elite plays again
+	cache.NextGeneration()
+	if _, ok := cache.get(kept); !ok {
+		t.Fatal("outcome of the previous generation lost")
+	}
+
+	cache.NextGeneration()
+	if _, ok := cache.get(dropped); ok {
+		t.Error("outcome unused for a generation still cached")
+	}
+	if res, ok := cache.get(kept); !ok || !res.Win {
+		t.Error("outcome looked up in the last generation dropped")
+	}
+	if n := cache.Len(); n != 1 {
+		t.Errorf("cache holds %d outcomes, want 1", n)
+	}
+}
@@ -2,6 +2,10 @@ package learning
 
 import (
 	"fmt"
+	"log/slog"
+	"math/rand"
+	"os"
+	"runtime"
 	"sync"
 
 	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
@@ -12,66 +16,89 @@ import (
 )
 
 // PlayMatchWithOpening plays a match between a model and a standard AI using a specific opening
-// This is the central match playing function used by evaluation
+// This is the central match playing function used by evaluation.
+// A game stopped by the watchdog, or whose opening cannot be played, is neither a win, a loss
+// nor a draw, its reason is returned.
 func PlayMatchWithOpening(
 	modelEval, standardEval evaluation.Evaluation,
 	op opening.Opening,
-	playerIndex int, maxDepth int8) (win, loss, draw bool, history []game.Position) {
+	playerIndex int, maxDepth int8) (win, loss, draw bool, history []game.Position, aborted game.AbortReason) {
+	return PlaySearcherMatch(
+		&evaluation.AlphaBetaSearcher{Depth: maxDepth, Eval: modelEval},
+		&evaluation.AlphaBetaSearcher{Depth: maxDepth, Eval: standardEval},
+		op, playerIndex)
+}
+
+// PlaySearcherMatch is PlayMatchWithOpening between two searchers, such as the baselines
+// evaluation.RandomPlayer and evaluation.GreedyPlayer
+func PlaySearcherMatch(
+	model, standard evaluation.Searcher,
+	op opening.Opening,
+	playerIndex int) (win, loss, draw bool, history []game.Position, aborted game.AbortReason) {
 	// Create a new game
 	g := game.NewGame("Black", "White")
-	var blackCount, whiteCount int
 	modelColor := game.Black
 	if playerIndex == 1 {
 		modelColor = game.White
 	}
 
-	// Apply opening moves
-	applyOpening(g, op)
-
-	for !game.IsGameFinished(g.Board) {
-		// Determine which evaluation to use
-		var currentEval evaluation.Evaluation
+	// Apply opening moves, a broken opening would play from a wrong position
+	if err := applyOpening(g, op); err != nil {
+		slog.Error("opening skipped", "opening", op.Name, "error", err)
+		return false, false, false, g.History, game.InvalidOpening
+	}
 
-		if g.CurrentPlayer.Color == modelColor {
-			currentEval = modelEval
-		} else {
-			currentEval = standardEval
+	// The game is played on a bitboard, the opening moves are the start of the history
+	bb := utils.BoardToBits(g.Board)
+	player := g.CurrentPlayer.Color
+	history = g.History
+	watchdog := game.NewWatchdog()
+	for {
+		if watchdog.Record(bb, player) {
+			slog.Warn("game aborted", "reason", watchdog.Reason, "opening", op.Name, "game", utils.TranscriptToAlgebraic(history))
+			return false, false, false, history, watchdog.Reason
 		}
 
-		// Check if current player has valid moves
-		validMoves := game.ValidMoves(g.Board, g.CurrentPlayer.Color)
-		if len(validMoves) > 0 {
-			// Get the best move using minimax search
-			pos, _ := evaluation.Solve(g.Board, g.CurrentPlayer.Color, maxDepth, currentEval)
-			if len(pos) == 0 || (len(pos) == 1 && pos[0].Row == -1 && pos[0].Col == -1) {
-				// No valid moves found, skip turn
-				fmt.Printf("No valid moves for %d (%d) game %s\n", g.CurrentPlayer.Color, modelColor, utils.PositionsToAlgebraic(g.History))
-				panic("No valid moves found for player")
-			}
-			g.ApplyMove(pos[0])
-		} else {
-			// Skip turn if no valid moves
-			g.CurrentPlayer = game.GetOtherPlayer(g.CurrentPlayer.Color)
+		finished, blackCanMove, whiteCanMove := game.GameStatusBitBoard(bb)
+		if finished {
+			break
+		}
+		if (player == game.Black && !blackCanMove) || (player == game.White && !whiteCanMove) {
+			history = append(history, game.PassMove)
+			player = game.GetOpponentColor(player)
+			continue
 		}
-	}
 
-	blackCount, whiteCount = game.CountPieces(g.Board)
+		// Determine which searcher plays
+		current := standard
+		if player == modelColor {
+			current = model
+		}
 
-	// Return result from model's perspective
-	if modelColor == game.Black {
-		if blackCount > whiteCount {
-			return true, false, false, g.History // Win
-		} else if blackCount < whiteCount {
-			return false, true, false, g.History // Loss
+		pos, _ := current.Search(utils.BitsToBoard(bb), player)
+		if len(pos) == 0 || (len(pos) == 1 && pos[0].Row == -1 && pos[0].Col == -1) {
+			// No valid moves found although the player has moves
+			slog.Error("no valid moves found", "player", player, "model", modelColor, "game", utils.TranscriptToAlgebraic(history))
+			panic("No valid moves found for player")
 		}
-		return false, false, true, g.History // Draw
-	} else {
-		if whiteCount > blackCount {
-			return true, false, false, g.History // Win
-		} else if whiteCount < blackCount {
-			return false, true, false, g.History // Loss
+		next, ok := game.GetNewBitBoardAfterMove(bb, pos[0], player)
+		if !ok {
+			slog.Error("search returned an illegal move", "move", utils.PositionToAlgebraic(pos[0]), "player", player, "model", modelColor, "game", utils.TranscriptToAlgebraic(history))
+			panic("Search returned an illegal move")
 		}
-		return false, false, true, g.History // Draw
+		bb = next
+		history = append(history, pos[0])
+		player = game.GetOpponentColor(player)
+	}
+
+	// Return result from model's perspective
+	switch game.WinnerBitBoard(bb) {
+	case modelColor:
+		return true, false, false, history, game.NotAborted // Win
+	case game.Empty:
+		return false, false, true, history, game.NotAborted // Draw
+	default:
+		return false, true, false, history, game.NotAborted // Loss
 	}
 }
 
@@ -79,6 +106,7 @@ func PlayMatchWithOpening(
 func createProgressBar(totalMatches int, description string) *progressbar.ProgressBar {
 	return progressbar.NewOptions(totalMatches,
 		progressbar.OptionSetDescription(description),
+		progressbar.OptionSetWriter(os.Stderr), // Keep stdout for logs
 		progressbar.OptionEnableColorCodes(true),
 		progressbar.OptionShowCount(),
 		progressbar.OptionShowIts(),
@@ -92,86 +120,199 @@ func createProgressBar(totalMatches int, description string) *progressbar.Progre
 	)
 }
 
+// selectOpenings returns up to numGames random known openings. With dedupe, openings
+// leading to the same position up to a symmetry of the board are played only once.
+func selectOpenings(numGames int, dedupe bool) []opening.Opening {
+	if !dedupe {
+		return opening.SelectRandomOpenings(numGames)
+	}
+	openings := DedupeOpenings(opening.KNOWN_OPENINGS)
+	rand.Shuffle(len(openings), func(i, j int) {
+		openings[i], openings[j] = openings[j], openings[i]
+	})
+	return openings[:min(numGames, len(openings))]
+}
+
+// DedupeOpenings returns the openings whose final position, up to a rotation or a
+// reflection of the board, is not the one of an earlier opening. Symmetric positions
+// play the same games, so only the first is kept. Openings that do not replay are kept.
+func DedupeOpenings(openings []opening.Opening) []opening.Opening {
+	seen := make(map[game.BoardFingerprint]bool, len(openings))
+	var kept []opening.Opening
+	for _, op := range openings {
+		g := game.NewGame("Black", "White")
+		if _, err := utils.ApplyTranscript(g, op.Transcript); err == nil {
+			key := game.Fingerprint(utils.BoardToBits(g.Board))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		kept = append(kept, op)
+	}
+	return kept
+}
+
 // applyOpening applies a predefined opening to a game
-func applyOpening(g *game.Game, op opening.Opening) {
-	// Apply the moves from the opening transcript
-	for _, move := range utils.AlgebraicToPositions(op.Transcript) {
-		g.ApplyMove(move)
+func applyOpening(g *game.Game, op opening.Opening) error {
+	if _, err := utils.ApplyTranscript(g, op.Transcript); err != nil {
+		return fmt.Errorf("opening %s: %w", op.Name, err)
+	}
+	return nil
+}
+
+// runPool calls play with every index in [0, count) from a pool of workers goroutines,
+// runtime.NumCPU() when workers <= 0, and returns once all calls have returned
+func runPool(count, workers int, play func(i int)) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	items := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, count) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				play(i)
+			}
+		}()
+	}
+	for i := range count {
+		items <- i
+	}
+	close(items)
+	wg.Wait()
+}
+
+// matchItem is a match of a population evaluation: a model plays an opening with a color
+type matchItem struct {
+	model   int
+	opening opening.Opening
+	player  int
+}
+
+// matchItems lists the matches of every model on every opening with both colors
+func matchItems(modelCount int, openings []opening.Opening) []matchItem {
+	items := make([]matchItem, 0, modelCount*len(openings)*2)
+	for m := range modelCount {
+		for _, op := range openings {
+			for playerIdx := range 2 {
+				items = append(items, matchItem{m, op, playerIdx})
+			}
+		}
 	}
+	return items
 }
 
-// evaluateModelsInParallel evaluates multiple models in parallel
+// evaluateModelsInParallel evaluates models by playing their matches on a pool of workers
+// goroutines, runtime.NumCPU() when workers <= 0. Each match writes its own result, the
+// statistics of the models are added up in match order once all are played, so they do not
+// depend on the scheduling.
+// Matches found in the cache are not replayed, a nil cache disables this.
+// onMatch, when not nil, receives each match once its result is known, one call at a time,
+// the first call giving the total number of matches.
+// It returns the number of matches taken from the cache.
 func evaluateModelsInParallel(
 	models []*EvaluationModel,
 	baseModel evaluation.EvaluationCoefficients,
 	maxDepth int8,
-	numGames int) {
+	openings []opening.Opening,
+	workers int,
+	cache *FitnessCache,
+	onMatch func(rec MatchRecord, totalMatches int)) (hits int) {
 
-	var wg sync.WaitGroup
 	var mutex sync.Mutex
 
-	// Calculate total number of matches to play (all models * selected openings * 2 player positions)
-	openingCount := min(numGames, len(opening.KNOWN_OPENINGS))
-	selectedOpenings := opening.SelectRandomOpenings(openingCount)
-	totalMatches := len(models) * openingCount * 2
+	// All models * openings * 2 player positions
+	items := matchItems(len(models), openings)
+	results := make([]matchResult, len(items))
 
 	// Create a single progress bar for all matches
-	bar := createProgressBar(totalMatches, "Evaluating models")
+	bar := createProgressBar(len(items), "Evaluating models")
 	bar.RenderBlank()
 
 	standardEval := evaluation.NewMixedEvaluation(baseModel)
+	opponent := coeffsFingerprint(baseModel)
+	evals := make([]evaluation.Evaluation, len(models))
+	fingerprints := make([]string, len(models))
+	for i, model := range models {
+		evals[i] = evaluation.NewMixedEvaluation(model.Coeffs)
+		fingerprints[i] = coeffsFingerprint(model.Coeffs)
+	}
 
-	// Launch goroutines for each model
-	for i := range models {
-		wg.Add(1)
-		go func(modelIdx int, model *EvaluationModel) {
-			defer wg.Done()
+	runPool(len(items), workers, func(i int) {
+		item := items[i]
+		key := matchKey{fingerprints[item.model], opponent, maxDepth, item.opening.Name, item.player}
+		res, cached := cache.get(key)
+		if !cached {
+			// Play the match
+			win, loss, draw, history, aborted := PlayMatchWithOpening(
+				evals[item.model], standardEval, item.opening, item.player, maxDepth)
+			res = matchResult{win, loss, draw, aborted, utils.TranscriptToAlgebraic(history)}
+			cache.put(key, res)
+		}
+		results[i] = res
 
-			// Reset statistics
-			model.Wins = 0
-			model.Losses = 0
-			model.Draws = 0
-			model.BlackGames = make(map[string]string, 0)
-			model.WhiteGames = make(map[string]string, 0)
-			evalFunc := evaluation.NewMixedEvaluation(model.Coeffs)
-
-			// Play games against standard AI with selected openings
-			for _, op := range selectedOpenings {
-				for playerIdx := range 2 {
-
-					// Play the match
-					win, loss, draw, history := PlayMatchWithOpening(
-						evalFunc, standardEval, op, playerIdx, maxDepth)
-
-					// Store the game history
-					historyString := utils.PositionsToAlgebraic(history)
-					if playerIdx == 0 {
-						model.BlackGames[op.Name] = historyString
-					} else {
-						model.WhiteGames[op.Name] = historyString
-					}
-
-					// Record game result
-					if win {
-						model.Wins++
-					} else if loss {
-						model.Losses++
-					} else if draw {
-						model.Draws++
-					}
-					// Update progress bar
-					mutex.Lock()
-					bar.Add(1)
-					mutex.Unlock()
-				}
-			}
+		// Update progress bar
+		mutex.Lock()
+		if cached {
+			hits++
+		}
+		bar.Add(1)
+		if onMatch != nil {
+			onMatch(MatchRecord{
+				Model:   item.model,
+				Opening: item.opening.Name,
+				Player:  item.player,
+				Win:     res.Win,
+				Loss:    res.Loss,
+				Draw:    res.Draw,
+				Aborted: res.Aborted,
+				Cached:  cached,
+				History: res.History,
+			}, len(items))
+		}
+		mutex.Unlock()
+	})
+	fmt.Fprintln(os.Stderr) // Add newline after progress bar completes
+
+	// Reset statistics
+	for _, model := range models {
+		model.Wins = 0
+		model.Losses = 0
+		model.Draws = 0
+		model.Aborted = 0
+		model.BlackGames = make(map[string]string, 0)
+		model.WhiteGames = make(map[string]string, 0)
+	}
+
+	for i, item := range items {
+		model := models[item.model]
+		res := results[i]
 
-			// Calculate fitness score
-			model.Fitness = float64(model.Wins) + float64(model.Draws)*0.5
+		// Store the game history
+		if item.player == 0 {
+			model.BlackGames[item.opening.Name] = res.History
+		} else {
+			model.WhiteGames[item.opening.Name] = res.History
+		}
 
-		}(i, models[i])
+		// Record game result
+		if res.Win {
+			model.Wins++
+		} else if res.Loss {
+			model.Losses++
+		} else if res.Draw {
+			model.Draws++
+		} else if res.Aborted != game.NotAborted {
+			model.Aborted++
+		}
 	}
 
-	wg.Wait()
-	fmt.Println() // Add newline after progress bar completes
+	// Calculate fitness scores
+	for _, model := range models {
+		model.Fitness = float64(model.Wins) + float64(model.Draws)*0.5
+	}
+	return hits
 }
@@ -0,0 +1,58 @@
+package learning
+
+import (
+	"maps"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+func TestRunPool(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 50} {
+		calls := make([]atomic.Int32, 20)
+		runPool(len(calls), workers, func(i int) {
+			calls[i].Add(1)
+		})
+		for i := range calls {
+			if n := calls[i].Load(); n != 1 {
+				t.Errorf("%d workers: index %d played %d times, want 1", workers, i, n)
+			}
+		}
+	}
+	runPool(0, 4, func(int) { t.Error("call with no items") })
+}
+
+func TestEvaluationIndependentOfWorkers(t *testing.T) {
+	openings := opening.KNOWN_OPENINGS[:3]
+	population := func() []*EvaluationModel {
+		return []*EvaluationModel{
+			{Coeffs: evaluation.V1Coeff},
+			{Coeffs: evaluation.V2Coeff},
+			{Coeffs: evaluation.V3Coeff},
+		}
+	}
+
+	sequential := population()
+	evaluateModelsInParallel(sequential, evaluation.V4Coeff, 2, openings, 1, nil, nil)
+	for _, workers := range []int{2, 8} {
+		parallel := population()
+		evaluateModelsInParallel(parallel, evaluation.V4Coeff, 2, openings, workers, nil, nil)
+		for i := range sequential {
+			s, p := sequential[i], parallel[i]
+			if s.Fitness != p.Fitness || s.Wins != p.Wins || s.Losses != p.Losses || s.Draws != p.Draws {
+				t.Errorf("%d workers: model %d fitness %v (%d/%d/%d), sequential %v (%d/%d/%d)",
+					workers, i, p.Fitness, p.Wins, p.Losses, p.Draws, s.Fitness, s.Wins, s.Losses, s.Draws)
+			}
+			if !maps.Equal(s.BlackGames, p.BlackGames) || !maps.Equal(s.WhiteGames, p.WhiteGames) {
+				t.Errorf("%d workers: model %d played other games", workers, i)
+			}
+		}
+	}
+	for i, model := range sequential {
+		if played := model.Wins + model.Losses + model.Draws + model.Aborted; played != 2*len(openings) {
+			t.Errorf("model %d played %d games, want %d", i, played, 2*len(openings))
+		}
+	}
+}
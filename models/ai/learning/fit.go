@@ -0,0 +1,248 @@
+package learning
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// fitFeatures is the number of fitted components: material, mobility, corners, parity,
+// stability and frontier, the components of EvaluationCoefficients.ToVector
+const fitFeatures = 6
+
+// Default fitting parameters
+const (
+	DefaultFitRidge      = 1.0 // Weight of the L2 penalty keeping the coefficients small
+	DefaultFitMinSamples = 100 // Phases with fewer samples keep the base coefficients
+)
+
+// LabeledPosition is a position with its target score, positive for White
+type LabeledPosition struct {
+	Board game.BitBoard
+	Score int16
+}
+
+// FitOptions tunes FitCoefficients
+type FitOptions struct {
+	// Ridge is the weight of the L2 penalty on the coefficients, DefaultFitRidge when 0
+	Ridge float64
+	// MinSamples is the number of samples a phase needs to be fitted, DefaultFitMinSamples when 0
+	MinSamples int
+	// Base gives the coefficients of the phases without enough samples. Its threat and
+	// tempo coefficients are not fitted and are dropped.
+	Base evaluation.EvaluationCoefficients
+	// Name of the fitted coefficients
+	Name string
+}
+
+// PhaseFit describes the fit of a game phase
+type PhaseFit struct {
+	Phase    int     `json:"phase"`
+	Samples  int     `json:"samples"`
+	Fitted   bool    `json:"fitted"`    // False when the phase kept the base coefficients
+	RMSE     float64 `json:"rmse"`      // Error of the fitted coefficients on the phase samples
+	BaseRMSE float64 `json:"base_rmse"` // Error of the base coefficients, for comparison
+}
+
+// FitReport describes the result of FitCoefficients
+type FitReport struct {
+	Samples int        `json:"samples"` // Samples used, finished games and won positions left out
+	Phases  []PhaseFit `json:"phases"`
+}
+
+// FitCoefficients fits, phase by phase, the coefficients whose weighted sum of the raw
+// component scores is the closest to the target scores in the least squares sense. The
+// normal equations are solved with a ridge penalty, then the coefficients are rounded and
+// brought within the bounds of Validate. Positions of finished games and scores outside
+// ]MIN_EVAL, MAX_EVAL[ are left out, the evaluation not being linear there.
+func FitCoefficients(samples []LabeledPosition, opts FitOptions) (evaluation.EvaluationCoefficients, FitReport) {
+	ridge := opts.Ridge
+	if ridge <= 0 {
+		ridge = DefaultFitRidge
+	}
+	minSamples := opts.MinSamples
+	if minSamples <= 0 {
+		minSamples = DefaultFitMinSamples
+	}
+
+	// Bucket the raw component scores by phase
+	phases := len(evaluation.DefaultPhaseBoundaries) + 1
+	features := make([][][fitFeatures]float64, phases)
+	targets := make([][]float64, phases)
+	components := evaluation.NewMixedEvaluation(opts.Base)
+	report := FitReport{}
+	for _, sample := range samples {
+		if sample.Score <= evaluation.MIN_EVAL || sample.Score >= evaluation.MAX_EVAL {
+			continue
+		}
+		pec := evaluation.PrecomputeEvaluationBitBoard(sample.Board)
+		if pec.IsGameOver || pec.WhitePieces == 0 || pec.BlackPieces == 0 {
+			continue
+		}
+		x := [fitFeatures]float64{
+			float64(components.MaterialEvaluation.PECEvaluate(sample.Board, pec)),
+			float64(components.MobilityEvaluation.PECEvaluate(sample.Board, pec)),
+			float64(components.CornersEvaluation.PECEvaluate(sample.Board, pec)),
+			float64(components.ParityEvaluation.PECEvaluate(sample.Board, pec)),
+			float64(components.StabilityEvaluation.PECEvaluate(sample.Board, pec)),
+			float64(components.FrontierEvaluation.PECEvaluate(sample.Board, pec)),
+		}
+		features[pec.Phase] = append(features[pec.Phase], x)
+		targets[pec.Phase] = append(targets[pec.Phase], float64(sample.Score))
+		report.Samples++
+	}
+
+	// Start from the base coefficients, with the default phases. Base slices of another
+	// number of phases are replaced by zeros.
+	fitted := opts.Base
+	fitted.PhaseBoundaries = nil
+	fitted.Name = opts.Name
+	coeffs := [fitFeatures][]int16{}
+	for i, base := range [fitFeatures][]int16{
+		opts.Base.MaterialCoeffs, opts.Base.MobilityCoeffs, opts.Base.CornersCoeffs,
+		opts.Base.ParityCoeffs, opts.Base.StabilityCoeffs, opts.Base.FrontierCoeffs,
+	} {
+		coeffs[i] = make([]int16, phases)
+		if len(base) == phases {
+			copy(coeffs[i], base)
+		}
+	}
+	// The fitted components explain the whole score, the optional ones are dropped
+	fitted.ThreatCoeffs = nil
+	fitted.TempoCoeffs = nil
+	fitted.EdgeCoeffs = nil
+
+	for phase := range phases {
+		fit := PhaseFit{Phase: phase, Samples: len(targets[phase])}
+		base := phaseWeights(coeffs, phase)
+		fit.BaseRMSE = rmse(features[phase], targets[phase], base)
+		if fit.Samples >= minSamples {
+			if w, ok := solveRidge(features[phase], targets[phase], ridge); ok {
+				for i := range coeffs {
+					coeffs[i][phase] = int16(math.Max(math.Min(math.Round(w[i]), evaluation.MaxCoeff), evaluation.MinCoeff))
+				}
+				fit.Fitted = true
+			}
+		}
+		report.Phases = append(report.Phases, fit)
+	}
+
+	fitted.MaterialCoeffs = coeffs[0]
+	fitted.MobilityCoeffs = coeffs[1]
+	fitted.CornersCoeffs = coeffs[2]
+	fitted.ParityCoeffs = coeffs[3]
+	fitted.StabilityCoeffs = coeffs[4]
+	fitted.FrontierCoeffs = coeffs[5]
+	fitted = fitted.Clamp()
+
+	// Errors of the coefficients actually returned, after rounding and clamping
+	final := [fitFeatures][]int16{
+		fitted.MaterialCoeffs, fitted.MobilityCoeffs, fitted.CornersCoeffs,
+		fitted.ParityCoeffs, fitted.StabilityCoeffs, fitted.FrontierCoeffs,
+	}
+	for phase := range report.Phases {
+		report.Phases[phase].RMSE = rmse(features[phase], targets[phase], phaseWeights(final, phase))
+	}
+	return fitted, report
+}
+
+// phaseWeights returns the coefficients of a phase as floats
+func phaseWeights(coeffs [fitFeatures][]int16, phase int) (w [fitFeatures]float64) {
+	for i := range coeffs {
+		w[i] = float64(coeffs[i][phase])
+	}
+	return w
+}
+
+// rmse returns the root mean square error of the linear prediction w·x, 0 without samples
+func rmse(features [][fitFeatures]float64, targets []float64, w [fitFeatures]float64) float64 {
+	if len(targets) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for n, x := range features {
+		prediction := 0.0
+		for i := range x {
+			prediction += w[i] * x[i]
+		}
+		sum += (prediction - targets[n]) * (prediction - targets[n])
+	}
+	return math.Sqrt(sum / float64(len(targets)))
+}
+
+// solveRidge solves (XᵀX + ridge·I) w = Xᵀy by Gaussian elimination with partial pivoting,
+// false when the system is singular
+func solveRidge(features [][fitFeatures]float64, targets []float64, ridge float64) ([fitFeatures]float64, bool) {
+	var a [fitFeatures][fitFeatures + 1]float64
+	for n, x := range features {
+		for i := range x {
+			for j := range x {
+				a[i][j] += x[i] * x[j]
+			}
+			a[i][fitFeatures] += x[i] * targets[n]
+		}
+	}
+	for i := range fitFeatures {
+		a[i][i] += ridge
+	}
+
+	for col := range fitFeatures {
+		pivot := col
+		for row := col + 1; row < fitFeatures; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return [fitFeatures]float64{}, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := range fitFeatures {
+			if row == col {
+				continue
+			}
+			factor := a[row][col] / a[col][col]
+			for k := col; k <= fitFeatures; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+		}
+	}
+
+	var w [fitFeatures]float64
+	for i := range w {
+		w[i] = a[i][fitFeatures] / a[i][i]
+	}
+	return w, true
+}
+
+// LoadDataset reads the positions and scores of a JSON lines dataset written by cmd/selfplay
+func LoadDataset(filename string) ([]LabeledPosition, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var samples []LabeledPosition
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		var record struct {
+			Black uint64 `json:"black"`
+			White uint64 `json:"white"`
+			Score int16  `json:"score"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, line, err)
+		}
+		samples = append(samples, LabeledPosition{
+			Board: game.BitBoard{BlackPieces: record.Black, WhitePieces: record.White},
+			Score: record.Score,
+		})
+	}
+	return samples, scanner.Err()
+}
@@ -0,0 +1,148 @@
+package learning
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// syntheticSamples returns random positions scored by the linear combination of the raw
+// component scores with the weights of a phase, weights[i][phase] for component i
+func syntheticSamples(t *testing.T, n int, weights [fitFeatures][]int16) []LabeledPosition {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	components := evaluation.NewMixedEvaluation(evaluation.V4Coeff)
+	var samples []LabeledPosition
+	for len(samples) < n {
+		g, err := game.RandomReachableBoard(rng, 1+rng.Intn(58))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bb := utils.BoardToBits(g.Board)
+		pec := evaluation.PrecomputeEvaluationBitBoard(bb)
+		if pec.IsGameOver || pec.WhitePieces == 0 || pec.BlackPieces == 0 {
+			continue
+		}
+		raw := [fitFeatures]int16{
+			components.MaterialEvaluation.PECEvaluate(bb, pec),
+			components.MobilityEvaluation.PECEvaluate(bb, pec),
+			components.CornersEvaluation.PECEvaluate(bb, pec),
+			components.ParityEvaluation.PECEvaluate(bb, pec),
+			components.StabilityEvaluation.PECEvaluate(bb, pec),
+			components.FrontierEvaluation.PECEvaluate(bb, pec),
+		}
+		score := 0
+		for i := range raw {
+			score += int(weights[i][pec.Phase]) * int(raw[i])
+		}
+		samples = append(samples, LabeledPosition{Board: bb, Score: int16(score)})
+	}
+	return samples
+}
+
+func TestFitCoefficientsRecoversWeights(t *testing.T) {
+	weights := [fitFeatures][]int16{
+		{1, 2, 3, 4, 5, 6},
+		{6, 5, 4, 3, 2, 1},
+		{9, 9, 8, 8, 7, 7},
+		{0, 1, 0, 1, 0, 1},
+		{2, 2, 2, 3, 3, 3},
+		{3, 3, 2, 2, 1, 1},
+	}
+	samples := syntheticSamples(t, 4000, weights)
+	fitted, report := FitCoefficients(samples, FitOptions{Ridge: 1e-6, Base: evaluation.V4Coeff, Name: "fitted"})
+
+	if fitted.Name != "fitted" {
+		t.Errorf("name %q, want fitted", fitted.Name)
+	}
+	if err := fitted.Validate(); err != nil {
+		t.Errorf("fitted coefficients invalid: %v", err)
+	}
+	if fitted.ThreatCoeffs != nil || fitted.TempoCoeffs != nil || fitted.EdgeCoeffs != nil {
+		t.Error("coefficients of the components not fitted kept")
+	}
+	if report.Samples != len(samples) {
+		t.Errorf("%d samples used, want %d", report.Samples, len(samples))
+	}
+
+	got := [fitFeatures][]int16{
+		fitted.MaterialCoeffs, fitted.MobilityCoeffs, fitted.CornersCoeffs,
+		fitted.ParityCoeffs, fitted.StabilityCoeffs, fitted.FrontierCoeffs,
+	}
+	for _, fit := range report.Phases {
+		if !fit.Fitted {
+			t.Errorf("phase %d with %d samples not fitted", fit.Phase, fit.Samples)
+			continue
+		}
+		// The targets are exactly linear: the fit reproduces them
+		if fit.RMSE > 0.5 || fit.RMSE > fit.BaseRMSE {
+			t.Errorf("phase %d: error %v, base error %v", fit.Phase, fit.RMSE, fit.BaseRMSE)
+		}
+	}
+	// Midgame positions vary in every component, each weight is found
+	for _, phase := range []int{2, 3} {
+		for i := range got {
+			if got[i][phase] != weights[i][phase] {
+				t.Errorf("phase %d component %d: weight %d, want %d", phase, i, got[i][phase], weights[i][phase])
+			}
+		}
+	}
+}
+
+func TestFitCoefficientsKeepsBaseOfSparsePhases(t *testing.T) {
+	weights := [fitFeatures][]int16{}
+	for i := range weights {
+		weights[i] = []int16{1, 1, 1, 1, 1, 1}
+	}
+	samples := syntheticSamples(t, 300, weights)
+	// Finished games and decided scores are left out
+	samples = append(samples,
+		LabeledPosition{Board: game.BitBoard{BlackPieces: 1}, Score: 0},
+		LabeledPosition{Board: samples[0].Board, Score: evaluation.MAX_EVAL})
+
+	fitted, report := FitCoefficients(samples, FitOptions{Base: evaluation.V4Coeff, MinSamples: 1000})
+	if report.Samples != 300 {
+		t.Errorf("%d samples used, want 300", report.Samples)
+	}
+	for _, fit := range report.Phases {
+		if fit.Fitted {
+			t.Errorf("phase %d with %d samples fitted, below the minimum", fit.Phase, fit.Samples)
+		}
+	}
+	if !slices.Equal(fitted.MaterialCoeffs, evaluation.V4Coeff.MaterialCoeffs) ||
+		!slices.Equal(fitted.FrontierCoeffs, evaluation.V4Coeff.FrontierCoeffs) {
+		t.Errorf("phases without enough samples changed the base coefficients: %+v", fitted)
+	}
+}
+
+func TestLoadDataset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dataset.jsonl")
+	data := "{\"black\": 1, \"white\": 2, \"score\": -30}\n{\"black\": 4, \"white\": 8, \"score\": 12}\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	samples, err := LoadDataset(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []LabeledPosition{
+		{Board: game.BitBoard{BlackPieces: 1, WhitePieces: 2}, Score: -30},
+		{Board: game.BitBoard{BlackPieces: 4, WhitePieces: 8}, Score: 12},
+	}
+	if !slices.Equal(samples, want) {
+		t.Errorf("samples %+v, want %+v", samples, want)
+	}
+
+	if err := os.WriteFile(path, []byte(data+"{\"black\": \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDataset(path); err == nil {
+		t.Error("invalid line: no error")
+	}
+}
@@ -26,22 +26,20 @@ func (t *Trainer) tournamentSelect(tournamentSize int) EvaluationModel {
 func (t *Trainer) crossover(parent1, parent2 EvaluationModel) EvaluationModel {
 	child := EvaluationModel{
 		Coeffs: evaluation.EvaluationCoefficients{
-			MaterialCoeffs:  make([]int16, 6),
-			MobilityCoeffs:  make([]int16, 6),
-			CornersCoeffs:   make([]int16, 6),
-			ParityCoeffs:    make([]int16, 6),
-			StabilityCoeffs: make([]int16, 6),
-			FrontierCoeffs:  make([]int16, 6),
+			PhaseBoundaries: parent1.Coeffs.PhaseBoundaries,
 		},
 	}
 
-	// Updated crossover patterns for length 6
+	// Crossover patterns, repeated over the phases of the parents
 	materialPattern := []bool{true, false, true, false, true, false}
 	mobilityPattern := []bool{false, true, false, true, false, true}
 	cornersPattern := []bool{true, true, false, false, true, false}
 	parityPattern := []bool{false, false, true, true, false, true}
 	stabilityPattern := []bool{true, false, true, false, true, false}
 	frontierPattern := []bool{false, true, false, true, false, true}
+	threatPattern := []bool{true, false, false, true, true, false}
+	tempoPattern := []bool{false, true, true, false, false, true}
+	edgePattern := []bool{true, true, false, true, false, false}
 
 	// Apply crossover patterns
 	child.Coeffs.MaterialCoeffs = crossoverCoefficients(
@@ -56,16 +54,35 @@ func (t *Trainer) crossover(parent1, parent2 EvaluationModel) EvaluationModel {
 		parent1.Coeffs.StabilityCoeffs, parent2.Coeffs.StabilityCoeffs, stabilityPattern)
 	child.Coeffs.FrontierCoeffs = crossoverCoefficients(
 		parent1.Coeffs.FrontierCoeffs, parent2.Coeffs.FrontierCoeffs, frontierPattern)
+	child.Coeffs.ThreatCoeffs = crossoverCoefficients(
+		threatCoeffs(parent1.Coeffs), threatCoeffs(parent2.Coeffs), threatPattern)
+	child.Coeffs.TempoCoeffs = crossoverCoefficients(
+		tempoCoeffs(parent1.Coeffs), tempoCoeffs(parent2.Coeffs), tempoPattern)
+	child.Coeffs.EdgeCoeffs = crossoverCoefficients(
+		edgeCoeffs(parent1.Coeffs), edgeCoeffs(parent2.Coeffs), edgePattern)
 
 	return child
 }
 
-// mutateModel applies random mutations to a model
+// mutateModel applies random mutations to a model, returning the model unchanged when
+// the mutation leaves it with invalid phases
 func (t *Trainer) mutateModel(model EvaluationModel) EvaluationModel {
 	mutated := model
 
 	// Use the mutation package for mutation
 	mutated.Coeffs = MutateCoefficients(model.Coeffs)
+	if t.MutatePhaseBoundaries {
+		mutated.Coeffs.PhaseBoundaries = MutatePhaseBoundaries(model.Coeffs.Boundaries())
+	}
+	// Clamp only fixes the values, a mutation breaking the phases is dropped
+	if err := mutated.Coeffs.ValidatePhases(); err != nil {
+		t.logger().Warn("mutated model has invalid phases, keeping the original", "err", err)
+		return model
+	}
+	if err := mutated.Coeffs.Validate(); err != nil {
+		t.logger().Warn("mutated model out of bounds, clamping", "err", err)
+		mutated.Coeffs = mutated.Coeffs.Clamp()
+	}
 
 	// Give the mutated model a name for tracking
 	if mutated.Coeffs.Name == "" {
@@ -0,0 +1,142 @@
+package learning
+
+import (
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+)
+
+func TestMutateModelRejectsInvalidPhases(t *testing.T) {
+	trainer := &Trainer{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	coeffs := evaluation.V4Coeff
+	// One phase short, which Clamp cannot fix
+	coeffs.FrontierCoeffs = coeffs.FrontierCoeffs[:5]
+	model := EvaluationModel{Coeffs: coeffs}
+
+	for range 20 {
+		mutated := trainer.mutateModel(model)
+		if !slices.Equal(mutated.Coeffs.MaterialCoeffs, model.Coeffs.MaterialCoeffs) ||
+			!slices.Equal(mutated.Coeffs.FrontierCoeffs, model.Coeffs.FrontierCoeffs) {
+			t.Fatalf("mutation of a model with invalid phases kept: %+v", mutated.Coeffs)
+		}
+	}
+}
+
+func TestMutateModelStaysValid(t *testing.T) {
+	trainer := &Trainer{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), MutatePhaseBoundaries: true}
+	model := EvaluationModel{Coeffs: evaluation.V4Coeff}
+	for range 100 {
+		model = trainer.mutateModel(model)
+		if err := model.Coeffs.Validate(); err != nil {
+			t.Fatalf("mutated model is invalid: %v", err)
+		}
+	}
+}
+
+func TestMutatePhaseBoundaries(t *testing.T) {
+	boundaries := slices.Clone(evaluation.DefaultPhaseBoundaries)
+	changed := false
+	for range 200 {
+		mutated := MutatePhaseBoundaries(boundaries)
+		if len(mutated) != len(boundaries) {
+			t.Fatalf("%d boundaries after mutation, want %d", len(mutated), len(boundaries))
+		}
+		for i, b := range mutated {
+			if d := int(b) - int(boundaries[i]); d < -PhaseBoundaryDeltaMax || d > PhaseBoundaryDeltaMax {
+				t.Errorf("boundary %d moved from %d to %d, more than %d", i, boundaries[i], b, PhaseBoundaryDeltaMax)
+			}
+			if i > 0 && b <= mutated[i-1] {
+				t.Errorf("boundaries %v are not increasing", mutated)
+			}
+		}
+		changed = changed || !slices.Equal(mutated, boundaries)
+	}
+	if !changed {
+		t.Error("no boundary ever moved")
+	}
+	if !slices.Equal(boundaries, evaluation.DefaultPhaseBoundaries) {
+		t.Errorf("mutation changed its argument to %v", boundaries)
+	}
+}
+
+func TestMutateModelKeepsBoundariesByDefault(t *testing.T) {
+	trainer := &Trainer{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	model := EvaluationModel{Coeffs: evaluation.V4Coeff}
+	want := model.Coeffs.Boundaries()
+	for range 50 {
+		if got := trainer.mutateModel(model).Coeffs.Boundaries(); !slices.Equal(got, want) {
+			t.Fatalf("boundaries mutated to %v without MutatePhaseBoundaries", got)
+		}
+	}
+}
+
+func TestEdgeCoeffsSurviveTraining(t *testing.T) {
+	trainer := &Trainer{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	withEdge := evaluation.V4Coeff
+	withEdge.EdgeCoeffs = []int16{10, 20, 30, 40, 50, 60}
+	parent1 := EvaluationModel{Coeffs: withEdge}
+	parent2 := EvaluationModel{Coeffs: evaluation.V4Coeff} // Predates the edge term
+
+	child := trainer.crossover(parent1, parent2)
+	if len(child.Coeffs.EdgeCoeffs) != len(withEdge.EdgeCoeffs) {
+		t.Fatalf("child edge coefficients %v, want %d phases", child.Coeffs.EdgeCoeffs, len(withEdge.EdgeCoeffs))
+	}
+	for i, c := range child.Coeffs.EdgeCoeffs {
+		if c != withEdge.EdgeCoeffs[i] && c != 0 {
+			t.Errorf("phase %d: child edge coefficient %d from neither parent", i, c)
+		}
+	}
+	if slices.Equal(child.Coeffs.EdgeCoeffs, make([]int16, len(withEdge.EdgeCoeffs))) {
+		t.Error("child lost the edge term of its parent")
+	}
+
+	if mutated := MutateCoefficients(withEdge); len(mutated.EdgeCoeffs) != len(withEdge.EdgeCoeffs) {
+		t.Errorf("mutated edge coefficients %v", mutated.EdgeCoeffs)
+	}
+	for range 20 {
+		diverse := CreateDiverseModel(parent1).Coeffs.EdgeCoeffs
+		for i, c := range diverse {
+			if low, high := int16(float64(withEdge.EdgeCoeffs[i])*0.8), int16(float64(withEdge.EdgeCoeffs[i])*1.2); c < low || c > high {
+				t.Fatalf("phase %d: diverse edge coefficient %d out of [%d, %d]", i, c, low, high)
+			}
+		}
+	}
+}
+
+func TestTrainingKeepsPhaseCount(t *testing.T) {
+	trainer := &Trainer{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	// Four phases instead of the default six
+	coeffs := evaluation.EvaluationCoefficients{
+		MaterialCoeffs:  []int16{10, 20, 30, 40},
+		MobilityCoeffs:  []int16{40, 30, 20, 10},
+		CornersCoeffs:   []int16{50, 50, 50, 50},
+		ParityCoeffs:    []int16{5, 10, 15, 20},
+		StabilityCoeffs: []int16{10, 30, 50, 70},
+		FrontierCoeffs:  []int16{20, 20, 10, 10},
+		PhaseBoundaries: []int16{15, 30, 45},
+	}
+	model := EvaluationModel{Coeffs: coeffs}
+	phases := len(coeffs.PhaseBoundaries) + 1
+
+	check := func(name string, c evaluation.EvaluationCoefficients) {
+		t.Helper()
+		for component, values := range map[string][]int16{
+			"material": c.MaterialCoeffs, "mobility": c.MobilityCoeffs, "corners": c.CornersCoeffs,
+			"parity": c.ParityCoeffs, "stability": c.StabilityCoeffs, "frontier": c.FrontierCoeffs,
+			"threat": c.ThreatCoeffs, "tempo": c.TempoCoeffs, "edge": c.EdgeCoeffs,
+		} {
+			if len(values) != phases {
+				t.Errorf("%s: %s has %d phases, want %d", name, component, len(values), phases)
+			}
+		}
+		if err := c.Validate(); err != nil {
+			t.Errorf("%s: invalid coefficients: %v", name, err)
+		}
+	}
+	check("crossover", trainer.crossover(model, model).Coeffs)
+	check("mutation", trainer.mutateModel(model).Coeffs)
+	check("diverse", CreateDiverseModel(model).Coeffs)
+}
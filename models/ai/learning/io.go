@@ -7,29 +7,17 @@ import (
 	"time"
 )
 
-func (t *Trainer) createModelDirectory() error {
-	// Create a directory for models if it doesn't exist
-	if _, err := os.Stat("training"); os.IsNotExist(err) {
-		return os.Mkdir("training", 0755)
+// store returns the artifact store, training/<name> when none is set
+func (t *Trainer) store() ArtifactStore {
+	if t.Store == nil {
+		t.Store = NewRunStore(DefaultRunRoot, t.Name, false)
 	}
-
-	// Create a subdirectory for the specific model
-	subdir := fmt.Sprintf("training/%s", t.Name)
-	if _, err := os.Stat(subdir); os.IsNotExist(err) {
-		return os.Mkdir(subdir, 0755)
-	}
-
-	return nil
+	return t.Store
 }
 
-// SaveModel saves a model to a JSON file
+// SaveModel saves a model to a JSON file of the artifact store
 func (t *Trainer) SaveModel(filename string, model EvaluationModel) error {
-	data, err := json.MarshalIndent(model, "", "  ")
-	if err != nil {
-		return err
-	}
-	filePath := fmt.Sprintf("training/%s/%s", t.Name, filename)
-	return os.WriteFile(filePath, data, 0644)
+	return t.store().SaveModel(filename, model)
 }
 
 // LoadModel loads a model from a JSON file
@@ -39,32 +27,36 @@ func (t *Trainer) LoadModel(filename string) (EvaluationModel, error) {
 	if err != nil {
 		return model, err
 	}
-	err = json.Unmarshal(data, &model)
-	return model, err
+	if err = json.Unmarshal(data, &model); err != nil {
+		return model, err
+	}
+	if err = model.Coeffs.Validate(); err != nil {
+		return model, fmt.Errorf("invalid model %s: %w", filename, err)
+	}
+	return model, nil
 }
 
-// SaveModelToFile is a generic helper method to save structs to JSON files
+// SaveModelToFile is a generic helper method to save structs to JSON files of the artifact store
 func (t *Trainer) SaveModelToFile(filename string, data interface{}) error {
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return err
-	}
-	filePath := fmt.Sprintf("training/%s/%s", t.Name, filename)
-	return os.WriteFile(filePath, jsonData, 0644)
+	return t.store().SaveCheckpoint(filename, data)
 }
 
 // SaveGenerationStats saves statistics about the current generation
 func (t *Trainer) SaveGenerationStats(gen int) error {
 	stats := struct {
-		Generation  int             `json:"generation"`
-		BestFitness float64         `json:"best_fitness"`
-		AvgFitness  float64         `json:"avg_fitness"`
-		BestModel   EvaluationModel `json:"best_model"`
-		Timestamp   string          `json:"timestamp"`
+		Generation  int                `json:"generation"`
+		BestFitness float64            `json:"best_fitness"`
+		AvgFitness  float64            `json:"avg_fitness"`
+		BestModel   EvaluationModel    `json:"best_model"`
+		CacheHits   int                `json:"cache_hits"`
+		Promotion   *PromotionDecision `json:"promotion,omitempty"`
+		Timestamp   string             `json:"timestamp"`
 	}{
 		Generation:  gen,
 		BestFitness: t.Models[0].Fitness,
 		BestModel:   t.Models[0],
+		CacheHits:   t.CacheHits,
+		Promotion:   t.promotion,
 		Timestamp:   time.Now().Format(time.RFC3339),
 	}
 
@@ -75,11 +67,5 @@ func (t *Trainer) SaveGenerationStats(gen int) error {
 	}
 	stats.AvgFitness = sum / float64(len(t.Models))
 
-	data, err := json.MarshalIndent(stats, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	filename := fmt.Sprintf("training/%s/stats_gen_%d.json", t.Name, gen)
-	return os.WriteFile(filename, data, 0644)
+	return t.store().SaveGenerationStats(gen, stats)
 }
@@ -0,0 +1,61 @@
+package learning
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestPlaySearcherMatchInvalidOpening(t *testing.T) {
+	// a1 is not a legal first move
+	op := opening.Opening{Name: "broken", Transcript: "a1"}
+	win, loss, draw, _, aborted := PlaySearcherMatch(evaluation.GreedyPlayer{}, evaluation.NewRandomPlayer(1), op, 0)
+	if win || loss || draw {
+		t.Errorf("invalid opening counted as a result: win %v, loss %v, draw %v", win, loss, draw)
+	}
+	if aborted != game.InvalidOpening {
+		t.Errorf("reason %v, want %v", aborted, game.InvalidOpening)
+	}
+}
+
+func TestPlaySearcherMatchPlaysToTheEnd(t *testing.T) {
+	for _, op := range opening.KNOWN_OPENINGS[:5] {
+		for player := range 2 {
+			win, loss, draw, history, aborted := PlaySearcherMatch(
+				evaluation.GreedyPlayer{}, evaluation.NewRandomPlayer(int64(player)), op, player)
+			if aborted != game.NotAborted {
+				t.Fatalf("%s: legal game aborted: %v", op.Name, aborted)
+			}
+			if n := btoi(win) + btoi(loss) + btoi(draw); n != 1 {
+				t.Fatalf("%s: win %v, loss %v, draw %v, want one result", op.Name, win, loss, draw)
+			}
+
+			// The history replays to a finished game whose winner is the result
+			g := game.NewGame("Black", "White")
+			if _, err := utils.ApplyTranscript(g, utils.TranscriptToAlgebraic(history)); err != nil {
+				t.Fatalf("%s: history does not replay: %v", op.Name, err)
+			}
+			if g.LegalState() != game.GameOver {
+				t.Fatalf("%s: history %s stops before the end", op.Name, utils.TranscriptToAlgebraic(history))
+			}
+			modelColor := game.Black
+			if player == 1 {
+				modelColor = game.White
+			}
+			switch winner := game.WinnerBitBoard(utils.BoardToBits(g.Board)); {
+			case win && winner != modelColor, loss && winner != game.GetOpponentColor(modelColor), draw && winner != game.Empty:
+				t.Errorf("%s: win %v, loss %v, draw %v with winner %v", op.Name, win, loss, draw, winner)
+			}
+		}
+	}
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
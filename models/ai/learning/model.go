@@ -12,6 +12,7 @@ type EvaluationModel struct {
 	Wins       int                               `json:"wins"`
 	Losses     int                               `json:"losses"`
 	Draws      int                               `json:"draws"`
+	Aborted    int                               `json:"aborted"` // Games stopped by the watchdog
 	BlackGames map[string]string                 `json:"black_game"`
 	WhiteGames map[string]string                 `json:"white_game"`
 }
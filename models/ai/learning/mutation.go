@@ -66,52 +66,81 @@ func MutateCoefficients(coeffs evaluation.EvaluationCoefficients) evaluation.Eva
 	mutated.ParityCoeffs = ImprovedMutateArray(coeffs.ParityCoeffs, ParityMin, ParityMax)
 	mutated.StabilityCoeffs = ImprovedMutateArray(coeffs.StabilityCoeffs, StabilityMin, StabilityMax)
 	mutated.FrontierCoeffs = ImprovedMutateArray(coeffs.FrontierCoeffs, FrontierMin, FrontierMax)
+	mutated.ThreatCoeffs = ImprovedMutateArray(threatCoeffs(coeffs), ThreatMin, ThreatMax)
+	mutated.TempoCoeffs = ImprovedMutateArray(tempoCoeffs(coeffs), TempoMin, TempoMax)
+	mutated.EdgeCoeffs = ImprovedMutateArray(edgeCoeffs(coeffs), EdgeMin, EdgeMax)
+
+	return mutated
+}
+
+// MutatePhaseBoundaries shifts phase boundaries by at most PhaseBoundaryDeltaMax
+// while keeping them strictly increasing
+func MutatePhaseBoundaries(boundaries []int16) []int16 {
+	mutated := make([]int16, len(boundaries))
+	copy(mutated, boundaries)
+
+	for i := range mutated {
+		if rand.Float64() >= PhaseBoundaryMutationRate {
+			continue
+		}
+
+		lower, upper := PhaseBoundaryMin, PhaseBoundaryMax
+		if i > 0 {
+			lower = int(mutated[i-1]) + 1
+		}
+		if i < len(mutated)-1 {
+			upper = int(mutated[i+1]) - 1
+		}
+
+		delta := rand.Intn(2*PhaseBoundaryDeltaMax+1) - PhaseBoundaryDeltaMax
+		mutated[i] = int16(AdjustValueInRange(int(mutated[i])+delta, lower, upper))
+	}
 
 	return mutated
 }
 
 // CreateDiverseModel creates a different but not wildly different model for initial population
 func CreateDiverseModel(baseModel EvaluationModel) EvaluationModel {
+	base := baseModel.Coeffs
 	newModel := EvaluationModel{
 		Coeffs: evaluation.EvaluationCoefficients{
-			MaterialCoeffs:  make([]int16, 6),
-			MobilityCoeffs:  make([]int16, 6),
-			CornersCoeffs:   make([]int16, 6),
-			ParityCoeffs:    make([]int16, 6),
-			StabilityCoeffs: make([]int16, 6),
-			FrontierCoeffs:  make([]int16, 6),
+			// Apply random scaling factors with sensible minimum values and maximum caps
+			MaterialCoeffs:  scaleCoefficients(base.MaterialCoeffs, 1, MaterialMax),
+			MobilityCoeffs:  scaleCoefficients(base.MobilityCoeffs, 1, MobilityMax),
+			CornersCoeffs:   scaleCoefficients(base.CornersCoeffs, 1, CornersMax),
+			ParityCoeffs:    scaleCoefficients(base.ParityCoeffs, 1, ParityMax),
+			StabilityCoeffs: scaleCoefficients(base.StabilityCoeffs, 1, StabilityMax),
+			FrontierCoeffs:  scaleCoefficients(base.FrontierCoeffs, 1, FrontierMax),
+			// Threat, tempo and edge may stay disabled, no minimum value
+			ThreatCoeffs:    scaleCoefficients(threatCoeffs(base), 0, ThreatMax),
+			TempoCoeffs:     scaleCoefficients(tempoCoeffs(base), 0, TempoMax),
+			EdgeCoeffs:      scaleCoefficients(edgeCoeffs(base), 0, EdgeMax),
+			PhaseBoundaries: base.PhaseBoundaries,
 			Name:            "Gen1",
 		},
 	}
 	newModel.Generation = baseModel.Generation + 1
 
-	// Apply factors to all coefficients with bounds checking
-	for i := range 6 {
-		// Apply random scaling factors with more moderate ranges
-		materialFactor := 0.8 + rand.Float64()*0.4 // 0.8x to 1.2x
-		mobilityFactor := 0.8 + rand.Float64()*0.4
-		cornersFactor := 0.8 + rand.Float64()*0.4
-		parityFactor := 0.8 + rand.Float64()*0.4
-		stabilityFactor := 0.8 + rand.Float64()*0.4
-		frontierFactor := 0.8 + rand.Float64()*0.4
-		// Apply the scaling factors with sensible minimum values
-		newModel.Coeffs.MaterialCoeffs[i] = int16(max(1, int(float64(baseModel.Coeffs.MaterialCoeffs[i])*materialFactor)))
-		newModel.Coeffs.MobilityCoeffs[i] = int16(max(1, int(float64(baseModel.Coeffs.MobilityCoeffs[i])*mobilityFactor)))
-		newModel.Coeffs.CornersCoeffs[i] = int16(max(1, int(float64(baseModel.Coeffs.CornersCoeffs[i])*cornersFactor)))
-		newModel.Coeffs.ParityCoeffs[i] = int16(max(1, int(float64(baseModel.Coeffs.ParityCoeffs[i])*parityFactor)))
-		newModel.Coeffs.StabilityCoeffs[i] = int16(max(1, int(float64(baseModel.Coeffs.StabilityCoeffs[i])*stabilityFactor)))
-		newModel.Coeffs.FrontierCoeffs[i] = int16(max(1, int(float64(baseModel.Coeffs.FrontierCoeffs[i])*frontierFactor)))
-
-		// Apply maximum caps to avoid extreme values
-		newModel.Coeffs.MaterialCoeffs[i] = int16(min(int(newModel.Coeffs.MaterialCoeffs[i]), MaterialMax))
-		newModel.Coeffs.MobilityCoeffs[i] = int16(min(int(newModel.Coeffs.MobilityCoeffs[i]), MobilityMax))
-		newModel.Coeffs.CornersCoeffs[i] = int16(min(int(newModel.Coeffs.CornersCoeffs[i]), CornersMax))
-		newModel.Coeffs.ParityCoeffs[i] = int16(min(int(newModel.Coeffs.ParityCoeffs[i]), ParityMax))
-		newModel.Coeffs.StabilityCoeffs[i] = int16(min(int(newModel.Coeffs.StabilityCoeffs[i]), StabilityMax))
-		newModel.Coeffs.FrontierCoeffs[i] = int16(min(int(newModel.Coeffs.FrontierCoeffs[i]), FrontierMax))
+	return newModel
+}
+
+// scaleCoefficients scales every phase of coeffs by a random 0.8x to 1.2x factor,
+// keeping the result within [minValue, maxValue]
+func scaleCoefficients(coeffs []int16, minValue, maxValue int) []int16 {
+	scaled := make([]int16, len(coeffs))
+	for i, c := range coeffs {
+		factor := 0.8 + rand.Float64()*0.4
+		scaled[i] = int16(min(max(minValue, int(float64(c)*factor)), maxValue))
 	}
+	return scaled
+}
 
-	return newModel
+// threatCoeffs returns the threat coefficients of a model, all zero when the model predates them
+func threatCoeffs(coeffs evaluation.EvaluationCoefficients) []int16 {
+	if len(coeffs.ThreatCoeffs) == 0 {
+		return make([]int16, len(coeffs.Boundaries())+1)
+	}
+	return coeffs.ThreatCoeffs
 }
 
 // Helper function for CreateDiverseModel
@@ -129,3 +158,19 @@ func max(a, b int) int {
 	}
 	return b
 }
+
+// tempoCoeffs returns the tempo coefficients of a model, all zero when the model predates them
+func tempoCoeffs(coeffs evaluation.EvaluationCoefficients) []int16 {
+	if len(coeffs.TempoCoeffs) == 0 {
+		return make([]int16, len(coeffs.Boundaries())+1)
+	}
+	return coeffs.TempoCoeffs
+}
+
+// edgeCoeffs returns the edge coefficients of a model, all zero when the model predates them
+func edgeCoeffs(coeffs evaluation.EvaluationCoefficients) []int16 {
+	if len(coeffs.EdgeCoeffs) == 0 {
+		return make([]int16, len(coeffs.Boundaries())+1)
+	}
+	return coeffs.EdgeCoeffs
+}
@@ -0,0 +1,312 @@
+package learning
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// BestNetworkFile is the file holding the best network of a neuroevolution run
+const BestNetworkFile = "best_network.bin"
+
+// Default neuroevolution parameters
+const (
+	DefaultNetworkMutationRate  = 0.1 // Share of the weights mutated in a child
+	DefaultNetworkMutationSigma = 0.1 // Standard deviation of the gaussian noise added to a mutated weight
+)
+
+// DefaultNetworkLayers is the architecture of the evolved networks when none is given
+var DefaultNetworkLayers = []int{evaluation.NeuralFeatures, 16, 8, 1}
+
+// NetworkModel is a network of the neuroevolution population with its results
+type NetworkModel struct {
+	Network    *evaluation.NeuralEvaluation `json:"-"`
+	Generation int                          `json:"generation"`
+	Fitness    float64                      `json:"fitness"`
+	Wins       int                          `json:"wins"`
+	Losses     int                          `json:"losses"`
+	Draws      int                          `json:"draws"`
+	Aborted    int                          `json:"aborted"`
+}
+
+// NeuroEvolutionTrainer evolves the weights of neural evaluations. Networks play the same
+// matches as the genetic trainer, against the mixed evaluation of BaseModel, and children
+// are copies of selected networks with gaussian noise added to their weights.
+type NeuroEvolutionTrainer struct {
+	Name           string
+	Layers         []int
+	Models         []NetworkModel
+	BaseModel      evaluation.EvaluationCoefficients
+	BestModel      NetworkModel
+	Generation     int
+	PopulationSize int
+	NumGames       int
+	MaxDepth       int8
+	MutationRate   float64
+	MutationSigma  float64
+	// Workers is the number of matches played at once, runtime.NumCPU() when 0
+	Workers int
+	// DedupeOpenings plays a single opening of those leading to symmetric positions
+	DedupeOpenings bool
+	// Store persists the networks and statistics, training/<Name> when nil
+	Store ArtifactStore
+	// Logger receives training events, slog.Default() when nil
+	Logger *slog.Logger
+	// Promotion, when set, makes a network with a better fitness than BestModel play it
+	// before replacing it. nil promotes on the fitness alone.
+	Promotion *PromotionGate
+
+	rng *rand.Rand
+	// promotion is the decision of the gate in the current generation, nil without a match
+	promotion *PromotionDecision
+}
+
+// NewNeuroEvolutionTrainer creates a neuroevolution trainer with default parameters
+func NewNeuroEvolutionTrainer(name string, layers []int, popSize, numGames int, depth int8, baseModelCoeffs evaluation.EvaluationCoefficients) *NeuroEvolutionTrainer {
+	return &NeuroEvolutionTrainer{
+		Name:           name,
+		Layers:         layers,
+		BaseModel:      baseModelCoeffs,
+		PopulationSize: popSize,
+		NumGames:       numGames,
+		MaxDepth:       depth,
+		MutationRate:   DefaultNetworkMutationRate,
+		MutationSigma:  DefaultNetworkMutationSigma,
+		Generation:     1,
+		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// StartTraining runs the neuroevolution for a number of generations
+func (t *NeuroEvolutionTrainer) StartTraining(generations int) {
+	log := t.logger()
+
+	trainingStart := time.Now()
+	if len(t.Models) == 0 {
+		t.InitializePopulation()
+	}
+
+	for gen := 1; gen <= generations; gen++ {
+		genStartTime := time.Now()
+
+		t.Generation = gen
+		log.Info("generation started", "generation", gen, "generations", generations)
+
+		t.evaluatePopulation()
+		sort.Slice(t.Models, func(i, j int) bool {
+			return t.Models[i].Fitness > t.Models[j].Fitness
+		})
+
+		t.promotion = nil
+		if t.BestModel.Network == nil || (t.Models[0].Fitness > t.BestModel.Fitness && t.promote(t.Models[0])) {
+			t.BestModel = t.Models[0]
+			if err := t.store().SaveNetwork(BestNetworkFile, gen, t.BestModel.Network); err != nil {
+				log.Warn("saving best network", "err", err)
+			}
+			log.Info("new best network",
+				"generation", gen,
+				"fitness", t.BestModel.Fitness,
+				"wins", t.BestModel.Wins,
+				"losses", t.BestModel.Losses,
+				"draws", t.BestModel.Draws)
+		}
+
+		log.Info("generation completed",
+			"generation", gen,
+			"duration", time.Since(genStartTime),
+			"best_fitness", t.Models[0].Fitness,
+			"avg_fitness", t.calculateAvgFitness())
+
+		if err := t.SaveGenerationStats(gen); err != nil {
+			log.Warn("saving generation stats", "generation", gen, "err", err)
+		}
+
+		if gen < generations {
+			t.createNextGeneration()
+		}
+	}
+
+	log.Info("training completed", "duration", time.Since(trainingStart))
+}
+
+// promote reports whether candidate, with a better fitness than the best network, replaces
+// it, after a head-to-head match when the trainer has a promotion gate
+func (t *NeuroEvolutionTrainer) promote(candidate NetworkModel) bool {
+	if t.Promotion == nil {
+		return true
+	}
+	d := t.Promotion.match(candidate.Network, t.BestModel.Network, t.MaxDepth, t.Workers, t.DedupeOpenings)
+	d.Candidate, d.CandidateFitness = candidate.Generation, candidate.Fitness
+	d.Incumbent, d.IncumbentFitness = t.BestModel.Generation, t.BestModel.Fitness
+	t.promotion = &d
+	logPromotion(t.logger(), d)
+	return d.Promoted
+}
+
+// logger returns the trainer logger, slog.Default() when none is set
+func (t *NeuroEvolutionTrainer) logger() *slog.Logger {
+	if t.Logger != nil {
+		return t.Logger
+	}
+	return slog.Default()
+}
+
+// store returns the artifact store, training/<name> when none is set
+func (t *NeuroEvolutionTrainer) store() ArtifactStore {
+	if t.Store == nil {
+		t.Store = NewRunStore(DefaultRunRoot, t.Name, false)
+	}
+	return t.Store
+}
+
+// InitializePopulation creates a population of random networks
+func (t *NeuroEvolutionTrainer) InitializePopulation() {
+	t.Models = make([]NetworkModel, t.PopulationSize)
+	for i := range t.Models {
+		t.Models[i] = NetworkModel{
+			Network:    evaluation.NewRandomNeuralEvaluation(t.Layers, t.rng),
+			Generation: 1,
+		}
+	}
+}
+
+// createNextGeneration keeps the best quarter of the population and fills the rest with
+// mutated copies of networks picked by tournament selection
+func (t *NeuroEvolutionTrainer) createNextGeneration() {
+	newModels := make([]NetworkModel, t.PopulationSize)
+
+	eliteCount := max(1, t.PopulationSize/4)
+	copy(newModels[:eliteCount], t.Models[:eliteCount])
+
+	for i := eliteCount; i < t.PopulationSize; i++ {
+		parent := t.tournamentSelect(5)
+		newModels[i] = NetworkModel{
+			Network:    t.mutateNetwork(parent.Network),
+			Generation: t.Generation + 1,
+		}
+	}
+
+	t.Models = newModels
+}
+
+// tournamentSelect returns the fittest of tournamentSize random networks
+func (t *NeuroEvolutionTrainer) tournamentSelect(tournamentSize int) NetworkModel {
+	best := t.Models[t.rng.Intn(len(t.Models))]
+	for i := 1; i < tournamentSize; i++ {
+		contender := t.Models[t.rng.Intn(len(t.Models))]
+		if contender.Fitness > best.Fitness {
+			best = contender
+		}
+	}
+	return best
+}
+
+// mutateNetwork returns a copy of a network where each weight has a MutationRate chance
+// to receive gaussian noise of standard deviation MutationSigma
+func (t *NeuroEvolutionTrainer) mutateNetwork(network *evaluation.NeuralEvaluation) *evaluation.NeuralEvaluation {
+	child := network.Clone()
+	child.Params(func(p *float32) {
+		if t.rng.Float64() < t.MutationRate {
+			*p += float32(t.rng.NormFloat64() * t.MutationSigma)
+		}
+	})
+	return child
+}
+
+// evaluatePopulation plays every network against the base model on random openings,
+// with both colors, and sets the fitness to the wins plus half the draws
+func (t *NeuroEvolutionTrainer) evaluatePopulation() {
+	var mutex sync.Mutex
+
+	items := matchItems(len(t.Models), selectOpenings(t.NumGames, t.DedupeOpenings))
+	results := make([]matchResult, len(items))
+	bar := createProgressBar(len(items), "Evaluating networks")
+	bar.RenderBlank()
+
+	standardEval := evaluation.NewMixedEvaluation(t.BaseModel)
+	runPool(len(items), t.Workers, func(i int) {
+		item := items[i]
+		win, loss, draw, _, aborted := PlayMatchWithOpening(
+			t.Models[item.model].Network, standardEval, item.opening, item.player, t.MaxDepth)
+		results[i] = matchResult{Win: win, Loss: loss, Draw: draw, Aborted: aborted}
+		mutex.Lock()
+		bar.Add(1)
+		mutex.Unlock()
+	})
+	fmt.Fprintln(os.Stderr) // Add newline after progress bar completes
+
+	for i := range t.Models {
+		model := &t.Models[i]
+		model.Wins, model.Losses, model.Draws, model.Aborted = 0, 0, 0, 0
+	}
+	for i, item := range items {
+		model := &t.Models[item.model]
+		if res := results[i]; res.Win {
+			model.Wins++
+		} else if res.Loss {
+			model.Losses++
+		} else if res.Draw {
+			model.Draws++
+		} else if res.Aborted != game.NotAborted {
+			model.Aborted++
+		}
+	}
+	for i := range t.Models {
+		model := &t.Models[i]
+		model.Fitness = float64(model.Wins) + float64(model.Draws)*0.5
+	}
+}
+
+// calculateAvgFitness calculates the average fitness of the population
+func (t *NeuroEvolutionTrainer) calculateAvgFitness() float64 {
+	sum := 0.0
+	for _, model := range t.Models {
+		sum += model.Fitness
+	}
+	return sum / float64(len(t.Models))
+}
+
+// SaveGenerationStats saves the statistics of a generation and its best network
+func (t *NeuroEvolutionTrainer) SaveGenerationStats(gen int) error {
+	stats := struct {
+		Generation  int                `json:"generation"`
+		Layers      []int              `json:"layers"`
+		BestFitness float64            `json:"best_fitness"`
+		AvgFitness  float64            `json:"avg_fitness"`
+		BestModel   NetworkModel       `json:"best_model"`
+		Promotion   *PromotionDecision `json:"promotion,omitempty"`
+		Timestamp   string             `json:"timestamp"`
+	}{
+		Generation:  gen,
+		Layers:      t.Layers,
+		BestFitness: t.Models[0].Fitness,
+		AvgFitness:  t.calculateAvgFitness(),
+		BestModel:   t.Models[0],
+		Promotion:   t.promotion,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+	if err := t.store().SaveNetwork(fmt.Sprintf("network_gen_%d.bin", gen), gen, t.Models[0].Network); err != nil {
+		return err
+	}
+	return t.store().SaveGenerationStats(gen, stats)
+}
+
+// LoadNetwork reads a network written by the trainer, such as best_network.bin
+func LoadNetwork(filename string) (*evaluation.NeuralEvaluation, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	network := &evaluation.NeuralEvaluation{}
+	if err := network.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("invalid network %s: %w", filename, err)
+	}
+	return network, nil
+}
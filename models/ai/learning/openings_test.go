@@ -0,0 +1,68 @@
+package learning
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestDedupeOpenings(t *testing.T) {
+	openings := []opening.Opening{
+		{Name: "Diagonal", Transcript: "c4c3"},
+		{Name: "Perpendicular", Transcript: "c4e3"},
+		{Name: "Diagonal rotated", Transcript: "f5f6"},
+		{Name: "Diagonal reflected", Transcript: "d3c3"},
+		{Name: "Broken", Transcript: "a1"},
+		{Name: "Perpendicular reflected", Transcript: "e6f4"},
+		{Name: "Parallel", Transcript: "c4c5"},
+	}
+	var names []string
+	for _, op := range DedupeOpenings(openings) {
+		names = append(names, op.Name)
+	}
+	want := []string{"Diagonal", "Perpendicular", "Broken", "Parallel"}
+	if len(names) != len(want) {
+		t.Fatalf("kept %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("kept %v, want %v", names, want)
+		}
+	}
+}
+
+func TestDedupeKnownOpenings(t *testing.T) {
+	kept := DedupeOpenings(opening.KNOWN_OPENINGS)
+	if len(kept) == 0 || len(kept) > len(opening.KNOWN_OPENINGS) {
+		t.Fatalf("kept %d of %d openings", len(kept), len(opening.KNOWN_OPENINGS))
+	}
+	seen := make(map[game.BoardFingerprint]string)
+	for _, op := range kept {
+		g := game.NewGame("Black", "White")
+		if _, err := utils.ApplyTranscript(g, op.Transcript); err != nil {
+			continue
+		}
+		key := game.Fingerprint(utils.BoardToBits(g.Board))
+		if other, ok := seen[key]; ok {
+			t.Errorf("%s and %s lead to symmetric positions", other, op.Name)
+		}
+		seen[key] = op.Name
+	}
+
+	// Every dropped opening is symmetric to a kept one
+	for _, op := range opening.KNOWN_OPENINGS {
+		g := game.NewGame("Black", "White")
+		if _, err := utils.ApplyTranscript(g, op.Transcript); err != nil {
+			continue
+		}
+		if _, ok := seen[game.Fingerprint(utils.BoardToBits(g.Board))]; !ok {
+			t.Errorf("%s dropped without a symmetric opening kept", op.Name)
+		}
+	}
+
+	if got := selectOpenings(len(opening.KNOWN_OPENINGS), true); len(got) != len(kept) {
+		t.Errorf("selected %d deduplicated openings, want all %d", len(got), len(kept))
+	}
+}
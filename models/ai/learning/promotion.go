@@ -0,0 +1,92 @@
+package learning
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// Default parameters of the promotion gate
+const (
+	DefaultPromotionGames     = 10   // Openings of the head-to-head match
+	DefaultPromotionThreshold = 0.55 // Share of the points the candidate needs
+)
+
+// PromotionGate keeps the best model of a run until a candidate beats it in a direct match.
+// A better fitness against the reference opponent is not enough on its own: over a few
+// dozen games, it is often noise.
+type PromotionGate struct {
+	// Games is the number of openings of the match, each played with both colors
+	Games int
+	// Threshold is the share of the points the candidate needs, draws counting half
+	Threshold float64
+}
+
+// PromotionDecision records a head-to-head match of the promotion gate, from the point of
+// view of the candidate. Models are identified by the generation they were created in.
+type PromotionDecision struct {
+	Candidate        int     `json:"candidate_generation"`
+	CandidateFitness float64 `json:"candidate_fitness"`
+	Incumbent        int     `json:"incumbent_generation"`
+	IncumbentFitness float64 `json:"incumbent_fitness"`
+	Wins             int     `json:"wins"`
+	Losses           int     `json:"losses"`
+	Draws            int     `json:"draws"`
+	Aborted          int     `json:"aborted"`
+	Score            float64 `json:"score"` // Share of the points of the candidate
+	Promoted         bool    `json:"promoted"`
+}
+
+// Decide scores a match and reports whether the candidate is promoted: its share of the
+// points must reach Threshold, over at least Games completed games, half the match
+func (g PromotionGate) Decide(wins, losses, draws int) (score float64, promoted bool) {
+	played := wins + losses + draws
+	if played == 0 {
+		return 0, false
+	}
+	score = (float64(wins) + float64(draws)*0.5) / float64(played)
+	return score, played >= g.Games && score >= g.Threshold
+}
+
+// match plays the candidate against the incumbent on Games openings with both colors, on
+// a pool of workers goroutines, and decides on the promotion
+func (g PromotionGate) match(candidate, incumbent evaluation.Evaluation, maxDepth int8, workers int, dedupe bool) PromotionDecision {
+	openings := selectOpenings(g.Games, dedupe)
+	items := matchItems(1, openings)
+
+	var d PromotionDecision
+	var mutex sync.Mutex
+	runPool(len(items), workers, func(i int) {
+		item := items[i]
+		win, loss, draw, _, aborted := PlayMatchWithOpening(candidate, incumbent, item.opening, item.player, maxDepth)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case win:
+			d.Wins++
+		case loss:
+			d.Losses++
+		case draw:
+			d.Draws++
+		case aborted != game.NotAborted:
+			d.Aborted++
+		}
+	})
+	d.Score, d.Promoted = g.Decide(d.Wins, d.Losses, d.Draws)
+	return d
+}
+
+// logPromotion logs the decision of the promotion gate
+func logPromotion(log *slog.Logger, d PromotionDecision) {
+	log.Info("promotion match",
+		"candidate_generation", d.Candidate,
+		"incumbent_generation", d.Incumbent,
+		"wins", d.Wins,
+		"losses", d.Losses,
+		"draws", d.Draws,
+		"score", d.Score,
+		"promoted", d.Promoted)
+}
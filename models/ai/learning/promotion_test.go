@@ -0,0 +1,93 @@
+package learning
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+)
+
+func TestPromotionDecide(t *testing.T) {
+	gate := PromotionGate{Games: 10, Threshold: 0.55}
+	tests := []struct {
+		wins, losses, draws int
+		score               float64
+		promoted            bool
+	}{
+		{0, 0, 0, 0, false},
+		{6, 4, 0, 0.6, true},
+		{5, 5, 0, 0.5, false},
+		{5, 4, 2, 0.5454545454545454, false},
+		{5, 3, 2, 0.6, true},
+		{11, 9, 0, 0.55, true},
+		// A winning score over too few games
+		{5, 0, 0, 1, false},
+		{9, 0, 1, 0.95, true},
+	}
+	for _, tt := range tests {
+		score, promoted := gate.Decide(tt.wins, tt.losses, tt.draws)
+		if score != tt.score || promoted != tt.promoted {
+			t.Errorf("%d/%d/%d: score %v, promoted %v, want %v and %v",
+				tt.wins, tt.losses, tt.draws, score, promoted, tt.score, tt.promoted)
+		}
+	}
+}
+
+func TestPromotionGateInTraining(t *testing.T) {
+	for _, tt := range []struct {
+		threshold float64
+		promoted  bool
+	}{
+		{0, true},
+		{1.01, false},
+	} {
+		var buf bytes.Buffer
+		trainer := NewTrainer("test", 2, 1, 1, evaluation.V4Coeff)
+		trainer.Workers = 2
+		store := NewFileStore(t.TempDir())
+		trainer.Store = store
+		trainer.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+		trainer.Promotion = &PromotionGate{Games: 1, Threshold: tt.threshold}
+		trainer.InitializePopulation()
+		initial := trainer.BestModel
+		trainer.StartTraining(1)
+
+		events := logEvents(t, &buf, "promotion match")
+		if len(events) != 1 {
+			t.Fatalf("threshold %v: %d promotion matches, want 1", tt.threshold, len(events))
+		}
+		if events[0]["promoted"] != tt.promoted {
+			t.Errorf("threshold %v: promoted %v, want %v", tt.threshold, events[0]["promoted"], tt.promoted)
+		}
+		if replaced := trainer.BestModel.Fitness != initial.Fitness; replaced != tt.promoted {
+			t.Errorf("threshold %v: best model replaced %v, want %v", tt.threshold, replaced, tt.promoted)
+		}
+
+		// The decision is saved with the statistics of the generation
+		data, err := os.ReadFile(filepath.Join(store.Dir, "stats_gen_1.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stats struct {
+			Promotion *PromotionDecision `json:"promotion"`
+		}
+		if err := json.Unmarshal(data, &stats); err != nil {
+			t.Fatal(err)
+		}
+		d := stats.Promotion
+		if d == nil {
+			t.Fatalf("threshold %v: no promotion in the statistics", tt.threshold)
+		}
+		if d.Promoted != tt.promoted || d.Wins+d.Losses+d.Draws+d.Aborted != 2 {
+			t.Errorf("threshold %v: decision %+v, want promoted %v after 2 games", tt.threshold, d, tt.promoted)
+		}
+		if d.IncumbentFitness != initial.Fitness || d.CandidateFitness <= d.IncumbentFitness {
+			t.Errorf("threshold %v: candidate fitness %v against %v, want a better candidate against %v",
+				tt.threshold, d.CandidateFitness, d.IncumbentFitness, initial.Fitness)
+		}
+	}
+}
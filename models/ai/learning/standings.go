@@ -0,0 +1,105 @@
+package learning
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// StandingsFile is the file of a run holding the standings of the running evaluation
+const StandingsFile = "standings.json"
+
+// DefaultStandingsEvery is the number of completed matches between two standings writes
+const DefaultStandingsEvery = 10
+
+// MatchRecord is the outcome of a match played, or read from the cache, during an evaluation
+type MatchRecord struct {
+	Generation int              `json:"generation"`
+	Model      int              `json:"model"` // Index of the model in the population
+	Opening    string           `json:"opening"`
+	Player     int              `json:"player"` // 0: the model played black, 1: white
+	Win        bool             `json:"win"`
+	Loss       bool             `json:"loss"`
+	Draw       bool             `json:"draw"`
+	Aborted    game.AbortReason `json:"aborted,omitempty"`
+	Cached     bool             `json:"cached,omitempty"`
+	History    string           `json:"history"`
+}
+
+// Standing is the record of a model in the running evaluation
+type Standing struct {
+	Model   int     `json:"model"` // Index of the model in the population
+	Name    string  `json:"name"`
+	Wins    int     `json:"wins"`
+	Losses  int     `json:"losses"`
+	Draws   int     `json:"draws"`
+	Aborted int     `json:"aborted"`
+	Score   float64 `json:"score"` // Wins plus half the draws, the fitness once every match is played
+}
+
+// Standings is the state of the evaluation of a generation
+type Standings struct {
+	Generation int        `json:"generation"`
+	Updated    time.Time  `json:"updated"`
+	Played     int        `json:"played"`
+	Remaining  int        `json:"remaining"`
+	Models     []Standing `json:"models"`
+}
+
+// newStandings creates the empty standings of the models of a generation
+func newStandings(gen int, models []*EvaluationModel, totalMatches int) *Standings {
+	s := &Standings{Generation: gen, Remaining: totalMatches, Models: make([]Standing, len(models))}
+	for i, model := range models {
+		s.Models[i] = Standing{Model: i, Name: model.Coeffs.Name}
+	}
+	return s
+}
+
+// record adds the outcome of a match
+func (s *Standings) record(rec MatchRecord) {
+	st := &s.Models[rec.Model]
+	switch {
+	case rec.Win:
+		st.Wins++
+	case rec.Loss:
+		st.Losses++
+	case rec.Draw:
+		st.Draws++
+	case rec.Aborted != game.NotAborted:
+		st.Aborted++
+	}
+	st.Score = float64(st.Wins) + float64(st.Draws)*0.5
+	s.Played++
+	s.Remaining--
+	s.Updated = time.Now()
+}
+
+// SaveStandings replaces the standings file of the run, it is not listed in the manifest
+func (s *FileStore) SaveStandings(standings *Standings) error {
+	data, err := json.MarshalIndent(standings, "", "  ")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(s.Dir, StandingsFile), data)
+}
+
+// ReadStandings reads the standings file of a run directory
+func ReadStandings(dir string) (*Standings, error) {
+	data, err := os.ReadFile(filepath.Join(dir, StandingsFile))
+	if err != nil {
+		return nil, err
+	}
+	var standings Standings
+	if err := json.Unmarshal(data, &standings); err != nil {
+		return nil, err
+	}
+	return &standings, nil
+}
@@ -0,0 +1,104 @@
+package learning
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+)
+
+func TestEvaluationReportsEveryMatch(t *testing.T) {
+	openings := opening.KNOWN_OPENINGS[:2]
+	models := []*EvaluationModel{{Coeffs: evaluation.V1Coeff}, {Coeffs: evaluation.V2Coeff}}
+	var records []MatchRecord
+	evaluateModelsInParallel(models, evaluation.V4Coeff, 1, openings, 4, nil, func(rec MatchRecord, total int) {
+		if total != 2*2*len(openings) {
+			t.Errorf("total %d matches, want %d", total, 2*2*len(openings))
+		}
+		records = append(records, rec)
+	})
+
+	// The standings built from the records are the fitness of the models
+	standings := newStandings(1, models, len(records))
+	for _, rec := range records {
+		standings.record(rec)
+	}
+	if standings.Played != len(records) || standings.Remaining != 0 {
+		t.Errorf("standings played %d, remaining %d, want %d and 0", standings.Played, standings.Remaining, len(records))
+	}
+	for i, model := range models {
+		st := standings.Models[i]
+		if st.Score != model.Fitness || st.Wins != model.Wins || st.Losses != model.Losses || st.Draws != model.Draws {
+			t.Errorf("model %d standing %+v, fitness %v (%d/%d/%d)", i, st, model.Fitness, model.Wins, model.Losses, model.Draws)
+		}
+	}
+}
+
+func TestStandingsRoundTrip(t *testing.T) {
+	models := []*EvaluationModel{{Coeffs: evaluation.V1Coeff}, {Coeffs: evaluation.V2Coeff}}
+	standings := newStandings(3, models, 4)
+	standings.record(MatchRecord{Model: 0, Win: true})
+	standings.record(MatchRecord{Model: 0, Draw: true})
+	standings.record(MatchRecord{Model: 1, Aborted: game.TooManyPlies})
+
+	store := NewFileStore(t.TempDir())
+	if err := store.SaveStandings(standings); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadStandings(store.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Generation != 3 || read.Played != 3 || read.Remaining != 1 {
+		t.Errorf("generation %d, played %d, remaining %d, want 3, 3 and 1", read.Generation, read.Played, read.Remaining)
+	}
+	want := []Standing{
+		{Model: 0, Name: evaluation.V1Coeff.Name, Wins: 1, Draws: 1, Score: 1.5},
+		{Model: 1, Name: evaluation.V2Coeff.Name, Aborted: 1},
+	}
+	for i, st := range want {
+		if read.Models[i] != st {
+			t.Errorf("standing %d: %+v, want %+v", i, read.Models[i], st)
+		}
+	}
+
+	// The standings are replaced, not listed in the manifest
+	if _, err := ReadManifest(store.Dir); err == nil {
+		t.Error("standings written to the manifest")
+	}
+}
+
+func TestTrainerPublishesStandings(t *testing.T) {
+	trainer := NewTrainer("test", 2, 2, 1, evaluation.V4Coeff)
+	trainer.Workers = 2
+	trainer.Store = NewFileStore(t.TempDir())
+	trainer.StandingsEvery = 3
+	var records []MatchRecord
+	trainer.OnMatchComplete = func(rec MatchRecord) { records = append(records, rec) }
+	trainer.InitializePopulation()
+	trainer.evaluatePopulation()
+
+	if len(records) != 2*2*2 {
+		t.Fatalf("%d matches reported, want 8", len(records))
+	}
+	for _, rec := range records {
+		if rec.Generation != trainer.Generation {
+			t.Errorf("match of generation %d, want %d", rec.Generation, trainer.Generation)
+		}
+	}
+
+	// The last write holds the final standings, once every match is played
+	standings, err := ReadStandings(trainer.Store.(*FileStore).Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if standings.Played != len(records) || standings.Remaining != 0 {
+		t.Errorf("standings played %d, remaining %d, want %d and 0", standings.Played, standings.Remaining, len(records))
+	}
+	for i, model := range trainer.Models {
+		if standings.Models[i].Score != model.Fitness {
+			t.Errorf("model %d score %v, fitness %v", i, standings.Models[i].Score, model.Fitness)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package learning
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+)
+
+// DefaultRunRoot is the directory holding the training runs
+const DefaultRunRoot = "training"
+
+// ManifestFile is the file listing the artifacts of a run
+const ManifestFile = "manifest.json"
+
+// Kinds of artifacts
+const (
+	ArtifactModel      = "model"
+	ArtifactStats      = "stats"
+	ArtifactCheckpoint = "checkpoint"
+	ArtifactNetwork    = "network"
+)
+
+// ArtifactStore persists the files produced by a training run
+type ArtifactStore interface {
+	SaveModel(name string, model EvaluationModel) error
+	SaveGenerationStats(gen int, stats any) error
+	SaveCheckpoint(name string, data any) error
+	// SaveStandings replaces the live standings of the running evaluation
+	SaveStandings(standings *Standings) error
+	// SaveNetwork writes a neural network in its binary encoding
+	SaveNetwork(name string, gen int, network *evaluation.NeuralEvaluation) error
+}
+
+// ManifestEntry describes an artifact of a run
+type ManifestEntry struct {
+	Name       string    `json:"name"`
+	Kind       string    `json:"kind"`
+	Generation int       `json:"generation,omitempty"`
+	SHA256     string    `json:"sha256"`
+	Created    time.Time `json:"created"`
+}
+
+// Manifest lists every artifact of a run, the latest version of each file
+type Manifest struct {
+	Run       string          `json:"run"`
+	Created   time.Time       `json:"created"`
+	Artifacts []ManifestEntry `json:"artifacts"`
+}
+
+// FileStore is the ArtifactStore writing JSON files in a run directory.
+// Files and the manifest are written to a temporary file then renamed, so an
+// interrupted write never leaves a truncated file or a manifest listing one.
+type FileStore struct {
+	Dir string
+
+	mu       sync.Mutex
+	manifest *Manifest
+}
+
+// NewFileStore creates a store rooted at dir, created on the first write
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{Dir: dir}
+}
+
+// NewRunStore creates a store for the run name under root. With timestamp, the
+// creation time is appended to the directory so runs with the same name do not collide.
+func NewRunStore(root, name string, timestamp bool) *FileStore {
+	if timestamp {
+		name += "-" + time.Now().Format("20060102-150405")
+	}
+	return NewFileStore(filepath.Join(root, name))
+}
+
+// SaveModel writes a model under name
+func (s *FileStore) SaveModel(name string, model EvaluationModel) error {
+	return s.save(name, ArtifactModel, model.Generation, model)
+}
+
+// SaveGenerationStats writes the statistics of a generation to stats_gen_<gen>.json
+func (s *FileStore) SaveGenerationStats(gen int, stats any) error {
+	return s.save(fmt.Sprintf("stats_gen_%d.json", gen), ArtifactStats, gen, stats)
+}
+
+// SaveCheckpoint writes any data under name
+func (s *FileStore) SaveCheckpoint(name string, data any) error {
+	return s.save(name, ArtifactCheckpoint, 0, data)
+}
+
+// SaveNetwork writes a neural network under name
+func (s *FileStore) SaveNetwork(name string, gen int, network *evaluation.NeuralEvaluation) error {
+	data, err := network.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return s.saveBytes(name, ArtifactNetwork, gen, data)
+}
+
+// save writes an artifact as JSON and records it in the manifest
+func (s *FileStore) save(name, kind string, gen int, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.saveBytes(name, kind, gen, data)
+}
+
+// saveBytes writes an artifact and records it in the manifest
+func (s *FileStore) saveBytes(name, kind string, gen int, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(s.Dir, name), data); err != nil {
+		return err
+	}
+
+	manifest, err := s.loadManifest()
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	entry := ManifestEntry{Name: name, Kind: kind, Generation: gen, SHA256: hex.EncodeToString(sum[:]), Created: time.Now()}
+	replaced := false
+	for i := range manifest.Artifacts {
+		if manifest.Artifacts[i].Name == name {
+			manifest.Artifacts[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		manifest.Artifacts = append(manifest.Artifacts, entry)
+	}
+	data, err = json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(s.Dir, ManifestFile), data)
+}
+
+// loadManifest returns the manifest, read from the run directory the first time
+func (s *FileStore) loadManifest() (*Manifest, error) {
+	if s.manifest != nil {
+		return s.manifest, nil
+	}
+	manifest, err := ReadManifest(s.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		manifest = &Manifest{Run: filepath.Base(s.Dir), Created: time.Now()}
+	} else if err != nil {
+		return nil, err
+	}
+	s.manifest = manifest
+	return manifest, nil
+}
+
+// ReadManifest reads the manifest of a run directory
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest in %s: %w", dir, err)
+	}
+	return &manifest, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory then renames it
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadRunModel loads a model of a run under root, referenced as "NAME" for the best model
+// of the run or "NAME@genN" for the best model of generation N. The file is checked
+// against the hash recorded in the manifest.
+func LoadRunModel(root, ref string) (EvaluationModel, error) {
+	var model EvaluationModel
+	run, genRef, hasGen := strings.Cut(ref, "@")
+	dir := filepath.Join(root, run)
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return model, err
+	}
+
+	name := "best_model.json"
+	if hasGen {
+		gen, err := strconv.Atoi(strings.TrimPrefix(genRef, "gen"))
+		if err != nil {
+			return model, fmt.Errorf("invalid generation %q in %q", genRef, ref)
+		}
+		name = fmt.Sprintf("stats_gen_%d.json", gen)
+	}
+
+	var entry *ManifestEntry
+	for i := range manifest.Artifacts {
+		if manifest.Artifacts[i].Name == name {
+			entry = &manifest.Artifacts[i]
+		}
+	}
+	if entry == nil {
+		return model, fmt.Errorf("run %s has no artifact %s", run, name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return model, err
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != entry.SHA256 {
+		return model, fmt.Errorf("%s of run %s does not match its manifest hash", name, run)
+	}
+
+	if hasGen {
+		var stats struct {
+			BestModel EvaluationModel `json:"best_model"`
+		}
+		err = json.Unmarshal(data, &stats)
+		model = stats.BestModel
+	} else {
+		err = json.Unmarshal(data, &model)
+	}
+	if err != nil {
+		return model, err
+	}
+	if err := model.Coeffs.Validate(); err != nil {
+		return model, fmt.Errorf("invalid model %s of run %s: %w", name, run, err)
+	}
+	return model, nil
+}
@@ -0,0 +1,146 @@
+package learning
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+)
+
+// saveRun writes the best model of a run and the statistics of two generations
+func saveRun(t *testing.T, store *FileStore) {
+	t.Helper()
+	best := EvaluationModel{Coeffs: evaluation.V4Coeff, Generation: 2, Fitness: 7}
+	if err := store.SaveModel("best_model.json", best); err != nil {
+		t.Fatal(err)
+	}
+	for gen, coeffs := range map[int]evaluation.EvaluationCoefficients{1: evaluation.V2Coeff, 2: evaluation.V4Coeff} {
+		stats := struct {
+			BestModel EvaluationModel `json:"best_model"`
+		}{EvaluationModel{Coeffs: coeffs, Generation: gen}}
+		if err := store.SaveGenerationStats(gen, stats); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFileStoreManifest(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "run")
+	saveRun(t, NewFileStore(dir))
+
+	// A second store of the same run adds to the manifest written by the first
+	store := NewFileStore(dir)
+	if err := store.SaveCheckpoint("checkpoint.json", []int{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveModel("best_model.json", EvaluationModel{Coeffs: evaluation.V4Coeff, Generation: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Run != "run" {
+		t.Errorf("run %q, want run", manifest.Run)
+	}
+	kinds := make(map[string]string)
+	for _, entry := range manifest.Artifacts {
+		if _, ok := kinds[entry.Name]; ok {
+			t.Errorf("%s listed twice", entry.Name)
+		}
+		kinds[entry.Name] = entry.Kind
+		if _, err := os.Stat(filepath.Join(dir, entry.Name)); err != nil {
+			t.Errorf("listed artifact %s: %v", entry.Name, err)
+		}
+	}
+	want := map[string]string{
+		"best_model.json":  ArtifactModel,
+		"stats_gen_1.json": ArtifactStats,
+		"stats_gen_2.json": ArtifactStats,
+		"checkpoint.json":  ArtifactCheckpoint,
+	}
+	if len(kinds) != len(want) {
+		t.Errorf("manifest lists %v, want %v", kinds, want)
+	}
+	for name, kind := range want {
+		if kinds[name] != kind {
+			t.Errorf("%s has kind %q, want %q", name, kinds[name], kind)
+		}
+	}
+
+	// The rewritten model is the one referenced
+	model, err := LoadRunModel(filepath.Dir(dir), "run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model.Generation != 3 {
+		t.Errorf("best model of generation %d, want the rewritten one of generation 3", model.Generation)
+	}
+}
+
+func TestFileStoreInterruptedWrite(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "run")
+	saveRun(t, NewFileStore(dir))
+
+	// A write stopped before its rename leaves a temporary file the manifest does not list
+	tmp := filepath.Join(dir, "best_model.json.tmp123")
+	if err := os.WriteFile(tmp, []byte(`{"coeffs": {"na`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range manifest.Artifacts {
+		if strings.Contains(entry.Name, ".tmp") {
+			t.Errorf("manifest lists the temporary file %s", entry.Name)
+		}
+	}
+	if _, err := LoadRunModel(root, "run"); err != nil {
+		t.Errorf("run with a leftover temporary file: %v", err)
+	}
+
+	// An artifact changed after the manifest was written is rejected
+	if err := os.WriteFile(filepath.Join(dir, "best_model.json"), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRunModel(root, "run"); err == nil || !strings.Contains(err.Error(), "hash") {
+		t.Errorf("artifact not matching its hash: error %v", err)
+	}
+}
+
+func TestLoadRunModel(t *testing.T) {
+	root := t.TempDir()
+	saveRun(t, NewRunStore(root, "run", false))
+
+	tests := []struct {
+		ref  string
+		name string
+		gen  int
+	}{
+		{"run", evaluation.V4Coeff.Name, 2},
+		{"run@gen1", evaluation.V2Coeff.Name, 1},
+		{"run@gen2", evaluation.V4Coeff.Name, 2},
+	}
+	for _, tt := range tests {
+		model, err := LoadRunModel(root, tt.ref)
+		if err != nil {
+			t.Errorf("%s: %v", tt.ref, err)
+			continue
+		}
+		if model.Coeffs.Name != tt.name || model.Generation != tt.gen {
+			t.Errorf("%s: model %s of generation %d, want %s of generation %d",
+				tt.ref, model.Coeffs.Name, model.Generation, tt.name, tt.gen)
+		}
+	}
+
+	for _, ref := range []string{"run@gen3", "run@genx", "missing", "missing@gen1"} {
+		if _, err := LoadRunModel(root, ref); err == nil {
+			t.Errorf("%s: no error", ref)
+		}
+	}
+}
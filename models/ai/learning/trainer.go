@@ -1,7 +1,7 @@
 package learning
 
 import (
-	"fmt"
+	"log/slog"
 	"sort"
 	"time"
 
@@ -19,16 +19,13 @@ func NewTrainer(name string, popSize, numGames int, depth int8, baseModelCoeffs
 		NumGames:       numGames,
 		MaxDepth:       depth,
 		Generation:     1,
+		Cache:          NewFitnessCache(),
 	}
 }
 
 // StartTraining begins the genetic algorithm training process
 func (t *Trainer) StartTraining(generations int) {
-
-	if t.createModelDirectory() != nil {
-		fmt.Println("Error creating model directory")
-		return
-	}
+	log := t.logger()
 
 	trainingStart := time.Now()
 	if len(t.Models) == 0 {
@@ -39,33 +36,44 @@ func (t *Trainer) StartTraining(generations int) {
 		genStartTime := time.Now()
 
 		t.Generation = gen
-		fmt.Printf("\nGeneration %d/%d\n", gen, generations)
+		log.Info("generation started", "generation", gen, "generations", generations)
 
 		// Evaluate all models
 		t.evaluatePopulation()
 		t.sortModelsByFitness()
 
-		fmt.Println("Generation time:", time.Since(genStartTime))
-
 		// Update best model
-		if t.Models[0].Fitness > t.BestModel.Fitness {
+		t.promotion = nil
+		if t.Models[0].Fitness > t.BestModel.Fitness && t.promote(t.Models[0]) {
 			t.BestModel = t.Models[0]
-			t.SaveModel("best_model.json", t.BestModel)
-			fmt.Printf("New best model: fitness %.2f, win rate %.2f%%\n",
-				t.BestModel.Fitness,
-				float64(t.BestModel.Wins)/float64(t.BestModel.Wins+t.BestModel.Losses+t.BestModel.Draws)*100)
+			if err := t.SaveModel("best_model.json", t.BestModel); err != nil {
+				log.Warn("saving best model", "err", err)
+			}
+			log.Info("new best model",
+				"generation", gen,
+				"fitness", t.BestModel.Fitness,
+				"win_rate", float64(t.BestModel.Wins)/float64(t.BestModel.Wins+t.BestModel.Losses+t.BestModel.Draws)*100,
+				"wins", t.BestModel.Wins,
+				"losses", t.BestModel.Losses,
+				"draws", t.BestModel.Draws)
 
 			if t.BestModel.Fitness >= 2*float64(t.NumGames)-1 {
-				fmt.Println("Best model reached target fitness, now training on this best model.")
+				log.Info("best model reached target fitness, now training on this best model", "generation", gen)
 				t.BaseModel = t.BestModel.Coeffs
 			}
 		}
 
-		// Display current best fitness
-		fmt.Printf("Best fitness: %.2f, Avg fitness: %.2f\n", t.Models[0].Fitness, t.calculateAvgFitness())
+		log.Info("generation completed",
+			"generation", gen,
+			"duration", time.Since(genStartTime),
+			"best_fitness", t.Models[0].Fitness,
+			"avg_fitness", t.calculateAvgFitness(),
+			"cache_hits", t.CacheHits)
 
 		// Save generation statistics
-		t.SaveGenerationStats(gen)
+		if err := t.SaveGenerationStats(gen); err != nil {
+			log.Warn("saving generation stats", "generation", gen, "err", err)
+		}
 
 		// Create next generation if not last generation
 		if gen < generations {
@@ -73,7 +81,29 @@ func (t *Trainer) StartTraining(generations int) {
 		}
 	}
 
-	fmt.Printf("\nTraining completed in %s\n", time.Since(trainingStart))
+	log.Info("training completed", "duration", time.Since(trainingStart))
+}
+
+// promote reports whether candidate, with a better fitness than the best model, replaces
+// it, after a head-to-head match when the trainer has a promotion gate
+func (t *Trainer) promote(candidate EvaluationModel) bool {
+	if t.Promotion == nil {
+		return true
+	}
+	d := t.Promotion.match(evaluation.NewMixedEvaluation(candidate.Coeffs), evaluation.NewMixedEvaluation(t.BestModel.Coeffs), t.MaxDepth, t.Workers, t.DedupeOpenings)
+	d.Candidate, d.CandidateFitness = candidate.Generation, candidate.Fitness
+	d.Incumbent, d.IncumbentFitness = t.BestModel.Generation, t.BestModel.Fitness
+	t.promotion = &d
+	logPromotion(t.logger(), d)
+	return d.Promoted
+}
+
+// logger returns the trainer logger, slog.Default() when none is set
+func (t *Trainer) logger() *slog.Logger {
+	if t.Logger != nil {
+		return t.Logger
+	}
+	return slog.Default()
 }
 
 // InitializePopulation creates initial random population of models
@@ -133,8 +163,30 @@ func (t *Trainer) evaluatePopulation() {
 		modelPtrs[i] = &t.Models[i]
 	}
 
-	// Evaluate all models in parallel
-	evaluateModelsInParallel(modelPtrs, t.BaseModel, t.MaxDepth, t.NumGames)
+	// Evaluate all models in parallel, publishing the standings as matches complete
+	every := t.StandingsEvery
+	if every <= 0 {
+		every = DefaultStandingsEvery
+	}
+	var standings *Standings
+	onMatch := func(rec MatchRecord, totalMatches int) {
+		rec.Generation = t.Generation
+		if standings == nil {
+			standings = newStandings(t.Generation, modelPtrs, totalMatches)
+		}
+		standings.record(rec)
+		if standings.Played%every == 0 || standings.Remaining == 0 {
+			if err := t.store().SaveStandings(standings); err != nil {
+				t.logger().Warn("saving standings", "err", err)
+			}
+		}
+		if t.OnMatchComplete != nil {
+			t.OnMatchComplete(rec)
+		}
+	}
+	// Outcomes of the models dropped by the last selection are forgotten
+	t.Cache.NextGeneration()
+	t.CacheHits = evaluateModelsInParallel(modelPtrs, t.BaseModel, t.MaxDepth, selectOpenings(t.NumGames, t.DedupeOpenings), t.Workers, t.Cache, onMatch)
 }
 
 // sortModelsByFitness sorts models by fitness in descending order
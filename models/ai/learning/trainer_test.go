@@ -0,0 +1,66 @@
+package learning
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+)
+
+// logEvents decodes the records of a JSON log with the message msg
+func logEvents(t *testing.T, buf *bytes.Buffer, msg string) []map[string]any {
+	t.Helper()
+	var events []map[string]any
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var event map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid log record %q: %v", scanner.Text(), err)
+		}
+		if event["msg"] == msg {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestTrainingLogsNewBestModel(t *testing.T) {
+	var buf bytes.Buffer
+	trainer := NewTrainer("test", 2, 1, 1, evaluation.V4Coeff)
+	trainer.Workers = 2
+	trainer.Store = NewFileStore(t.TempDir())
+	trainer.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+
+	// The base model plays itself with both colors: its fitness is 1, above the 0 of
+	// the initial best model, so the first generation has a new best model
+	trainer.StartTraining(1)
+
+	events := logEvents(t, &buf, "new best model")
+	if len(events) != 1 {
+		t.Fatalf("%d new best model events, want 1", len(events))
+	}
+	event := events[0]
+	if event["level"] != "INFO" {
+		t.Errorf("level %v, want INFO", event["level"])
+	}
+	best := trainer.BestModel
+	want := map[string]float64{
+		"generation": 1,
+		"fitness":    best.Fitness,
+		"wins":       float64(best.Wins),
+		"losses":     float64(best.Losses),
+		"draws":      float64(best.Draws),
+		"win_rate":   float64(best.Wins) / float64(best.Wins+best.Losses+best.Draws) * 100,
+	}
+	for key, value := range want {
+		if got, ok := event[key].(float64); !ok || got != value {
+			t.Errorf("%s = %v, want %v", key, event[key], value)
+		}
+	}
+	if best.Fitness < 1 {
+		t.Errorf("best model fitness %v, want at least the 1 of the base model", best.Fitness)
+	}
+}
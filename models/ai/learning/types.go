@@ -1,6 +1,8 @@
 package learning
 
 import (
+	"log/slog"
+
 	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
 )
 
@@ -15,6 +17,32 @@ type Trainer struct {
 	MutationRate   float64
 	NumGames       int
 	MaxDepth       int8
+	// Workers is the number of matches played at once, runtime.NumCPU() when 0
+	Workers int
+	// DedupeOpenings plays a single opening of those leading to symmetric positions,
+	// fewer games per model for the same positions
+	DedupeOpenings bool
+	// MutatePhaseBoundaries also mutates the piece counts separating game phases
+	MutatePhaseBoundaries bool
+	// Cache keeps match outcomes across generations, nil disables it
+	Cache *FitnessCache
+	// CacheHits counts the matches of the last evaluation taken from the cache
+	CacheHits int
+	// Store persists the models and statistics, training/<Name> when nil
+	Store ArtifactStore
+	// Logger receives training events, slog.Default() when nil
+	Logger *slog.Logger
+	// OnMatchComplete, when set, is called after each match of an evaluation, one call at a time
+	OnMatchComplete func(MatchRecord)
+	// StandingsEvery is the number of matches between two writes of the standings file,
+	// DefaultStandingsEvery when 0
+	StandingsEvery int
+	// Promotion, when set, makes a model with a better fitness than BestModel play it
+	// before replacing it. nil promotes on the fitness alone.
+	Promotion *PromotionGate
+
+	// promotion is the decision of the gate in the current generation, nil without a match
+	promotion *PromotionDecision
 }
 
 // TrainerInterface defines the common interface for all trainers
@@ -14,6 +14,16 @@ const (
 	StabilityMax = 100
 	FrontierMin  = 1
 	FrontierMax  = 100
+	ThreatMin    = 0
+	ThreatMax    = 100
+	TempoMin     = 0
+	TempoMax     = 100
+	EdgeMin      = 0
+	EdgeMax      = 100
+
+	// Phase boundaries stay within these piece counts
+	PhaseBoundaryMin = 5
+	PhaseBoundaryMax = 63
 )
 
 // New improved mutation parameters
@@ -32,4 +42,8 @@ const (
 
 	// Completely new value generation (very rare)
 	RerollRate = 0.03
+
+	// Phase boundary shifts
+	PhaseBoundaryMutationRate = 0.2
+	PhaseBoundaryDeltaMax     = 2
 )
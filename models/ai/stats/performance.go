@@ -50,3 +50,51 @@ func (s *PerformanceStats) RecordOperation(name string, duration time.Duration,
 		s.Operations[name].Cache[hash]++
 	}
 }
+
+// clone returns a deep copy of the operation statistics
+func (o *OperationStats) clone() *OperationStats {
+	c := &OperationStats{
+		Count: o.Count,
+		Time:  o.Time,
+		Cache: make(map[string]int64, len(o.Cache)),
+	}
+	for hash, hits := range o.Cache {
+		c.Cache[hash] = hits
+	}
+	return c
+}
+
+// Clone returns a deep copy of the statistics, safe to read while s keeps recording
+func (s *PerformanceStats) Clone() *PerformanceStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := &PerformanceStats{
+		Operations: make(map[string]*OperationStats, len(s.Operations)),
+	}
+	for name, op := range s.Operations {
+		c.Operations[name] = op.clone()
+	}
+	return c
+}
+
+// MergeFrom adds the statistics of other to s
+func (s *PerformanceStats) MergeFrom(other *PerformanceStats) {
+	if other == nil || other == s {
+		return
+	}
+	snapshot := other.Clone()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, op := range snapshot.Operations {
+		existing, ok := s.Operations[name]
+		if !ok {
+			s.Operations[name] = op
+			continue
+		}
+		existing.Count += op.Count
+		existing.Time += op.Time
+		for hash, hits := range op.Cache {
+			existing.Cache[hash] += hits
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCloneIsDeep(t *testing.T) {
+	s := NewPerformanceStats()
+	s.RecordOperation("solve", time.Millisecond, "a")
+
+	c := s.Clone()
+	s.RecordOperation("solve", time.Millisecond, "a")
+	s.RecordOperation("eval", time.Millisecond, "b")
+
+	op := c.Operations["solve"]
+	if op.Count != 1 || op.Time != time.Millisecond || op.Cache["a"] != 1 {
+		t.Errorf("clone changed by later records: %+v", op)
+	}
+	if _, ok := c.Operations["eval"]; ok {
+		t.Error("clone has an operation recorded after it was taken")
+	}
+}
+
+func TestMergeFrom(t *testing.T) {
+	s := NewPerformanceStats()
+	s.RecordOperation("solve", time.Millisecond, "a")
+	other := NewPerformanceStats()
+	other.RecordOperation("solve", 2*time.Millisecond, "a")
+	other.RecordOperation("solve", time.Millisecond, "b")
+	other.RecordOperation("eval", time.Millisecond, "c")
+
+	s.MergeFrom(other)
+	s.MergeFrom(nil)
+	s.MergeFrom(s)
+
+	solve := s.Operations["solve"]
+	if solve.Count != 3 || solve.Time != 4*time.Millisecond || solve.Cache["a"] != 2 || solve.Cache["b"] != 1 {
+		t.Errorf("merged solve %+v, want 3 calls in 4ms, hashes a:2 b:1", solve)
+	}
+	if eval := s.Operations["eval"]; eval == nil || eval.Count != 1 {
+		t.Errorf("merged eval %+v, want 1 call", eval)
+	}
+
+	// The merged operations are copies, other keeps its own
+	other.RecordOperation("eval", time.Millisecond, "c")
+	if eval := s.Operations["eval"]; eval.Count != 1 {
+		t.Errorf("merged eval shares its statistics with the source: %d calls", eval.Count)
+	}
+}
+
+func TestMergeFromConcurrentWorkers(t *testing.T) {
+	total := NewPerformanceStats()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := NewPerformanceStats()
+			for range 100 {
+				local.RecordOperation("solve", time.Microsecond, "a")
+			}
+			total.MergeFrom(local)
+		}()
+	}
+	wg.Wait()
+
+	if solve := total.Operations["solve"]; solve.Count != 800 || solve.Cache["a"] != 800 {
+		t.Errorf("merged %d calls, %d hits, want 800", solve.Count, solve.Cache["a"])
+	}
+}
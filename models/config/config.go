@@ -0,0 +1,198 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/ai/learning"
+)
+
+// Version is the build version, injected with
+// -ldflags "-X github.com/Coloc3G/othello-engine/models/config.Version=v1.0.0"
+var Version = "dev"
+
+// DefaultFile is the config file looked up in the home directory when -config is not given
+const DefaultFile = ".othello-engine.json"
+
+// Config holds the settings shared by every cmd tool. Values come from the defaults,
+// then the config file, then the command line flags.
+type Config struct {
+	// Search depth
+	Depth int `json:"depth"`
+	// Search time budget in milliseconds, 0 for depth only
+	TimeMs int `json:"time_ms"`
+	// Number of threads or parallel workers
+	Threads int `json:"threads"`
+	// Evaluation model name, see evaluation.Models, or "run:NAME[@genN]" for a model
+	// of a training run under learning.DefaultRunRoot
+	Model string `json:"model"`
+	// Log level: debug, info, warn or error
+	LogLevel string `json:"log_level"`
+
+	configPath  string
+	showVersion bool
+}
+
+// Default returns the settings used when neither a file nor a flag sets them
+func Default() Config {
+	return Config{
+		Depth:    5,
+		Threads:  runtime.NumCPU(),
+		Model:    evaluation.Models[len(evaluation.Models)-1].Name,
+		LogLevel: "info",
+	}
+}
+
+// Flags selects the shared settings a tool registers as flags, those it honours
+type Flags uint
+
+const (
+	DepthFlag    Flags = 1 << iota // -depth
+	TimeFlag                       // -time-ms
+	ThreadsFlag                    // -threads
+	ModelFlag                      // -model
+	LogLevelFlag                   // -log-level
+)
+
+// RegisterFlags registers the shared flags selected by flags on fs, using the current values
+// of cfg as defaults. -config and -version are always registered. The settings of the config
+// file that are not registered as flags are loaded but left unused by the tool.
+func RegisterFlags(fs *flag.FlagSet, cfg *Config, flags Flags) {
+	if flags&DepthFlag != 0 {
+		fs.IntVar(&cfg.Depth, "depth", cfg.Depth, "Search depth")
+	}
+	if flags&TimeFlag != 0 {
+		fs.IntVar(&cfg.TimeMs, "time-ms", cfg.TimeMs, "Search time budget in milliseconds (0 = depth only)")
+	}
+	if flags&ThreadsFlag != 0 {
+		fs.IntVar(&cfg.Threads, "threads", cfg.Threads, "Number of threads to use")
+	}
+	if flags&ModelFlag != 0 {
+		fs.StringVar(&cfg.Model, "model", cfg.Model, "Evaluation model to use, or run:NAME[@genN] for a trained model")
+	}
+	if flags&LogLevelFlag != 0 {
+		fs.StringVar(&cfg.LogLevel, "log-level", cfg.LogLevel, "Log level (debug, info, warn, error)")
+	}
+	fs.StringVar(&cfg.configPath, "config", "", "Config file (default ~/"+DefaultFile+" when it exists)")
+	fs.BoolVar(&cfg.showVersion, "version", false, "Print the version and exit")
+}
+
+// Alias registers legacy as another name for the flag name, kept for compatibility
+func Alias(fs *flag.FlagSet, legacy, name string) {
+	f := fs.Lookup(name)
+	if f == nil {
+		panic("config: alias of unknown flag " + name)
+	}
+	fs.Var(f.Value, legacy, "Deprecated: use -"+name)
+}
+
+// Parse parses args, prints the version and exits if -version is given, then
+// loads the config file. Flags set on the command line take precedence over the file.
+func Parse(fs *flag.FlagSet, args []string, cfg *Config) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if cfg.showVersion {
+		fmt.Printf("%s %s\n", filepath.Base(fs.Name()), Version)
+		os.Exit(0)
+	}
+
+	// Remember the flags given explicitly to apply them again after the file
+	set := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = f.Value.String()
+	})
+
+	path := cfg.configPath
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(home, DefaultFile)
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+	}
+	if err := cfg.loadFile(path); err != nil {
+		return err
+	}
+
+	for name, value := range set {
+		if err := fs.Set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadFile reads a JSON config file into cfg, warning about unknown fields
+func (cfg *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	known := knownFields()
+	for name := range fields {
+		if !known[name] {
+			fmt.Fprintf(os.Stderr, "config: unknown field %q in %s\n", name, path)
+		}
+	}
+	return nil
+}
+
+// knownFields returns the JSON names of the Config fields
+func knownFields() map[string]bool {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(Config{})
+	for i := range t.NumField() {
+		if tag := t.Field(i).Tag.Get("json"); tag != "" {
+			known[strings.Split(tag, ",")[0]] = true
+		}
+	}
+	return known
+}
+
+// Coefficients returns the coefficients of the configured model
+func (cfg Config) Coefficients() (evaluation.EvaluationCoefficients, error) {
+	if ref, ok := strings.CutPrefix(cfg.Model, "run:"); ok {
+		model, err := learning.LoadRunModel(learning.DefaultRunRoot, ref)
+		return model.Coeffs, err
+	}
+	coeffs, found := evaluation.GetCoefficientsByName(cfg.Model)
+	if !found {
+		names := make([]string, len(evaluation.Models))
+		for i, model := range evaluation.Models {
+			names[i] = model.Name
+		}
+		return coeffs, fmt.Errorf("model '%s' not found, available models: %s", cfg.Model, strings.Join(names, " "))
+	}
+	return coeffs, nil
+}
+
+// SlogLevel parses the configured log level
+func (cfg Config) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		return level, fmt.Errorf("invalid log level %q: %w", cfg.LogLevel, err)
+	}
+	return level, nil
+}
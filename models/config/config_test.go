@@ -0,0 +1,152 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newFlagSet returns a flag set registering flags with cfg, errors returned and not printed
+func newFlagSet(cfg *Config, flags Flags) *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	RegisterFlags(fs, cfg, flags)
+	return fs
+}
+
+// writeConfig writes a config file in a temporary directory and returns its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPrecedence(t *testing.T) {
+	// No config file in the home directory
+	t.Setenv("HOME", t.TempDir())
+	all := DepthFlag | TimeFlag | ThreadsFlag | ModelFlag | LogLevelFlag
+	path := writeConfig(t, `{"depth": 7, "model": "V3", "time_ms": 500}`)
+
+	tests := []struct {
+		name   string
+		args   []string
+		depth  int
+		model  string
+		timeMs int
+	}{
+		{"default", nil, 5, Default().Model, 0},
+		{"file", []string{"-config", path}, 7, "V3", 500},
+		{"flag", []string{"-depth", "9"}, 9, Default().Model, 0},
+		{"flag over file", []string{"-config", path, "-depth", "9", "-model", "V2"}, 9, "V2", 500},
+		{"flag before config", []string{"-time-ms", "100", "-config", path}, 7, "V3", 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			if err := Parse(newFlagSet(&cfg, all), tt.args, &cfg); err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Depth != tt.depth || cfg.Model != tt.model || cfg.TimeMs != tt.timeMs {
+				t.Errorf("got depth %d, model %s, time %d, want %d, %s, %d", cfg.Depth, cfg.Model, cfg.TimeMs, tt.depth, tt.model, tt.timeMs)
+			}
+		})
+	}
+}
+
+func TestHomeConfigFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, DefaultFile), []byte(`{"threads": 3}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Default()
+	if err := Parse(newFlagSet(&cfg, ThreadsFlag), nil, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Threads != 3 {
+		t.Errorf("got %d threads, want 3 from ~/%s", cfg.Threads, DefaultFile)
+	}
+}
+
+func TestUnknownFieldWarning(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := writeConfig(t, `{"depth": 4, "hash_size": 64}`)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	cfg := Default()
+	err = Parse(newFlagSet(&cfg, DepthFlag), []string{"-config", path}, &cfg)
+	os.Stderr = stderr
+	w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, _ := io.ReadAll(r)
+	if !strings.Contains(string(out), `unknown field "hash_size"`) {
+		t.Errorf("no warning about the unknown field, got %q", out)
+	}
+	if strings.Contains(string(out), `"depth"`) {
+		t.Errorf("warning about a known field: %q", out)
+	}
+	if cfg.Depth != 4 {
+		t.Errorf("got depth %d, want 4", cfg.Depth)
+	}
+}
+
+func TestLegacyAlias(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := Default()
+	fs := newFlagSet(&cfg, ModelFlag)
+	// cmd/train named the model -base
+	Alias(fs, "base", "model")
+	if err := Parse(fs, []string{"-base", "V2"}, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Model != "V2" {
+		t.Errorf("got model %s, want V2", cfg.Model)
+	}
+}
+
+func TestAliasOverridesFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := writeConfig(t, `{"model": "V3"}`)
+	cfg := Default()
+	fs := newFlagSet(&cfg, ModelFlag)
+	Alias(fs, "base", "model")
+	if err := Parse(fs, []string{"-config", path, "-base", "V2"}, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Model != "V2" {
+		t.Errorf("got model %s, want the -base flag V2 over the file", cfg.Model)
+	}
+}
+
+func TestOnlySelectedFlagsRegistered(t *testing.T) {
+	cfg := Default()
+	fs := newFlagSet(&cfg, DepthFlag)
+	for _, name := range []string{"time-ms", "threads", "model", "log-level"} {
+		if fs.Lookup(name) != nil {
+			t.Errorf("-%s is registered without being selected", name)
+		}
+	}
+	for _, name := range []string{"depth", "config", "version"} {
+		if fs.Lookup(name) == nil {
+			t.Errorf("-%s is not registered", name)
+		}
+	}
+	if err := fs.Parse([]string{"-time-ms", "100"}); err == nil {
+		t.Error("an unregistered flag was accepted")
+	}
+}
@@ -0,0 +1,59 @@
+package game
+
+import "math/bits"
+
+// Weights of BitBoardFastScore terms
+const (
+	FastScoreCornerWeight   = 25
+	FastScoreMobilityWeight = 5
+)
+
+const cornersMask = 0x8100000000000081
+
+// BitBoardFastScore is a cheap heuristic for very shallow searches, positive for White.
+// It combines the piece difference, corners and mobility without allocating.
+func BitBoardFastScore(bb BitBoard) int16 {
+	empty := ^(bb.WhitePieces | bb.BlackPieces)
+	whiteMobility := bits.OnesCount64(generateValidMovesOptimized(bb.WhitePieces, bb.BlackPieces, empty))
+	blackMobility := bits.OnesCount64(generateValidMovesOptimized(bb.BlackPieces, bb.WhitePieces, empty))
+
+	material := bits.OnesCount64(bb.WhitePieces) - bits.OnesCount64(bb.BlackPieces)
+	corners := bits.OnesCount64(bb.WhitePieces&cornersMask) - bits.OnesCount64(bb.BlackPieces&cornersMask)
+
+	return int16(material + FastScoreCornerWeight*corners + FastScoreMobilityWeight*(whiteMobility-blackMobility))
+}
+
+// BoardToBitBoard converts an array board to a bitboard
+func BoardToBitBoard(b Board) BitBoard {
+	var bb BitBoard
+	for i := range 8 {
+		for j := range 8 {
+			switch b[i][j] {
+			case Black:
+				bb.BlackPieces |= 1 << (i*8 + j)
+			case White:
+				bb.WhitePieces |= 1 << (i*8 + j)
+			}
+		}
+	}
+	return bb
+}
+
+// HasAnyMovesBitBoard reports whether a player has a valid move, without listing the moves
+func HasAnyMovesBitBoard(bb BitBoard, playerColor Piece) bool {
+	playerBits, opponentBits := bb.BlackPieces, bb.WhitePieces
+	if playerColor == White {
+		playerBits, opponentBits = opponentBits, playerBits
+	}
+	return generateValidMovesOptimized(playerBits, opponentBits, ^(playerBits|opponentBits)) != 0
+}
+
+// GameStatusBitBoard is GameStatus on a bitboard
+func GameStatusBitBoard(bb BitBoard) (finished, blackCanMove, whiteCanMove bool) {
+	if bb.BlackPieces|bb.WhitePieces == ^uint64(0) {
+		return true, false, false
+	}
+	blackCanMove = HasAnyMovesBitBoard(bb, Black)
+	whiteCanMove = HasAnyMovesBitBoard(bb, White)
+	return !blackCanMove && !whiteCanMove, blackCanMove, whiteCanMove
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBitBoardStatusMatchesBoard(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for i := 0; i < 500; i++ {
+		g, err := RandomReachableBoard(rng, rng.Intn(61))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bb := BoardToBitBoard(g.Board)
+		black, white := CountPieces(g.Board)
+		if blackBits, whiteBits := CountPiecesBitBoard(bb); blackBits != black || whiteBits != white {
+			t.Fatalf("board %d: bitboard counts %d/%d, board %d/%d", i, blackBits, whiteBits, black, white)
+		}
+		for _, color := range []Piece{Black, White} {
+			want := len(ValidMoves(g.Board, color)) > 0
+			if got := HasAnyMovesBitBoard(bb, color); got != want {
+				t.Errorf("board %d: HasAnyMovesBitBoard(%d) = %v, board has moves %v", i, color, got, want)
+			}
+		}
+
+		finished, blackCanMove, whiteCanMove := GameStatusBitBoard(bb)
+		wantBlack, wantWhite := len(ValidMoves(g.Board, Black)) > 0, len(ValidMoves(g.Board, White)) > 0
+		if finished != (!wantBlack && !wantWhite) || blackCanMove != wantBlack || whiteCanMove != wantWhite {
+			t.Errorf("board %d: GameStatusBitBoard = %v %v %v, want %v %v %v",
+				i, finished, blackCanMove, whiteCanMove, !wantBlack && !wantWhite, wantBlack, wantWhite)
+		}
+		if f, b, w := GameStatus(g.Board); f != finished || b != blackCanMove || w != whiteCanMove {
+			t.Errorf("board %d: GameStatus = %v %v %v, bitboard %v %v %v", i, f, b, w, finished, blackCanMove, whiteCanMove)
+		}
+		if IsGameFinishedBitBoard(bb) != finished || IsGameFinished(g.Board) != finished {
+			t.Errorf("board %d: game finished differs from GameStatusBitBoard %v", i, finished)
+		}
+	}
+}
+
+func TestBoardToBitBoard(t *testing.T) {
+	var board Board
+	board[0][0] = Black // a1, bit 0
+	board[0][7] = White // h1, bit 7
+	board[7][7] = Black // h8, bit 63
+	bb := BoardToBitBoard(board)
+	if bb.BlackPieces != 1|1<<63 || bb.WhitePieces != 1<<7 {
+		t.Errorf("black %#x, white %#x", bb.BlackPieces, bb.WhitePieces)
+	}
+}
@@ -37,19 +37,28 @@ func (g *Game) DisplayBoard(board Board) {
 	}
 }
 
-// GetNewBoardAfterMove returns a new game state after applying a move
+// GetNewBoardAfterMove returns a new game state after applying a move.
+// It is ApplyMoveToBoard with the position before the player color.
 func GetNewBoardAfterMove(board Board, pos Position, player Piece) (Board, bool) {
 	return ApplyMoveToBoard(board, player, pos)
 }
 
-// GetNewBitBoardAfterMove returns a new bitboard state after applying a move
+// GetNewBitBoardAfterMove returns a new bitboard state after applying a move.
+// It is ApplyMoveToBitBoard with the position before the player color.
 func GetNewBitBoardAfterMove(bb BitBoard, pos Position, player Piece) (BitBoard, bool) {
 	return ApplyMoveToBitBoard(bb, player, pos)
 }
 
+// Preview returns the board after the current player plays pos, without changing the game
+func (g *Game) Preview(pos Position) (Board, bool) {
+	return ApplyMoveToBoard(g.Board, g.CurrentPlayer.Color, pos)
+}
+
 // GetNewBoardAfterMoveMethod is a method wrapper for GetNewBoardAfterMove
+//
+// Deprecated: use Preview.
 func (g *Game) GetNewBoardAfterMoveMethod(pos Position) (Board, bool) {
-	return GetNewBoardAfterMove(g.Board, pos, g.CurrentPlayer.Color)
+	return g.Preview(pos)
 }
 
 // CountPieces counts the number of pieces of each color on the board
@@ -77,7 +86,12 @@ func CountPiecesBitBoard(bb BitBoard) (int, int) {
 	return bits.OnesCount64(bb.BlackPieces), bits.OnesCount64(bb.WhitePieces)
 }
 
-// CountPiecesMethod is a method wrapper for CountPieces
+// CountPiecesMethod returns the discs of each color of the game, BlackCount and WhiteCount
 func (g *Game) CountPiecesMethod() (int, int) {
-	return CountPieces(g.Board)
+	return g.BlackCount, g.WhiteCount
+}
+
+// RecountPieces sets BlackCount and WhiteCount from Board, after Board is set directly
+func (g *Game) RecountPieces() {
+	g.BlackCount, g.WhiteCount = CountPieces(g.Board)
 }
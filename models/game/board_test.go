@@ -0,0 +1,111 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPreviewLeavesGameUnchanged(t *testing.T) {
+	g := NewGame("Black", "White")
+	before := g.Board
+	f5 := Position{Row: 4, Col: 5}
+	preview, ok := g.Preview(f5)
+	if !ok {
+		t.Fatal("f5 is not legal in the initial position")
+	}
+	if g.Board != before || g.CurrentPlayer.Color != Black || len(g.History) != 0 {
+		t.Fatal("Preview changed the game")
+	}
+	if err := g.ApplyMove(f5); err != nil {
+		t.Fatal(err)
+	}
+	if preview != g.Board {
+		t.Error("Preview differs from the board after the move")
+	}
+
+	if _, ok := g.Preview(Position{Row: 0, Col: 0}); ok {
+		t.Error("Preview of an illegal move succeeded")
+	}
+}
+
+func TestBoardAndBitBoardMovesAgree(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for range 20 {
+		g := NewGame("Black", "White")
+		for g.LegalState() != GameOver {
+			if g.LegalState() == MustPass {
+				g.Pass()
+				continue
+			}
+			player := g.CurrentPlayer.Color
+			bb := BoardToBitBoard(g.Board)
+			for _, move := range ValidMoves(g.Board, player) {
+				board, ok := GetNewBoardAfterMove(g.Board, move, player)
+				bits, bitsOk := GetNewBitBoardAfterMove(bb, move, player)
+				if !ok || !bitsOk || BoardToBitBoard(board) != bits {
+					t.Fatalf("move %v of %d: board %v, bitboard %+v", move, player, board, bits)
+				}
+			}
+			// A square taken is not a move for either function
+			for _, occupied := range []Position{{Row: 3, Col: 3}, {Row: 4, Col: 4}} {
+				if _, ok := GetNewBoardAfterMove(g.Board, occupied, player); ok {
+					t.Fatalf("move on the occupied square %v accepted", occupied)
+				}
+				if _, ok := GetNewBitBoardAfterMove(bb, occupied, player); ok {
+					t.Fatalf("bitboard move on the occupied square %v accepted", occupied)
+				}
+			}
+			moves := g.GetValidMovesForCurrentPlayer()
+			if err := g.ApplyMove(moves[rng.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestIncrementalCounts(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	passes := 0
+	for range 100 {
+		g := NewGame("Black", "White")
+		for {
+			black, white := g.CountPiecesMethod()
+			if wantBlack, wantWhite := CountPieces(g.Board); black != wantBlack || white != wantWhite {
+				t.Fatalf("after %v: counts %d/%d, want %d/%d", g.History, black, white, wantBlack, wantWhite)
+			}
+			state := g.LegalState()
+			if state == GameOver {
+				break
+			}
+			if state == MustPass {
+				if err := g.Pass(); err != nil {
+					t.Fatal(err)
+				}
+				passes++
+				continue
+			}
+			moves := g.GetValidMovesForCurrentPlayer()
+			if err := g.ApplyMove(moves[rng.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// An illegal move leaves the counts unchanged
+		black, white := g.CountPiecesMethod()
+		g.ApplyMove(Position{Row: 0, Col: 0})
+		if b, w := g.CountPiecesMethod(); b != black || w != white {
+			t.Fatalf("rejected move changed the counts from %d/%d to %d/%d", black, white, b, w)
+		}
+	}
+	if passes == 0 {
+		t.Error("no game with a pass")
+	}
+
+	// Games built from discs start from their counts
+	g, err := NewGameBuilder().WithTranscript("f5d6c3").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if black, white := g.CountPiecesMethod(); black != 5 || white != 2 {
+		t.Errorf("built game counts %d/%d, want 5/2", black, white)
+	}
+}
@@ -0,0 +1,104 @@
+package game
+
+import "fmt"
+
+// GameBuilder builds a game from the initial position by playing a transcript and setting
+// squares, for positions that would be tedious to write square by square. The first error
+// is kept and returned by Build, the following calls doing nothing.
+type GameBuilder struct {
+	game *Game
+	err  error
+}
+
+// NewGameBuilder creates a builder starting from the initial position, Black to move
+func NewGameBuilder() *GameBuilder {
+	return &GameBuilder{game: NewGame("Black", "White")}
+}
+
+// WithPiece sets the square at row, col, Empty clearing it. The player to move and the
+// history are unchanged, so the history may no longer lead to the board.
+func (b *GameBuilder) WithPiece(row, col int, piece Piece) *GameBuilder {
+	if b.err != nil {
+		return b
+	}
+	if row < 0 || row > 7 || col < 0 || col > 7 {
+		b.err = fmt.Errorf("square %d,%d is off the board", row, col)
+		return b
+	}
+	if piece != Empty && piece != Black && piece != White {
+		b.err = fmt.Errorf("unknown piece %d", piece)
+		return b
+	}
+	b.game.Board[row][col] = piece
+	return b
+}
+
+// WithPieceAt sets the square given in algebraic notation, like "c4"
+func (b *GameBuilder) WithPieceAt(algebraic string, piece Piece) *GameBuilder {
+	if b.err != nil {
+		return b
+	}
+	pos, ok := parseSquare(algebraic)
+	if !ok {
+		b.err = fmt.Errorf("invalid square %q", algebraic)
+		return b
+	}
+	return b.WithPiece(int(pos.Row), int(pos.Col), piece)
+}
+
+// WithTranscript plays the moves of a transcript such as "f5d6c3" from the current board,
+// passing when the player to move has no valid move
+func (b *GameBuilder) WithTranscript(transcript string) *GameBuilder {
+	if b.err != nil {
+		return b
+	}
+	if len(transcript)%2 != 0 {
+		b.err = fmt.Errorf("transcript %q has an odd length", transcript)
+		return b
+	}
+	for i := 0; i < len(transcript); i += 2 {
+		pos, ok := parseSquare(transcript[i : i+2])
+		if !ok {
+			b.err = fmt.Errorf("move %d: invalid square %q", i/2+1, transcript[i:i+2])
+			return b
+		}
+		if b.game.LegalState() == MustPass {
+			b.game.Pass()
+		}
+		if err := b.game.ApplyMove(pos); err != nil {
+			b.err = fmt.Errorf("move %d %s: %w", i/2+1, transcript[i:i+2], err)
+			return b
+		}
+	}
+	return b
+}
+
+// Build returns the game, or the first error met. The board must keep the four center
+// squares occupied, as in every position reachable from the initial one.
+func (b *GameBuilder) Build() (*Game, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	for _, pos := range []Position{{3, 3}, {3, 4}, {4, 3}, {4, 4}} {
+		if b.game.Board[pos.Row][pos.Col] == Empty {
+			return nil, fmt.Errorf("center square %c%c is empty", 'a'+pos.Col, '1'+pos.Row)
+		}
+	}
+	g := *b.game
+	g.History = append([]Position(nil), b.game.History...)
+	g.RecountPieces()
+	return &g, nil
+}
+
+// parseSquare parses a square in algebraic notation, column letter then row number
+func parseSquare(algebraic string) (Position, bool) {
+	if len(algebraic) != 2 {
+		return Position{}, false
+	}
+	col := int8(algebraic[0] - 'a')
+	row := int8(algebraic[1] - '1')
+	if row < 0 || row > 7 || col < 0 || col > 7 {
+		return Position{}, false
+	}
+	return Position{Row: row, Col: col}, true
+}
@@ -0,0 +1,18 @@
+package game
+
+// BoardFingerprint identifies a position up to the symmetries of the square: boards that
+// are rotations or reflections of each other have the same fingerprint
+type BoardFingerprint [2]uint64
+
+// Fingerprint returns the smallest Hash128 of the 8 images of bb by the symmetries of the
+// square. As Hash128, it does not include the side to move.
+func Fingerprint(bb BitBoard) BoardFingerprint {
+	var best BoardFingerprint
+	for i, b := range bb.Symmetries() {
+		h := BoardFingerprint(b.Hash128())
+		if i == 0 || h[0] < best[0] || (h[0] == best[0] && h[1] < best[1]) {
+			best = h
+		}
+	}
+	return best
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestSymmetriesRoundTrip(t *testing.T) {
+	for i, bb := range randomDiscs(50) {
+		images := bb.Symmetries()
+		if images[0] != bb {
+			t.Errorf("board %d: first image %+v, want the board", i, images[0])
+		}
+		for j, image := range images {
+			// Each symmetry has an inverse among the 8, which brings the board back
+			back := false
+			for _, b := range image.Symmetries() {
+				back = back || b == bb
+			}
+			if !back {
+				t.Errorf("board %d: no symmetry of image %d gives the board back", i, j)
+			}
+			// The images of an image are the images of the board
+			for _, b := range image.Symmetries() {
+				found := false
+				for _, other := range images {
+					found = found || b == other
+				}
+				if !found {
+					t.Errorf("board %d: image %d has an image %+v outside the 8 of the board", i, j, b)
+				}
+			}
+		}
+	}
+}
+
+func TestSymmetriesDistinct(t *testing.T) {
+	// A board without symmetry has 8 distinct images, with as many discs
+	bb := BitBoard{BlackPieces: 1<<0 | 1<<1 | 1<<10, WhitePieces: 1 << 20}
+	seen := make(map[BitBoard]int)
+	for j, image := range bb.Symmetries() {
+		if other, ok := seen[image]; ok {
+			t.Errorf("images %d and %d are the same", other, j)
+		}
+		seen[image] = j
+		if image.BlackPieces&image.WhitePieces != 0 {
+			t.Errorf("image %d has squares of both colors", j)
+		}
+		if b, w := bits.OnesCount64(image.BlackPieces), bits.OnesCount64(image.WhitePieces); b != 3 || w != 1 {
+			t.Errorf("image %d has %d black and %d white discs, want 3 and 1", j, b, w)
+		}
+	}
+
+	// The corners are exchanged among themselves
+	for j, image := range (BitBoard{BlackPieces: 1}).Symmetries() {
+		if image.BlackPieces&0x8100000000000081 == 0 {
+			t.Errorf("image %d moves a1 to %#x, not a corner", j, image.BlackPieces)
+		}
+	}
+}
+
+func TestFingerprintInvariant(t *testing.T) {
+	for i, bb := range randomDiscs(50) {
+		want := Fingerprint(bb)
+		for j, image := range bb.Symmetries() {
+			if got := Fingerprint(image); got != want {
+				t.Errorf("board %d, symmetry %d: fingerprint %x, want %x", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestFingerprintSeparatesPositions(t *testing.T) {
+	start := BoardToBitBoard(NewGame("Black", "White").Board)
+	tests := []struct {
+		name string
+		a, b BitBoard
+		same bool
+	}{
+		{"start and itself", start, start, true},
+		// The 4 first moves lead to symmetric positions
+		{"d3 and f5", playMoves(t, "d3"), playMoves(t, "f5"), true},
+		{"c4 and e6", playMoves(t, "c4"), playMoves(t, "e6"), true},
+		{"diagonal and perpendicular", playMoves(t, "f5f6"), playMoves(t, "f5d6"), false},
+		{"colors swapped", start, BitBoard{BlackPieces: start.WhitePieces, WhitePieces: start.BlackPieces}, true},
+		{"one more disc", start, BitBoard{BlackPieces: start.BlackPieces | 1, WhitePieces: start.WhitePieces}, false},
+	}
+	for _, tt := range tests {
+		if same := Fingerprint(tt.a) == Fingerprint(tt.b); same != tt.same {
+			t.Errorf("%s: same fingerprint %v, want %v", tt.name, same, tt.same)
+		}
+	}
+}
+
+// playMoves returns the board after the moves of a transcript from the start position
+func playMoves(t *testing.T, transcript string) BitBoard {
+	t.Helper()
+	g, err := NewGameBuilder().WithTranscript(transcript).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return BoardToBitBoard(g.Board)
+}
@@ -1,27 +1,21 @@
 package game
 
-// IsGameFinished checks if the game is over on a given board by determining if any valid moves remain
-// The game is finished when neither player has any valid moves
+// IsGameFinished reports whether the board is full or neither player has a valid move
 func IsGameFinished(board Board) bool {
-	// Check if black player has valid moves
-	blackMoves := ValidMoves(board, Black)
-
-	// Check if white player has valid moves
-	whiteMoves := ValidMoves(board, White)
+	finished, _, _ := GameStatus(board)
+	return finished
+}
 
-	// Game is finished if neither player has valid moves
-	return len(blackMoves) == 0 && len(whiteMoves) == 0
+// GameStatus reports whether the game is finished and which players can move.
+// The game is finished when the board is full or neither player has a valid move.
+func GameStatus(board Board) (finished, blackCanMove, whiteCanMove bool) {
+	return GameStatusBitBoard(BoardToBitBoard(board))
 }
 
+// IsGameFinishedBitBoard reports whether the board is full or neither player has a valid move
 func IsGameFinishedBitBoard(bb BitBoard) bool {
-	// Check if black player has valid moves
-	blackMoves := ValidMovesBitBoard(bb, Black)
-
-	// Check if white player has valid moves
-	whiteMoves := ValidMovesBitBoard(bb, White)
-
-	// Game is finished if neither player has valid moves
-	return len(blackMoves) == 0 && len(whiteMoves) == 0
+	finished, _, _ := GameStatusBitBoard(bb)
+	return finished
 }
 
 // IsGameFinishedMethod is a method wrapper for IsGameFinished
@@ -30,46 +24,12 @@ func (g *Game) IsGameFinishedMethod() bool {
 }
 
 // GetWinner returns the winner of the game (color with more pieces)
-// If it's a tie, returns Empty
-// GetWinner determines the winner of an Othello game based on the current board.
-// It counts the number of black and white pieces on the board and returns:
-// - Black if there are more black pieces
-// - White if there are more white pieces
-// - Empty if there's a tie (equal number of black and white pieces)
-//
-// Parameters:
-//   - board: The game board to evaluate
-//
-// Returns:
-//   - Piece: The winning piece color (Black, White) or Empty in case of a tie
+// If it's a tie, returns Empty. It is Winner, kept for the existing callers.
 func GetWinner(board Board) Piece {
-	blackCount := 0
-	whiteCount := 0
-
-	// Count pieces
-	for row := range board {
-		for col := range board[row] {
-			switch board[row][col] {
-			case Black:
-				blackCount++
-			case White:
-				whiteCount++
-			}
-		}
-	}
-
-	// Determine winner
-	if blackCount > whiteCount {
-		return Black
-	} else if whiteCount > blackCount {
-		return White
-	}
-
-	// Tie
-	return Empty
+	return Winner(board)
 }
 
-// GetWinnerMethod is a method wrapper for GetWinner
+// GetWinnerMethod returns the winner of the game under its variant
 func (g *Game) GetWinnerMethod() Piece {
-	return GetWinner(g.Board)
+	return GetWinnerVariant(g.Board, g.Variant)
 }
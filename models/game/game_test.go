@@ -0,0 +1,47 @@
+package game
+
+import "testing"
+
+func TestGameStatus(t *testing.T) {
+	var full Board
+	for row := range 8 {
+		for col := range 8 {
+			full[row][col] = Black
+		}
+	}
+	full[0][0] = White
+
+	// White can flip b1 from c1, nothing flips the White corner
+	oneSide := Board{}
+	oneSide[0][0], oneSide[0][1] = White, Black
+
+	// A Black corner and a White center, no line reaches an empty square
+	neither := Board{}
+	neither[0][0] = Black
+	neither[3][3], neither[3][4], neither[4][3], neither[4][4] = White, White, White, White
+
+	tests := []struct {
+		name                   string
+		board                  Board
+		finished, black, white bool
+	}{
+		{"initial", NewGame("Black", "White").Board, false, true, true},
+		{"full board", full, true, false, false},
+		{"one side can move", oneSide, false, false, true},
+		{"neither can move", neither, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finished, black, white := GameStatus(tt.board)
+			if finished != tt.finished || black != tt.black || white != tt.white {
+				t.Errorf("got finished %v, black %v, white %v, want %v, %v, %v", finished, black, white, tt.finished, tt.black, tt.white)
+			}
+			if IsGameFinished(tt.board) != tt.finished {
+				t.Errorf("IsGameFinished disagrees with GameStatus")
+			}
+			if HasAnyMoves(tt.board, Black) != tt.black || HasAnyMoves(tt.board, White) != tt.white {
+				t.Errorf("HasAnyMoves disagrees with GameStatus")
+			}
+		})
+	}
+}
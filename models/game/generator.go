@@ -0,0 +1,60 @@
+package game
+
+// BoardGenerator enumerates the positions reachable from a position
+type BoardGenerator struct {
+	// ToMove is the player to move in the initial position, Black when Empty
+	ToMove Piece
+
+	visited map[positionKey]bool // Positions already emitted, with their side to move
+}
+
+// Generate emits the distinct positions reachable from initial in 1 to maxMoves moves, in BFS
+// order, each once. Passes do not count as moves, so the positions of depth d are those with
+// d more discs than initial. A board reached with both players to move is emitted twice.
+// Transpositions are emitted once, so the positions of a depth are not its perft count, the
+// number of move sequences: from the initial position, depth 3 has 54 positions for 56 sequences.
+// The channel is closed once every position is emitted, it must be drained.
+func (bg *BoardGenerator) Generate(initial BitBoard, maxMoves int) <-chan BitBoard {
+	out := make(chan BitBoard, 64)
+	player := bg.ToMove
+	if player == Empty {
+		player = Black
+	}
+	bg.visited = make(map[positionKey]bool)
+
+	go func() {
+		defer close(out)
+		type node struct {
+			board  BitBoard
+			player Piece
+		}
+		level := []node{{initial, player}}
+		bg.visited[positionKey{initial, player}] = true
+
+		for depth := 0; depth < maxMoves && len(level) > 0; depth++ {
+			var next []node
+			for _, n := range level {
+				mover := n.player
+				moves := ValidMovesMask(n.board, mover)
+				if moves == 0 {
+					// Pass, or nothing more to play when the opponent cannot move either
+					mover = GetOpponentColor(mover)
+					moves = ValidMovesMask(n.board, mover)
+				}
+				for _, move := range MaskToPositions(moves) {
+					child, _ := GetNewBitBoardAfterMove(n.board, move, mover)
+					opponent := GetOpponentColor(mover)
+					key := positionKey{child, opponent}
+					if bg.visited[key] {
+						continue
+					}
+					bg.visited[key] = true
+					out <- child
+					next = append(next, node{child, opponent})
+				}
+			}
+			level = next
+		}
+	}()
+	return out
+}
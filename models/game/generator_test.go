@@ -0,0 +1,37 @@
+package game
+
+import (
+	"math/bits"
+	"testing"
+)
+
+// generatedPerDepth counts the positions Generate emits per depth, their discs added to initial
+func generatedPerDepth(initial BitBoard, maxMoves int) []int {
+	counts := make([]int, maxMoves+1)
+	discs := bits.OnesCount64(initial.BlackPieces | initial.WhitePieces)
+	var bg BoardGenerator
+	for b := range bg.Generate(initial, maxMoves) {
+		counts[bits.OnesCount64(b.BlackPieces|b.WhitePieces)-discs]++
+	}
+	return counts
+}
+
+func TestGenerateTwoMoves(t *testing.T) {
+	counts := generatedPerDepth(BoardToBitBoard(NewGame("Black", "White").Board), 2)
+	// perft(1) = 4 and perft(2) = 12: no two sequences of two moves transpose
+	if counts[1] != 4 || counts[2] != 12 {
+		t.Errorf("positions per depth %v, want 4 then 12", counts[1:])
+	}
+}
+
+func TestGenerateDistinctPositions(t *testing.T) {
+	counts := generatedPerDepth(BoardToBitBoard(NewGame("Black", "White").Board), 4)
+	// perft(3) = 56 and perft(4) = 244 count the transpositions again
+	want := []int{0, 4, 12, 54, 236}
+	for depth := range want {
+		if counts[depth] != want[depth] {
+			t.Errorf("positions per depth %v, want %v", counts, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"math/bits"
+	"math/rand"
+)
+
+// Seeds of the two independent key tables of Hash128
+const (
+	Hash128Seed0 int64 = 0x5eed0001
+	Hash128Seed1 int64 = 0x5eed0002
+)
+
+// hash128Keys holds the Zobrist keys of Hash128, indexed by table, color (0 black, 1 white) and square
+var hash128Keys = func() (keys [2][2][64]uint64) {
+	for t, seed := range []int64{Hash128Seed0, Hash128Seed1} {
+		r := rand.New(rand.NewSource(seed))
+		for c := range keys[t] {
+			for sq := range keys[t][c] {
+				keys[t][c][sq] = r.Uint64()
+			}
+		}
+	}
+	return keys
+}()
+
+// Hash128 returns two independent 64-bit Zobrist hashes of the discs, the side to move is not included.
+// Checking the second half on a transposition table hit makes a collision practically impossible.
+func (bb BitBoard) Hash128() [2]uint64 {
+	var h [2]uint64
+	for b := bb.BlackPieces; b != 0; b &= b - 1 {
+		sq := bits.TrailingZeros64(b)
+		h[0] ^= hash128Keys[0][0][sq]
+		h[1] ^= hash128Keys[1][0][sq]
+	}
+	for w := bb.WhitePieces; w != 0; w &= w - 1 {
+		sq := bits.TrailingZeros64(w)
+		h[0] ^= hash128Keys[0][1][sq]
+		h[1] ^= hash128Keys[1][1][sq]
+	}
+	return h
+}
@@ -0,0 +1,66 @@
+package game
+
+import "fmt"
+
+// GameMode tells which players of a game are humans, so code playing the moves does not
+// depend on the names of the players
+type GameMode uint8
+
+const (
+	// HumanVsHuman: both players are humans, the mode of NewGame
+	HumanVsHuman GameMode = iota
+	// HumanVsAI: Game.Human is played by a human, the other color by the AI
+	HumanVsAI
+	// AIVsAI: both players are played by the AI
+	AIVsAI
+)
+
+func (m GameMode) String() string {
+	switch m {
+	case HumanVsHuman:
+		return "human vs human"
+	case HumanVsAI:
+		return "human vs AI"
+	case AIVsAI:
+		return "AI vs AI"
+	default:
+		return fmt.Sprintf("GameMode(%d)", int(m))
+	}
+}
+
+// NewHumanVsHumanGame creates a game between two humans, player1 playing Black
+func NewHumanVsHumanGame(player1, player2 string) *Game {
+	return NewGame(player1, player2)
+}
+
+// NewHumanVsAIGame creates a game of a human against the AI, player1 playing Black. human
+// is the color of the human.
+func NewHumanVsAIGame(player1, player2 string, human Piece) *Game {
+	g := NewGame(player1, player2)
+	g.Mode = HumanVsAI
+	g.Human = human
+	return g
+}
+
+// NewAIVsAIGame creates a game between two AIs, player1 playing Black
+func NewAIVsAIGame(player1, player2 string) *Game {
+	g := NewGame(player1, player2)
+	g.Mode = AIVsAI
+	return g
+}
+
+// IsHuman reports whether the player of color is a human in the mode of the game
+func (g *Game) IsHuman(color Piece) bool {
+	switch g.Mode {
+	case HumanVsHuman:
+		return true
+	case HumanVsAI:
+		return color == g.Human
+	}
+	return false
+}
+
+// IsHumanTurn reports whether the player to move is a human
+func (g *Game) IsHumanTurn() bool {
+	return g.IsHuman(g.CurrentPlayer.Color)
+}
@@ -65,6 +65,16 @@ func ValidMoves(board Board, playerColor Piece) []Position {
 // ValidMovesBitBoard returns all valid moves for a player using state-of-the-art bitboard operations
 // Uses optimized Kogge-Stone sliding attack generation for maximum performance
 func ValidMovesBitBoard(board BitBoard, playerColor Piece) []Position {
+	return bitboardToPositionsOptimized(ValidMovesMask(board, playerColor))
+}
+
+// MaskToPositions returns the squares of a move mask in the order of ValidMovesBitBoard
+func MaskToPositions(mask uint64) []Position {
+	return bitboardToPositionsOptimized(mask)
+}
+
+// ValidMovesMask returns the squares where a player can move as a bitboard, without allocating
+func ValidMovesMask(board BitBoard, playerColor Piece) uint64 {
 	var playerBits, opponentBits uint64
 	if playerColor == White {
 		playerBits = board.WhitePieces
@@ -77,9 +87,7 @@ func ValidMovesBitBoard(board BitBoard, playerColor Piece) []Position {
 	emptyBits := ^(playerBits | opponentBits)
 
 	// Use state-of-the-art move generation combining all directions
-	validMoves := generateValidMovesOptimized(playerBits, opponentBits, emptyBits)
-
-	return bitboardToPositionsOptimized(validMoves)
+	return generateValidMovesOptimized(playerBits, opponentBits, emptyBits)
 }
 
 // generateValidMovesOptimized uses optimized Kogge-Stone algorithm for all 8 directions
@@ -316,9 +324,15 @@ func (g *Game) GetValidMovesForCurrentPlayer() []Position {
 //
 // If the move is invalid, the original board is returned unchanged with false as the second return value.
 func ApplyMoveToBoard(board Board, playerColor Piece, pos Position) (Board, bool) {
+	newBoard, _, ok := applyMoveToBoard(board, playerColor, pos)
+	return newBoard, ok
+}
+
+// applyMoveToBoard is ApplyMoveToBoard, also returning the number of discs flipped
+func applyMoveToBoard(board Board, playerColor Piece, pos Position) (Board, int, bool) {
 	// Check if the move is valid
 	if !IsValidMove(board, playerColor, pos) {
-		return board, false
+		return board, 0, false
 	}
 
 	// Create a copy of the board
@@ -338,6 +352,7 @@ func ApplyMoveToBoard(board Board, playerColor Piece, pos Position) (Board, bool
 	}
 
 	// Check all 8 directions and flip pieces
+	flipped := 0
 	for _, dir := range directions {
 		// Store pieces to flip
 		piecesToFlip := []Position{}
@@ -356,10 +371,11 @@ func ApplyMoveToBoard(board Board, playerColor Piece, pos Position) (Board, bool
 			for _, flipPos := range piecesToFlip {
 				newBoard[flipPos.Row][flipPos.Col] = playerColor
 			}
+			flipped += len(piecesToFlip)
 		}
 	}
 
-	return newBoard, true
+	return newBoard, flipped, true
 }
 
 // ApplyMoveToBitBoard applies a move to a bitboard and returns the new bitboard state
@@ -438,29 +454,49 @@ func ApplyMoveToBitBoard(board BitBoard, playerColor Piece, pos Position) (BitBo
 	return newBoard, true
 }
 
-// ApplyMove applies a move to the current game state
-func (g *Game) ApplyMove(pos Position) bool {
-	newBoard, success := ApplyMoveToBoard(g.Board, g.CurrentPlayer.Color, pos)
+// ApplyMove applies a move for the current player to the game state.
+// It returns ErrGameOver when nobody can move, ErrMustPass when the current player
+// has to pass instead, and ErrIllegalMove when the move is not valid.
+func (g *Game) ApplyMove(pos Position) error {
+	switch g.LegalState() {
+	case GameOver:
+		return ErrGameOver
+	case MustPass:
+		return ErrMustPass
+	}
 
+	newBoard, flipped, success := applyMoveToBoard(g.Board, g.CurrentPlayer.Color, pos)
 	if !success {
-		return false
+		return ErrIllegalMove
 	}
 
 	g.Board = newBoard
+	if g.CurrentPlayer.Color == Black {
+		g.BlackCount += flipped + 1
+		g.WhiteCount -= flipped
+	} else {
+		g.WhiteCount += flipped + 1
+		g.BlackCount -= flipped
+	}
 	g.NbMoves++
 	g.History = append(g.History, pos)
 
 	// Switch to the other player
-	otherPlayer := GetOtherPlayer(g.CurrentPlayer.Color)
-	g.CurrentPlayer = otherPlayer
+	g.switchPlayer()
+
+	return nil
+}
 
-	return true
+// TryApplyMove applies a move and reports whether it was accepted.
+//
+// Deprecated: use ApplyMove, which reports why a move was rejected.
+func (g *Game) TryApplyMove(pos Position) bool {
+	return g.ApplyMove(pos) == nil
 }
 
 // HasAnyMoves checks if there are any valid moves for a given player color on a board
 func HasAnyMoves(board Board, playerColor Piece) bool {
-	moves := ValidMoves(board, playerColor)
-	return len(moves) > 0
+	return HasAnyMovesBitBoard(BoardToBitBoard(board), playerColor)
 }
 
 // HasAnyMovesInGame is a method wrapper for HasAnyMoves
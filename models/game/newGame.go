@@ -21,6 +21,7 @@ func NewGame(player1, player2 string) *Game {
 	g.Board[3][4] = Black
 	g.Board[4][3] = Black
 	g.Board[4][4] = White
+	g.BlackCount, g.WhiteCount = 2, 2
 
 	// Initialize both players
 	g.Players[0] = Player{Color: Black, Name: player1}
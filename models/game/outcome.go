@@ -0,0 +1,36 @@
+package game
+
+import "math/bits"
+
+// Winner returns the color with the most discs on the board, Empty for a draw.
+// The board is not checked to be finished.
+func Winner(board Board) Piece {
+	return winnerOfMargin(FinalMargin(board))
+}
+
+// FinalMargin returns the number of black discs minus the number of white discs
+func FinalMargin(board Board) int {
+	black, white := CountPieces(board)
+	return black - white
+}
+
+// WinnerBitBoard is Winner on a bitboard
+func WinnerBitBoard(bb BitBoard) Piece {
+	return winnerOfMargin(FinalMarginBitBoard(bb))
+}
+
+// FinalMarginBitBoard is FinalMargin on a bitboard
+func FinalMarginBitBoard(bb BitBoard) int {
+	return bits.OnesCount64(bb.BlackPieces) - bits.OnesCount64(bb.WhitePieces)
+}
+
+// winnerOfMargin returns the winner of a game ending with a black minus white margin
+func winnerOfMargin(margin int) Piece {
+	switch {
+	case margin > 0:
+		return Black
+	case margin < 0:
+		return White
+	}
+	return Empty
+}
@@ -0,0 +1,47 @@
+package game
+
+import "testing"
+
+// boardOf fills a board row by row from a1 with count discs of first then the others of second
+func boardOf(first Piece, count int, second Piece) Board {
+	var b Board
+	for square := range 64 {
+		p := second
+		if square < count {
+			p = first
+		}
+		b[square/8][square%8] = p
+	}
+	return b
+}
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		board  Board
+		winner Piece
+		margin int
+	}{
+		{"black wins 40-24", boardOf(Black, 40, White), Black, 16},
+		{"white wins 33-31", boardOf(White, 33, Black), White, -2},
+		{"draw 32-32", boardOf(Black, 32, White), Empty, 0},
+		{"black wipeout", boardOf(Black, 10, Empty), Black, 10},
+		{"white wipeout with empty squares", boardOf(White, 7, Empty), White, -7},
+		{"empty board", Board{}, Empty, 0},
+	}
+	for _, tt := range tests {
+		if got := Winner(tt.board); got != tt.winner {
+			t.Errorf("%s: Winner %d, want %d", tt.name, got, tt.winner)
+		}
+		if got := FinalMargin(tt.board); got != tt.margin {
+			t.Errorf("%s: FinalMargin %d, want %d", tt.name, got, tt.margin)
+		}
+		bb := BoardToBitBoard(tt.board)
+		if got := WinnerBitBoard(bb); got != tt.winner {
+			t.Errorf("%s: WinnerBitBoard %d, want %d", tt.name, got, tt.winner)
+		}
+		if got := FinalMarginBitBoard(bb); got != tt.margin {
+			t.Errorf("%s: FinalMarginBitBoard %d, want %d", tt.name, got, tt.margin)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package game
+
+import "math/rand"
+
+// RandomReachableBoard plays numMoves random legal moves from the initial position, drawing them
+// from rng so the same seed always gives the same game. Passes are played when needed and do not
+// count as moves. The game stops early when it is over.
+func RandomReachableBoard(rng *rand.Rand, numMoves int) (*Game, error) {
+	g := NewGame("Black", "White")
+	for played := 0; played < numMoves; {
+		switch g.LegalState() {
+		case GameOver:
+			return g, nil
+		case MustPass:
+			g.Pass()
+			continue
+		}
+
+		moves := ValidMovesBitBoard(BoardToBitBoard(g.Board), g.CurrentPlayer.Color)
+		if err := g.ApplyMove(moves[rng.Intn(len(moves))]); err != nil {
+			return nil, err
+		}
+		played++
+	}
+	return g, nil
+}
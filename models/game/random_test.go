@@ -0,0 +1,58 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandomReachableBoardSameSeed(t *testing.T) {
+	for _, moves := range []int{0, 1, 10, 30, 80} {
+		a, err := RandomReachableBoard(rand.New(rand.NewSource(7)), moves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := RandomReachableBoard(rand.New(rand.NewSource(7)), moves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Board != b.Board || a.CurrentPlayer.Color != b.CurrentPlayer.Color || len(a.History) != len(b.History) {
+			t.Errorf("%d moves: the same seed gives different games", moves)
+		}
+	}
+
+	a, _ := RandomReachableBoard(rand.New(rand.NewSource(1)), 20)
+	b, _ := RandomReachableBoard(rand.New(rand.NewSource(2)), 20)
+	if a.Board == b.Board {
+		t.Error("seeds 1 and 2 give the same game")
+	}
+}
+
+func TestRandomReachableBoardMoves(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, moves := range []int{0, 5, 20, 40} {
+		g, err := RandomReachableBoard(rng, moves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Passes are recorded in the history but not counted
+		played := 0
+		for _, move := range g.History {
+			if move != PassMove {
+				played++
+			}
+		}
+		black, white := CountPieces(g.Board)
+		if played != moves || black+white != 4+moves {
+			t.Errorf("%d moves asked: %d played, %d discs", moves, played, black+white)
+		}
+	}
+
+	// A game cannot go past its end
+	g, err := RandomReachableBoard(rng, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.LegalState() != GameOver {
+		t.Errorf("game of 200 moves not over: %v", g.LegalState())
+	}
+}
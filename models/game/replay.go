@@ -0,0 +1,104 @@
+package game
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultFlipDelay is the time between two flips of a GameReplayer animation
+const DefaultFlipDelay = 40 * time.Millisecond
+
+// Clockwise order of the directions around a placed piece, starting north
+var spiralDirections = [3][3]int{
+	{7, 0, 1}, // Row above: north-west, north, north-east
+	{6, 8, 2}, // Same row: west, the placed piece, east
+	{5, 4, 3}, // Row below: south-west, south, south-east
+}
+
+// GameReplayer applies the flips of a move one at a time, for animations.
+// The flips spiral outward from the placed piece: nearest discs first, clockwise
+// from north between discs at the same distance.
+type GameReplayer struct {
+	FlipDelay time.Duration // Minimum time between two flips, 0 flips on every frame
+
+	board    Board
+	player   Piece
+	flips    []Position
+	next     int
+	lastFlip time.Time
+}
+
+// NewGameReplayer creates a replayer flipping a disc every DefaultFlipDelay
+func NewGameReplayer() *GameReplayer {
+	return &GameReplayer{FlipDelay: DefaultFlipDelay}
+}
+
+// StartFlip starts the animation of player playing move on board. The placed piece is
+// shown at once. An illegal move has nothing to animate.
+func (r *GameReplayer) StartFlip(board Board, move Position, player Piece) {
+	r.board = board
+	r.player = player
+	r.flips = r.flips[:0]
+	r.next = 0
+	r.lastFlip = time.Now()
+
+	after, ok := ApplyMoveToBoard(board, player, move)
+	if !ok {
+		return
+	}
+	r.board[move.Row][move.Col] = player
+	for row := range after {
+		for col := range after[row] {
+			if after[row][col] != board[row][col] && (int8(row) != move.Row || int8(col) != move.Col) {
+				r.flips = append(r.flips, Position{Row: int8(row), Col: int8(col)})
+			}
+		}
+	}
+
+	distance := func(p Position) int {
+		return max(abs(int(p.Row-move.Row)), abs(int(p.Col-move.Col)))
+	}
+	direction := func(p Position) int {
+		return spiralDirections[sign(int(p.Row-move.Row))+1][sign(int(p.Col-move.Col))+1]
+	}
+	sort.SliceStable(r.flips, func(i, j int) bool {
+		di, dj := distance(r.flips[i]), distance(r.flips[j])
+		if di != dj {
+			return di < dj
+		}
+		return direction(r.flips[i]) < direction(r.flips[j])
+	})
+}
+
+// NextFrame returns the board to draw, flipping the next disc once FlipDelay has
+// passed since the previous flip. done is true once every disc is flipped.
+func (r *GameReplayer) NextFrame() (board Board, done bool) {
+	if r.next >= len(r.flips) {
+		return r.board, true
+	}
+	if r.FlipDelay > 0 && time.Since(r.lastFlip) < r.FlipDelay {
+		return r.board, false
+	}
+	flip := r.flips[r.next]
+	r.board[flip.Row][flip.Col] = r.player
+	r.next++
+	r.lastFlip = time.Now()
+	return r.board, r.next == len(r.flips)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
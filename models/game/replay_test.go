@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// square returns the position of a square such as "d4"
+func square(name string) Position {
+	return Position{Row: int8(name[1] - '1'), Col: int8(name[0] - 'a')}
+}
+
+// boardWith returns an empty board with the discs of each color
+func boardWith(black, white []string) Board {
+	var b Board
+	for _, s := range black {
+		p := square(s)
+		b[p.Row][p.Col] = Black
+	}
+	for _, s := range white {
+		p := square(s)
+		b[p.Row][p.Col] = White
+	}
+	return b
+}
+
+func TestReplayerSpiralOrder(t *testing.T) {
+	// Black d4 flips d5 and d6 to the south, e4 to the east and c3 to the north-west
+	board := boardWith([]string{"d7", "f4", "b2"}, []string{"d5", "d6", "e4", "c3"})
+	move := square("d4")
+	r := &GameReplayer{}
+	r.StartFlip(board, move, Black)
+
+	want := []string{"e4", "d5", "c3", "d6"}
+	previous := board
+	previous[move.Row][move.Col] = Black
+	for i, name := range want {
+		frame, done := r.NextFrame()
+		if frame[move.Row][move.Col] != Black {
+			t.Fatalf("frame %d: placed piece not shown", i)
+		}
+		p := square(name)
+		if frame[p.Row][p.Col] != Black {
+			t.Fatalf("frame %d: %s not flipped", i, name)
+		}
+		if diff := countDiff(previous, frame); diff != 1 {
+			t.Fatalf("frame %d: %d squares changed, want 1", i, diff)
+		}
+		if done != (i == len(want)-1) {
+			t.Errorf("frame %d: done %v", i, done)
+		}
+		previous = frame
+	}
+
+	after, _ := ApplyMoveToBoard(board, Black, move)
+	if frame, done := r.NextFrame(); !done || frame != after {
+		t.Errorf("final frame %v (done %v), want the board after the move", frame, done)
+	}
+}
+
+func TestReplayerIllegalMove(t *testing.T) {
+	board := NewGame("Black", "White").Board
+	r := &GameReplayer{}
+	r.StartFlip(board, square("a1"), Black)
+	if frame, done := r.NextFrame(); !done || frame != board {
+		t.Errorf("illegal move animated: done %v", done)
+	}
+}
+
+func TestReplayerFlipDelay(t *testing.T) {
+	board := NewGame("Black", "White").Board
+	r := &GameReplayer{FlipDelay: time.Hour}
+	move := square("d3")
+	r.StartFlip(board, move, Black)
+	frame, done := r.NextFrame()
+	if done {
+		t.Fatal("animation done before the delay")
+	}
+	// Only the placed piece is shown until the delay has passed
+	want := board
+	want[move.Row][move.Col] = Black
+	if frame != want {
+		t.Errorf("disc flipped before the delay")
+	}
+}
+
+// countDiff returns the number of squares differing between two boards
+func countDiff(a, b Board) int {
+	n := 0
+	for row := range a {
+		for col := range a[row] {
+			if a[row][col] != b[row][col] {
+				n++
+			}
+		}
+	}
+	return n
+}
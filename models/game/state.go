@@ -0,0 +1,63 @@
+package game
+
+import "errors"
+
+// LegalState describes what the player to move is allowed to do
+type LegalState int
+
+const (
+	HasMoves LegalState = iota // The current player has at least one valid move
+	MustPass                   // The current player has no move but the opponent does
+	GameOver                   // Neither player can move
+)
+
+// Errors returned by ApplyMove and Pass
+var (
+	ErrGameOver    = errors.New("game is over")
+	ErrMustPass    = errors.New("current player has no valid move and must pass")
+	ErrIllegalMove = errors.New("illegal move")
+	ErrIllegalPass = errors.New("current player has valid moves and cannot pass")
+)
+
+// PassMove is the entry of Game.History recording a pass, off the board
+var PassMove = Position{Row: -1, Col: -1}
+
+// LegalState returns whether the current player can move, must pass, or if the game is over
+func (g *Game) LegalState() LegalState {
+	finished, blackCanMove, whiteCanMove := GameStatus(g.Board)
+	if finished {
+		return GameOver
+	}
+	if (g.CurrentPlayer.Color == Black && blackCanMove) || (g.CurrentPlayer.Color == White && whiteCanMove) {
+		return HasMoves
+	}
+	return MustPass
+}
+
+// Pass gives the turn to the opponent when the current player has no valid move, recorded
+// as PassMove in History. It returns ErrIllegalPass if the current player can move and
+// ErrGameOver if nobody can.
+func (g *Game) Pass() error {
+	switch g.LegalState() {
+	case HasMoves:
+		return ErrIllegalPass
+	case GameOver:
+		return ErrGameOver
+	}
+
+	g.History = append(g.History, PassMove)
+	g.switchPlayer()
+	return nil
+}
+
+// switchPlayer hands the turn to the opponent, keeping the player's name from g.Players
+func (g *Game) switchPlayer() {
+	next := GetOtherPlayer(g.CurrentPlayer.Color)
+	for _, p := range g.Players {
+		if p.Color == next.Color {
+			next = p
+			break
+		}
+	}
+	g.CurrentPlayer = next
+}
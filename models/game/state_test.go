@@ -0,0 +1,120 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// gameOverGame returns a game where neither player can move with empty squares left:
+// a Black corner that no line reaches and a White center
+func gameOverGame(t *testing.T) *Game {
+	t.Helper()
+	g, err := NewGameBuilder().
+		WithPieceAt("d4", White).WithPieceAt("e4", White).
+		WithPieceAt("d5", White).WithPieceAt("e5", White).
+		WithPieceAt("a1", Black).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// mustPassGame returns a random game where the player to move must pass
+func mustPassGame(t *testing.T) *Game {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	for range 1000 {
+		g := NewGame("Black", "White")
+		for g.LegalState() == HasMoves {
+			moves := g.GetValidMovesForCurrentPlayer()
+			if err := g.ApplyMove(moves[rng.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if g.LegalState() == MustPass {
+			return g
+		}
+	}
+	t.Fatal("no random game needed a pass")
+	return nil
+}
+
+func TestLegalStateInitial(t *testing.T) {
+	if state := NewGame("Black", "White").LegalState(); state != HasMoves {
+		t.Errorf("initial position: got %v, want HasMoves", state)
+	}
+}
+
+func TestDoublePassEndsGame(t *testing.T) {
+	g := gameOverGame(t)
+	if state := g.LegalState(); state != GameOver {
+		t.Fatalf("got %v, want GameOver", state)
+	}
+	if !IsGameFinished(g.Board) {
+		t.Error("IsGameFinished is false when neither player can move")
+	}
+
+	// Passing would hand the turn back to a player who cannot move either
+	g.CurrentPlayer = g.Players[1]
+	if state := g.LegalState(); state != GameOver {
+		t.Errorf("White to move: got %v, want GameOver", state)
+	}
+	if err := g.Pass(); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Pass: got %v, want ErrGameOver", err)
+	}
+}
+
+func TestPassRecordedAndSwitchesPlayer(t *testing.T) {
+	g := mustPassGame(t)
+	player := g.CurrentPlayer.Color
+	history := len(g.History)
+
+	moves := ValidMoves(g.Board, GetOpponentColor(player))
+	if err := g.ApplyMove(moves[0]); !errors.Is(err, ErrMustPass) {
+		t.Errorf("ApplyMove instead of passing: got %v, want ErrMustPass", err)
+	}
+	if err := g.Pass(); err != nil {
+		t.Fatalf("Pass: %v", err)
+	}
+	if g.CurrentPlayer.Color != GetOpponentColor(player) {
+		t.Errorf("the turn stayed with %v", player)
+	}
+	if len(g.History) != history+1 || g.History[history] != PassMove {
+		t.Errorf("the pass is not recorded in %v", g.History)
+	}
+	if state := g.LegalState(); state != HasMoves {
+		t.Errorf("after the pass: got %v, want HasMoves", state)
+	}
+}
+
+func TestPassWithMovesRejected(t *testing.T) {
+	g := NewGame("Black", "White")
+	if err := g.Pass(); !errors.Is(err, ErrIllegalPass) {
+		t.Fatalf("got %v, want ErrIllegalPass", err)
+	}
+	if g.CurrentPlayer.Color != Black || len(g.History) != 0 {
+		t.Error("a rejected pass changed the game")
+	}
+}
+
+func TestMoveAfterGameOverRejected(t *testing.T) {
+	g := gameOverGame(t)
+	board := g.Board
+	for _, pos := range []Position{{Row: 0, Col: 1}, {Row: 2, Col: 3}, {Row: 7, Col: 7}} {
+		if err := g.ApplyMove(pos); !errors.Is(err, ErrGameOver) {
+			t.Errorf("move %v: got %v, want ErrGameOver", pos, err)
+		}
+	}
+	if g.Board != board || g.NbMoves != 0 {
+		t.Error("a rejected move changed the game")
+	}
+}
+
+func TestIllegalMoveRejected(t *testing.T) {
+	g := NewGame("Black", "White")
+	if err := g.ApplyMove(Position{Row: 0, Col: 0}); !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("got %v, want ErrIllegalMove", err)
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import "math/bits"
+
+// Canonical returns the smallest of the 8 boards obtained by rotating and reflecting bb,
+// comparing the black then the white discs. Boards that are images of each other by a
+// symmetry of the square have the same canonical board.
+func (bb BitBoard) Canonical() BitBoard {
+	best := bb
+	for _, b := range bb.Symmetries() {
+		if b.BlackPieces < best.BlackPieces || (b.BlackPieces == best.BlackPieces && b.WhitePieces < best.WhitePieces) {
+			best = b
+		}
+	}
+	return best
+}
+
+// Symmetries returns the 8 images of bb by the symmetries of the square, bb first
+func (bb BitBoard) Symmetries() [8]BitBoard {
+	var images [8]BitBoard
+	b := bb
+	for i := 0; i < 8; i += 2 {
+		images[i] = b
+		images[i+1] = b.apply(flipVertical)
+		b = b.apply(flipDiagonal).apply(flipVertical) // Quarter turn
+	}
+	return images
+}
+
+// MirrorDiagonal returns bb reflected along the a1-h8 diagonal: the disc of row r and
+// column c moves to row c and column r
+func (bb BitBoard) MirrorDiagonal() BitBoard {
+	return bb.apply(flipDiagonal)
+}
+
+// MirrorAntiDiagonal returns bb reflected along the a8-h1 diagonal: the disc of row r and
+// column c moves to row 7-c and column 7-r
+func (bb BitBoard) MirrorAntiDiagonal() BitBoard {
+	return bb.apply(flipAntiDiagonal)
+}
+
+// apply applies a transformation of the squares to the discs of both players
+func (bb BitBoard) apply(f func(uint64) uint64) BitBoard {
+	return BitBoard{BlackPieces: f(bb.BlackPieces), WhitePieces: f(bb.WhitePieces)}
+}
+
+// flipVertical swaps row r and row 7-r
+func flipVertical(x uint64) uint64 {
+	return bits.ReverseBytes64(x)
+}
+
+// flipDiagonal swaps square (r, c) and square (c, r)
+func flipDiagonal(x uint64) uint64 {
+	const (
+		k1 = 0x5500550055005500
+		k2 = 0x3333000033330000
+		k4 = 0x0f0f0f0f00000000
+	)
+	t := k4 & (x ^ (x << 28))
+	x ^= t ^ (t >> 28)
+	t = k2 & (x ^ (x << 14))
+	x ^= t ^ (t >> 14)
+	t = k1 & (x ^ (x << 7))
+	x ^= t ^ (t >> 7)
+	return x
+}
+
+// flipAntiDiagonal swaps square (r, c) and square (7-c, 7-r)
+func flipAntiDiagonal(x uint64) uint64 {
+	const (
+		k1 = 0xaa00aa00aa00aa00
+		k2 = 0xcccc0000cccc0000
+		k4 = 0xf0f0f0f00f0f0f0f
+	)
+	t := x ^ (x << 36)
+	x ^= k4 & (t ^ (x >> 36))
+	t = k2 & (x ^ (x << 18))
+	x ^= t ^ (t >> 18)
+	t = k1 & (x ^ (x << 9))
+	x ^= t ^ (t >> 9)
+	return x
+}
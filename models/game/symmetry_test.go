@@ -0,0 +1,53 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// randomDiscs returns random boards with disjoint black and white discs
+func randomDiscs(count int) []BitBoard {
+	rng := rand.New(rand.NewSource(1))
+	boards := []BitBoard{{BlackPieces: 0x0000000810000000, WhitePieces: 0x0000001008000000}}
+	for len(boards) < count {
+		black := rng.Uint64()
+		boards = append(boards, BitBoard{BlackPieces: black, WhitePieces: rng.Uint64() &^ black})
+	}
+	return boards
+}
+
+func TestMirrorInvolutions(t *testing.T) {
+	for i, bb := range randomDiscs(200) {
+		if got := bb.MirrorDiagonal().MirrorDiagonal(); got != bb {
+			t.Errorf("board %d: MirrorDiagonal twice gives %+v, want %+v", i, got, bb)
+		}
+		if got := bb.MirrorAntiDiagonal().MirrorAntiDiagonal(); got != bb {
+			t.Errorf("board %d: MirrorAntiDiagonal twice gives %+v, want %+v", i, got, bb)
+		}
+	}
+}
+
+func TestMirrorSquares(t *testing.T) {
+	for r := range 8 {
+		for c := range 8 {
+			bb := BitBoard{BlackPieces: 1 << (r*8 + c)}
+			if got, want := bb.MirrorDiagonal().BlackPieces, uint64(1)<<(c*8+r); got != want {
+				t.Errorf("MirrorDiagonal moves row %d column %d to %#x, want %#x", r, c, got, want)
+			}
+			if got, want := bb.MirrorAntiDiagonal().BlackPieces, uint64(1)<<((7-c)*8+7-r); got != want {
+				t.Errorf("MirrorAntiDiagonal moves row %d column %d to %#x, want %#x", r, c, got, want)
+			}
+		}
+	}
+}
+
+func TestCanonicalOfSymmetries(t *testing.T) {
+	for i, bb := range randomDiscs(50) {
+		want := bb.Canonical()
+		for j, image := range bb.Symmetries() {
+			if got := image.Canonical(); got != want {
+				t.Errorf("board %d, symmetry %d: canonical %+v, want %+v", i, j, got, want)
+			}
+		}
+	}
+}
@@ -34,5 +34,12 @@ type Game struct {
 	Players       [2]Player
 	CurrentPlayer Player
 	NbMoves       int
-	History       []Position
+	History       []Position // Moves and passes (PassMove) played, in order
+	Variant       Variant    // Rules deciding the winner, Standard by default
+	Mode          GameMode   // Which players are humans, HumanVsHuman by default
+	Human         Piece      // Color of the human in the HumanVsAI mode
+	// Discs of each color on Board, kept up to date by ApplyMove. Code setting Board
+	// directly calls RecountPieces.
+	BlackCount int
+	WhiteCount int
 }
@@ -0,0 +1,45 @@
+package game
+
+import "fmt"
+
+// Variant is the rule set deciding who wins a finished game
+type Variant int
+
+const (
+	// Standard: the player with the most discs wins
+	Standard Variant = iota
+	// Misere: the player with the fewest discs wins
+	Misere
+)
+
+func (v Variant) String() string {
+	switch v {
+	case Standard:
+		return "standard"
+	case Misere:
+		return "misere"
+	default:
+		return fmt.Sprintf("Variant(%d)", int(v))
+	}
+}
+
+// ParseVariant parses a variant name as returned by Variant.String
+func ParseVariant(s string) (Variant, error) {
+	switch s {
+	case "standard", "":
+		return Standard, nil
+	case "misere":
+		return Misere, nil
+	}
+	return Standard, fmt.Errorf("unknown variant %q (standard or misere)", s)
+}
+
+// GetWinnerVariant returns the winner of the board under the rules of the variant,
+// Empty in case of a tie
+func GetWinnerVariant(board Board, variant Variant) Piece {
+	winner := Winner(board)
+	if variant == Misere && winner != Empty {
+		return GetOpponentColor(winner)
+	}
+	return winner
+}
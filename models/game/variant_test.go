@@ -0,0 +1,41 @@
+package game
+
+import "testing"
+
+func TestGetWinnerVariant(t *testing.T) {
+	tests := []struct {
+		name            string
+		board           Board
+		standard, miser Piece
+	}{
+		{"black wins 40-24", boardOf(Black, 40, White), Black, White},
+		{"white wins 33-31", boardOf(White, 33, Black), White, Black},
+		{"draw 32-32", boardOf(Black, 32, White), Empty, Empty},
+		{"black wipeout", boardOf(Black, 10, Empty), Black, White},
+	}
+	for _, tt := range tests {
+		if got := GetWinnerVariant(tt.board, Standard); got != tt.standard {
+			t.Errorf("%s: standard winner %d, want %d", tt.name, got, tt.standard)
+		}
+		if got := GetWinnerVariant(tt.board, Misere); got != tt.miser {
+			t.Errorf("%s: misere winner %d, want %d", tt.name, got, tt.miser)
+		}
+	}
+}
+
+func TestParseVariant(t *testing.T) {
+	for _, v := range []Variant{Standard, Misere} {
+		if got, err := ParseVariant(v.String()); err != nil || got != v {
+			t.Errorf("ParseVariant(%q) = %v, %v, want %v", v.String(), got, err, v)
+		}
+	}
+	if got, err := ParseVariant(""); err != nil || got != Standard {
+		t.Errorf("empty variant: %v, %v, want standard", got, err)
+	}
+	if _, err := ParseVariant("reversi"); err == nil {
+		t.Error("unknown variant: no error")
+	}
+	if s := Variant(5).String(); s != "Variant(5)" {
+		t.Errorf("unknown variant named %q", s)
+	}
+}
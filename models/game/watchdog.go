@@ -0,0 +1,73 @@
+package game
+
+// MaxPlies is more than any legal game can last, passes included
+const MaxPlies = 70
+
+// AbortReason tells why a game was stopped before its normal end
+type AbortReason int
+
+const (
+	NotAborted       AbortReason = iota // The game is running or ended normally
+	TooManyPlies                        // More than MaxPlies moves and passes were played
+	RepeatedPosition                    // The same position came back with the same side to move
+	InvalidOpening                      // The opening transcript could not be played
+)
+
+func (r AbortReason) String() string {
+	switch r {
+	case NotAborted:
+		return "not aborted"
+	case TooManyPlies:
+		return "too many plies"
+	case RepeatedPosition:
+		return "repeated position"
+	case InvalidOpening:
+		return "invalid opening"
+	}
+	return "unknown"
+}
+
+// positionKey identifies a position with its side to move, in the sets of positions seen
+type positionKey struct {
+	board  BitBoard
+	toMove Piece
+}
+
+// Watchdog detects runaway game loops, which legal Othello never produces:
+// positions cannot repeat since every move adds a disc, and a game has at most 60 moves.
+type Watchdog struct {
+	seen   map[positionKey]struct{}
+	plies  int
+	Reason AbortReason
+}
+
+func NewWatchdog() *Watchdog {
+	return &Watchdog{seen: make(map[positionKey]struct{}, MaxPlies)}
+}
+
+// Record registers the position about to be played, either a move or a pass.
+// It returns true once the game must be aborted, the cause being stored in Reason.
+func (w *Watchdog) Record(b BitBoard, toMove Piece) bool {
+	if w.Reason != NotAborted {
+		return true
+	}
+
+	w.plies++
+	if w.plies > MaxPlies {
+		w.Reason = TooManyPlies
+		return true
+	}
+
+	key := positionKey{board: b, toMove: toMove}
+	if _, ok := w.seen[key]; ok {
+		w.Reason = RepeatedPosition
+		return true
+	}
+	w.seen[key] = struct{}{}
+	return false
+}
+
+// RecordGame registers the current position of g, see Record
+func (w *Watchdog) RecordGame(g *Game) bool {
+	return w.Record(BoardToBitBoard(g.Board), g.CurrentPlayer.Color)
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWatchdogStopsAfterMaxPlies(t *testing.T) {
+	w := NewWatchdog()
+	// Distinct positions, as many as the plies of a game
+	for i := range MaxPlies {
+		b := BitBoard{BlackPieces: uint64(i), WhitePieces: 1 << 63}
+		if w.Record(b, Black) {
+			t.Fatalf("aborted at ply %d of %d: %v", i+1, MaxPlies, w.Reason)
+		}
+	}
+	if !w.Record(BitBoard{BlackPieces: MaxPlies}, Black) || w.Reason != TooManyPlies {
+		t.Errorf("ply %d: reason %v, want %v", MaxPlies+1, w.Reason, TooManyPlies)
+	}
+	// Once aborted, the game stays aborted
+	if !w.Record(BitBoard{BlackPieces: 1 << 40}, White) || w.Reason != TooManyPlies {
+		t.Errorf("after the abort: reason %v, want %v", w.Reason, TooManyPlies)
+	}
+}
+
+func TestWatchdogRepeatedPosition(t *testing.T) {
+	start := BoardToBitBoard(NewGame("Black", "White").Board)
+	w := NewWatchdog()
+	if w.Record(start, Black) {
+		t.Fatal("first position aborted")
+	}
+	// The same board with the other side to move is another position
+	if w.Record(start, White) {
+		t.Fatal("same board with the other side to move aborted")
+	}
+	// Both sides passing back to the same position, the loop of a desynchronized engine
+	if !w.Record(start, Black) || w.Reason != RepeatedPosition {
+		t.Errorf("repeated position: reason %v, want %v", w.Reason, RepeatedPosition)
+	}
+}
+
+func TestWatchdogPassesLegalGames(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 50 {
+		g := NewGame("Black", "White")
+		w := NewWatchdog()
+		for {
+			if w.RecordGame(g) {
+				t.Fatalf("legal game aborted: %v after %v", w.Reason, g.History)
+			}
+			state := g.LegalState()
+			if state == GameOver {
+				break
+			}
+			if state == MustPass {
+				if err := g.Pass(); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			moves := g.GetValidMovesForCurrentPlayer()
+			if err := g.ApplyMove(moves[rng.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestAbortReasonString(t *testing.T) {
+	for reason, want := range map[AbortReason]string{
+		NotAborted:       "not aborted",
+		TooManyPlies:     "too many plies",
+		RepeatedPosition: "repeated position",
+		InvalidOpening:   "invalid opening",
+		AbortReason(9):   "unknown",
+	} {
+		if got := reason.String(); got != want {
+			t.Errorf("%d: %q, want %q", int(reason), got, want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package opening
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// BookPlies is the number of plies of each game a Book records
+const BookPlies = 8
+
+// Default thresholds of Book.Probe
+const (
+	DefaultBookMinGames   = 10
+	DefaultBookMinWinRate = 0.5
+)
+
+// BookEntry holds the games of a Book reaching a position, scored for the player who
+// played the move leading to it
+type BookEntry struct {
+	Games int
+	Wins  int
+	Draws int
+}
+
+// WinRate returns the share of the points of the player who moved, draws counting half
+func (e BookEntry) WinRate() float64 {
+	if e.Games == 0 {
+		return 0
+	}
+	return (float64(e.Wins) + float64(e.Draws)*0.5) / float64(e.Games)
+}
+
+// bookKey identifies a position of a Book up to the symmetries of the square
+type bookKey struct {
+	fingerprint game.BoardFingerprint
+	mover       game.Piece // Player who moved to the position
+}
+
+// Book records the results of a set of games for the positions of their first BookPlies
+// plies. Unlike KNOWN_OPENINGS, which is matched on the moves of the game, positions are
+// looked up up to the symmetries of the square, so transpositions and rotated or mirrored
+// openings are found.
+type Book struct {
+	entries map[bookKey]*BookEntry
+}
+
+// NewBook returns an empty book
+func NewBook() *Book {
+	return &Book{entries: make(map[bookKey]*BookEntry)}
+}
+
+// ReadBook reads a book from games, one per line: a transcript, such as "f5d6c3", and the
+// result, "black", "white" or "draw". Empty lines and lines starting with # are skipped.
+func ReadBook(r io.Reader) (*Book, error) {
+	b := NewBook()
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("book line %d: want a transcript and a result", line)
+		}
+		var winner game.Piece
+		switch strings.ToLower(fields[1]) {
+		case "black":
+			winner = game.Black
+		case "white":
+			winner = game.White
+		case "draw":
+			winner = game.Empty
+		default:
+			return nil, fmt.Errorf("book line %d: unknown result %q", line, fields[1])
+		}
+		g := game.NewGame("Black", "White")
+		if _, err := utils.ApplyTranscript(g, fields[0]); err != nil {
+			return nil, fmt.Errorf("book line %d: %w", line, err)
+		}
+		b.AddGame(g.History, winner)
+	}
+	return b, scanner.Err()
+}
+
+// LoadBook reads a book from a file in the format of ReadBook
+func LoadBook(filename string) (*Book, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadBook(f)
+}
+
+// AddGame records the first BookPlies plies of a game from the initial position, given
+// as a Game.History, won by winner or drawn when winner is game.Empty
+func (b *Book) AddGame(history []game.Position, winner game.Piece) {
+	g := game.NewGame("Black", "White")
+	for ply, move := range history {
+		if ply >= BookPlies {
+			return
+		}
+		mover := g.CurrentPlayer.Color
+		if move == game.PassMove {
+			if g.Pass() != nil {
+				return
+			}
+			continue
+		}
+		if g.LegalState() == game.MustPass {
+			g.Pass()
+			mover = g.CurrentPlayer.Color
+		}
+		if g.ApplyMove(move) != nil {
+			return
+		}
+
+		key := bookKey{fingerprint: game.Fingerprint(utils.BoardToBits(g.Board)), mover: mover}
+		entry := b.entries[key]
+		if entry == nil {
+			entry = &BookEntry{}
+			b.entries[key] = entry
+		}
+		entry.Games++
+		switch winner {
+		case mover:
+			entry.Wins++
+		case game.Empty:
+			entry.Draws++
+		}
+	}
+}
+
+// Len returns the number of positions of the book
+func (b *Book) Len() int {
+	return len(b.entries)
+}
+
+// Probe returns the move of player on bb leading to the book position with the best win
+// rate for player, among those reached by at least minGames games with a win rate above
+// minWinRate. It reports false when no move qualifies.
+func (b *Book) Probe(bb game.BitBoard, player game.Piece, minGames int, minWinRate float64) (game.Position, BookEntry, bool) {
+	var best game.Position
+	var bestEntry BookEntry
+	found := false
+	for _, move := range game.ValidMovesBitBoard(bb, player) {
+		next, _ := game.GetNewBitBoardAfterMove(bb, move, player)
+		entry := b.entries[bookKey{fingerprint: game.Fingerprint(next), mover: player}]
+		if entry == nil || entry.Games < minGames || entry.WinRate() <= minWinRate {
+			continue
+		}
+		if !found || entry.WinRate() > bestEntry.WinRate() || (entry.WinRate() == bestEntry.WinRate() && entry.Games > bestEntry.Games) {
+			best, bestEntry, found = move, *entry, true
+		}
+	}
+	return best, bestEntry, found
+}
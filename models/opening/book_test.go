@@ -0,0 +1,80 @@
+package opening
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// Symmetries of the square applied to the squares of a transcript
+var transcriptSymmetries = map[string]func(col, row byte) (byte, byte){
+	"identity":      func(c, r byte) (byte, byte) { return c, r },
+	"diagonal":      func(c, r byte) (byte, byte) { return r, c },
+	"anti-diagonal": func(c, r byte) (byte, byte) { return 7 - r, 7 - c },
+	"half turn":     func(c, r byte) (byte, byte) { return 7 - c, 7 - r },
+}
+
+// transform applies a symmetry to every square of a transcript
+func transform(transcript string, f func(col, row byte) (byte, byte)) string {
+	var sb strings.Builder
+	for i := 0; i < len(transcript); i += 2 {
+		c, r := f(transcript[i]-'a', transcript[i+1]-'1')
+		sb.WriteByte('a' + c)
+		sb.WriteByte('1' + r)
+	}
+	return sb.String()
+}
+
+// playTranscript returns the game after a transcript
+func playTranscript(t *testing.T, transcript string) *game.Game {
+	t.Helper()
+	g := game.NewGame("Black", "White")
+	if _, err := utils.ApplyTranscript(g, transcript); err != nil {
+		t.Fatalf("%s: %v", transcript, err)
+	}
+	return g
+}
+
+func TestBookSymmetricGamesShareEntries(t *testing.T) {
+	const tanida = "c4c3d3c5d6f4f5d2"
+	for name, f := range transcriptSymmetries {
+		b := NewBook()
+		b.AddGame(playTranscript(t, tanida).History, game.White)
+		b.AddGame(playTranscript(t, transform(tanida, f)).History, game.White)
+		if b.Len() != BookPlies {
+			t.Errorf("%s: %d positions for a game and its image, want %d", name, b.Len(), BookPlies)
+		}
+		for key, entry := range b.entries {
+			if entry.Games != 2 {
+				t.Errorf("%s: position %v reached by %d games, want 2", name, key, entry.Games)
+			}
+		}
+	}
+}
+
+func TestBookProbeSymmetricPosition(t *testing.T) {
+	const line, reply = "c4c3d3c5d6f4f5", "d2"
+	b := NewBook()
+	b.AddGame(playTranscript(t, line+reply).History, game.White)
+	for name, f := range transcriptSymmetries {
+		g := playTranscript(t, transform(line, f))
+		move, entry, ok := b.Probe(utils.BoardToBits(g.Board), g.CurrentPlayer.Color, 1, DefaultBookMinWinRate)
+		if !ok {
+			t.Errorf("%s: no book move", name)
+			continue
+		}
+		if got, want := utils.PositionToAlgebraic(move), transform(reply, f); got != want || entry.Games != 1 {
+			t.Errorf("%s: book move %s from %d games, want %s from 1", name, got, entry.Games, want)
+		}
+	}
+
+	g := playTranscript(t, line)
+	if _, _, ok := b.Probe(utils.BoardToBits(g.Board), g.CurrentPlayer.Color, 2, DefaultBookMinWinRate); ok {
+		t.Error("book move with fewer games than the minimum")
+	}
+	if _, _, ok := b.Probe(utils.BoardToBits(g.Board), g.CurrentPlayer.Color, 1, 1); ok {
+		t.Error("book move with a win rate not above the minimum")
+	}
+}
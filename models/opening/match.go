@@ -1,8 +1,15 @@
 package opening
 
 import (
+	"fmt"
+	"math/bits"
 	"math/rand"
+	"slices"
 	"strings"
+	"sync"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
 )
 
 func MatchOpening(transcript string) []Opening {
@@ -32,3 +39,129 @@ func SelectRandomOpenings(numGames int) []Opening {
 	})
 	return shuffled[:numGames]
 }
+
+// positionKey identifies a position up to the symmetries of the square
+type positionKey struct {
+	fingerprint game.BoardFingerprint
+	toMove      game.Piece
+}
+
+// openingPosition is a position reached by a known opening
+type openingPosition struct {
+	board   game.BitBoard
+	opening int           // Index of the opening in KNOWN_OPENINGS
+	next    game.Position // Move of the opening from the position, PassMove at its end
+}
+
+var (
+	openingIndexOnce sync.Once
+	// Positions of the known openings, in the order of KNOWN_OPENINGS
+	openingIndex map[positionKey][]openingPosition
+)
+
+// linePosition is a position of a line of moves and its player to move
+type linePosition struct {
+	board  game.BitBoard
+	toMove game.Piece
+}
+
+// key returns the key of the position
+func (p linePosition) key() positionKey {
+	return positionKey{fingerprint: game.Fingerprint(p.board), toMove: p.toMove}
+}
+
+// openingPositions returns the positions of the known openings equal to p up to the
+// symmetries of the square
+func openingPositions(p linePosition) []openingPosition {
+	openingIndexOnce.Do(func() {
+		openingIndex = make(map[positionKey][]openingPosition)
+		for i, op := range KNOWN_OPENINGS {
+			line, err := transcriptPositions(op.Transcript)
+			if err != nil {
+				continue
+			}
+			for ply, pos := range line {
+				next := game.PassMove
+				if ply < len(line)-1 {
+					next = utils.AlgebraicToPosition(op.Transcript[2*ply : 2*ply+2])
+				}
+				key := pos.key()
+				openingIndex[key] = append(openingIndex[key], openingPosition{board: pos.board, opening: i, next: next})
+			}
+		}
+	})
+	return openingIndex[p.key()]
+}
+
+// transcriptPositions returns the position at each ply of a transcript, from the initial
+// position, passing whenever the player to move has no move
+func transcriptPositions(transcript string) ([]linePosition, error) {
+	if len(transcript)%2 != 0 {
+		return nil, fmt.Errorf("transcript %q has an odd length", transcript)
+	}
+	g := game.NewGame("Black", "White")
+	line := []linePosition{{board: utils.BoardToBits(g.Board), toMove: g.CurrentPlayer.Color}}
+	for i := 0; i < len(transcript); i += 2 {
+		if _, err := utils.ApplyTranscript(g, transcript[i:i+2]); err != nil {
+			return nil, fmt.Errorf("move %d: %w", i/2+1, err)
+		}
+		line = append(line, linePosition{board: utils.BoardToBits(g.Board), toMove: g.CurrentPlayer.Color})
+	}
+	return line, nil
+}
+
+// Identify returns the longest known opening whose position the game of the transcript
+// reaches, up to the symmetries of the square, so transpositions and rotated or mirrored
+// lines are identified. It reports false for an invalid transcript.
+func Identify(transcript string) (Opening, bool) {
+	line, err := transcriptPositions(strings.ToLower(transcript))
+	if err != nil {
+		return Opening{}, false
+	}
+	for ply := len(line) - 1; ply >= 0; ply-- {
+		for _, pos := range openingPositions(line[ply]) {
+			if pos.next == game.PassMove {
+				return KNOWN_OPENINGS[pos.opening], true
+			}
+		}
+	}
+	return Opening{}, false
+}
+
+// Continuations returns the distinct moves of the known openings from the position of the
+// transcript, found up to the symmetries of the square, in the order of KNOWN_OPENINGS.
+// The moves are given in the orientation of the transcript, with all their images when
+// the position is symmetric, such as the four first moves of the game.
+func Continuations(transcript string) []string {
+	moves := make([]string, 0)
+	line, err := transcriptPositions(strings.ToLower(transcript))
+	if err != nil {
+		return moves
+	}
+	last := line[len(line)-1]
+	for _, pos := range openingPositions(last) {
+		if pos.next == game.PassMove {
+			continue
+		}
+		for _, image := range moveImages(pos.next, pos.board, last.board) {
+			if move := utils.PositionToAlgebraic(image); !slices.Contains(moves, move) {
+				moves = append(moves, move)
+			}
+		}
+	}
+	return moves
+}
+
+// moveImages returns the images of move by the symmetries of the square mapping from to
+// to, several when the position is symmetric
+func moveImages(move game.Position, from, to game.BitBoard) []game.Position {
+	var images []game.Position
+	squares := game.BitBoard{BlackPieces: 1 << (move.Row*8 + move.Col)}.Symmetries()
+	for i, b := range from.Symmetries() {
+		if b == to {
+			index := bits.TrailingZeros64(squares[i].BlackPieces)
+			images = append(images, game.Position{Row: int8(index / 8), Col: int8(index % 8)})
+		}
+	}
+	return images
+}
@@ -0,0 +1,94 @@
+package opening
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// fingerprintOf returns the fingerprint of the position after a transcript
+func fingerprintOf(t *testing.T, transcript string) game.BoardFingerprint {
+	t.Helper()
+	return game.Fingerprint(utils.BoardToBits(playTranscript(t, transcript).Board))
+}
+
+func TestIdentifyKnownOpenings(t *testing.T) {
+	for _, op := range KNOWN_OPENINGS {
+		want := fingerprintOf(t, op.Transcript)
+		for name, f := range transcriptSymmetries {
+			got, ok := Identify(transform(op.Transcript, f))
+			if !ok {
+				t.Errorf("%s %s: not identified", op.Name, name)
+				continue
+			}
+			// Another opening may reach the same position
+			if len(got.Transcript) != len(op.Transcript) || fingerprintOf(t, got.Transcript) != want {
+				t.Errorf("%s %s: identified as %s (%s)", op.Name, name, got.Name, got.Transcript)
+			}
+		}
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	tests := []struct {
+		transcript string
+		want       string // Empty when no opening is identified
+	}{
+		{"", ""},
+		{"c4", ""},
+		{"c4c3", "Diagonal"},
+		{"f5f6", "Diagonal"},
+		{"c4c3a1", ""},
+		{"c4c3d3c5f6", "Buffalo"},
+		{"d3c3c4c5f6", "Buffalo"}, // Transposition
+		{"c4c3d3c5f6h8h8", ""},
+		{"C4C3D3C5F6", "Buffalo"},
+	}
+	for _, tt := range tests {
+		got, ok := Identify(tt.transcript)
+		if ok != (tt.want != "") || got.Name != tt.want {
+			t.Errorf("Identify(%q) = %q, %v, want %q", tt.transcript, got.Name, ok, tt.want)
+		}
+	}
+}
+
+func TestContinuationsKnownOpenings(t *testing.T) {
+	for _, op := range KNOWN_OPENINGS {
+		for ply := 0; ply < len(op.Transcript); ply += 2 {
+			for name, f := range transcriptSymmetries {
+				prefix := transform(op.Transcript[:ply], f)
+				next := transform(op.Transcript[ply:ply+2], f)
+				moves := Continuations(prefix)
+				if !slices.Contains(moves, next) {
+					t.Errorf("%s %s: continuations of %q are %v, want %s among them", op.Name, name, prefix, moves, next)
+				}
+				for _, move := range moves {
+					if _, err := utils.ApplyTranscript(playTranscript(t, prefix), move); err != nil {
+						t.Errorf("%s %s: continuation %s of %q: %v", op.Name, name, move, prefix, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestContinuations(t *testing.T) {
+	tests := []struct {
+		transcript string
+		want       []string
+	}{
+		{"", []string{"c4", "d3", "f5", "e6"}},
+		{"c4", []string{"c3", "e3", "c5"}},
+		{"f5", []string{"f6", "d6", "f4"}},
+		{"d3c3c4c5", Continuations("c4c3d3c5")}, // Transposition
+		{"c4c3a1", []string{}},
+		{"c4c3d3c5f6e3c6f5f4g5", []string{}},
+	}
+	for _, tt := range tests {
+		if got := Continuations(tt.transcript); !slices.Equal(got, tt.want) {
+			t.Errorf("Continuations(%q) = %v, want %v", tt.transcript, got, tt.want)
+		}
+	}
+}
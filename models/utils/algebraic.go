@@ -1,6 +1,10 @@
 package utils
 
-import "github.com/Coloc3G/othello-engine/models/game"
+import (
+	"strings"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
 
 // AlgebraicToPosition converts an algebraic position (like "c4") to a Position
 func AlgebraicToPosition(algebraic string) game.Position {
@@ -31,6 +35,9 @@ func PositionToAlgebraic(pos game.Position) string {
 	return string([]byte{col, row})
 }
 
+// PositionsToAlgebraic concatenates positions, such as the moves of a variation. Whole
+// games are written with TranscriptToAlgebraic or TranscriptFromHistory, which leave
+// passes out.
 func PositionsToAlgebraic(positions []game.Position) string {
 	algebraic := ""
 	for _, position := range positions {
@@ -39,6 +46,22 @@ func PositionsToAlgebraic(positions []game.Position) string {
 	return algebraic
 }
 
+// TranscriptToAlgebraic converts the moves of a game to a transcript. Passes are left
+// out, positions off the board included: a replay passes when the player has no move.
+func TranscriptToAlgebraic(history []game.Position) string {
+	var b strings.Builder
+	for _, pos := range history {
+		if pos.Row < 0 || pos.Row > 7 || pos.Col < 0 || pos.Col > 7 {
+			continue
+		}
+		b.WriteString(PositionToAlgebraic(pos))
+	}
+	return b.String()
+}
+
+// AlgebraicToPositions splits a string of squares into positions, invalid squares being
+// off the board. Games are replayed with ApplyTranscript or HistoryFromTranscript, which
+// check the moves and play the passes.
 func AlgebraicToPositions(algebraic string) []game.Position {
 	positions := make([]game.Position, len(algebraic)/2)
 	for i := 0; i < len(algebraic); i += 2 {
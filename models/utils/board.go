@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Coloc3G/othello-engine/models/game"
 )
@@ -32,21 +33,7 @@ func HashBitBoard(bb game.BitBoard) string {
 }
 
 func BoardToBits(b game.Board) game.BitBoard {
-	var black, white uint64
-	for i := range 8 {
-		for j := range 8 {
-			switch b[i][j] {
-			case game.Black:
-				black |= 1 << (i*8 + j)
-			case game.White:
-				white |= 1 << (i*8 + j)
-			}
-		}
-	}
-	return game.BitBoard{
-		BlackPieces: black,
-		WhitePieces: white,
-	}
+	return game.BoardToBitBoard(b)
 }
 
 func BitsToBoard(bb game.BitBoard) game.Board {
@@ -66,6 +53,48 @@ func BitsToBoard(bb game.BitBoard) game.Board {
 	return board
 }
 
+// BoardToString converts a board to a 64 characters string, row by row from a1 to h8,
+// using 'X' for black, 'O' for white and '-' for empty squares
+func BoardToString(b game.Board) string {
+	var sb strings.Builder
+	for i := range b {
+		for j := range b[i] {
+			switch b[i][j] {
+			case game.Black:
+				sb.WriteByte('X')
+			case game.White:
+				sb.WriteByte('O')
+			default:
+				sb.WriteByte('-')
+			}
+		}
+	}
+	return sb.String()
+}
+
+// StringToBoard parses a board written by BoardToString. Whitespace is ignored,
+// 'B' and 'W' are accepted for black and white, '.' for empty squares.
+func StringToBoard(s string) (game.Board, error) {
+	board := game.Board{}
+	s = strings.Join(strings.Fields(s), "")
+	if len(s) != 64 {
+		return board, fmt.Errorf("board must have 64 squares, got %d", len(s))
+	}
+	for i := range 64 {
+		switch s[i] {
+		case 'X', 'x', 'B', 'b':
+			board[i/8][i%8] = game.Black
+		case 'O', 'o', 'W', 'w':
+			board[i/8][i%8] = game.White
+		case '-', '.':
+			board[i/8][i%8] = game.Empty
+		default:
+			return board, fmt.Errorf("invalid square %q at index %d", s[i], i)
+		}
+	}
+	return board, nil
+}
+
 func PrintBoard(b game.Board) {
 	for i := range b {
 		for j := range b[i] {
@@ -97,3 +126,13 @@ func PrintBitBoard(bb game.BitBoard) {
 		fmt.Println()
 	}
 }
+
+// BoardToOTF converts a position to the Othello Text Format: the 64 squares of
+// BoardToString, a space and the side to move, 'X' for black or 'O' for white
+func BoardToOTF(b game.Board, toMove game.Piece) string {
+	side := "X"
+	if toMove == game.White {
+		side = "O"
+	}
+	return BoardToString(b) + " " + side
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// squareChar returns the character BoardToString uses for a piece
+func squareChar(p game.Piece) byte {
+	switch p {
+	case game.Black:
+		return 'X'
+	case game.White:
+		return 'O'
+	default:
+		return '-'
+	}
+}
+
+// DiffBoards renders two boards side by side with the differing squares bracketed,
+// and returns the differing positions row by row
+func DiffBoards(a, b game.Board) (string, []game.Position) {
+	var diff []game.Position
+	var sb strings.Builder
+	header := "   a  b  c  d  e  f  g  h "
+	sb.WriteString(header + "   " + header + "\n")
+	for i := range 8 {
+		var left, right strings.Builder
+		for j := range 8 {
+			lb, rb := byte(' '), byte(' ')
+			if a[i][j] != b[i][j] {
+				lb, rb = '[', ']'
+				diff = append(diff, game.Position{Row: int8(i), Col: int8(j)})
+			}
+			left.Write([]byte{lb, squareChar(a[i][j]), rb})
+			right.Write([]byte{lb, squareChar(b[i][j]), rb})
+		}
+		fmt.Fprintf(&sb, "%d %s    %d %s\n", i+1, left.String(), i+1, right.String())
+	}
+	if len(diff) == 0 {
+		sb.WriteString("boards are identical\n")
+	} else {
+		fmt.Fprintf(&sb, "%d differing squares: %s\n", len(diff), PositionsToAlgebraic(diff))
+	}
+	return sb.String(), diff
+}
+
+// DiffBitBoards is DiffBoards for bitboards
+func DiffBitBoards(a, b game.BitBoard) (string, []game.Position) {
+	return DiffBoards(BitsToBoard(a), BitsToBoard(b))
+}
+
+// PrintBoardWithMoves prints a board with the given moves marked by '*'
+func PrintBoardWithMoves(b game.Board, moves []game.Position) {
+	marked := make(map[game.Position]bool, len(moves))
+	for _, m := range moves {
+		marked[m] = true
+	}
+	fmt.Println("  a b c d e f g h")
+	for i := range b {
+		fmt.Print(i + 1)
+		for j := range b[i] {
+			if marked[game.Position{Row: int8(i), Col: int8(j)}] {
+				fmt.Print(" *")
+			} else {
+				fmt.Printf(" %c", squareChar(b[i][j]))
+			}
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestDiffBoards(t *testing.T) {
+	g := game.NewGame("Black", "White")
+	before := g.Board
+
+	out, diff := DiffBoards(before, before)
+	if len(diff) != 0 || !strings.Contains(out, "boards are identical") || strings.Contains(out, "[") {
+		t.Errorf("identical boards: %v\n%s", diff, out)
+	}
+
+	if err := g.ApplyMove(AlgebraicToPosition("f5")); err != nil {
+		t.Fatal(err)
+	}
+	out, diff = DiffBoards(before, g.Board)
+	// f5 is played and e5 flipped, row by row
+	want := []game.Position{AlgebraicToPosition("e5"), AlgebraicToPosition("f5")}
+	if !slices.Equal(diff, want) {
+		t.Errorf("differing squares %v, want %v", diff, want)
+	}
+	if !strings.Contains(out, "2 differing squares: e5f5") {
+		t.Errorf("summary missing:\n%s", out)
+	}
+	lines := strings.Split(out, "\n")
+	if got, want := lines[5], "5  -  -  -  X [O][-] -  -     5  -  -  -  X [X][X] -  - "; got != want {
+		t.Errorf("row 5:\n%q, want\n%q", got, want)
+	}
+
+	bitsOut, bitsDiff := DiffBitBoards(BoardToBits(before), BoardToBits(g.Board))
+	if bitsOut != out || !slices.Equal(bitsDiff, diff) {
+		t.Errorf("DiffBitBoards differs from DiffBoards:\n%s", bitsOut)
+	}
+}
+
+func ExamplePrintBoardWithMoves() {
+	g := game.NewGame("Black", "White")
+	PrintBoardWithMoves(g.Board, g.GetValidMovesForCurrentPlayer())
+	// Output:
+	//   a b c d e f g h
+	// 1 - - - - - - - -
+	// 2 - - - - - - - -
+	// 3 - - - * - - - -
+	// 4 - - * O X - - -
+	// 5 - - - X O * - -
+	// 6 - - - - * - - -
+	// 7 - - - - - - - -
+	// 8 - - - - - - - -
+}
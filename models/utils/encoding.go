@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// EncodedBoardSize is the size in bytes of an encoded board
+const EncodedBoardSize = 16
+
+// EncodeBoard packs a board in 16 bytes, 2 bits per square from a1 to h8,
+// the first square in the low bits of the first byte
+func EncodeBoard(b game.Board) [EncodedBoardSize]byte {
+	var buf [EncodedBoardSize]byte
+	for i := range 64 {
+		buf[i/4] |= byte(b[i/8][i%8]&0x3) << (2 * (i % 4))
+	}
+	return buf
+}
+
+// DecodeBoard unpacks a board written by EncodeBoard, unknown square values are read as empty
+func DecodeBoard(buf [EncodedBoardSize]byte) game.Board {
+	board := game.Board{}
+	for i := range 64 {
+		switch p := game.Piece(buf[i/4]>>(2*(i%4))) & 0x3; p {
+		case game.Black, game.White:
+			board[i/8][i%8] = p
+		}
+	}
+	return board
+}
+
+// EncodeBoardBase64 returns the encoded board as a base64 string, for embedding in JSON
+func EncodeBoardBase64(b game.Board) string {
+	buf := EncodeBoard(b)
+	return base64.StdEncoding.EncodeToString(buf[:])
+}
+
+// DecodeBoardBase64 parses a board written by EncodeBoardBase64
+func DecodeBoardBase64(s string) (game.Board, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return game.Board{}, err
+	}
+	if len(data) != EncodedBoardSize {
+		return game.Board{}, fmt.Errorf("encoded board must be %d bytes, got %d", EncodedBoardSize, len(data))
+	}
+	return DecodeBoard([EncodedBoardSize]byte(data)), nil
+}
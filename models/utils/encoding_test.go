@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"math/rand"
+	"testing"
+	"unsafe"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestEncodeBoardSize(t *testing.T) {
+	buf := EncodeBoard(game.NewGame("Black", "White").Board)
+	if size := unsafe.Sizeof(buf); size != 16 {
+		t.Errorf("encoded board is %d bytes, want 16", size)
+	}
+}
+
+func TestEncodeBoardRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := range 500 {
+		var b game.Board
+		for row := range 8 {
+			for col := range 8 {
+				b[row][col] = game.Piece(rng.Intn(3))
+			}
+		}
+		if got := DecodeBoard(EncodeBoard(b)); got != b {
+			t.Fatalf("board %d: decoded\n%v\nwant\n%v", i, got, b)
+		}
+
+		s := EncodeBoardBase64(b)
+		got, err := DecodeBoardBase64(s)
+		if err != nil {
+			t.Fatalf("board %d: %v", i, err)
+		}
+		if got != b {
+			t.Fatalf("board %d: base64 round trip changed the board", i)
+		}
+	}
+}
+
+func TestDecodeBoardBase64WrongSize(t *testing.T) {
+	if _, err := DecodeBoardBase64("AAAA"); err == nil {
+		t.Error("3 bytes decoded as a board")
+	}
+}
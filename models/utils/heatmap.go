@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"slices"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// Layout and scale of the heatmaps
+const (
+	heatmapCell       = 48  // Side of a square in pixels
+	heatmapDisc       = 18  // Radius of a disc in pixels
+	heatmapPercentile = 0.9 // Magnitudes above this percentile get the strongest color
+)
+
+var (
+	heatmapBoard = color.RGBA{0, 110, 60, 255}
+	heatmapGrid  = color.RGBA{0, 60, 30, 255}
+	heatmapWhite = color.RGBA{40, 120, 255, 255} // Squares good for White
+	heatmapBlack = color.RGBA{230, 40, 40, 255}  // Squares good for Black
+)
+
+// WriteHeatmapPNG draws the board with each square tinted by its value, indexed by
+// row*8+col, blue when positive and red when negative, and writes it as a PNG. The
+// scale ends at a percentile of the magnitudes, so a few extreme values do not wash
+// out the others.
+func WriteHeatmapPNG(w io.Writer, b game.Board, values [64]int16) error {
+	scale := heatmapScale(values)
+	size := 8 * heatmapCell
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		for x := range size {
+			row, col := y/heatmapCell, x/heatmapCell
+			c := heatmapBoard
+			if v := float64(values[row*8+col]); v != 0 && scale > 0 {
+				tint := heatmapWhite
+				if v < 0 {
+					tint = heatmapBlack
+					v = -v
+				}
+				c = blend(c, tint, 0.75*min(v/scale, 1))
+			}
+			if x%heatmapCell == 0 || y%heatmapCell == 0 {
+				c = heatmapGrid
+			}
+			// Discs are drawn over the tint
+			dx, dy := x%heatmapCell-heatmapCell/2, y%heatmapCell-heatmapCell/2
+			if dx*dx+dy*dy <= heatmapDisc*heatmapDisc {
+				switch b[row][col] {
+				case game.Black:
+					c = color.RGBA{0, 0, 0, 255}
+				case game.White:
+					c = color.RGBA{255, 255, 255, 255}
+				}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// heatmapScale returns the heatmapPercentile percentile of the non zero magnitudes
+func heatmapScale(values [64]int16) float64 {
+	var magnitudes []float64
+	for _, v := range values {
+		if v != 0 {
+			magnitudes = append(magnitudes, max(float64(v), -float64(v)))
+		}
+	}
+	if len(magnitudes) == 0 {
+		return 0
+	}
+	slices.Sort(magnitudes)
+	return magnitudes[int(heatmapPercentile*float64(len(magnitudes)-1))]
+}
+
+// blend mixes c with tint, weight 0 keeping c and 1 giving tint
+func blend(c, tint color.RGBA, weight float64) color.RGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a)*(1-weight) + float64(b)*weight)
+	}
+	return color.RGBA{mix(c.R, tint.R), mix(c.G, tint.G), mix(c.B, tint.B), 255}
+}
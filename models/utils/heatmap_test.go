@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// heatmapPixel returns the color of the heatmap at the corner of a square, off its disc
+// and its grid line
+func heatmapPixel(img image.Image, row, col int) color.RGBA {
+	r, g, b, a := img.At(col*heatmapCell+3, row*heatmapCell+3).RGBA()
+	return color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
+
+func TestWriteHeatmapPNG(t *testing.T) {
+	var board game.Board
+	board[0][0] = game.Black
+	board[7][7] = game.White
+	var values [64]int16
+	values[0] = -100   // a1, good for Black
+	values[63] = 100   // h8, good for White
+	values[9] = 50     // b2, half the scale
+	values[18] = 30000 // c3, beyond the scale
+	values[27] = -200  // d4
+	values[36] = 1     // e5
+	values[45] = -1    // f6
+	values[54] = 100   // g7
+	values[62] = 100   // g8
+	values[61] = -100  // f8
+
+	var buf bytes.Buffer
+	if err := WriteHeatmapPNG(&buf, board, values); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := img.Bounds().Size(); size.X != 8*heatmapCell || size.Y != 8*heatmapCell {
+		t.Fatalf("image of %v, want %d pixels square", size, 8*heatmapCell)
+	}
+
+	// The scale is the 90th percentile of the magnitudes, 200
+	if scale := heatmapScale(values); scale != 200 {
+		t.Fatalf("scale %v, want 200", scale)
+	}
+	tests := []struct {
+		name     string
+		row, col int
+		want     color.RGBA
+	}{
+		{"no value", 4, 0, heatmapBoard},
+		{"good for Black", 0, 0, blend(heatmapBoard, heatmapBlack, 0.75*0.5)},
+		{"good for White", 7, 7, blend(heatmapBoard, heatmapWhite, 0.75*0.5)},
+		{"at the scale", 3, 3, blend(heatmapBoard, heatmapBlack, 0.75)},
+		{"beyond the scale", 2, 2, blend(heatmapBoard, heatmapWhite, 0.75)},
+	}
+	for _, tt := range tests {
+		if got := heatmapPixel(img, tt.row, tt.col); got != tt.want {
+			t.Errorf("%s: color %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Discs are drawn over the tint, at the center of their square
+	center := func(row, col int) color.Color {
+		return img.At(col*heatmapCell+heatmapCell/2, row*heatmapCell+heatmapCell/2)
+	}
+	if r, g, b, _ := center(0, 0).RGBA(); r|g|b != 0 {
+		t.Errorf("black disc of color %v", center(0, 0))
+	}
+	if r, g, b, _ := center(7, 7).RGBA(); r&g&b != 0xffff {
+		t.Errorf("white disc of color %v", center(7, 7))
+	}
+}
+
+func TestHeatmapScaleEmpty(t *testing.T) {
+	if scale := heatmapScale([64]int16{}); scale != 0 {
+		t.Errorf("scale %v of no value, want 0", scale)
+	}
+	var buf bytes.Buffer
+	if err := WriteHeatmapPNG(&buf, game.Board{}, [64]int16{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// Game results accepted by FormatGameAsPGN
+const (
+	ResultDraw       = 0
+	ResultBlackWins  = 1
+	ResultWhiteWins  = 2
+	ResultUnfinished = 3
+)
+
+// GameMeta holds the headers of a PGN-like game record, empty fields are not written
+type GameMeta struct {
+	Event   string
+	Date    string
+	Round   string
+	Black   string
+	White   string
+	Opening string
+}
+
+// GameRecord is a game read from a PGN-like file
+type GameRecord struct {
+	Meta   GameMeta
+	Result int // One of the Result constants, ResultUnfinished when the file gives none
+	Moves  []game.Position
+}
+
+// pgnResult returns the PGN result token of a game result
+func pgnResult(result int) string {
+	switch result {
+	case ResultDraw:
+		return "1/2-1/2"
+	case ResultBlackWins:
+		return "1-0"
+	case ResultWhiteWins:
+		return "0-1"
+	default:
+		return "*"
+	}
+}
+
+// FormatGameAsPGN writes a game in a PGN-like format: key-value headers followed by
+// the numbered moves in algebraic notation and the result.
+// A move number holds a Black move and the White reply, the number of a White move
+// following a Black pass is written "12...". The passes, the PassMove entries of a
+// Game.History or the forced passes a transcript leaves out, are not written.
+func FormatGameAsPGN(transcript []game.Position, result int, meta GameMeta) string {
+	var sb strings.Builder
+	event := meta.Event
+	if event == "" {
+		event = "Othello Match"
+	}
+	headers := []struct{ key, value string }{
+		{"Event", event},
+		{"Date", meta.Date},
+		{"Round", meta.Round},
+		{"Black", meta.Black},
+		{"White", meta.White},
+		{"Opening", meta.Opening},
+		{"Result", pgnResult(result)},
+	}
+	for _, h := range headers {
+		if h.value != "" {
+			fmt.Fprintf(&sb, "[%s %q]\n", h.key, h.value)
+		}
+	}
+	sb.WriteString("\n")
+
+	written := false
+	for _, ply := range pgnPlies(transcript) {
+		if written {
+			sb.WriteString(" ")
+		}
+		switch {
+		case ply.Player == game.Black:
+			fmt.Fprintf(&sb, "%d. ", ply.number)
+		case !ply.afterBlack:
+			// White moves first in this move number, Black having passed
+			fmt.Fprintf(&sb, "%d... ", ply.number)
+		}
+		sb.WriteString(PositionToAlgebraic(ply.Position))
+		written = true
+	}
+	if written {
+		sb.WriteString(" ")
+	}
+	sb.WriteString(pgnResult(result) + "\n")
+	return sb.String()
+}
+
+// pgnPly is a move of a game with its PGN move number
+type pgnPly struct {
+	AnnotatedMove
+	number     int
+	afterBlack bool // White move answering the Black move of the same number
+}
+
+// pgnPlies numbers the moves of a game from the side to move, replayed from the initial
+// position to find the passes. Moves the rules reject, and those after them, alternate sides.
+func pgnPlies(transcript []game.Position) []pgnPly {
+	history, err := ReplayHistory(game.NewGame("Black", "White"), transcript)
+	if err != nil {
+		var played int
+		for _, ply := range history {
+			if !ply.Pass {
+				played++
+			}
+		}
+		player := game.Black
+		if len(history) > 0 {
+			player = game.GetOpponentColor(history[len(history)-1].Player)
+		}
+		for _, pos := range transcript {
+			if pos == game.PassMove {
+				continue
+			}
+			if played > 0 {
+				played--
+				continue
+			}
+			history = append(history, AnnotatedMove{Player: player, Position: pos})
+			player = game.GetOpponentColor(player)
+		}
+	}
+
+	var plies []pgnPly
+	number := 0
+	blackMoved := false
+	for _, move := range history {
+		if move.Player == game.Black {
+			number++
+		}
+		if !move.Pass {
+			plies = append(plies, pgnPly{AnnotatedMove: move, number: max(number, 1), afterBlack: move.Player == game.White && blackMoved})
+		}
+		blackMoved = move.Player == game.Black && !move.Pass
+	}
+	return plies
+}
+
+// parseResult returns the game result of a PGN result token
+func parseResult(token string) (int, bool) {
+	switch token {
+	case "1/2-1/2":
+		return ResultDraw, true
+	case "1-0":
+		return ResultBlackWins, true
+	case "0-1":
+		return ResultWhiteWins, true
+	case "*":
+		return ResultUnfinished, true
+	}
+	return 0, false
+}
+
+// ParseOthelloPGN reads the games written by FormatGameAsPGN. A game is made of its headers
+// followed by its moves and ends with the result token, or with the headers of the next game.
+// Move numbers like "12." or "12..." are optional, moves may also be glued to them like "12.f5".
+// The moves are not checked against the rules.
+func ParseOthelloPGN(reader io.Reader) ([]GameRecord, error) {
+	var games []GameRecord
+	var current *GameRecord
+	inMoves := false
+
+	// finish ends the current game, if it has anything
+	finish := func() {
+		if current != nil {
+			games = append(games, *current)
+		}
+		current = nil
+		inMoves = false
+	}
+	start := func() {
+		if current == nil {
+			current = &GameRecord{Result: ResultUnfinished}
+		}
+	}
+
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			// Headers after moves start the next game
+			if inMoves {
+				finish()
+			}
+			start()
+			key, value, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(text, "["), "]"), " ")
+			if !ok {
+				return nil, fmt.Errorf("line %d: invalid header %q", line, text)
+			}
+			value, err := strconv.Unquote(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid header value in %q: %w", line, text, err)
+			}
+			switch key {
+			case "Event":
+				current.Meta.Event = value
+			case "Date":
+				current.Meta.Date = value
+			case "Round":
+				current.Meta.Round = value
+			case "Black":
+				current.Meta.Black = value
+			case "White":
+				current.Meta.White = value
+			case "Opening":
+				current.Meta.Opening = value
+			case "Result":
+				if result, ok := parseResult(value); ok {
+					current.Result = result
+				}
+			}
+			continue
+		}
+
+		for _, token := range strings.Fields(text) {
+			start()
+			inMoves = true
+			if result, ok := parseResult(token); ok {
+				current.Result = result
+				finish()
+				continue
+			}
+			// Drop the move number, "12." or "12..." before a White move
+			if i := strings.Index(token, "."); i >= 0 {
+				if _, err := strconv.Atoi(token[:i]); err != nil {
+					return nil, fmt.Errorf("line %d: invalid move number %q", line, token)
+				}
+				token = strings.TrimLeft(token[i:], ".")
+			}
+			if token == "" {
+				continue
+			}
+			pos := AlgebraicToPosition(strings.ToLower(token))
+			if len(token) != 2 || pos.Row < 0 {
+				return nil, fmt.Errorf("line %d: invalid move %q", line, token)
+			}
+			current.Moves = append(current.Moves, pos)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	finish()
+	return games, nil
+}
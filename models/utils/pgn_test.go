@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// finishedGameWithPass plays random games to their end until one has a pass
+func finishedGameWithPass(t *testing.T) *game.Game {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	for range 1000 {
+		g, err := game.RandomReachableBoard(rng, 60)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slices.Contains(g.History, game.PassMove) {
+			return g
+		}
+	}
+	t.Fatal("no random game had a pass")
+	return nil
+}
+
+func TestPGNRoundTripWithPass(t *testing.T) {
+	g := finishedGameWithPass(t)
+	text := FormatGameAsPGN(g.History, ResultDraw, GameMeta{Black: "a", White: "b"})
+	if strings.Contains(text, "invalid") {
+		t.Fatalf("pass written as a move:\n%s", text)
+	}
+
+	records, err := ParseOthelloPGN(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("%v in\n%s", err, text)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d games, want 1", len(records))
+	}
+	var moves []game.Position
+	for _, pos := range g.History {
+		if pos != game.PassMove {
+			moves = append(moves, pos)
+		}
+	}
+	if !slices.Equal(records[0].Moves, moves) {
+		t.Errorf("parsed moves %v, want %v", records[0].Moves, moves)
+	}
+
+	replay := game.NewGame("Black", "White")
+	if _, err := ReplayHistory(replay, records[0].Moves); err != nil {
+		t.Fatal(err)
+	}
+	if replay.Board != g.Board {
+		t.Error("the parsed game does not reach the final board")
+	}
+}
+
+// gameWithBlackPass plays random games until Black passes before White's next move
+func gameWithBlackPass(t *testing.T) *game.Game {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	for range 5000 {
+		g, err := game.RandomReachableBoard(rng, 60)
+		if err != nil {
+			t.Fatal(err)
+		}
+		plies, err := ReplayHistory(game.NewGame("Black", "White"), g.History)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 1; i < len(plies); i++ {
+			if plies[i-1].Pass && plies[i-1].Player == game.Black && !plies[i].Pass {
+				return g
+			}
+		}
+	}
+	t.Fatal("Black never passed in the random games")
+	return nil
+}
+
+func TestPGNMoveNumbersFollowSideToMove(t *testing.T) {
+	g := gameWithBlackPass(t)
+	text := FormatGameAsPGN(g.History, ResultUnfinished, GameMeta{})
+	_, moves, _ := strings.Cut(text, "\n\n")
+	if !strings.Contains(moves, "...") {
+		t.Fatalf("no White move numbered after the Black pass in %s", moves)
+	}
+
+	replay := game.NewGame("Black", "White")
+	number := 0
+	expected := game.Empty // Player of the next move given by its number, Empty without one
+	for _, token := range strings.Fields(moves) {
+		if _, ok := parseResult(token); ok {
+			break
+		}
+		if n, ok := strings.CutSuffix(token, "..."); ok {
+			number++
+			if n != strconv.Itoa(number) {
+				t.Fatalf("move number %s, want %d", token, number)
+			}
+			expected = game.White
+			continue
+		}
+		if n, ok := strings.CutSuffix(token, "."); ok {
+			number++
+			if n != strconv.Itoa(number) {
+				t.Fatalf("move number %s, want %d", token, number)
+			}
+			expected = game.Black
+			continue
+		}
+
+		if replay.LegalState() == game.MustPass {
+			replay.Pass()
+		}
+		player := replay.CurrentPlayer.Color
+		if expected != game.Empty && player != expected {
+			t.Fatalf("move %s numbered for %v is played by %v in %s", token, expected, player, moves)
+		}
+		if expected == game.Empty && player != game.White {
+			t.Fatalf("Black move %s has no number in %s", token, moves)
+		}
+		if err := replay.ApplyMove(AlgebraicToPosition(token)); err != nil {
+			t.Fatalf("move %s: %v", token, err)
+		}
+		expected = game.Empty
+	}
+	if replay.Board != g.Board {
+		t.Error("the numbered moves do not reach the final board")
+	}
+}
+
+func TestPGNMoveNumbers(t *testing.T) {
+	moves := AlgebraicToPositions("f5d6c3")
+	text := FormatGameAsPGN(moves, ResultUnfinished, GameMeta{})
+	if want := "1. f5 d6 2. c3 *\n"; !strings.HasSuffix(text, want) {
+		t.Errorf("got %q, want the moves %q", text, want)
+	}
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// AnnotatedMove is a ply of a game: a move of Player, or a pass of Player when Pass is set
+type AnnotatedMove struct {
+	Player   game.Piece
+	Position game.Position // Not set for a pass
+	Pass     bool
+}
+
+// TranscriptFromHistory returns the transcript of a game, such as "f5d6c3", in lowercase.
+// Passes are left out, as in every Othello transcript: they are forced, so replaying the
+// moves finds them again.
+func TranscriptFromHistory(moves []AnnotatedMove) string {
+	var b strings.Builder
+	for _, move := range moves {
+		if !move.Pass {
+			b.WriteString(PositionToAlgebraic(move.Position))
+		}
+	}
+	return b.String()
+}
+
+// HistoryFromTranscript replays a transcript from the initial position and returns its
+// plies, the passes included. A forced pass after the last move is included as well, so
+// the history of a game recording each pass when it happens comes back unchanged.
+func HistoryFromTranscript(transcript string) ([]AnnotatedMove, error) {
+	return ApplyTranscript(game.NewGame("Black", "White"), transcript)
+}
+
+// ReplayHistory plays the moves of a Game.History on g and returns the plies played. Its
+// passes are honored, and a forced pass the history leaves out is played as well. On
+// error, g holds the moves before the faulty one.
+func ReplayHistory(g *game.Game, moves []game.Position) ([]AnnotatedMove, error) {
+	var history []AnnotatedMove
+	pass := func() error {
+		player := g.CurrentPlayer.Color
+		if err := g.Pass(); err != nil {
+			return err
+		}
+		history = append(history, AnnotatedMove{Player: player, Pass: true})
+		return nil
+	}
+	for i, pos := range moves {
+		if pos == game.PassMove {
+			if err := pass(); err != nil {
+				return history, fmt.Errorf("ply %d pass: %w", i+1, err)
+			}
+			continue
+		}
+		if g.LegalState() == game.MustPass {
+			pass()
+		}
+		player := g.CurrentPlayer.Color
+		if err := g.ApplyMove(pos); err != nil {
+			return history, fmt.Errorf("ply %d %s: %w", i+1, PositionToAlgebraic(pos), err)
+		}
+		history = append(history, AnnotatedMove{Player: player, Position: pos})
+	}
+	return history, nil
+}
+
+// ApplyTranscript plays the moves of a transcript on g, passing whenever the player to
+// move has no valid move, and returns the plies played. Squares may be in upper case.
+// On error, g holds the moves before the faulty one.
+func ApplyTranscript(g *game.Game, transcript string) ([]AnnotatedMove, error) {
+	if len(transcript)%2 != 0 {
+		return nil, fmt.Errorf("transcript %q has an odd length", transcript)
+	}
+	transcript = strings.ToLower(transcript)
+	var history []AnnotatedMove
+	pass := func() {
+		history = append(history, AnnotatedMove{Player: g.CurrentPlayer.Color, Pass: true})
+		g.Pass()
+	}
+	for i := 0; i < len(transcript); i += 2 {
+		square := transcript[i : i+2]
+		pos := AlgebraicToPosition(square)
+		if pos.Row < 0 {
+			return history, fmt.Errorf("move %d: invalid square %q", i/2+1, square)
+		}
+		if g.LegalState() == game.MustPass {
+			pass()
+		}
+		player := g.CurrentPlayer.Color
+		if err := g.ApplyMove(pos); err != nil {
+			return history, fmt.Errorf("move %d %s: %w", i/2+1, square, err)
+		}
+		history = append(history, AnnotatedMove{Player: player, Position: pos})
+	}
+	if g.LegalState() == game.MustPass {
+		pass()
+	}
+	return history, nil
+}
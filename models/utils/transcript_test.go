@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestReplayHistoryWithPass(t *testing.T) {
+	g := finishedGameWithPass(t)
+
+	replay := game.NewGame("Black", "White")
+	plies, err := ReplayHistory(replay, g.History)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.Board != g.Board {
+		t.Error("the replayed history does not reach the final board")
+	}
+	if !slices.Equal(replay.History, g.History) {
+		t.Errorf("replayed history %v, want %v", replay.History, g.History)
+	}
+	if len(plies) != len(g.History) {
+		t.Errorf("%d plies replayed, the history has %d", len(plies), len(g.History))
+	}
+
+	// The transcript leaves the passes out, replaying it finds them again
+	history, err := HistoryFromTranscript(TranscriptToAlgebraic(g.History))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != len(plies) {
+		t.Errorf("%d plies from the transcript, want %d", len(history), len(plies))
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+)
+
+// adaptiveMaxDepth bounds the searches of the adaptive opponent, which stop at the node
+// budget of the level well before it in the middle game
+const adaptiveMaxDepth = 16
+
+// AdaptiveDifficulty ramps the AI opponent strength with the human results,
+// blending From (t = 0) towards To (t = 1)
+type AdaptiveDifficulty struct {
+	From      evaluation.EvaluationCoefficients
+	To        evaluation.EvaluationCoefficients
+	RampGames int // Number of human wins needed to go from From to To
+	games     int
+	humanWins int
+	t         float64
+}
+
+// NewAdaptiveDifficulty starts at V1 and reaches V4 after 50 human wins
+func NewAdaptiveDifficulty() *AdaptiveDifficulty {
+	return &AdaptiveDifficulty{
+		From:      evaluation.V1Coeff,
+		To:        evaluation.V4Coeff,
+		RampGames: 50,
+	}
+}
+
+// RecordGame updates the difficulty after a game: a human win makes the AI stronger,
+// a loss makes it weaker, a draw keeps it
+func (a *AdaptiveDifficulty) RecordGame(humanWon, draw bool) {
+	a.games++
+	step := 1 / float64(max(1, a.RampGames))
+	switch {
+	case draw:
+	case humanWon:
+		a.humanWins++
+		a.t = math.Min(1, a.t+step)
+	default:
+		a.t = math.Max(0, a.t-step)
+	}
+}
+
+// WinRate returns the proportion of games won by the human
+func (a *AdaptiveDifficulty) WinRate() float64 {
+	if a.games == 0 {
+		return 0
+	}
+	return float64(a.humanWins) / float64(a.games)
+}
+
+// Level returns the current interpolation factor between From and To
+func (a *AdaptiveDifficulty) Level() float64 {
+	return a.t
+}
+
+// Coefficients returns the coefficients the AI opponent should use
+func (a *AdaptiveDifficulty) Coefficients() evaluation.EvaluationCoefficients {
+	return a.From.Interpolate(a.To, a.t)
+}
+
+// NodeBudget returns the node budget of the searches of the AI opponent, the preset of
+// evaluation.NodeBudgets of the level, so the depth grows with the level as well
+func (a *AdaptiveDifficulty) NodeBudget() int64 {
+	i := int(a.t * float64(len(evaluation.NodeBudgets)))
+	return evaluation.NodeBudgets[min(i, len(evaluation.NodeBudgets)-1)]
+}
@@ -1,40 +1,36 @@
 package ui
 
 import (
+	"fmt"
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
+
+	"github.com/Coloc3G/othello-engine/ui/draw"
+	"github.com/Coloc3G/othello-engine/ui/view"
 )
 
 // AISelectionScreen represents the screen for selecting an AI opponent
 type AISelectionScreen struct {
 	ui               *UI
 	face             font.Face
-	selectedAI       int      // -1: none, 0: V1, 1: V2
-	aiButtonBounds   [][4]int // Bounds for each AI button
-	playButtonBounds [4]int   // Bounds for play button
-	backButtonBounds [4]int   // Bounds for back button
-	buttonHovered    int      // -1: none, 0-n: AI buttons, n+1: play, n+2: back
-	initialized      bool     // Whether the screen has been initialized
+	list             *view.List // Adaptive difficulty first, then the models of the UI
+	playButtonBounds [4]int     // Bounds for play button
+	backButtonBounds [4]int     // Bounds for back button
+	buttonHovered    int        // -1: none, 0: play, 1: back
 }
 
 // NewAISelectionScreen creates a new AI selection screen
 func NewAISelectionScreen(ui *UI) *AISelectionScreen {
-	// Initialize with 2 AI options
-	aiButtonBounds := make([][4]int, 2)
-
 	return &AISelectionScreen{
-		ui:             ui,
-		face:           basicfont.Face7x13,
-		selectedAI:     -1,
-		buttonHovered:  -1,
-		aiButtonBounds: aiButtonBounds,
-		initialized:    false,
+		ui:            ui,
+		face:          basicfont.Face7x13,
+		list:          view.NewList(),
+		buttonHovered: -1,
 	}
 }
 
@@ -43,44 +39,36 @@ func (s *AISelectionScreen) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return outsideWidth, outsideHeight
 }
 
+// options returns the labels of the list: the adaptive difficulty, then every model
+func (s *AISelectionScreen) options() []string {
+	adaptive := fmt.Sprintf("Adaptive (level %.0f%%, %dk nodes)", 100*s.ui.difficulty.Level(), s.ui.difficulty.NodeBudget()/1000)
+	return append([]string{adaptive}, view.ModelNames(s.ui.models)...)
+}
+
 // Update handles input on the AI selection screen
 func (s *AISelectionScreen) Update() error {
 	screenWidth, screenHeight := ebiten.WindowSize()
 
 	// Define button dimensions
-	aiButtonWidth := 100
-	aiButtonHeight := 40
-	aiButtonSpacing := 20
+	listWidth := 260
+	buttonSpacing := 20
 	playButtonWidth := 150
 	playButtonHeight := 50
 	backButtonWidth := 100
 	backButtonHeight := 40
 
 	// Calculate positions
-	aiButtonY := screenHeight / 2
+	listY := screenHeight/4 + 30
 	playButtonY := screenHeight - 120
 	backButtonY := screenHeight - 120
 
-	// Update AI button bounds - we have 2 AIs (V1, V2)
-	numAIOptions := 2
-	aiStartX := (screenWidth - ((aiButtonWidth * numAIOptions) + (aiButtonSpacing * (numAIOptions - 1)))) / 2
-
-	s.aiButtonBounds = make([][4]int, numAIOptions)
-	for i := 0; i < numAIOptions; i++ {
-		s.aiButtonBounds[i] = [4]int{
-			aiStartX + (i * (aiButtonWidth + aiButtonSpacing)),
-			aiButtonY,
-			aiButtonWidth,
-			aiButtonHeight,
-		}
-	}
-
-	// Mark as initialized
-	s.initialized = true
+	mouseX, mouseY := ebiten.CursorPosition()
+	clicked := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	updateModelList(s.list, len(s.options()), [4]int{(screenWidth - listWidth) / 2, listY, listWidth, playButtonY - 20 - listY}, mouseX, mouseY, clicked)
 
 	// Play button bounds
 	s.playButtonBounds = [4]int{
-		(screenWidth + aiButtonSpacing) / 2,
+		(screenWidth + buttonSpacing) / 2,
 		playButtonY,
 		playButtonWidth,
 		playButtonHeight,
@@ -88,49 +76,31 @@ func (s *AISelectionScreen) Update() error {
 
 	// Back button bounds
 	s.backButtonBounds = [4]int{
-		(screenWidth-playButtonWidth-aiButtonSpacing)/2 - backButtonWidth,
+		(screenWidth-playButtonWidth-buttonSpacing)/2 - backButtonWidth,
 		backButtonY,
 		backButtonWidth,
 		backButtonHeight,
 	}
 
-	// Check mouse position
-	mouseX, mouseY := ebiten.CursorPosition()
 	s.buttonHovered = -1
-
-	// Check AI buttons
-	for i := 0; i < numAIOptions; i++ {
-		bounds := s.aiButtonBounds[i]
-		if mouseX >= bounds[0] && mouseX < bounds[0]+bounds[2] &&
-			mouseY >= bounds[1] && mouseY < bounds[1]+bounds[3] {
-			s.buttonHovered = i
-			break
-		}
+	if view.InBounds(s.playButtonBounds, mouseX, mouseY) {
+		s.buttonHovered = 0
 	}
-
-	// Check play button
-	if mouseX >= s.playButtonBounds[0] && mouseX < s.playButtonBounds[0]+s.playButtonBounds[2] &&
-		mouseY >= s.playButtonBounds[1] && mouseY < s.playButtonBounds[1]+s.playButtonBounds[3] {
-		s.buttonHovered = numAIOptions
-	}
-
-	// Check back button
-	if mouseX >= s.backButtonBounds[0] && mouseX < s.backButtonBounds[0]+s.backButtonBounds[2] &&
-		mouseY >= s.backButtonBounds[1] && mouseY < s.backButtonBounds[1]+s.backButtonBounds[3] {
-		s.buttonHovered = numAIOptions + 1
+	if view.InBounds(s.backButtonBounds, mouseX, mouseY) {
+		s.buttonHovered = 1
 	}
 
 	// Handle clicks
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	if clicked {
 		switch s.buttonHovered {
-		case 0, 1: // AI selection buttons
-			s.selectedAI = s.buttonHovered
-		case 2: // Play button
-			if s.selectedAI >= 0 {
-				// Start game with selected AI
-				s.ui.StartPlayerVsAIGame(s.selectedAI)
+		case 0: // Play button
+			switch selected := s.list.Selected; {
+			case selected == 0:
+				s.ui.StartAdaptiveGame()
+			case selected > 0:
+				s.ui.StartPlayerVsAIGame(s.ui.models[selected-1])
 			}
-		case 3: // Back button
+		case 1: // Back button
 			s.ui.SwitchToHomeScreen()
 		}
 	}
@@ -151,57 +121,19 @@ func (s *AISelectionScreen) Draw(screen *ebiten.Image) {
 	titleX := (screenWidth - titleBounds.Dx()) / 2
 	text.Draw(screen, title, s.face, titleX, screenHeight/4, color.White)
 
-	// Check if initialized before drawing buttons
-	if !s.initialized || len(s.aiButtonBounds) == 0 {
-		// Draw loading message or just return
-		text.Draw(screen, "Loading...", s.face, screenWidth/2-30, screenHeight/2, color.White)
-		return
-	}
-
-	// Draw AI buttons
-	aiOptions := []string{"V1", "V2"}
-	for i, optionText := range aiOptions {
-		if i >= len(s.aiButtonBounds) {
-			continue // Skip if index is out of bounds
-		}
-
-		bounds := s.aiButtonBounds[i]
-
-		// Button background color
-		var buttonColor color.RGBA
-		if s.selectedAI == i {
-			buttonColor = color.RGBA{0, 150, 0, 255} // Selected
-		} else if s.buttonHovered == i {
-			buttonColor = color.RGBA{0, 120, 0, 255} // Hovered
-		} else {
-			buttonColor = color.RGBA{0, 80, 0, 255} // Normal
-		}
-
-		// Draw button
-		ebitenutil.DrawRect(screen,
-			float64(bounds[0]),
-			float64(bounds[1]),
-			float64(bounds[2]),
-			float64(bounds[3]),
-			buttonColor)
-
-		// Draw button text
-		btnBounds := text.BoundString(s.face, optionText)
-		btnTextX := bounds[0] + (bounds[2]-btnBounds.Dx())/2
-		btnTextY := bounds[1] + (bounds[3]+btnBounds.Dy())/2
-		text.Draw(screen, optionText, s.face, btnTextX, btnTextY, color.White)
-	}
+	// Draw the AI options
+	drawModelList(screen, s.face, s.list, s.options())
 
 	// Draw play button (only if an AI is selected)
 	buttonColor := color.RGBA{100, 100, 100, 255} // Disabled
-	if s.selectedAI >= 0 {
+	if s.list.Selected >= 0 {
 		buttonColor = color.RGBA{0, 100, 0, 255} // Enabled
-		if s.buttonHovered == 2 {
+		if s.buttonHovered == 0 {
 			buttonColor = color.RGBA{0, 150, 0, 255} // Hovered
 		}
 	}
 
-	ebitenutil.DrawRect(screen,
+	draw.Rect(screen,
 		float64(s.playButtonBounds[0]),
 		float64(s.playButtonBounds[1]),
 		float64(s.playButtonBounds[2]),
@@ -209,18 +141,15 @@ func (s *AISelectionScreen) Draw(screen *ebiten.Image) {
 		buttonColor)
 
 	playText := "Play"
-	btnBounds := text.BoundString(s.face, playText)
-	btnTextX := s.playButtonBounds[0] + (s.playButtonBounds[2]-btnBounds.Dx())/2
-	btnTextY := s.playButtonBounds[1] + (s.playButtonBounds[3]+btnBounds.Dy())/2
-	text.Draw(screen, playText, s.face, btnTextX, btnTextY, color.White)
+	draw.TextCentered(screen, playText, s.face, s.playButtonBounds[0], s.playButtonBounds[1], s.playButtonBounds[2], s.playButtonBounds[3], color.White)
 
 	// Draw back button
 	backButtonColor := color.RGBA{100, 70, 70, 255}
-	if s.buttonHovered == 3 {
+	if s.buttonHovered == 1 {
 		backButtonColor = color.RGBA{150, 70, 70, 255}
 	}
 
-	ebitenutil.DrawRect(screen,
+	draw.Rect(screen,
 		float64(s.backButtonBounds[0]),
 		float64(s.backButtonBounds[1]),
 		float64(s.backButtonBounds[2]),
@@ -228,8 +157,5 @@ func (s *AISelectionScreen) Draw(screen *ebiten.Image) {
 		backButtonColor)
 
 	backText := "Back"
-	backBounds := text.BoundString(s.face, backText)
-	backTextX := s.backButtonBounds[0] + (s.backButtonBounds[2]-backBounds.Dx())/2
-	backTextY := s.backButtonBounds[1] + (s.backButtonBounds[3]+backBounds.Dy())/2
-	text.Draw(screen, backText, s.face, backTextX, backTextY, color.White)
+	draw.TextCentered(screen, backText, s.face, s.backButtonBounds[0], s.backButtonBounds[1], s.backButtonBounds[2], s.backButtonBounds[3], color.White)
 }
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/ui/draw"
+	"github.com/Coloc3G/othello-engine/ui/view"
+)
+
+// discs renders the discs of every board, the screens being drawn one at a time
+var discs = draw.NewDiscs(ColorBlack, ColorWhite)
+
+// boardView places a playable board on the screen: X, Y is its top left corner and
+// Cell the size of a square
+type boardView struct {
+	X, Y, Cell int
+}
+
+// size is the width and height of the board
+func (v boardView) size() int {
+	return 8 * v.Cell
+}
+
+// cellAt returns the square under a screen point, false outside the board
+func (v boardView) cellAt(x, y int) (game.Position, bool) {
+	if v.Cell <= 0 || x < v.X || x >= v.X+v.size() || y < v.Y || y >= v.Y+v.size() {
+		return game.Position{}, false
+	}
+	return game.Position{Row: int8((y - v.Y) / v.Cell), Col: int8((x - v.X) / v.Cell)}, true
+}
+
+// draw renders the squares, the valid moves and the pieces of board, highlight
+// being drawn in the last move color
+func (v boardView) draw(screen *ebiten.Image, board game.Board, validMoves []game.Position, highlight game.Position) {
+	// Draw board background
+	draw.Rect(screen, float64(v.X), float64(v.Y),
+		float64(v.size()), float64(v.size()),
+		color.RGBA{34, 100, 34, 255})
+
+	valid := make(map[game.Position]bool, len(validMoves))
+	for _, pos := range validMoves {
+		valid[pos] = true
+	}
+
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			x := v.X + col*v.Cell
+			y := v.Y + row*v.Cell
+
+			// Draw cell border
+			draw.Rect(screen, float64(x), float64(y),
+				float64(v.Cell), float64(v.Cell),
+				ColorGrid)
+
+			cellColor := color.RGBA{50, 150, 50, 255} // Default cell color
+			if highlight.Row == int8(row) && highlight.Col == int8(col) {
+				cellColor = ColorLastMove
+			}
+			draw.Rect(screen, float64(x+1), float64(y+1),
+				float64(v.Cell-2), float64(v.Cell-2),
+				cellColor)
+
+			if valid[game.Position{Row: int8(row), Col: int8(col)}] {
+				draw.Rect(screen, float64(x+3), float64(y+3),
+					float64(v.Cell-6), float64(v.Cell-6),
+					ColorValid)
+			}
+
+			discs.Draw(screen, board[row][col], float64(x+v.Cell/2), float64(y+v.Cell/2), view.DiscRadius(v.Cell))
+		}
+	}
+}
+
+// drawCoordinates draws the column letters above the board and the row numbers on its left
+func (v boardView) drawCoordinates(screen *ebiten.Image, face font.Face) {
+	// Column labels (A-H)
+	for col := 0; col < 8; col++ {
+		colLabel := string(rune('A' + col))
+		labelBounds := text.BoundString(face, colLabel)
+		labelX := v.X + col*v.Cell + (v.Cell-labelBounds.Dx())/2
+		labelY := v.Y - 5 // Above the board
+		text.Draw(screen, colLabel, face, labelX, labelY, ColorLabelText)
+	}
+
+	// Row labels (1-8) - only on the left
+	for row := 0; row < 8; row++ {
+		rowLabel := fmt.Sprintf("%d", row+1)
+		labelBounds := text.BoundString(face, rowLabel)
+		labelX := v.X - labelBounds.Dx() - 5 // Left of the board
+		labelY := v.Y + row*v.Cell + (v.Cell+labelBounds.Dy())/2
+		text.Draw(screen, rowLabel, face, labelX, labelY, ColorLabelText)
+	}
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCommands lists the commands writing their input to the clipboard, by platform
+var clipboardCommands = map[string][][]string{
+	"windows": {{"clip"}},
+	"darwin":  {{"pbcopy"}},
+}
+
+// Clipboard commands of the other platforms, tried in order
+var defaultClipboardCommands = [][]string{
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+}
+
+// writeClipboard copies text to the system clipboard with the first available clipboard command
+func writeClipboard(text string) error {
+	commands, ok := clipboardCommands[runtime.GOOS]
+	if !ok {
+		commands = defaultClipboardCommands
+	}
+	for _, args := range commands {
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return errors.New("no clipboard command available on " + runtime.GOOS)
+}
@@ -0,0 +1,88 @@
+// Package draw holds the drawing helpers shared by the screens of the UI, built on the
+// vector package of ebiten
+package draw
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/ui/view"
+)
+
+// Rect fills the rectangle of top left corner x, y
+func Rect(dst *ebiten.Image, x, y, w, h float64, c color.Color) {
+	vector.DrawFilledRect(dst, float32(x), float32(y), float32(w), float32(h), c, false)
+}
+
+// StrokeRect draws the outline of the rectangle of top left corner x, y, width pixels thick
+func StrokeRect(dst *ebiten.Image, x, y, w, h, width float64, c color.Color) {
+	vector.StrokeRect(dst, float32(x), float32(y), float32(w), float32(h), float32(width), c, false)
+}
+
+// Line draws a line one pixel thick
+func Line(dst *ebiten.Image, x0, y0, x1, y1 float64, c color.Color) {
+	vector.StrokeLine(dst, float32(x0), float32(y0), float32(x1), float32(y1), 1, c, false)
+}
+
+// Circle fills the circle of center cx, cy
+func Circle(dst *ebiten.Image, cx, cy, radius float64, c color.Color) {
+	vector.DrawFilledCircle(dst, float32(cx), float32(cy), float32(radius), c, true)
+}
+
+// TextCentered draws s centered in the rectangle of top left corner x, y
+func TextCentered(dst *ebiten.Image, s string, face font.Face, x, y, w, h int, c color.Color) {
+	bounds := text.BoundString(face, s)
+	tx, ty := view.CenterText(x, y, w, h, bounds.Dx(), bounds.Dy())
+	text.Draw(dst, s, face, tx, ty, c)
+}
+
+// Discs draws the discs of the board from two images, one per color, rendered once for
+// a radius and again only when the radius changes, such as when the window is resized
+type Discs struct {
+	black, white color.Color
+	radius       int
+	images       [2]*ebiten.Image // Black then white disc
+}
+
+// NewDiscs returns the disc images of the given colors, rendered on first use
+func NewDiscs(black, white color.Color) *Discs {
+	return &Discs{black: black, white: white}
+}
+
+// Draw draws the disc of piece, centered on cx, cy. Empty squares draw nothing.
+func (d *Discs) Draw(dst *ebiten.Image, piece game.Piece, cx, cy float64, radius int) {
+	if piece == game.Empty || radius <= 0 {
+		return
+	}
+	if radius != d.radius || d.images[0] == nil {
+		d.render(radius)
+	}
+	img := d.images[0]
+	if piece == game.White {
+		img = d.images[1]
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(cx-float64(radius)-1, cy-float64(radius)-1)
+	dst.DrawImage(img, op)
+}
+
+// render draws the disc images for radius, with a pixel of margin for the antialiasing
+func (d *Discs) render(radius int) {
+	for _, img := range d.images {
+		if img != nil {
+			img.Deallocate()
+		}
+	}
+	size := 2*radius + 2
+	for i, c := range []color.Color{d.black, d.white} {
+		img := ebiten.NewImage(size, size)
+		Circle(img, float64(radius)+1, float64(radius)+1, float64(radius), c)
+		d.images[i] = img
+	}
+	d.radius = radius
+}
@@ -0,0 +1,388 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+	"github.com/Coloc3G/othello-engine/ui/draw"
+	"github.com/Coloc3G/othello-engine/ui/view"
+)
+
+const (
+	accuracyDepth  = 3  // Depth of the engine choice each move is compared to
+	accuracyMargin = 25 // Score a move may lose against the engine choice and still count as accurate
+)
+
+// Buttons of the game over screen
+const (
+	gameOverRematch = iota
+	gameOverSave
+	gameOverMenu
+	gameOverButtons
+)
+
+// accuracyUpdate is the progress of the accuracy computation of a game
+type accuracyUpdate struct {
+	Gen      int    // Game the update belongs to, updates of older games are dropped
+	Done     int    // Plies analysed
+	Total    int    // Plies of the game
+	Accurate [2]int // Accurate moves of black and white
+	Moves    [2]int // Moves played by black and white
+}
+
+// savedGame is the JSON export of a finished game
+type savedGame struct {
+	Black      string     `json:"black"`
+	White      string     `json:"white"`
+	Variant    string     `json:"variant"`
+	Moves      string     `json:"moves"`
+	Winner     string     `json:"winner"`
+	BlackDiscs int        `json:"black_discs"`
+	WhiteDiscs int        `json:"white_discs"`
+	Passes     [2]int     `json:"passes"`             // Passes of black and white
+	Accuracy   *[2]string `json:"accuracy,omitempty"` // Accuracy of black and white, once computed
+}
+
+// GameOverScreen shows the final position and a summary of the finished game
+type GameOverScreen struct {
+	ui            *UI
+	face          font.Face
+	buttonBounds  [gameOverButtons][4]int // x, y, width, height
+	buttonHovered int                     // -1: none, else a gameOver button
+	passes        [2]int                  // Passes of black and white
+	accuracy      accuracyUpdate
+	accuracyGen   int
+	accuracyDone  bool
+	updates       chan accuracyUpdate
+	cancel        chan struct{} // Closed to stop the accuracy computation
+	savedAt       time.Time
+	savedMsg      string
+}
+
+// NewGameOverScreen creates a new game over screen
+func NewGameOverScreen(ui *UI) *GameOverScreen {
+	return &GameOverScreen{
+		ui:            ui,
+		face:          basicfont.Face7x13,
+		buttonHovered: -1,
+		updates:       make(chan accuracyUpdate, 1),
+	}
+}
+
+// Layout implements ebiten.Game
+func (s *GameOverScreen) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+// Start shows the summary of the game of the UI and starts computing the accuracy of its moves
+func (s *GameOverScreen) Start() {
+	s.Stop()
+	s.passes = countPasses(s.ui.game.History)
+	moves := playedMoves(s.ui.game.History)
+	s.accuracyGen++
+	s.accuracy = accuracyUpdate{Gen: s.accuracyGen, Total: len(moves)}
+	s.accuracyDone = s.accuracy.Total == 0
+	s.cancel = make(chan struct{})
+	eval := evaluation.ForVariant(evaluation.NewMixedEvaluation(evaluation.V4Coeff), s.ui.game.Variant)
+	go computeAccuracy(s.accuracyGen, moves, eval, s.cancel, s.updates)
+}
+
+// Stop cancels the accuracy computation, done when leaving the screen
+func (s *GameOverScreen) Stop() {
+	if s.cancel != nil {
+		close(s.cancel)
+		s.cancel = nil
+	}
+}
+
+// Update handles input on the game over screen
+func (s *GameOverScreen) Update() error {
+	// Read the accuracy progress
+	select {
+	case update := <-s.updates:
+		if update.Gen == s.accuracyGen {
+			s.accuracy = update
+			s.accuracyDone = update.Done == update.Total
+		}
+	default:
+	}
+
+	// Buttons in a row at the bottom
+	screenWidth, screenHeight := ebiten.WindowSize()
+	buttonWidth := 150
+	buttonHeight := 40
+	spacing := 20
+	left := (screenWidth - gameOverButtons*buttonWidth - (gameOverButtons-1)*spacing) / 2
+	for i := range s.buttonBounds {
+		s.buttonBounds[i] = [4]int{left + i*(buttonWidth+spacing), screenHeight - 80, buttonWidth, buttonHeight}
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	s.buttonHovered = -1
+	for i, bounds := range s.buttonBounds {
+		if view.InBounds(bounds, mouseX, mouseY) {
+			s.buttonHovered = i
+		}
+	}
+
+	clicked := -1
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		clicked = s.buttonHovered
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		clicked = gameOverMenu
+	}
+	switch clicked {
+	case gameOverRematch:
+		s.Stop()
+		s.ui.Rematch()
+	case gameOverSave:
+		path, err := s.SaveGame()
+		s.savedMsg = "Saved " + path
+		if err != nil {
+			s.savedMsg = "Save failed: " + err.Error()
+		}
+		s.savedAt = time.Now()
+	case gameOverMenu:
+		s.Stop()
+		s.ui.NewGame()
+	}
+	return nil
+}
+
+// Draw renders the final board, the result and the statistics of the game
+func (s *GameOverScreen) Draw(screen *ebiten.Image) {
+	screenWidth := screen.Bounds().Dx()
+	screen.Fill(ColorBackground)
+
+	g := s.ui.game
+	blackCount, whiteCount := game.CountPieces(g.Board)
+	names := [2]string{g.Players[0].Name, g.Players[1].Name}
+
+	title := "Game Over"
+	titleBounds := text.BoundString(s.face, title)
+	text.Draw(screen, title, s.face, (screenWidth-titleBounds.Dx())/2, 30, color.White)
+
+	// Final board snapshot
+	const cell = 28
+	boardX, boardY := 40, 60
+	draw.Rect(screen, float64(boardX), float64(boardY), 8*cell, 8*cell, ColorGrid)
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			x := float64(boardX + col*cell)
+			y := float64(boardY + row*cell)
+			draw.Rect(screen, x+1, y+1, cell-2, cell-2, color.RGBA{50, 150, 50, 255})
+			switch g.Board[row][col] {
+			case game.Black:
+				draw.Rect(screen, x+5, y+5, cell-10, cell-10, ColorBlack)
+			case game.White:
+				draw.Rect(screen, x+5, y+5, cell-10, cell-10, ColorWhite)
+			}
+		}
+	}
+
+	var result string
+	switch g.GetWinnerMethod() {
+	case game.Black:
+		result = fmt.Sprintf("Black wins: %s", names[0])
+	case game.White:
+		result = fmt.Sprintf("White wins: %s", names[1])
+	default:
+		result = "It's a tie!"
+	}
+	if g.Variant != game.Standard {
+		result += fmt.Sprintf(" (%s rules)", g.Variant)
+	}
+
+	lines := []string{
+		result,
+		fmt.Sprintf("Final Score: Black %d - %d White", blackCount, whiteCount),
+		fmt.Sprintf("Game length: %d moves", len(playedMoves(g.History))),
+		fmt.Sprintf("Passes: Black %d - %d White", s.passes[0], s.passes[1]),
+		"",
+	}
+	if s.accuracyDone {
+		lines = append(lines, fmt.Sprintf("Accuracy (moves within %d of a depth %d search):", accuracyMargin, accuracyDepth))
+	} else {
+		lines = append(lines, fmt.Sprintf("Computing accuracy... %d / %d plies", s.accuracy.Done, s.accuracy.Total))
+	}
+	for i, name := range names {
+		lines = append(lines, fmt.Sprintf("  %s: %s", name, accuracyText(s.accuracy, i)))
+	}
+
+	infoX := boardX + 8*cell + 40
+	for i, line := range lines {
+		text.Draw(screen, line, s.face, infoX, boardY+15+i*20, color.White)
+	}
+
+	// Progress bar of the accuracy computation
+	if !s.accuracyDone && s.accuracy.Total > 0 {
+		barY := float64(boardY + 15 + len(lines)*20)
+		draw.Rect(screen, float64(infoX), barY, 200, 10, ColorGrid)
+		draw.Rect(screen, float64(infoX), barY, 200*float64(s.accuracy.Done)/float64(s.accuracy.Total), 10, color.RGBA{0, 150, 0, 255})
+	}
+
+	if time.Since(s.savedAt) < 3*time.Second {
+		text.Draw(screen, s.savedMsg, s.face, boardX, boardY+8*cell+30, color.RGBA{255, 215, 0, 255})
+	}
+
+	labels := [gameOverButtons]string{"Rematch", "Save Game", "Main Menu"}
+	for i, bounds := range s.buttonBounds {
+		buttonColor := color.RGBA{0, 100, 0, 255}
+		if s.buttonHovered == i {
+			buttonColor = color.RGBA{0, 150, 0, 255}
+		}
+		draw.Rect(screen, float64(bounds[0]), float64(bounds[1]), float64(bounds[2]), float64(bounds[3]), buttonColor)
+		draw.TextCentered(screen, labels[i], s.face, bounds[0], bounds[1], bounds[2], bounds[3], color.White)
+	}
+}
+
+// SaveGame writes the game to a JSON file in the working directory and returns its path
+func (s *GameOverScreen) SaveGame() (string, error) {
+	g := s.ui.game
+	saved := savedGame{
+		Black:   g.Players[0].Name,
+		White:   g.Players[1].Name,
+		Variant: g.Variant.String(),
+		Moves:   utils.TranscriptToAlgebraic(g.History),
+		Passes:  s.passes,
+	}
+	saved.BlackDiscs, saved.WhiteDiscs = game.CountPieces(g.Board)
+	switch g.GetWinnerMethod() {
+	case game.Black:
+		saved.Winner = "black"
+	case game.White:
+		saved.Winner = "white"
+	default:
+		saved.Winner = "draw"
+	}
+	if s.accuracyDone {
+		saved.Accuracy = &[2]string{accuracyText(s.accuracy, 0), accuracyText(s.accuracy, 1)}
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := fmt.Sprintf("game_%s.json", time.Now().Format("20060102-150405"))
+	return path, os.WriteFile(path, data, 0644)
+}
+
+// accuracyText formats the accuracy of a player, index 0 for black
+func accuracyText(a accuracyUpdate, player int) string {
+	if a.Moves[player] == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%% (%d/%d)", 100*float64(a.Accurate[player])/float64(a.Moves[player]), a.Accurate[player], a.Moves[player])
+}
+
+// countPasses replays the history of a game and returns the passes of black and white
+func countPasses(history []game.Position) [2]int {
+	var passes [2]int
+	plies, _ := utils.ReplayHistory(game.NewGame("Black", "White"), history)
+	for _, ply := range plies {
+		if ply.Pass {
+			passes[playerIndex(ply.Player)]++
+		}
+	}
+	return passes
+}
+
+// playedMoves returns the moves of the history of a game, without its passes
+func playedMoves(history []game.Position) []game.Position {
+	moves := make([]game.Position, 0, len(history))
+	for _, move := range history {
+		if move != game.PassMove {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// playerIndex returns 0 for black and 1 for white
+func playerIndex(p game.Piece) int {
+	if p == game.White {
+		return 1
+	}
+	return 0
+}
+
+// computeAccuracy compares every move of a game to the best move of a depth accuracyDepth
+// search, sending its progress after each ply until cancel is closed
+func computeAccuracy(gen int, moves []game.Position, eval evaluation.Evaluation, cancel <-chan struct{}, out chan accuracyUpdate) {
+	update := accuracyUpdate{Gen: gen, Total: len(moves)}
+	g := game.NewGame("Black", "White")
+	for _, move := range moves {
+		for g.LegalState() == game.MustPass {
+			g.Pass()
+		}
+		mover := g.CurrentPlayer.Color
+		b := utils.BoardToBits(g.Board)
+
+		after, ok := game.GetNewBitBoardAfterMove(b, move, mover)
+		if !ok || g.ApplyMove(move) != nil {
+			break
+		}
+
+		// Loss of the played move against the best one, from the mover's point of view.
+		// A forced move is always accurate.
+		loss := 0
+		if len(game.ValidMovesBitBoard(b, mover)) > 1 {
+			_, best := evaluation.SolveBitBoard(b, mover, accuracyDepth, eval)
+			played, _ := evaluation.MMAB(after, game.GetOpponentColor(mover), accuracyDepth-1, evaluation.MIN_EVAL, evaluation.MAX_EVAL, eval, nil, nil)
+			loss = int(best) - int(played)
+			if mover == game.Black {
+				loss = -loss
+			}
+		}
+
+		player := playerIndex(mover)
+		update.Moves[player]++
+		if loss <= accuracyMargin {
+			update.Accurate[player]++
+		}
+		update.Done++
+
+		if !sendAccuracy(update, cancel, out) {
+			return
+		}
+	}
+
+	// An illegal transcript ends early, report it as complete
+	if update.Done < update.Total {
+		update.Total = update.Done
+		sendAccuracy(update, cancel, out)
+	}
+}
+
+// sendAccuracy replaces the progress the screen has not read yet with update.
+// It returns false once cancel is closed.
+func sendAccuracy(update accuracyUpdate, cancel <-chan struct{}, out chan accuracyUpdate) bool {
+	select {
+	case <-cancel:
+		return false
+	default:
+	}
+	select {
+	case <-out:
+	default:
+	}
+	select {
+	case out <- update:
+		return true
+	case <-cancel:
+		return false
+	}
+}
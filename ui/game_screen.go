@@ -1,12 +1,14 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"image/color"
+	"log"
+	"os"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font"
@@ -15,55 +17,56 @@ import (
 	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
 	"github.com/Coloc3G/othello-engine/models/game"
 	"github.com/Coloc3G/othello-engine/models/utils"
+	"github.com/Coloc3G/othello-engine/ui/draw"
+	"github.com/Coloc3G/othello-engine/ui/view"
 )
 
-// MoveRecord represents a single move made by a player
-type MoveRecord struct {
-	Position game.Position
-	Pass     bool
-}
-
 // GameScreen manages the main game UI
 type GameScreen struct {
-	ui              *UI
-	lastMove        time.Time
-	lastMovePos     game.Position   // Track the last move position
-	moveHistory     [][2]MoveRecord // Store move history as pairs [black, white]
-	scrollOffset    int             // For scrolling through move history
-	maxVisibleMoves int             // Maximum number of visible moves in the history panel
-	boardSize       int
-	cellSize        int
-	boardOffsetX    int
-	boardOffsetY    int
-	face            font.Face
-	evaluationValue int                         // Current evaluation value
-	evalHistory     []int                       // History of evaluations for visualization
-	evaluator       *evaluation.MixedEvaluation // Evaluation function
-	evalChan        chan int                    // Channel for receiving evaluation results
-	evaluating      bool                        // Flag to track if evaluation is in progress
-	currentDepth    int                         // Current evaluation depth
-	resultDepth     int                         // Depth of the current evaluation result
-	maxDepth        int                         // Maximum evaluation depth
-	depthUpdateChan chan int                    // Channel for receiving depth updates
-	evalCancelChan  chan struct{}               // Channel for cancelling ongoing evaluations
+	ui           *UI
+	lastMove     time.Time
+	lastMovePos  game.Position  // Track the last move position
+	moves        *view.MoveList // Move list of the history panel
+	boardSize    int
+	cellSize     int
+	boardOffsetX int
+	boardOffsetY int
+	face         font.Face
+	evaluator    *evaluation.MixedEvaluation // Evaluation function
+	// Evaluation of the AI opponent in human vs AI games, set from the adaptive difficulty
+	opponentEvaluator *evaluation.MixedEvaluation
+	// Evaluations of the black and white engines in AI vs AI games
+	aiEvaluators [2]*evaluation.MixedEvaluation
+	scoreGraph   *ScoreGraph // Scores of both engines in AI vs AI games
+	// Pondering on the predicted human reply, toggled with the P key
+	ponderEnabled bool
+	ponderer      evaluation.Ponderer
+	eval          *view.Evaluation     // Progressive evaluation of the position
+	evalUpdates   chan view.EvalUpdate // Completed iterations of the progressive evaluation
+	evalCancel    context.CancelFunc   // Stops the running evaluation
+	copiedAt      time.Time            // Time of the last copy, for the toast
+	copiedText    string               // Message of the toast
+	replayer      *game.GameReplayer
+	animBoard     game.Board // Board drawn while the flips of the last move are animated
+	animating     bool
 }
 
+// How long the copy toast stays on screen
+const copiedToastDuration = 2 * time.Second
+
 // NewGameScreen creates a new game screen
 func NewGameScreen(ui *UI) *GameScreen {
 	return &GameScreen{
-		ui:              ui,
-		lastMove:        time.Now(),
-		lastMovePos:     game.Position{Row: -1, Col: -1}, // Initialize with invalid position
-		moveHistory:     make([][2]MoveRecord, 0),
-		scrollOffset:    0,
-		maxVisibleMoves: 10, // Number of moves visible in the history panel
-		face:            basicfont.Face7x13,
-		evalHistory:     make([]int, 0),
-		evaluator:       evaluation.NewMixedEvaluation(evaluation.V4Coeff),
-		evalChan:        make(chan int, 1),      // Buffered channel for evaluation results
-		depthUpdateChan: make(chan int, 1),      // Buffered channel for depth updates
-		evalCancelChan:  make(chan struct{}, 1), // Buffered channel for cancellation signal
-		maxDepth:        5,                      // Maximum evaluation depth
+		ui:          ui,
+		lastMove:    time.Now(),
+		lastMovePos: game.Position{Row: -1, Col: -1}, // Initialize with invalid position
+		moves:       view.NewMoveList(10),
+		face:        basicfont.Face7x13,
+		evaluator:   evaluation.NewMixedEvaluation(evaluation.V4Coeff),
+		eval:        view.NewEvaluation(5),
+		evalUpdates: make(chan view.EvalUpdate, view.MaxEvalDepth),
+		replayer:    game.NewGameReplayer(),
+		scoreGraph:  NewScoreGraph(basicfont.Face7x13),
 	}
 }
 
@@ -72,40 +75,20 @@ func (s *GameScreen) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return outsideWidth, outsideHeight
 }
 
-// AddMoveToHistory adds a move to the history table
-func (s *GameScreen) AddMoveToHistory(pos game.Position, playerColor game.Piece, pass bool) {
-	moveRecord := MoveRecord{
-		Position: pos,
-		Pass:     pass,
-	}
+// AddMoveToHistory adds a move to the history table, a pass when pos is game.PassMove as
+// in the history of the game
+func (s *GameScreen) AddMoveToHistory(pos game.Position, playerColor game.Piece) {
+	s.moves.Add(pos, playerColor)
+}
 
-	// If it's a black move, create a new turn
-	if playerColor == game.Black {
-		s.moveHistory = append(s.moveHistory, [2]MoveRecord{
-			moveRecord, // Black move
-			{Position: game.Position{Row: -1, Col: -1}}, // Placeholder for white move
-		})
-	} else {
-		// If it's a white move, update the last turn's white move
-		if len(s.moveHistory) > 0 {
-			lastIdx := len(s.moveHistory) - 1
-			s.moveHistory[lastIdx][1] = moveRecord
-		} else {
-			// This is an unusual case where white moves first
-			// Create a new entry with placeholder for black
-			s.moveHistory = append(s.moveHistory, [2]MoveRecord{
-				{Position: game.Position{Row: -1, Col: -1}}, // Placeholder for black
-				moveRecord, // White move
-			})
-		}
-	}
+// addBookMoveToHistory adds a move played from an opening book to the history, marked as such
+func (s *GameScreen) addBookMoveToHistory(pos game.Position, playerColor game.Piece) {
+	s.moves.AddBook(pos, playerColor)
+}
 
-	// Adjust scroll offset to show the latest moves only if we need to scroll
-	if len(s.moveHistory) > s.maxVisibleMoves {
-		s.scrollOffset = len(s.moveHistory) - s.maxVisibleMoves
-	} else {
-		s.scrollOffset = 0 // No need to scroll when fewer moves than visible area
-	}
+// bookMove returns the move of the opening books for the player to move, see view.BookMove
+func (s *GameScreen) bookMove() (game.Position, bool) {
+	return view.BookMove(context.Background(), s.ui.bookEngine, s.ui.game.History, s.ui.game.Variant)
 }
 
 // Update updates the game state
@@ -113,10 +96,17 @@ func (s *GameScreen) Update() error {
 	// Calculate board dimensions based on screen size
 	screenWidth, screenHeight := ebiten.WindowSize()
 	s.boardSize = min(screenWidth-300, screenHeight-100) // Reduce board size to make room for history
+	if s.ui.game.Mode == game.AIVsAI {
+		s.boardSize = min(screenWidth-300, screenHeight-100-s.scoreGraph.Height()) // Make room for the score graph
+	}
 	s.cellSize = s.boardSize / 8
 	s.boardOffsetX = (screenWidth - s.boardSize - 250) / 2 // Shift board left to make room for eval bar and history
 	s.boardOffsetY = 80                                    // Leave space for header
 
+	if s.ui.game.Mode == game.AIVsAI {
+		s.scoreGraph.Update(s.boardOffsetX, s.boardOffsetY+s.boardSize+10, s.boardSize)
+	}
+
 	// Handle mouse wheel for scrolling move history
 	_, scrollY := ebiten.Wheel()
 	if scrollY != 0 {
@@ -125,132 +115,204 @@ func (s *GameScreen) Update() error {
 		historyPanelX := s.boardOffsetX + s.boardSize + 80
 		if mouseX >= historyPanelX {
 			// Scroll history
-			s.scrollOffset -= int(scrollY * 3) // Adjust scroll speed
+			s.moves.Scroll(-int(scrollY * 3)) // Adjust scroll speed
+		}
+	}
 
-			// Clamp scrolling
-			if s.scrollOffset < 0 {
-				s.scrollOffset = 0
-			}
-			maxScroll := max(0, len(s.moveHistory)-s.maxVisibleMoves)
-			if s.scrollOffset > maxScroll {
-				s.scrollOffset = maxScroll
-			}
+	// Let the flips of the last move play before the game goes on
+	if s.animating {
+		var done bool
+		s.animBoard, done = s.replayer.NextFrame()
+		if !done {
+			s.readEvalUpdates()
+			return nil
 		}
+		s.animating = false
 	}
 
-	// Check if game is over
-	if game.IsGameFinished(s.ui.game.Board) {
+	switch s.ui.game.LegalState() {
+	case game.GameOver:
 		s.ui.EndGame()
 		return nil
-	}
-
-	// Check if current player has any valid moves
-	if !s.ui.game.HasAnyMovesInGame() {
+	case game.MustPass:
 		// No valid moves, add a "Pass" record to history
-		s.AddMoveToHistory(game.Position{Row: -1, Col: -1}, s.ui.game.CurrentPlayer.Color, true)
+		s.AddMoveToHistory(game.PassMove, s.ui.game.CurrentPlayer.Color)
 
 		// Switch to the other player
-		s.ui.game.CurrentPlayer = s.ui.game.GetOtherPlayerMethod()
+		s.ui.game.Pass()
 		return nil
 	}
 
-	// Check for depth updates
-	select {
-	case newDepth := <-s.depthUpdateChan:
-		s.currentDepth = newDepth
-	default:
-		// No depth update
-	}
-
-	// Check for finished evaluations
-	select {
-	case evalResult := <-s.evalChan:
-		s.evaluationValue = evalResult
-		s.resultDepth = s.currentDepth // Store the depth of this evaluation result
-		s.evalHistory = append(s.evalHistory, evalResult)
+	// Read the completed evaluation iterations
+	s.readEvalUpdates()
 
-		// Cap history size to prevent memory issues
-		if len(s.evalHistory) > 100 {
-			s.evalHistory = s.evalHistory[len(s.evalHistory)-100:]
+	// Copy the position with Ctrl+C or Cmd+C
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) && (ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)) {
+		if err := s.ExportCurrentPosition(); err != nil {
+			log.Printf("warning: could not copy the position: %v", err)
+		}
+	} else if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		// Copy the moves transcript alone with C
+		if err := s.ExportTranscript(); err != nil {
+			log.Printf("warning: could not export the transcript: %v", err)
 		}
-	default:
-		// No evaluation result ready yet
+	}
+
+	// Change the evaluation depth
+	delta := 0
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadAdd) {
+		delta++
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadSubtract) {
+		delta--
+	}
+	if s.eval.ChangeDepth(delta) {
+		s.updateProgressiveEvaluation()
 	}
 
 	// Handle AI vs AI mode
-	if s.ui.aivsAiMode {
+	if s.ui.game.Mode == game.AIVsAI {
 		currentTime := time.Now()
 		if currentTime.Sub(s.ui.aivsAiTimer) >= s.ui.aivsAiMoveDelay {
-			// Time to make another AI move
-			eval := s.evaluator
-			moves, _ := evaluation.Solve(s.ui.game.Board, s.ui.game.CurrentPlayer.Color, 5, eval)
+			// Time to make another AI move, with the engine of the player to move
+			mover := s.ui.game.CurrentPlayer.Color
+			if pos, ok := s.bookMove(); ok {
+				before := s.ui.game.Board
+				if s.ui.game.ApplyMove(pos) == nil {
+					s.animateMove(before, pos, mover)
+					s.lastMovePos = pos
+					s.addBookMoveToHistory(pos, mover)
+					s.updateProgressiveEvaluation()
+					s.ui.aivsAiTimer = currentTime
+				}
+				return nil
+			}
+			engine := 0
+			if mover == game.White {
+				engine = 1
+			}
+			eval := evaluation.ForVariant(s.aiEvaluators[engine], s.ui.game.Variant)
+			moves, score := evaluation.Solve(s.ui.game.Board, mover, 5, eval)
 			if len(moves) == 0 || (len(moves) == 1 && moves[0].Row == -1 && moves[0].Col == -1) {
-				// No valid moves found, switch player
-				s.ui.game.CurrentPlayer = s.ui.game.GetOtherPlayerMethod()
 				return nil
 			}
 
+			// Chart the mover's score and have the other engine assess the same position
+			ply := len(s.ui.game.History)
+			s.scoreGraph.Record(ply, mover, engine, int(score))
+			other := evaluation.ForVariant(s.aiEvaluators[1-engine], s.ui.game.Variant)
+			s.scoreGraph.Assess(utils.BoardToBits(s.ui.game.Board), ply, mover, 1-engine, other)
+
 			pos := moves[0]
 
 			// Apply move and update evaluation
-			if s.ui.game.ApplyMove(pos) {
-				s.lastMovePos = pos                                           // Update last move position
-				s.AddMoveToHistory(pos, s.ui.game.CurrentPlayer.Color, false) // Add to history
-				s.updateProgressiveEvaluation()                               // Update evaluation
-				s.ui.aivsAiTimer = currentTime                                // Reset timer for next move
+			before := s.ui.game.Board
+			if s.ui.game.ApplyMove(pos) == nil {
+				s.animateMove(before, pos, mover)
+				s.lastMovePos = pos             // Update last move position
+				s.AddMoveToHistory(pos, mover)  // Add to history
+				s.updateProgressiveEvaluation() // Update evaluation
+				s.ui.aivsAiTimer = currentTime  // Reset timer for next move
 			}
 		}
 		return nil
 	}
 
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		s.ponderEnabled = !s.ponderEnabled
+		if !s.ponderEnabled {
+			s.ponderer.Stop()
+		}
+	}
+
 	// Handle human vs AI mode
-	if s.ui.game.CurrentPlayer.Name == "Human" {
+	if s.ui.game.IsHumanTurn() {
 		// Handle mouse input
 		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 			x, y := ebiten.CursorPosition()
 
 			// Determine if click was within board bounds
-			if x >= s.boardOffsetX && x < s.boardOffsetX+s.boardSize &&
-				y >= s.boardOffsetY && y < s.boardOffsetY+s.boardSize {
-
-				// Calculate board position
-				boardX := (x - s.boardOffsetX) / s.cellSize
-				boardY := (y - s.boardOffsetY) / s.cellSize
-
-				pos := game.Position{Row: int8(boardY), Col: int8(boardX)}
-
+			if pos, ok := s.boardView().cellAt(x, y); ok {
 				// Try to make the move
-				if s.ui.game.ApplyMove(pos) {
-					s.lastMovePos = pos                                           // Update last move position
-					s.AddMoveToHistory(pos, s.ui.game.CurrentPlayer.Color, false) // Add to history
-					s.updateProgressiveEvaluation()                               // Update evaluation
+				mover := s.ui.game.CurrentPlayer.Color
+				before := s.ui.game.Board
+				if s.ui.game.ApplyMove(pos) == nil {
+					s.animateMove(before, pos, mover)
+					s.lastMovePos = pos             // Update last move position
+					s.AddMoveToHistory(pos, mover)  // Add to history
+					s.updateProgressiveEvaluation() // Update evaluation
 					s.lastMove = time.Now()
 				}
 			}
 		}
-	} else if s.ui.game.CurrentPlayer.Name != "Human" {
+	} else {
 		// Handle AI move
-		eval := s.evaluator
-		moves, _ := evaluation.Solve(s.ui.game.Board, s.ui.game.CurrentPlayer.Color, 5, eval)
+		evaluator := s.evaluator
+		if s.opponentEvaluator != nil {
+			evaluator = s.opponentEvaluator
+		}
+		eval := evaluation.ForVariant(evaluator, s.ui.game.Variant)
+
+		// Play the book move without search, no reply is pondered
+		if pos, ok := s.bookMove(); ok {
+			mover := s.ui.game.CurrentPlayer.Color
+			before := s.ui.game.Board
+			if s.ui.game.ApplyMove(pos) == nil {
+				s.ponderer.Stop()
+				s.animateMove(before, pos, mover)
+				s.lastMovePos = pos
+				s.addBookMoveToHistory(pos, mover)
+				s.updateProgressiveEvaluation()
+				s.lastMove = time.Now()
+			}
+			return nil
+		}
+
+		// Answer from the pondered search when the human played the predicted move
+		moves, _, hit := s.ponderer.Result(s.ui.game.Board, s.ui.game.CurrentPlayer.Color)
+		if !hit {
+			moves = s.opponentSearch(eval)
+		}
 		if len(moves) == 0 || (len(moves) == 1 && moves[0].Row == -1 && moves[0].Col == -1) {
-			// No valid moves found, switch player
-			s.ui.game.CurrentPlayer = s.ui.game.GetOtherPlayerMethod()
 			return nil
 		}
 
 		pos := moves[0] // Get the best move
+		mover := s.ui.game.CurrentPlayer.Color
 		// Apply move and update evaluation
-		if s.ui.game.ApplyMove(pos) {
-			s.lastMovePos = pos                                           // Update last move position
-			s.AddMoveToHistory(pos, s.ui.game.CurrentPlayer.Color, false) // Add to history
-			s.updateProgressiveEvaluation()                               // Update evaluation
+		before := s.ui.game.Board
+		if s.ui.game.ApplyMove(pos) == nil {
+			s.animateMove(before, pos, mover)
+			s.lastMovePos = pos             // Update last move position
+			s.AddMoveToHistory(pos, mover)  // Add to history
+			s.updateProgressiveEvaluation() // Update evaluation
 			s.lastMove = time.Now()
+
+			// The second move of the principal variation is the expected human reply
+			if s.ponderEnabled && len(moves) > 1 {
+				if s.ui.adaptiveOpponent {
+					s.ponderer.StartNodes(s.ui.game.Board, s.ui.game.CurrentPlayer.Color, moves[1], adaptiveMaxDepth, eval, s.ui.difficulty.NodeBudget())
+				} else {
+					s.ponderer.Start(s.ui.game.Board, s.ui.game.CurrentPlayer.Color, moves[1], 5, eval)
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
+// opponentSearch returns the principal variation of the AI opponent of the human, searched
+// within the node budget of the adaptive difficulty for an adaptive opponent
+func (s *GameScreen) opponentSearch(eval evaluation.Evaluation) []game.Position {
+	if s.ui.adaptiveOpponent {
+		moves, _, _ := evaluation.SolveNodes(s.ui.game.Board, s.ui.game.CurrentPlayer.Color, adaptiveMaxDepth, eval, s.ui.difficulty.NodeBudget())
+		return moves
+	}
+	moves, _ := evaluation.Solve(s.ui.game.Board, s.ui.game.CurrentPlayer.Color, 5, eval)
+	return moves
+}
+
 // Draw renders the game screen
 func (s *GameScreen) Draw(screen *ebiten.Image) {
 	// Fill background
@@ -268,8 +330,9 @@ func (s *GameScreen) Draw(screen *ebiten.Image) {
 	// Draw evaluation bar
 	s.drawEvaluationBar(screen)
 
-	// Draw AI vs AI indicator if in that mode
-	if s.ui.aivsAiMode {
+	// Draw AI vs AI indicator and score graph if in that mode
+	if s.ui.game.Mode == game.AIVsAI {
+		s.scoreGraph.Draw(screen)
 		screenWidth, _ := screen.Bounds().Dx(), screen.Bounds().Dy()
 		aivsaiText := "AI vs AI Mode"
 		text.Draw(screen, aivsaiText, s.face, screenWidth-120, 20, color.RGBA{255, 215, 0, 255})
@@ -279,7 +342,7 @@ func (s *GameScreen) Draw(screen *ebiten.Image) {
 // drawHeaderInfo renders the game status information
 func (s *GameScreen) drawHeaderInfo(screen *ebiten.Image) {
 	currentPlayer := s.ui.game.CurrentPlayer
-	blackCount, whiteCount := game.CountPieces(s.ui.game.Board)
+	blackCount, whiteCount := s.ui.game.CountPiecesMethod()
 
 	// Draw title
 	title := "Othello"
@@ -302,6 +365,19 @@ func (s *GameScreen) drawHeaderInfo(screen *ebiten.Image) {
 	scoreBounds := text.BoundString(s.face, scoreInfo)
 	scoreX := (screen.Bounds().Dx() - scoreBounds.Dx()) / 2
 	text.Draw(screen, scoreInfo, s.face, scoreX, 60, color.White)
+
+	// Draw pondering state
+	if s.ponderEnabled {
+		text.Draw(screen, "Pondering (P)", s.face, 10, 20, color.White)
+	}
+
+	// Draw evaluation depth
+	text.Draw(screen, fmt.Sprintf("Eval depth: %d (+/-)", s.eval.MaxDepth), s.face, 10, 40, color.White)
+
+	// Draw the copy toast
+	if time.Since(s.copiedAt) < copiedToastDuration {
+		text.Draw(screen, s.copiedText, s.face, 10, 60, color.RGBA{255, 215, 0, 255})
+	}
 }
 
 // drawMoveHistory draws the move history table
@@ -314,10 +390,10 @@ func (s *GameScreen) drawMoveHistory(screen *ebiten.Image) {
 	cellHeight := 25
 
 	// Dynamically calculate the maximum visible moves based on available height
-	s.maxVisibleMoves = (historyHeight - 24) / cellHeight // Subtract header height (24px)
+	s.moves.SetVisible((historyHeight - 24) / cellHeight) // Subtract header height (24px)
 
 	// Draw history panel background
-	ebitenutil.DrawRect(screen, float64(historyX), float64(historyY),
+	draw.Rect(screen, float64(historyX), float64(historyY),
 		float64(historyWidth), float64(historyHeight),
 		color.RGBA{40, 40, 40, 255})
 
@@ -335,7 +411,7 @@ func (s *GameScreen) drawMoveHistory(screen *ebiten.Image) {
 	colWidth := historyWidth / 3
 
 	// Draw header background
-	ebitenutil.DrawRect(screen, float64(historyX), float64(historyY),
+	draw.Rect(screen, float64(historyX), float64(historyY),
 		float64(historyWidth), float64(24),
 		color.RGBA{60, 60, 60, 255})
 
@@ -345,30 +421,24 @@ func (s *GameScreen) drawMoveHistory(screen *ebiten.Image) {
 	text.Draw(screen, whiteCol, s.face, historyX+2*colWidth+10, historyY+16, color.White)
 
 	// Draw horizontal line under header
-	ebitenutil.DrawLine(screen,
+	draw.Line(screen,
 		float64(historyX), float64(historyY+24),
 		float64(historyX+historyWidth), float64(historyY+24),
 		color.RGBA{100, 100, 100, 255})
 
 	// Draw vertical lines between columns
-	ebitenutil.DrawLine(screen,
+	draw.Line(screen,
 		float64(historyX+colWidth), float64(historyY),
 		float64(historyX+colWidth), float64(historyY+historyHeight),
 		color.RGBA{100, 100, 100, 255})
 
-	ebitenutil.DrawLine(screen,
+	draw.Line(screen,
 		float64(historyX+2*colWidth), float64(historyY),
 		float64(historyX+2*colWidth), float64(historyY+historyHeight),
 		color.RGBA{100, 100, 100, 255})
 
-	// Determine visible range of moves
-	startIdx := 0
-	if len(s.moveHistory) > s.maxVisibleMoves {
-		startIdx = s.scrollOffset
-	}
-	endIdx := min(len(s.moveHistory), startIdx+s.maxVisibleMoves)
-
 	// Draw visible moves
+	startIdx, endIdx := s.moves.Range()
 	for i := startIdx; i < endIdx; i++ {
 		rowY := historyY + 24 + (i-startIdx)*cellHeight
 
@@ -378,7 +448,7 @@ func (s *GameScreen) drawMoveHistory(screen *ebiten.Image) {
 			rowColor = color.RGBA{45, 45, 45, 255}
 		}
 
-		ebitenutil.DrawRect(screen, float64(historyX), float64(rowY),
+		draw.Rect(screen, float64(historyX), float64(rowY),
 			float64(historyWidth), float64(cellHeight),
 			rowColor)
 
@@ -386,37 +456,22 @@ func (s *GameScreen) drawMoveHistory(screen *ebiten.Image) {
 		turnText := fmt.Sprintf("%d", i+1)
 		text.Draw(screen, turnText, s.face, historyX+10, rowY+16, color.White)
 
-		// Draw black move
-		blackMove := s.moveHistory[i][0]
-		blackText := "Pass"
-		if !blackMove.Pass && blackMove.Position.Row >= 0 {
-			colLetter := string('A' + blackMove.Position.Col)
-			rowNumber := blackMove.Position.Row + 1
-			blackText = fmt.Sprintf("%s%d", colLetter, rowNumber)
-		}
-		text.Draw(screen, blackText, s.face, historyX+colWidth+10, rowY+16, color.White)
-
-		// Draw white move
-		whiteMove := s.moveHistory[i][1]
-		whiteText := "Pass"
-		if !whiteMove.Pass && whiteMove.Position.Row >= 0 {
-			colLetter := string('A' + whiteMove.Position.Col)
-			rowNumber := whiteMove.Position.Row + 1
-			whiteText = fmt.Sprintf("%s%d", colLetter, rowNumber)
-		}
-		text.Draw(screen, whiteText, s.face, historyX+2*colWidth+10, rowY+16, color.White)
+		// Draw black and white moves
+		turn := s.moves.Turns[i]
+		text.Draw(screen, turn[0].String(), s.face, historyX+colWidth+10, rowY+16, color.White)
+		text.Draw(screen, turn[1].String(), s.face, historyX+2*colWidth+10, rowY+16, color.White)
 
 		// Draw horizontal line under each row
-		ebitenutil.DrawLine(screen,
+		draw.Line(screen,
 			float64(historyX), float64(rowY+cellHeight),
 			float64(historyX+historyWidth), float64(rowY+cellHeight),
 			color.RGBA{70, 70, 70, 255})
 	}
 
 	// Only show scroll indicators and instructions if there are more moves than can be displayed
-	if len(s.moveHistory) > s.maxVisibleMoves {
+	if above, below := s.moves.More(); above || below {
 		// Draw scroll indicators if needed
-		if s.scrollOffset > 0 {
+		if above {
 			// More moves above
 			upArrow := "▲"
 			arrowBounds := text.BoundString(s.face, upArrow)
@@ -424,7 +479,7 @@ func (s *GameScreen) drawMoveHistory(screen *ebiten.Image) {
 			text.Draw(screen, upArrow, s.face, arrowX, historyY+40, color.RGBA{200, 200, 200, 255})
 		}
 
-		if s.scrollOffset+s.maxVisibleMoves < len(s.moveHistory) {
+		if below {
 			// More moves below
 			downArrow := "▼"
 			arrowBounds := text.BoundString(s.face, downArrow)
@@ -442,80 +497,22 @@ func (s *GameScreen) drawMoveHistory(screen *ebiten.Image) {
 
 // drawGameBoard renders the game board
 func (s *GameScreen) drawGameBoard(screen *ebiten.Image) {
-	// Draw board background
-	ebitenutil.DrawRect(screen, float64(s.boardOffsetX), float64(s.boardOffsetY),
-		float64(s.boardSize), float64(s.boardSize),
-		color.RGBA{34, 100, 34, 255})
-
-	// Get valid moves for current player
-	validMoves := s.ui.game.GetValidMovesForCurrentPlayer()
-
-	// Draw grid and pieces
-	for row := 0; row < 8; row++ {
-		for col := 0; col < 8; col++ {
-			x := int8(s.boardOffsetX + col*s.cellSize)
-			y := int8(s.boardOffsetY + row*s.cellSize)
-
-			// Draw cell border
-			ebitenutil.DrawRect(screen, float64(x), float64(y),
-				float64(s.cellSize), float64(s.cellSize),
-				ColorGrid)
-
-			// Determine cell color - check if this is the last move position
-			cellColor := color.RGBA{50, 150, 50, 255} // Default cell color
-
-			if s.lastMovePos.Row == int8(row) && s.lastMovePos.Col == int8(col) {
-				// Highlight the last move with a different color
-				cellColor = ColorLastMove
-			}
-
-			// Draw cell interior
-			ebitenutil.DrawRect(screen, float64(x+1), float64(y+1),
-				float64(s.cellSize-2), float64(s.cellSize-2),
-				cellColor)
-
-			// Check if this is a valid move
-			isValidMove := false
-			for _, pos := range validMoves {
-				if pos.Row == int8(row) && pos.Col == int8(col) {
-					isValidMove = true
-					break
-				}
-			}
-
-			// Draw valid move indicator
-			if isValidMove {
-				ebitenutil.DrawRect(screen, float64(x+3), float64(y+3),
-					float64(s.cellSize-6), float64(s.cellSize-6),
-					ColorValid)
-			}
-
-			// Draw piece if present
-			piece := s.ui.game.Board[row][col]
-			if piece != game.Empty {
-				pieceColor := ColorWhite
-				if piece == game.Black {
-					pieceColor = ColorBlack
-				}
-
-				// Draw circle for piece
-				centerX := float64(int(x) + s.cellSize/2)
-				centerY := float64(int(y) + s.cellSize/2)
-				radius := float64(s.cellSize/2 - 4)
-				s.drawCircle(screen, centerX, centerY, radius, pieceColor)
-			}
-		}
+	// Valid moves for current player, hidden during the flip animation
+	board := s.ui.game.Board
+	var validMoves []game.Position
+	if s.animating {
+		board = s.animBoard
+	} else {
+		validMoves = s.ui.game.GetValidMovesForCurrentPlayer()
 	}
+	s.boardView().draw(screen, board, validMoves, s.lastMovePos)
 
 	// Draw coordinate labels around the board
-	s.drawBoardCoordinates(screen)
+	s.boardView().drawCoordinates(screen, s.face)
 
 	// Draw last move indicator text
-	if s.lastMovePos.Row >= 0 && s.lastMovePos.Row < 8 &&
-		s.lastMovePos.Col >= 0 && s.lastMovePos.Col < 8 {
-		colLetter := string('A' + s.lastMovePos.Col)
-		rowNumber := s.lastMovePos.Row + 1
-		lastMoveText := fmt.Sprintf("Last move: %s%d", colLetter, rowNumber)
+	if square := view.SquareName(s.lastMovePos); square != "" {
+		lastMoveText := "Last move: " + square
 
 		textX := s.boardOffsetX + s.boardSize + 80
 		textY := s.boardOffsetY + s.boardSize - 20
@@ -525,117 +522,84 @@ func (s *GameScreen) drawGameBoard(screen *ebiten.Image) {
 	}
 }
 
-// drawBoardCoordinates draws the row and column coordinate labels
-func (s *GameScreen) drawBoardCoordinates(screen *ebiten.Image) {
-	// Column labels (A-H)
-	for col := 0; col < 8; col++ {
-		colLabel := string('A' + col)
-		labelBounds := text.BoundString(s.face, colLabel)
-		labelX := s.boardOffsetX + col*s.cellSize + (s.cellSize-labelBounds.Dx())/2
-		labelY := s.boardOffsetY - 5 // Above the board
-		text.Draw(screen, colLabel, s.face, labelX, labelY, ColorLabelText)
-	}
+// boardView returns the placement of the board on the screen
+func (s *GameScreen) boardView() boardView {
+	return boardView{X: s.boardOffsetX, Y: s.boardOffsetY, Cell: s.cellSize}
+}
+
+// animateMove starts the flip animation of a move played on before
+func (s *GameScreen) animateMove(before game.Board, pos game.Position, mover game.Piece) {
+	s.replayer.StartFlip(before, pos, mover)
+	s.animBoard, _ = s.replayer.NextFrame()
+	s.animating = true
+}
 
-	// Row labels (1-8) - only on the left
-	for row := 0; row < 8; row++ {
-		rowLabel := fmt.Sprintf("%d", row+1)
-		labelBounds := text.BoundString(s.face, rowLabel)
-		labelX := s.boardOffsetX - labelBounds.Dx() - 5 // Left of the board
-		labelY := s.boardOffsetY + row*s.cellSize + (s.cellSize+labelBounds.Dy())/2
-		text.Draw(screen, rowLabel, s.face, labelX, labelY, ColorLabelText)
+// ExportCurrentPosition copies the position in OTF notation and the moves transcript,
+// on a second line, to the clipboard. The transcript can be pasted in cmd/cli.
+func (s *GameScreen) ExportCurrentPosition() error {
+	otf := utils.BoardToOTF(s.ui.game.Board, s.ui.game.CurrentPlayer.Color)
+	if err := writeClipboard(otf + "\n" + utils.TranscriptToAlgebraic(s.ui.game.History) + "\n"); err != nil {
+		return err
 	}
+	s.copiedAt = time.Now()
+	s.copiedText = "Copied!"
+	return nil
 }
 
-// drawCircle draws a filled circle
-func (s *GameScreen) drawCircle(screen *ebiten.Image, x, y, radius float64, col color.Color) {
-	// Draw a circle using the midpoint circle algorithm
-	for yOff := -radius; yOff <= radius; yOff++ {
-		for xOff := -radius; xOff <= radius; xOff++ {
-			if xOff*xOff+yOff*yOff <= radius*radius {
-				screen.Set(int(x+xOff), int(y+yOff), col)
-			}
+// ExportTranscript copies the moves transcript to the clipboard. Without a clipboard
+// command, the transcript is written to a file of the working directory instead.
+func (s *GameScreen) ExportTranscript() error {
+	transcript := utils.TranscriptToAlgebraic(s.ui.game.History)
+	if err := writeClipboard(transcript + "\n"); err != nil {
+		path := fmt.Sprintf("transcript_%s.txt", time.Now().Format("20060102_150405"))
+		if err := os.WriteFile(path, []byte(transcript+"\n"), 0644); err != nil {
+			return err
 		}
+		s.copiedAt = time.Now()
+		s.copiedText = "Transcript saved to " + path
+		return nil
 	}
+	s.copiedAt = time.Now()
+	s.copiedText = "Transcript copied!"
+	return nil
 }
 
 // updateProgressiveEvaluation starts an asynchronous progressive depth evaluation
+// of the current position, stopping the previous one
 func (s *GameScreen) updateProgressiveEvaluation() {
-	// Cancel any ongoing evaluation
-	if s.evaluating {
-		select {
-		case s.evalCancelChan <- struct{}{}:
-			// Signal sent successfully
-		default:
-			// Channel already has a signal, no need to send another one
-		}
-	}
-
-	// Start the progressive evaluation process
-	s.evaluating = true
-	s.currentDepth = 1 // Reset depth counter
+	s.stopEvaluation()
 
-	// Create a copy of the game for evaluation
-	gameCopy := *s.ui.game
+	gen := s.eval.Start()
+	var ctx context.Context
+	ctx, s.evalCancel = context.WithCancel(context.Background())
 
 	// Always evaluate from black's perspective for consistency
-	player := s.ui.game.Players[0]
-
-	// Create a new evaluation cycle
-	go func() {
-		defer func() { s.evaluating = false }()
-
-		// Start with shallow depth and progressively increase
-		for depth := 2; depth <= s.maxDepth; depth += 1 {
-			// Check if we should cancel this evaluation
-			select {
-			case <-s.evalCancelChan:
-				return // Stop evaluating
-			default:
-				// Continue evaluation
-			}
-
-			// Update the current depth
-			select {
-			case s.depthUpdateChan <- depth:
-			default:
-				// Channel full, continue anyway
-			}
+	b := s.ui.game.Board
+	player := s.ui.game.Players[0].Color
+	eval := evaluation.ForVariant(s.evaluator, s.ui.game.Variant)
+	go view.Progressive(ctx, gen, b, player, eval, s.eval.MaxDepth, s.evalUpdates)
+}
 
-			// Perform evaluation at current depth
-			evalScore, _ := evaluation.MMAB(
-				utils.BoardToBits(gameCopy.Board),
-				player.Color,
-				int8(depth),
-				evaluation.MIN_EVAL, // alpha
-				evaluation.MAX_EVAL, // beta
-				s.evaluator,
-				nil,
-				nil) // Pass nil for performance stats since we don't track them in the UI
-
-			// Check again if we should cancel before sending result
-			select {
-			case <-s.evalCancelChan:
-				return // Stop evaluating and don't send result
-			default:
-				// Send the result
-				select {
-				case s.evalChan <- int(evalScore):
-					// Successfully sent
-				default:
-					// Channel full, clear it and send new value
-					select {
-					case <-s.evalChan: // Discard old value
-					default:
-						// Channel was already empty
-					}
-					s.evalChan <- int(evalScore)
-				}
-			}
+// stopEvaluation cancels the running evaluation, which publishes nothing more
+func (s *GameScreen) stopEvaluation() {
+	if s.evalCancel != nil {
+		s.evalCancel()
+		s.evalCancel = nil
+	}
+	s.eval.Stop()
+}
 
-			// Small sleep to prevent CPU hogging and allow UI updates
-			time.Sleep(50 * time.Millisecond)
+// readEvalUpdates applies the iterations completed by the current evaluation
+func (s *GameScreen) readEvalUpdates() {
+	for {
+		select {
+		case update := <-s.evalUpdates:
+			// Updates of an older position, finished before being cancelled, are dropped
+			s.eval.Apply(update)
+		default:
+			return
 		}
-	}()
+	}
 }
 
 // drawEvaluationBar draws the evaluation bar on the right side of the board
@@ -647,13 +611,13 @@ func (s *GameScreen) drawEvaluationBar(screen *ebiten.Image) {
 	barHeight := s.boardSize
 
 	// Draw bar background
-	ebitenutil.DrawRect(screen, float64(barX), float64(barY),
+	draw.Rect(screen, float64(barX), float64(barY),
 		float64(barWidth), float64(barHeight), color.RGBA{40, 40, 40, 255})
 
 	// Calculate bar fill based on evaluation
 	// Normalize evaluation value to a percentage (-2000 to +2000 range)
 	evalRange := 2000.0
-	normalizedEval := float64(s.evaluationValue) / evalRange
+	normalizedEval := float64(s.eval.Value) / evalRange
 	if normalizedEval > 1.0 {
 		normalizedEval = 1.0
 	}
@@ -665,7 +629,7 @@ func (s *GameScreen) drawEvaluationBar(screen *ebiten.Image) {
 	centerY := barY + barHeight/2
 
 	// Draw the neutral line
-	ebitenutil.DrawLine(screen,
+	draw.Line(screen,
 		float64(barX), float64(centerY),
 		float64(barX+barWidth), float64(centerY),
 		color.RGBA{100, 100, 100, 255})
@@ -678,26 +642,21 @@ func (s *GameScreen) drawEvaluationBar(screen *ebiten.Image) {
 	if normalizedEval > 0 {
 		// Positive evaluation (good for black) - green bar going up from center
 		fillColor = color.RGBA{0, 200, 0, 255}
-		ebitenutil.DrawRect(screen,
+		draw.Rect(screen,
 			float64(barX), float64(centerY-fillHeight),
 			float64(barWidth), float64(fillHeight),
 			fillColor)
 	} else {
 		// Negative evaluation (good for white) - red bar going down from center
 		fillColor = color.RGBA{200, 0, 0, 255}
-		ebitenutil.DrawRect(screen,
+		draw.Rect(screen,
 			float64(barX), float64(centerY),
 			float64(barWidth), float64(-fillHeight),
 			fillColor)
 	}
 
 	// Draw evaluation text with depth information
-	var evalText string
-	if s.evaluating {
-		evalText = fmt.Sprintf("%+d d:%d/%d", s.evaluationValue, s.resultDepth, s.currentDepth)
-	} else {
-		evalText = fmt.Sprintf("%+d d:%d", s.evaluationValue, s.resultDepth)
-	}
+	evalText := s.eval.Text()
 
 	textBounds := text.BoundString(s.face, evalText)
 	textX := barX + (barWidth-textBounds.Dx())/2
@@ -705,7 +664,7 @@ func (s *GameScreen) drawEvaluationBar(screen *ebiten.Image) {
 	text.Draw(screen, evalText, s.face, textX, textY, color.White)
 
 	// Add a "thinking" indicator if evaluation is in progress
-	if s.evaluating {
+	if s.eval.Searching {
 		thinkingText := "thinking..."
 		thinkX := barX - 10
 		thinkY := barY - 20
@@ -719,19 +678,3 @@ func (s *GameScreen) drawEvaluationBar(screen *ebiten.Image) {
 	whiteLabelY := barY + barHeight + 35
 	text.Draw(screen, "White", s.face, barX, whiteLabelY, color.White)
 }
-
-// max returns the maximum of two integers
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-// min returns the minimum of a and b
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
@@ -3,8 +3,9 @@ package ui
 import (
 	"image/color"
 
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/ui/draw"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font"
@@ -15,8 +16,8 @@ import (
 type HomeScreen struct {
 	ui            *UI
 	face          font.Face
-	buttonBounds  [2][4]int // Two buttons: [0] for Player vs AI, [1] for AI vs AI
-	buttonHovered int       // -1: none, 0: Player vs AI, 1: AI vs AI
+	buttonBounds  [5][4]int // Five buttons: [0] for Player vs AI, [1] for AI vs AI, [2] for Tournament, [3] for the opening trainer, [4] for the rules
+	buttonHovered int       // -1: none, 0: Player vs AI, 1: AI vs AI, 2: Tournament, 3: Opening Trainer, 4: Rules
 }
 
 // NewHomeScreen creates a new home screen
@@ -40,32 +41,24 @@ func (s *HomeScreen) Update() error {
 	// Define button dimensions
 	buttonWidth := 250
 	buttonHeight := 50
-	buttonSpacing := 30
-
-	// Calculate button positions
-	firstButtonY := screenHeight/2 + 20
-	secondButtonY := firstButtonY + buttonHeight + buttonSpacing
-
-	// Update button bounds
-	s.buttonBounds[0] = [4]int{
-		(screenWidth - buttonWidth) / 2,
-		firstButtonY,
-		buttonWidth,
-		buttonHeight,
-	}
-
-	s.buttonBounds[1] = [4]int{
-		(screenWidth - buttonWidth) / 2,
-		secondButtonY,
-		buttonWidth,
-		buttonHeight,
+	buttonSpacing := 20
+
+	// Stack the buttons below the title
+	firstButtonY := screenHeight/4 + 50
+	for i := range s.buttonBounds {
+		s.buttonBounds[i] = [4]int{
+			(screenWidth - buttonWidth) / 2,
+			firstButtonY + i*(buttonHeight+buttonSpacing),
+			buttonWidth,
+			buttonHeight,
+		}
 	}
 
 	// Check if mouse is over any button
 	mouseX, mouseY := ebiten.CursorPosition()
 	s.buttonHovered = -1
 
-	for i := 0; i < 2; i++ {
+	for i := 0; i < len(s.buttonBounds); i++ {
 		bounds := s.buttonBounds[i]
 		if mouseX >= bounds[0] && mouseX < bounds[0]+bounds[2] &&
 			mouseY >= bounds[1] && mouseY < bounds[1]+bounds[3] {
@@ -83,6 +76,19 @@ func (s *HomeScreen) Update() error {
 		case 1:
 			// AI vs AI button clicked - go to dual AI selection screen
 			s.ui.SwitchToDualAISelectionScreen()
+		case 2:
+			// Tournament button clicked - go to tournament screen
+			s.ui.SwitchToTournamentScreen()
+		case 3:
+			// Opening trainer button clicked - start quizzing on the known openings
+			s.ui.SwitchToOpeningTrainerScreen()
+		case 4:
+			// Rules button clicked - toggle between standard and misère games
+			if s.ui.variant == game.Standard {
+				s.ui.variant = game.Misere
+			} else {
+				s.ui.variant = game.Standard
+			}
 		}
 	}
 
@@ -104,7 +110,11 @@ func (s *HomeScreen) Draw(screen *ebiten.Image) {
 	text.Draw(screen, title, titleFace, titleX, screenHeight/4, color.White)
 
 	// Draw buttons
-	buttonTexts := []string{"Player vs AI", "AI vs AI"}
+	rules := "Rules: Standard"
+	if s.ui.variant == game.Misere {
+		rules = "Rules: Misere (fewest discs wins)"
+	}
+	buttonTexts := []string{"Player vs AI", "AI vs AI", "Tournament", "Opening Trainer", rules}
 
 	for i, buttonText := range buttonTexts {
 		bounds := s.buttonBounds[i]
@@ -115,7 +125,7 @@ func (s *HomeScreen) Draw(screen *ebiten.Image) {
 			buttonColor = color.RGBA{0, 150, 0, 255}
 		}
 
-		ebitenutil.DrawRect(screen,
+		draw.Rect(screen,
 			float64(bounds[0]),
 			float64(bounds[1]),
 			float64(bounds[2]),
@@ -123,9 +133,6 @@ func (s *HomeScreen) Draw(screen *ebiten.Image) {
 			buttonColor)
 
 		// Draw button text
-		btnBounds := text.BoundString(s.face, buttonText)
-		btnTextX := bounds[0] + (bounds[2]-btnBounds.Dx())/2
-		btnTextY := bounds[1] + (bounds[3]+btnBounds.Dy())/2
-		text.Draw(screen, buttonText, s.face, btnTextX, btnTextY, color.White)
+		draw.TextCentered(screen, buttonText, s.face, bounds[0], bounds[1], bounds[2], bounds[3], color.White)
 	}
 }
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+
+	"github.com/Coloc3G/othello-engine/ui/draw"
+	"github.com/Coloc3G/othello-engine/ui/view"
+)
+
+// updateModelList places a model list, scrolls it with the mouse wheel and selects the
+// clicked row. It returns true when the selection changed.
+func updateModelList(l *view.List, count int, bounds [4]int, mouseX, mouseY int, clicked bool) bool {
+	_, dy := ebiten.Wheel()
+	return l.Update(count, bounds, mouseX, mouseY, dy, clicked)
+}
+
+// drawModelList renders the visible rows of a model list and, when it does not fit, a
+// scroll bar
+func drawModelList(screen *ebiten.Image, face font.Face, l *view.List, names []string) {
+	for i := 0; i < l.VisibleRows() && l.Offset+i < len(names); i++ {
+		index := l.Offset + i
+		bounds := l.RowBounds(i)
+
+		var buttonColor color.RGBA
+		if l.Selected == index {
+			buttonColor = color.RGBA{0, 150, 0, 255} // Selected
+		} else if l.Hovered == index {
+			buttonColor = color.RGBA{0, 120, 0, 255} // Hovered
+		} else {
+			buttonColor = color.RGBA{0, 80, 0, 255} // Normal
+		}
+		draw.Rect(screen, float64(bounds[0]), float64(bounds[1]), float64(bounds[2]), float64(bounds[3]), buttonColor)
+
+		draw.TextCentered(screen, names[index], face, bounds[0], bounds[1], bounds[2], bounds[3], color.White)
+	}
+
+	if top, thumb, ok := l.ScrollThumb(len(names)); ok {
+		x := float64(l.Bounds[0] + l.Bounds[2] - 4)
+		draw.Rect(screen, x, float64(l.Bounds[1]), 4, float64(l.Bounds[3]), ColorGrid)
+		draw.Rect(screen, x, top, 4, thumb, ColorLabelText)
+	}
+}
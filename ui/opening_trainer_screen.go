@@ -0,0 +1,467 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+	"github.com/Coloc3G/othello-engine/ui/draw"
+	"github.com/Coloc3G/othello-engine/ui/view"
+)
+
+const (
+	openingProfileFile = "opening_profile.json" // Statistics of the opening trainer, in the working directory
+	openingCheckDepth  = 6                      // Search depth comparing a wrong move to the book move
+)
+
+// Difficulties of the opening trainer, by the longest line they pick in plies
+var openingDifficulties = []struct {
+	Name     string
+	MaxPlies int
+}{
+	{"Easy", 6},
+	{"Medium", 10},
+	{"Hard", 60},
+}
+
+// Buttons of the opening trainer screen
+const (
+	trainerDifficulty = iota
+	trainerNext
+	trainerSummary
+	trainerBack
+	trainerButtons
+)
+
+// openingStats counts the answers given in the lines of an opening
+type openingStats struct {
+	Attempts int `json:"attempts"`
+	Correct  int `json:"correct"`
+}
+
+// openingProfile holds the opening trainer statistics of the user
+type openingProfile struct {
+	Openings   map[string]*openingStats `json:"openings"`
+	BestStreak int                      `json:"best_streak"`
+}
+
+// moveCheck compares the move of the user to the book move with a search
+type moveCheck struct {
+	Round int // Round the check belongs to, checks of older rounds are dropped
+	Loss  int // Score the user's move loses against the book move, from the mover's point of view
+}
+
+// OpeningTrainerScreen quizzes the user on the continuations of the known openings: a line
+// is played up to a random ply and the user has to find the next book move
+type OpeningTrainerScreen struct {
+	ui            *UI
+	face          font.Face
+	view          boardView
+	buttonBounds  [trainerButtons][4]int // x, y, width, height
+	buttonHovered int                    // -1: none, else a trainer button
+	difficulty    int                    // Index in openingDifficulties
+	profile       openingProfile
+	profileErr    error
+	showSummary   bool
+
+	line      opening.Opening // Line of the round, switched when the user follows another book line
+	game      *game.Game
+	lastMove  game.Position
+	streak    int
+	round     int
+	finished  bool   // The round is over, waiting for the next one
+	message   string // Result of the last answer
+	wrongMove game.Position
+	bookMove  game.Position
+	check     *moveCheck
+	checks    chan moveCheck
+}
+
+// NewOpeningTrainerScreen creates the opening trainer and loads the profile of the user
+func NewOpeningTrainerScreen(ui *UI) *OpeningTrainerScreen {
+	s := &OpeningTrainerScreen{
+		ui:            ui,
+		face:          basicfont.Face7x13,
+		buttonHovered: -1,
+		difficulty:    1,
+		checks:        make(chan moveCheck, 1),
+	}
+	s.profile, s.profileErr = loadOpeningProfile(openingProfileFile)
+	return s
+}
+
+// Layout implements the Screen interface
+func (s *OpeningTrainerScreen) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+// Start begins a new round
+func (s *OpeningTrainerScreen) Start() {
+	s.showSummary = false
+	s.newRound()
+}
+
+// trainingLines returns the openings of at least 2 plies and at most the difficulty length
+func trainingLines(maxPlies int) []opening.Opening {
+	lines := make([]opening.Opening, 0)
+	for _, op := range opening.KNOWN_OPENINGS {
+		if plies := len(op.Transcript) / 2; plies >= 2 && plies <= maxPlies {
+			lines = append(lines, op)
+		}
+	}
+	return lines
+}
+
+// newRound picks a line and plays it up to a random ply, the user finding the next moves
+func (s *OpeningTrainerScreen) newRound() {
+	s.round++
+	s.finished = false
+	s.message = ""
+	s.check = nil
+	s.wrongMove = game.Position{Row: -1, Col: -1}
+	s.bookMove = game.Position{Row: -1, Col: -1}
+	s.lastMove = game.Position{Row: -1, Col: -1}
+
+	lines := trainingLines(openingDifficulties[s.difficulty].MaxPlies)
+	s.line = lines[rand.Intn(len(lines))]
+	moves := utils.AlgebraicToPositions(s.line.Transcript)
+	s.game = game.NewGame("Black", "White")
+	for _, move := range moves[:1+rand.Intn(len(moves)-1)] {
+		s.play(move)
+	}
+}
+
+// play applies a move of the current line, passing first when needed
+func (s *OpeningTrainerScreen) play(move game.Position) {
+	if s.game.LegalState() == game.MustPass {
+		s.game.Pass()
+	}
+	if s.game.ApplyMove(move) == nil {
+		s.lastMove = move
+	}
+}
+
+// transcript returns the moves played in the round
+func (s *OpeningTrainerScreen) transcript() string {
+	return utils.TranscriptToAlgebraic(s.game.History)
+}
+
+// answer checks the move of the user against every book line from the current position
+func (s *OpeningTrainerScreen) answer(move game.Position) {
+	if _, ok := s.game.Preview(move); !ok {
+		return
+	}
+	transcript := s.transcript()
+	book := utils.AlgebraicToPosition(s.line.Transcript[len(transcript) : len(transcript)+2])
+	stats := s.stats(s.line.Name)
+	stats.Attempts++
+
+	played := utils.PositionToAlgebraic(move)
+	if len(opening.MatchOpening(transcript+played)) == 0 {
+		s.streak = 0
+		s.finished = true
+		s.wrongMove = move
+		s.bookMove = book
+		s.message = fmt.Sprintf("%s leaves the book, %s plays %s", played, s.line.Name, utils.PositionToAlgebraic(book))
+		s.checkMove(move, book)
+		s.saveProfile()
+		return
+	}
+
+	stats.Correct++
+	s.streak++
+	s.profile.BestStreak = max(s.profile.BestStreak, s.streak)
+	s.play(move)
+	s.message = fmt.Sprintf("%s is book", played)
+
+	// Follow the line the user chose when it leaves the one of the round
+	transcript = s.transcript()
+	if !continues(s.line, transcript) {
+		for _, op := range opening.MatchOpening(transcript) {
+			if continues(op, transcript) {
+				s.line = op
+				break
+			}
+		}
+	}
+	if !continues(s.line, transcript) {
+		s.finished = true
+		name := s.line.Name
+		if op, ok := opening.Identify(transcript); ok {
+			name = op.Name
+		}
+		s.message = fmt.Sprintf("%s completes the %s line", played, name)
+	}
+	s.saveProfile()
+}
+
+// continues tells if an opening goes on after the transcript
+func continues(op opening.Opening, transcript string) bool {
+	return len(op.Transcript) > len(transcript) && strings.HasPrefix(op.Transcript, transcript)
+}
+
+// checkMove searches the positions after the user's move and after the book move in the
+// background, the difference being shown once known
+func (s *OpeningTrainerScreen) checkMove(move, book game.Position) {
+	b := utils.BoardToBits(s.game.Board)
+	mover := s.game.CurrentPlayer.Color
+	round := s.round
+	go func() {
+		eval := evaluation.NewMixedEvaluation(evaluation.V4Coeff)
+		score := func(pos game.Position) int {
+			after, _ := game.GetNewBitBoardAfterMove(b, pos, mover)
+			value, _ := evaluation.MMAB(after, game.GetOpponentColor(mover), openingCheckDepth-1, evaluation.MIN_EVAL, evaluation.MAX_EVAL, eval, nil, nil)
+			return int(value)
+		}
+		loss := score(book) - score(move)
+		if mover == game.Black {
+			loss = -loss
+		}
+		select {
+		case <-s.checks:
+		default:
+		}
+		s.checks <- moveCheck{Round: round, Loss: loss}
+	}()
+}
+
+// stats returns the statistics of an opening, created on first use
+func (s *OpeningTrainerScreen) stats(name string) *openingStats {
+	if s.profile.Openings == nil {
+		s.profile.Openings = make(map[string]*openingStats)
+	}
+	stats, ok := s.profile.Openings[name]
+	if !ok {
+		stats = &openingStats{}
+		s.profile.Openings[name] = stats
+	}
+	return stats
+}
+
+// loadOpeningProfile reads the profile, empty when the file does not exist yet
+func loadOpeningProfile(path string) (openingProfile, error) {
+	profile := openingProfile{Openings: make(map[string]*openingStats)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return profile, nil
+	} else if err != nil {
+		return profile, err
+	}
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return profile, fmt.Errorf("invalid profile %s: %w", path, err)
+	}
+	if profile.Openings == nil {
+		profile.Openings = make(map[string]*openingStats)
+	}
+	return profile, nil
+}
+
+// saveProfile writes the profile after each answer
+func (s *OpeningTrainerScreen) saveProfile() {
+	data, err := json.MarshalIndent(s.profile, "", "  ")
+	if err == nil {
+		err = os.WriteFile(openingProfileFile, data, 0644)
+	}
+	s.profileErr = err
+}
+
+// Update handles the answers and the buttons
+func (s *OpeningTrainerScreen) Update() error {
+	if s.game == nil {
+		s.Start()
+	}
+	select {
+	case check := <-s.checks:
+		if check.Round == s.round {
+			s.check = &check
+		}
+	default:
+	}
+
+	screenWidth, screenHeight := ebiten.WindowSize()
+	cell := max(20, min(screenWidth-320, screenHeight-160)/8)
+	s.view = boardView{X: 40, Y: 80, Cell: cell}
+
+	// Buttons in a column right of the board
+	buttonWidth := 180
+	buttonHeight := 36
+	buttonX := s.view.X + s.view.size() + 40
+	for i := range s.buttonBounds {
+		s.buttonBounds[i] = [4]int{buttonX, screenHeight - 80 - (trainerButtons-1-i)*(buttonHeight+10), buttonWidth, buttonHeight}
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	s.buttonHovered = -1
+	for i, bounds := range s.buttonBounds {
+		if view.InBounds(bounds, mouseX, mouseY) {
+			s.buttonHovered = i
+		}
+	}
+
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return nil
+	}
+	switch s.buttonHovered {
+	case trainerDifficulty:
+		s.difficulty = (s.difficulty + 1) % len(openingDifficulties)
+		s.newRound()
+	case trainerNext:
+		s.newRound()
+	case trainerSummary:
+		s.showSummary = !s.showSummary
+	case trainerBack:
+		s.ui.SwitchToHomeScreen()
+	default:
+		if pos, ok := s.view.cellAt(mouseX, mouseY); ok && !s.finished && !s.showSummary {
+			s.answer(pos)
+		}
+	}
+	return nil
+}
+
+// Draw renders the quiz or the summary of the profile
+func (s *OpeningTrainerScreen) Draw(screen *ebiten.Image) {
+	screen.Fill(ColorBackground)
+	text.Draw(screen, "Opening Trainer", s.face, s.view.X, 30, color.White)
+
+	if s.showSummary {
+		s.drawSummary(screen)
+	} else {
+		s.drawQuiz(screen)
+	}
+
+	labels := [trainerButtons]string{
+		"Difficulty: " + openingDifficulties[s.difficulty].Name,
+		"Next Line",
+		"Summary",
+		"Back",
+	}
+	if s.showSummary {
+		labels[trainerSummary] = "Back to Quiz"
+	}
+	for i, bounds := range s.buttonBounds {
+		buttonColor := color.RGBA{0, 100, 0, 255}
+		if s.buttonHovered == i {
+			buttonColor = color.RGBA{0, 150, 0, 255}
+		}
+		draw.Rect(screen, float64(bounds[0]), float64(bounds[1]), float64(bounds[2]), float64(bounds[3]), buttonColor)
+		draw.TextCentered(screen, labels[i], s.face, bounds[0], bounds[1], bounds[2], bounds[3], color.White)
+	}
+	if s.profileErr != nil {
+		text.Draw(screen, "Profile: "+s.profileErr.Error(), s.face, s.view.X, screen.Bounds().Dy()-15, color.RGBA{220, 50, 50, 255})
+	}
+}
+
+// drawQuiz renders the board of the round and the feedback on the last answer
+func (s *OpeningTrainerScreen) drawQuiz(screen *ebiten.Image) {
+	if s.game == nil {
+		return
+	}
+	var validMoves []game.Position
+	if !s.finished {
+		validMoves = s.game.GetValidMovesForCurrentPlayer()
+	}
+	s.view.draw(screen, s.game.Board, validMoves, s.lastMove)
+	s.view.drawCoordinates(screen, s.face)
+
+	// Mark the wrong move in red and the book move in gold
+	for _, mark := range []struct {
+		pos game.Position
+		col color.RGBA
+	}{{s.wrongMove, color.RGBA{220, 50, 50, 255}}, {s.bookMove, color.RGBA{255, 215, 0, 255}}} {
+		if mark.pos.Row < 0 {
+			continue
+		}
+		x := float64(s.view.X + int(mark.pos.Col)*s.view.Cell)
+		y := float64(s.view.Y + int(mark.pos.Row)*s.view.Cell)
+		draw.Circle(screen, x+float64(s.view.Cell)/2, y+float64(s.view.Cell)/2, float64(s.view.Cell)/6, mark.col)
+	}
+
+	toMove := "Black"
+	if s.game.CurrentPlayer.Color == game.White {
+		toMove = "White"
+	}
+	stats := s.profile.Openings[s.line.Name]
+	lines := []string{
+		fmt.Sprintf("Line: %s", s.line.Name),
+		fmt.Sprintf("Moves: %s", s.transcript()),
+		fmt.Sprintf("%s to play the book move", toMove),
+		fmt.Sprintf("Streak: %d (best %d)", s.streak, s.profile.BestStreak),
+	}
+	if stats != nil && stats.Attempts > 0 {
+		lines = append(lines, fmt.Sprintf("This opening: %d / %d correct", stats.Correct, stats.Attempts))
+	}
+	lines = append(lines, "", s.message)
+	if s.wrongMove.Row >= 0 {
+		if s.check == nil {
+			lines = append(lines, fmt.Sprintf("Comparing with the book move (depth %d)...", openingCheckDepth))
+		} else {
+			lines = append(lines, fmt.Sprintf("Your move scores %d less than the book move (depth %d)", s.check.Loss, openingCheckDepth))
+		}
+	}
+	if s.finished {
+		lines = append(lines, "Click Next Line to continue")
+	}
+
+	infoX := s.view.X + s.view.size() + 40
+	for i, line := range lines {
+		text.Draw(screen, line, s.face, infoX, s.view.Y+15+i*20, color.White)
+	}
+}
+
+// drawSummary renders the success rate of each opening practised, most practised first
+func (s *OpeningTrainerScreen) drawSummary(screen *ebiten.Image) {
+	names := make([]string, 0, len(s.profile.Openings))
+	attempts, correct := 0, 0
+	for name, stats := range s.profile.Openings {
+		names = append(names, name)
+		attempts += stats.Attempts
+		correct += stats.Correct
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := s.profile.Openings[names[i]], s.profile.Openings[names[j]]
+		if a.Attempts != b.Attempts {
+			return a.Attempts > b.Attempts
+		}
+		return names[i] < names[j]
+	})
+
+	x, y := s.view.X, s.view.Y
+	text.Draw(screen, fmt.Sprintf("%d answers, %s correct, best streak %d",
+		attempts, percent(correct, attempts), s.profile.BestStreak), s.face, x, y, color.White)
+	text.Draw(screen, fmt.Sprintf("%-32s %8s %8s %8s", "Opening", "Correct", "Answers", "Rate"), s.face, x, y+30, ColorLabelText)
+
+	// As many rows as fit above the buttons
+	rows := max(0, (screen.Bounds().Dy()-y-70)/18)
+	for i, name := range names[:min(len(names), rows)] {
+		stats := s.profile.Openings[name]
+		row := fmt.Sprintf("%-32s %8d %8d %8s", name, stats.Correct, stats.Attempts, percent(stats.Correct, stats.Attempts))
+		text.Draw(screen, row, s.face, x, y+50+i*18, color.White)
+	}
+	if len(names) == 0 {
+		text.Draw(screen, "No answers yet", s.face, x, y+50, color.White)
+	}
+}
+
+// percent formats n out of total as a percentage
+func percent(n, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", 100*float64(n)/float64(total))
+}
@@ -47,15 +47,16 @@ func (s *ResultScreen) Draw(screen *ebiten.Image) {
 	screen.Fill(ColorBackground)
 
 	// Calculate scores
-	blackCount, whiteCount := game.CountPieces(s.ui.game.Board)
+	blackCount, whiteCount := s.ui.game.CountPiecesMethod()
 
 	// Determine winner
 	var winner string
-	if blackCount > whiteCount {
+	switch s.ui.game.GetWinnerMethod() {
+	case game.Black:
 		winner = "Black wins!"
-	} else if whiteCount > blackCount {
+	case game.White:
 		winner = "White wins!"
-	} else {
+	default:
 		winner = "It's a tie!"
 	}
 
@@ -0,0 +1,305 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"image/color"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/ui/draw"
+	"github.com/Coloc3G/othello-engine/ui/view"
+)
+
+// Layout of the score graph panel below the board
+const (
+	scoreGraphHeaderHeight = 18
+	scoreGraphHeight       = 110
+)
+
+const (
+	scoreGraphPlies       = 128  // Plies kept by the score graph, older ones are dropped
+	scoreGraphRange       = 2000 // Scores are clamped to +/- this range, like the evaluation bar
+	disagreementThreshold = 600  // Score difference marked with a dot
+	quickAssessmentDepth  = 3    // Search depth of the non-mover's assessment
+)
+
+// Colors of the engines' polylines, black's engine first
+var scoreGraphColors = [2]color.RGBA{{80, 160, 255, 255}, {255, 160, 40, 255}}
+
+// plyScores holds the scores both engines gave to the position before a ply, from White's point of view
+type plyScores struct {
+	Ply    int
+	Mover  game.Piece
+	Scores [2]int  // Indexed by engine, black's engine first
+	Has    [2]bool // Whether each engine's score arrived
+}
+
+// disagrees tells if both engines scored the ply and their scores differ by more than the threshold
+func (p plyScores) disagrees() bool {
+	if !p.Has[0] || !p.Has[1] {
+		return false
+	}
+	diff := clampScore(p.Scores[0]) - clampScore(p.Scores[1])
+	return diff > disagreementThreshold || -diff > disagreementThreshold
+}
+
+// scoreRing keeps the scores of the last scoreGraphPlies plies, keyed by ply
+type scoreRing struct {
+	entries [scoreGraphPlies]plyScores
+	end     int // One past the latest ply recorded
+}
+
+// set records the score of an engine for a ply, ignored once the ply left the ring
+func (r *scoreRing) set(ply int, mover game.Piece, engine, score int) {
+	if ply < r.end-scoreGraphPlies {
+		return
+	}
+	slot := &r.entries[ply%scoreGraphPlies]
+	if slot.Ply != ply || (!slot.Has[0] && !slot.Has[1]) {
+		*slot = plyScores{Ply: ply, Mover: mover}
+	}
+	slot.Scores[engine] = score
+	slot.Has[engine] = true
+	r.end = max(r.end, ply+1)
+}
+
+// series returns the recorded plies in order
+func (r *scoreRing) series() []plyScores {
+	series := make([]plyScores, 0, scoreGraphPlies)
+	for ply := max(0, r.end-scoreGraphPlies); ply < r.end; ply++ {
+		entry := r.entries[ply%scoreGraphPlies]
+		if entry.Ply == ply && (entry.Has[0] || entry.Has[1]) {
+			series = append(series, entry)
+		}
+	}
+	return series
+}
+
+// graphScore is the quick assessment of a position by the engine not to move
+type graphScore struct {
+	Game   int // Game the assessment belongs to, results of older games are dropped
+	Ply    int
+	Mover  game.Piece
+	Engine int
+	Score  int
+}
+
+// ScoreGraph charts the root score each engine gives to the positions of an AI vs AI game.
+// The mover's score comes from the search of its move, the other engine's score from a quick
+// search run in the background.
+type ScoreGraph struct {
+	face       font.Face
+	ring       scoreRing
+	names      [2]string
+	open       bool
+	gameID     int
+	updates    chan graphScore
+	panel      [4]int // Graph area
+	toggle     [4]int // Header, opening and closing the graph
+	export     [4]int // Export button in the header
+	hovered    int    // Index in the series of the hovered disagreement, -1 for none
+	exportedAt time.Time
+	exportMsg  string
+}
+
+// NewScoreGraph creates a closed score graph
+func NewScoreGraph(face font.Face) *ScoreGraph {
+	return &ScoreGraph{face: face, updates: make(chan graphScore, 64), hovered: -1}
+}
+
+// Reset clears the graph for a new game between two engines
+func (g *ScoreGraph) Reset(names [2]string) {
+	g.ring = scoreRing{}
+	g.names = names
+	g.gameID++
+	g.hovered = -1
+}
+
+// Height is the vertical space the graph takes below the board
+func (g *ScoreGraph) Height() int {
+	if g.open {
+		return scoreGraphHeaderHeight + scoreGraphHeight + 10
+	}
+	return scoreGraphHeaderHeight + 10
+}
+
+// Record adds the score an engine found for the position before ply
+func (g *ScoreGraph) Record(ply int, mover game.Piece, engine, score int) {
+	g.ring.set(ply, mover, engine, score)
+}
+
+// Assess searches the position before ply with the engine not to move, in the background
+func (g *ScoreGraph) Assess(b game.BitBoard, ply int, mover game.Piece, engine int, eval evaluation.Evaluation) {
+	gameID := g.gameID
+	go func() {
+		_, score := evaluation.SolveBitBoard(b, mover, quickAssessmentDepth, eval)
+		select {
+		case g.updates <- graphScore{Game: gameID, Ply: ply, Mover: mover, Engine: engine, Score: int(score)}:
+		default: // The screen stopped reading, drop it
+		}
+	}()
+}
+
+// Update reads the background assessments and handles the header buttons and hovering.
+// x, y and width place the graph below the board.
+func (g *ScoreGraph) Update(x, y, width int) {
+	for done := false; !done; {
+		select {
+		case update := <-g.updates:
+			if update.Game == g.gameID {
+				g.ring.set(update.Ply, update.Mover, update.Engine, update.Score)
+			}
+		default:
+			done = true
+		}
+	}
+
+	exportWidth := 90
+	g.toggle = [4]int{x, y, width - exportWidth - 5, scoreGraphHeaderHeight}
+	g.export = [4]int{x + width - exportWidth, y, exportWidth, scoreGraphHeaderHeight}
+	g.panel = [4]int{x, y + scoreGraphHeaderHeight + 5, width, scoreGraphHeight}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) ||
+		(inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && view.InBounds(g.toggle, mouseX, mouseY)) {
+		g.open = !g.open
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && view.InBounds(g.export, mouseX, mouseY) {
+		path, err := g.ExportCSV()
+		g.exportMsg = "Saved " + path
+		if err != nil {
+			g.exportMsg = "Export failed: " + err.Error()
+		}
+		g.exportedAt = time.Now()
+	}
+
+	// Hover the disagreement dot closest to the cursor
+	g.hovered = -1
+	if !g.open {
+		return
+	}
+	series := g.ring.series()
+	best := 6 * 6
+	for i, p := range series {
+		if !p.disagrees() {
+			continue
+		}
+		px, py := g.point(i, len(series), (p.Scores[0]+p.Scores[1])/2)
+		dx, dy := int(px)-mouseX, int(py)-mouseY
+		if d := dx*dx + dy*dy; d <= best {
+			best = d
+			g.hovered = i
+		}
+	}
+}
+
+// point returns the screen position of the ith of n plies at score
+func (g *ScoreGraph) point(i, n, score int) (float64, float64) {
+	x, y, w, h := float64(g.panel[0]), float64(g.panel[1]), float64(g.panel[2]), float64(g.panel[3])
+	step := w / float64(max(n-1, 1))
+	// Positive scores go up, like the evaluation bar
+	return x + float64(i)*step, y + h/2 - float64(clampScore(score))/scoreGraphRange*h/2
+}
+
+// Draw renders the header and, when open, the two polylines and the disagreement dots
+func (g *ScoreGraph) Draw(screen *ebiten.Image) {
+	// Header
+	draw.Rect(screen, float64(g.toggle[0]), float64(g.toggle[1]), float64(g.toggle[2]), float64(g.toggle[3]), color.RGBA{60, 60, 60, 255})
+	label := "[+] Score graph (G)"
+	if g.open {
+		label = "[-] Score graph (G)"
+	}
+	text.Draw(screen, label, g.face, g.toggle[0]+5, g.toggle[1]+13, color.White)
+	draw.Rect(screen, float64(g.export[0]), float64(g.export[1]), float64(g.export[2]), float64(g.export[3]), color.RGBA{0, 100, 0, 255})
+	text.Draw(screen, "Export CSV", g.face, g.export[0]+10, g.export[1]+13, color.White)
+	if time.Since(g.exportedAt) < 3*time.Second {
+		text.Draw(screen, g.exportMsg, g.face, g.toggle[0]+160, g.toggle[1]+13, color.RGBA{255, 215, 0, 255})
+	}
+	if !g.open {
+		return
+	}
+
+	x, y, w, h := float64(g.panel[0]), float64(g.panel[1]), float64(g.panel[2]), float64(g.panel[3])
+	draw.Rect(screen, x, y, w, h, color.RGBA{40, 40, 40, 255})
+	draw.Line(screen, x, y+h/2, x+w, y+h/2, color.RGBA{100, 100, 100, 255})
+
+	// Legend
+	for engine, name := range g.names {
+		text.Draw(screen, name, g.face, g.panel[0]+5+engine*120, g.panel[1]+13, scoreGraphColors[engine])
+	}
+
+	series := g.ring.series()
+	for engine := range 2 {
+		prevX, prevY, hasPrev := 0.0, 0.0, false
+		for i, p := range series {
+			if !p.Has[engine] {
+				continue
+			}
+			px, py := g.point(i, len(series), p.Scores[engine])
+			if hasPrev {
+				draw.Line(screen, prevX, prevY, px, py, scoreGraphColors[engine])
+			}
+			prevX, prevY, hasPrev = px, py, true
+		}
+	}
+
+	for i, p := range series {
+		if !p.disagrees() {
+			continue
+		}
+		px, py := g.point(i, len(series), (p.Scores[0]+p.Scores[1])/2)
+		dotColor := color.RGBA{220, 50, 50, 255}
+		if i == g.hovered {
+			dotColor = color.RGBA{255, 255, 0, 255}
+		}
+		draw.Rect(screen, px-3, py-3, 6, 6, dotColor)
+	}
+
+	if g.hovered >= 0 {
+		p := series[g.hovered]
+		tip := fmt.Sprintf("ply %d: %s %+d / %s %+d", p.Ply+1, g.names[0], p.Scores[0], g.names[1], p.Scores[1])
+		text.Draw(screen, tip, g.face, g.panel[0]+5, g.panel[1]+g.panel[3]-5, color.White)
+	}
+}
+
+// ExportCSV writes the series to a CSV file in the working directory and returns its path
+func (g *ScoreGraph) ExportCSV() (string, error) {
+	path := fmt.Sprintf("score_graph_%s.csv", time.Now().Format("20060102-150405"))
+	f, err := os.Create(path)
+	if err != nil {
+		return path, err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"ply", "mover", g.names[0], g.names[1]})
+	for _, p := range g.ring.series() {
+		mover := "black"
+		if p.Mover == game.White {
+			mover = "white"
+		}
+		row := []string{strconv.Itoa(p.Ply + 1), mover, "", ""}
+		for engine := range 2 {
+			if p.Has[engine] {
+				row[2+engine] = strconv.Itoa(p.Scores[engine])
+			}
+		}
+		w.Write(row)
+	}
+	w.Flush()
+	return path, w.Error()
+}
+
+// clampScore limits a score to the range shown by the graph
+func clampScore(score int) int {
+	return min(max(score, -scoreGraphRange), scoreGraphRange)
+}
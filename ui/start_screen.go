@@ -4,11 +4,10 @@ import (
 	"image/color"
 
 	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/ui/draw"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
 )
@@ -159,7 +158,7 @@ func (s *StartScreen) Draw(screen *ebiten.Image) {
 
 	// Player 1 field
 	text.Draw(screen, "Player 1 (Black):", s.face, inputX, 180, color.White)
-	vector.DrawFilledRect(screen, float32(inputX), 190, float32(inputWidth), 30, color.RGBA{60, 60, 60, 255}, false)
+	draw.Rect(screen, float64(inputX), 190, float64(inputWidth), 30, color.RGBA{60, 60, 60, 255})
 	text.Draw(screen, s.playerNames[0], s.face, inputX+5, 210, color.White)
 
 	// Draw cursor for player 1 field
@@ -168,12 +167,12 @@ func (s *StartScreen) Draw(screen *ebiten.Image) {
 		if s.cursorPos > 0 {
 			cursorX += text.BoundString(s.face, s.playerNames[0][:s.cursorPos]).Dx()
 		}
-		ebitenutil.DrawLine(screen, float64(cursorX), 195, float64(cursorX), 215, color.White)
+		draw.Line(screen, float64(cursorX), 195, float64(cursorX), 215, color.White)
 	}
 
 	// Player 2 field
 	text.Draw(screen, "Player 2 (White):", s.face, inputX, 260, color.White)
-	ebitenutil.DrawRect(screen, float64(inputX), 270, float64(inputWidth), 30, color.RGBA{60, 60, 60, 255})
+	draw.Rect(screen, float64(inputX), 270, float64(inputWidth), 30, color.RGBA{60, 60, 60, 255})
 	text.Draw(screen, s.playerNames[1], s.face, inputX+5, 290, color.White)
 
 	// Draw cursor for player 2 field
@@ -182,7 +181,7 @@ func (s *StartScreen) Draw(screen *ebiten.Image) {
 		if s.cursorPos > 0 {
 			cursorX += text.BoundString(s.face, s.playerNames[1][:s.cursorPos]).Dx()
 		}
-		ebitenutil.DrawLine(screen, float64(cursorX), 275, float64(cursorX), 295, color.White)
+		draw.Line(screen, float64(cursorX), 275, float64(cursorX), 295, color.White)
 	}
 
 	// Draw button
@@ -191,7 +190,7 @@ func (s *StartScreen) Draw(screen *ebiten.Image) {
 		buttonColor = color.RGBA{0, 150, 0, 255}
 	}
 
-	ebitenutil.DrawRect(screen,
+	draw.Rect(screen,
 		float64(s.buttonBounds[0]),
 		float64(s.buttonBounds[1]),
 		float64(s.buttonBounds[2]),
@@ -200,22 +199,21 @@ func (s *StartScreen) Draw(screen *ebiten.Image) {
 
 	// Draw button text
 	buttonText := "Start Game"
-	btnBounds := text.BoundString(s.face, buttonText)
-	btnTextX := s.buttonBounds[0] + (s.buttonBounds[2]-btnBounds.Dx())/2
-	btnTextY := s.buttonBounds[1] + (s.buttonBounds[3]+btnBounds.Dy())/2
-	text.Draw(screen, buttonText, s.face, btnTextX, btnTextY, color.White)
+	draw.TextCentered(screen, buttonText, s.face, s.buttonBounds[0], s.buttonBounds[1], s.buttonBounds[2], s.buttonBounds[3], color.White)
 }
 
-// StartGame initializes a new game with the specified player names
+// StartGame initializes a new game of the human player1, playing Black, against the AI
+// named player2
 func (s *UI) StartGame(player1, player2 string) {
 	// Create new game
-	s.game = game.NewGame(player1, player2)
+	s.game = game.NewHumanVsAIGame(player1, player2, game.Black)
+	s.game.Variant = s.variant
 
 	// Reset game screen properties
 	if s.gameScreen != nil {
+		s.gameScreen.stopEvaluation()
 		s.gameScreen.lastMovePos = game.Position{Row: -1, Col: -1}
-		s.gameScreen.moveHistory = make([][2]MoveRecord, 0)
-		s.gameScreen.scrollOffset = 0
+		s.gameScreen.moves.Reset()
 	}
 
 	// Switch to game screen
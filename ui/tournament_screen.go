@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/ui/draw"
+)
+
+// Search depth of the tournament games, kept low so the games stay watchable
+const tournamentDepth = 4
+
+// Number of games a tournament can be set to, cycled by the game count button
+var tournamentGameCounts = []int{2, 10, 20, 50, 100}
+
+// TournamentState is a snapshot of a running tournament
+type TournamentState struct {
+	Board game.Board // Board of the game being played
+	Game  int        // Number of games started
+	Games int        // Number of games of the tournament
+	Wins  [2]int     // Wins of each model
+	Draws int
+	Done  bool
+	Names [2]string // Names of the two models
+}
+
+// RunUITournament plays numGames between two models, alternating colors,
+// in a goroutine. The channel receives the latest state after every move and is closed when
+// the tournament ends. Calling stop ends the tournament early.
+func RunUITournament(models [2]evaluation.EvaluationCoefficients, numGames int) (updates <-chan TournamentState, stop func()) {
+	ch := make(chan TournamentState, 1)
+	quit := make(chan struct{})
+	evals := [2]*evaluation.MixedEvaluation{
+		evaluation.NewMixedEvaluation(models[0]),
+		evaluation.NewMixedEvaluation(models[1]),
+	}
+
+	// publish replaces any state the screen has not read yet
+	publish := func(state TournamentState) {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- state
+	}
+
+	go func() {
+		defer close(ch)
+		state := TournamentState{Games: numGames, Names: [2]string{models[0].Name, models[1].Name}}
+		for i := 0; i < numGames; i++ {
+			// Model 0 plays black in even games
+			black := i % 2
+			g := game.NewGame("Black", "White")
+			state.Game = i + 1
+			state.Board = g.Board
+			publish(state)
+
+			for {
+				select {
+				case <-quit:
+					return
+				default:
+				}
+
+				st := g.LegalState()
+				if st == game.GameOver {
+					break
+				}
+				if st == game.MustPass {
+					g.Pass()
+					continue
+				}
+
+				player := black
+				if g.CurrentPlayer.Color == game.White {
+					player = 1 - black
+				}
+				moves, _ := evaluation.Solve(g.Board, g.CurrentPlayer.Color, tournamentDepth, evals[player])
+				if len(moves) == 0 || g.ApplyMove(moves[0]) != nil {
+					break
+				}
+				state.Board = g.Board
+				publish(state)
+			}
+
+			switch g.GetWinnerMethod() {
+			case game.Black:
+				state.Wins[black]++
+			case game.White:
+				state.Wins[1-black]++
+			default:
+				state.Draws++
+			}
+			publish(state)
+		}
+		state.Done = true
+		publish(state)
+	}()
+
+	return ch, func() { close(quit) }
+}
+
+// TournamentScreen lets two AI models play a series of games and shows the live results
+type TournamentScreen struct {
+	ui            *UI
+	face          font.Face
+	models        [2]int // Indexes in the models of the UI
+	gameCount     int    // Index in tournamentGameCounts
+	state         TournamentState
+	updates       <-chan TournamentState
+	stop          func()
+	running       bool
+	buttonBounds  [5][4]int // Model 1, model 2, game count, start, back
+	buttonHovered int       // -1: none, else index in buttonBounds
+}
+
+// NewTournamentScreen creates a new tournament screen
+func NewTournamentScreen(ui *UI) *TournamentScreen {
+	return &TournamentScreen{
+		ui:            ui,
+		face:          basicfont.Face7x13,
+		models:        [2]int{0, len(ui.models) - 1},
+		gameCount:     1,
+		buttonHovered: -1,
+	}
+}
+
+// Layout implements the Screen interface
+func (s *TournamentScreen) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+// Update handles input and reads the tournament progress
+func (s *TournamentScreen) Update() error {
+	screenWidth, screenHeight := ebiten.WindowSize()
+
+	// Selectors on the left, buttons at the bottom
+	buttonWidth := 150
+	buttonHeight := 40
+	s.buttonBounds[0] = [4]int{40, 80, buttonWidth, buttonHeight}
+	s.buttonBounds[1] = [4]int{40, 160, buttonWidth, buttonHeight}
+	s.buttonBounds[2] = [4]int{40, 240, buttonWidth, buttonHeight}
+	s.buttonBounds[3] = [4]int{screenWidth/2 + 10, screenHeight - 80, buttonWidth, buttonHeight}
+	s.buttonBounds[4] = [4]int{screenWidth/2 - 10 - buttonWidth, screenHeight - 80, buttonWidth, buttonHeight}
+
+	// Keep the latest state of the tournament
+	if s.running {
+		select {
+		case state, ok := <-s.updates:
+			if ok {
+				s.state = state
+			} else {
+				s.running = false
+			}
+		default:
+		}
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	s.buttonHovered = -1
+	for i, bounds := range s.buttonBounds {
+		if mouseX >= bounds[0] && mouseX < bounds[0]+bounds[2] &&
+			mouseY >= bounds[1] && mouseY < bounds[1]+bounds[3] {
+			s.buttonHovered = i
+			break
+		}
+	}
+
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return nil
+	}
+	switch s.buttonHovered {
+	case 0, 1:
+		// Cycle through the models, only between tournaments
+		if !s.running {
+			s.models[s.buttonHovered] = (s.models[s.buttonHovered] + 1) % len(s.ui.models)
+		}
+	case 2:
+		if !s.running {
+			s.gameCount = (s.gameCount + 1) % len(tournamentGameCounts)
+		}
+	case 3:
+		if !s.running {
+			models := [2]evaluation.EvaluationCoefficients{s.ui.models[s.models[0]], s.ui.models[s.models[1]]}
+			s.updates, s.stop = RunUITournament(models, tournamentGameCounts[s.gameCount])
+			s.state = TournamentState{Games: tournamentGameCounts[s.gameCount], Names: [2]string{models[0].Name, models[1].Name}}
+			s.running = true
+		}
+	case 4:
+		if s.running {
+			s.stop()
+			s.running = false
+		}
+		s.ui.SwitchToHomeScreen()
+	}
+	return nil
+}
+
+// Draw renders the selectors, the live board and the results
+func (s *TournamentScreen) Draw(screen *ebiten.Image) {
+	screenWidth := screen.Bounds().Dx()
+	screen.Fill(ColorBackground)
+
+	title := "AI Tournament"
+	titleBounds := text.BoundString(s.face, title)
+	text.Draw(screen, title, s.face, (screenWidth-titleBounds.Dx())/2, 40, color.White)
+
+	labels := []string{"Model 1 (click to change):", "Model 2 (click to change):", "Games:"}
+	values := []string{
+		s.ui.models[s.models[0]].Name,
+		s.ui.models[s.models[1]].Name,
+		fmt.Sprint(tournamentGameCounts[s.gameCount]),
+	}
+	for i := range labels {
+		bounds := s.buttonBounds[i]
+		text.Draw(screen, labels[i], s.face, bounds[0], bounds[1]-10, ColorLabelText)
+		s.drawButton(screen, bounds, values[i], i, !s.running)
+	}
+	s.drawButton(screen, s.buttonBounds[3], "Start Tournament", 3, !s.running)
+	s.drawButton(screen, s.buttonBounds[4], "Back", 4, true)
+
+	if s.state.Games == 0 {
+		return
+	}
+
+	// Miniature board of the current game
+	const cell = 24
+	boardX, boardY := 260, 80
+	draw.Rect(screen, float64(boardX), float64(boardY), 8*cell, 8*cell, ColorGrid)
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			x := float64(boardX + col*cell)
+			y := float64(boardY + row*cell)
+			draw.Rect(screen, x+1, y+1, cell-2, cell-2, color.RGBA{50, 150, 50, 255})
+			switch s.state.Board[row][col] {
+			case game.Black:
+				draw.Rect(screen, x+5, y+5, cell-10, cell-10, ColorBlack)
+			case game.White:
+				draw.Rect(screen, x+5, y+5, cell-10, cell-10, ColorWhite)
+			}
+		}
+	}
+
+	// Live results
+	resultsX := boardX + 8*cell + 30
+	names := s.state.Names
+	lines := []string{
+		fmt.Sprintf("Game %d / %d", s.state.Game, s.state.Games),
+		fmt.Sprintf("%s wins: %d", names[0], s.state.Wins[0]),
+		fmt.Sprintf("%s wins: %d", names[1], s.state.Wins[1]),
+		fmt.Sprintf("Draws: %d", s.state.Draws),
+	}
+	if s.state.Done {
+		lines = append(lines, "Tournament finished")
+	}
+	for i, line := range lines {
+		text.Draw(screen, line, s.face, resultsX, boardY+15+i*20, color.White)
+	}
+
+	// Progress bar over the finished games
+	finished := s.state.Wins[0] + s.state.Wins[1] + s.state.Draws
+	barX, barY, barWidth := float64(boardX), float64(boardY+8*cell+20), float64(8*cell+200)
+	draw.Rect(screen, barX, barY, barWidth, 12, ColorGrid)
+	draw.Rect(screen, barX, barY, barWidth*float64(finished)/float64(s.state.Games), 12, color.RGBA{0, 150, 0, 255})
+}
+
+// drawButton draws a button, greyed out when it is disabled
+func (s *TournamentScreen) drawButton(screen *ebiten.Image, bounds [4]int, label string, index int, enabled bool) {
+	buttonColor := color.RGBA{100, 100, 100, 255}
+	if enabled {
+		buttonColor = color.RGBA{0, 100, 0, 255}
+		if s.buttonHovered == index {
+			buttonColor = color.RGBA{0, 150, 0, 255}
+		}
+	}
+	draw.Rect(screen,
+		float64(bounds[0]),
+		float64(bounds[1]),
+		float64(bounds[2]),
+		float64(bounds[3]),
+		buttonColor)
+
+	draw.TextCentered(screen, label, s.face, bounds[0], bounds[1], bounds[2], bounds[3], color.White)
+}
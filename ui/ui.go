@@ -1,9 +1,14 @@
 package ui
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/Coloc3G/othello-engine/engine"
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
 	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/ui/view"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -16,6 +21,7 @@ const (
 	StateDualAISelection
 	StateGame
 	StateEnd
+	StateTournament
 )
 
 // UI manages the game UI
@@ -26,11 +32,18 @@ type UI struct {
 	dualAISelectionScreen *DualAISelectionScreen
 	gameScreen            *GameScreen
 	resultScreen          *ResultScreen
-	endScreen             *EndScreen
+	gameOverScreen        *GameOverScreen
+	tournamentScreen      *TournamentScreen
+	openingTrainerScreen  *OpeningTrainerScreen
 	currentScreen         Screen
-	aivsAiMode            bool
 	aivsAiTimer           time.Time
 	aivsAiMoveDelay       time.Duration
+	difficulty            *AdaptiveDifficulty                   // Strength of the AI opponent against the human
+	variant               game.Variant                          // Rules of the next games, toggled on the home screen
+	models                []evaluation.EvaluationCoefficients   // Models offered by the selection screens
+	aiModels              [2]*evaluation.EvaluationCoefficients // Models of the black and white players of the last game, nil for the human
+	adaptiveOpponent      bool                                  // Whether the AI opponent of the human follows the adaptive difficulty
+	bookEngine            *engine.Engine                        // Engine playing the moves of the opening books for the AIs, nil without a position book
 }
 
 // Screen interface for different game screens
@@ -62,7 +75,8 @@ func NewUI(g *game.Game) *UI {
 	ui := &UI{
 		game:            g,
 		aivsAiMoveDelay: time.Second, // 1 second delay between AI moves
-		aivsAiMode:      false,
+		difficulty:      NewAdaptiveDifficulty(),
+		models:          append([]evaluation.EvaluationCoefficients(nil), evaluation.Models...),
 	}
 
 	// Create all screens
@@ -71,7 +85,9 @@ func NewUI(g *game.Game) *UI {
 	ui.dualAISelectionScreen = NewDualAISelectionScreen(ui)
 	ui.gameScreen = NewGameScreen(ui)
 	ui.resultScreen = NewResultScreen(ui)
-	ui.endScreen = NewEndScreen(ui)
+	ui.gameOverScreen = NewGameOverScreen(ui)
+	ui.tournamentScreen = NewTournamentScreen(ui)
+	ui.openingTrainerScreen = NewOpeningTrainerScreen(ui)
 
 	// Set initial screen to home screen
 	ui.currentScreen = ui.homeScreen
@@ -94,45 +110,121 @@ func (s *UI) SwitchToDualAISelectionScreen() {
 	s.currentScreen = s.dualAISelectionScreen
 }
 
-// StartPlayerVsAIGame starts a game with a human player against the selected AI
-func (s *UI) StartPlayerVsAIGame(aiVersion int) {
+// SwitchToTournamentScreen switches to the tournament screen
+func (s *UI) SwitchToTournamentScreen() {
+	s.currentScreen = s.tournamentScreen
+}
+
+// SwitchToOpeningTrainerScreen starts a new round of the opening trainer
+func (s *UI) SwitchToOpeningTrainerScreen() {
+	s.openingTrainerScreen.Start()
+	s.currentScreen = s.openingTrainerScreen
+}
+
+// StartPlayerVsAIGame starts a game with a human player against the AI using coeffs
+func (s *UI) StartPlayerVsAIGame(coeffs evaluation.EvaluationCoefficients) {
+	s.startPlayerVsAIGame(coeffs, game.White, false)
+}
+
+// StartAdaptiveGame starts a game with a human player against the AI of the adaptive difficulty
+func (s *UI) StartAdaptiveGame() {
+	s.startPlayerVsAIGame(s.adaptiveCoefficients(), game.White, true)
+}
+
+// adaptiveCoefficients returns the coefficients of the current adaptive difficulty level
+func (s *UI) adaptiveCoefficients() evaluation.EvaluationCoefficients {
+	coeffs := s.difficulty.Coefficients()
+	coeffs.Name = "Adaptive"
+	return coeffs
+}
+
+// startPlayerVsAIGame starts a game with a human player of the given color against the AI
+// using coeffs, adaptive telling whether the result changes the difficulty level
+func (s *UI) startPlayerVsAIGame(coeffs evaluation.EvaluationCoefficients, human game.Piece, adaptive bool) {
 	// Create game with human player vs AI
-	s.game = game.NewGame(getAIVersionName(aiVersion), "Human")
-	s.aivsAiMode = false
+	if human == game.Black {
+		s.game = game.NewHumanVsAIGame("Human", aiName(coeffs), human)
+		s.aiModels = [2]*evaluation.EvaluationCoefficients{nil, &coeffs}
+	} else {
+		s.game = game.NewHumanVsAIGame(aiName(coeffs), "Human", human)
+		s.aiModels = [2]*evaluation.EvaluationCoefficients{&coeffs, nil}
+	}
+	s.game.Variant = s.variant
+	s.adaptiveOpponent = adaptive
 
 	// Reset the game screen
 	if s.gameScreen != nil {
+		s.gameScreen.opponentEvaluator = evaluation.NewMixedEvaluation(coeffs)
+		s.gameScreen.ponderer.Stop()
+		s.gameScreen.stopEvaluation()
 		s.gameScreen.lastMovePos = game.Position{Row: -1, Col: -1}
-		s.gameScreen.moveHistory = make([][2]MoveRecord, 0)
-		s.gameScreen.scrollOffset = 0
+		s.gameScreen.moves.Reset()
 	}
 
 	s.currentScreen = s.gameScreen
 }
 
-// StartAIVsAIGame starts a game with two AI players
-func (s *UI) StartAIVsAIGame(ai1Version, ai2Version int) {
+// StartAIVsAIGame starts a game between the AIs using the black and white coefficients
+func (s *UI) StartAIVsAIGame(black, white evaluation.EvaluationCoefficients) {
 	// Create game with AI vs AI
-	s.game = game.NewGame(
-		getAIVersionName(ai1Version),
-		getAIVersionName(ai2Version),
-	)
-	s.aivsAiMode = true
+	s.game = game.NewAIVsAIGame(aiName(black), aiName(white))
+	s.game.Variant = s.variant
+	s.aiModels = [2]*evaluation.EvaluationCoefficients{&black, &white}
+	s.adaptiveOpponent = false
 	s.aivsAiTimer = time.Now()
 
 	// Reset the game screen
 	if s.gameScreen != nil {
+		s.gameScreen.aiEvaluators = [2]*evaluation.MixedEvaluation{
+			evaluation.NewMixedEvaluation(black),
+			evaluation.NewMixedEvaluation(white),
+		}
+		s.gameScreen.scoreGraph.Reset([2]string{black.Name, white.Name})
+		s.gameScreen.stopEvaluation()
 		s.gameScreen.lastMovePos = game.Position{Row: -1, Col: -1}
-		s.gameScreen.moveHistory = make([][2]MoveRecord, 0)
-		s.gameScreen.scrollOffset = 0
+		s.gameScreen.moves.Reset()
 	}
 
 	s.currentScreen = s.gameScreen
 }
 
-// EndGame switches to the result screen
+// EndGame switches to the game over screen. The AI vs AI moves are only played by the
+// game screen, they stop with it.
 func (ui *UI) EndGame() {
-	ui.currentScreen = ui.endScreen
+	ui.gameScreen.stopEvaluation()
+	ui.gameScreen.ponderer.Stop()
+	if ui.adaptiveOpponent {
+		winner := ui.game.GetWinnerMethod()
+		ui.difficulty.RecordGame(winner == humanColor(ui.game), winner == game.Empty)
+	}
+	ui.gameOverScreen.Start()
+	ui.currentScreen = ui.gameOverScreen
+}
+
+// Rematch starts a game with the settings of the last one, colors swapped. The adaptive
+// opponent plays at the level reached after the last game.
+func (ui *UI) Rematch() {
+	black, white := ui.aiModels[1], ui.aiModels[0]
+	switch {
+	case ui.game.Mode == game.AIVsAI:
+		ui.StartAIVsAIGame(*black, *white)
+	case ui.adaptiveOpponent && black == nil:
+		ui.startPlayerVsAIGame(ui.adaptiveCoefficients(), game.Black, true)
+	case ui.adaptiveOpponent:
+		ui.startPlayerVsAIGame(ui.adaptiveCoefficients(), game.White, true)
+	case black == nil:
+		ui.startPlayerVsAIGame(*white, game.Black, false)
+	default:
+		ui.startPlayerVsAIGame(*black, game.White, false)
+	}
+}
+
+// humanColor returns the color played by the human against the AI
+func humanColor(g *game.Game) game.Piece {
+	if g.Mode != game.HumanVsAI {
+		return game.Empty
+	}
+	return g.Human
 }
 
 // NewGame starts a new game
@@ -140,25 +232,40 @@ func (ui *UI) NewGame() {
 	ui.SwitchToHomeScreen()
 }
 
-// getAIVersionName returns the name for an AI version
-func getAIVersionName(version int) string {
-	switch version {
-	case 0:
-		return "AI (V1)"
-	case 1:
-		return "AI (V2)"
-	default:
+// aiName returns the player name of the AI using coeffs
+func aiName(coeffs evaluation.EvaluationCoefficients) string {
+	if coeffs.Name == "" {
 		return "AI"
 	}
+	return "AI (" + coeffs.Name + ")"
 }
 
-// RunUI runs the UI
-func RunUI() {
+// RunUI runs the UI. The models of the JSON files of modelDir, when not empty, are offered
+// by the selection screens after the built-in ones. The AIs play the moves of the position
+// book of bookFile, when not empty, and of the named openings without search.
+func RunUI(modelDir, bookFile string) {
 	// Create initial game (won't be used until player makes a selection)
 	g := game.NewGame("Player", "AI")
 
 	// Create UI
 	ui := NewUI(g)
+	if modelDir != "" {
+		models, err := view.LoadModelDir(modelDir)
+		if err != nil {
+			fmt.Println("Error loading models:", err)
+		}
+		ui.models = append(ui.models, models...)
+	}
+	if bookFile != "" {
+		book, err := opening.LoadBook(bookFile)
+		if err != nil {
+			fmt.Println("Error loading the position book:", err)
+		} else {
+			// The depth only matters out of the books, where the move is searched by the UI
+			ui.bookEngine = engine.New(engine.WithDepth(1),
+				engine.WithPositionBook(book, opening.DefaultBookMinGames, opening.DefaultBookMinWinRate))
+		}
+	}
 
 	// Initialize window
 	ebiten.SetWindowSize(800, 600)
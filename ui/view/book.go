@@ -0,0 +1,26 @@
+package view
+
+import (
+	"context"
+
+	"github.com/Coloc3G/othello-engine/engine"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// BookMove returns the move the opening books of e play after the moves of history, from
+// the start position. ok is unset out of the books, for a nil engine and for the variants
+// other than Standard, the books holding standard games only.
+func BookMove(ctx context.Context, e *engine.Engine, history []game.Position, variant game.Variant) (move game.Position, ok bool) {
+	if e == nil || variant != game.Standard {
+		return game.PassMove, false
+	}
+	if err := e.SetPosition(utils.TranscriptToAlgebraic(history)); err != nil {
+		return game.PassMove, false
+	}
+	best, analysis, err := e.BestMove(ctx)
+	if err != nil || !analysis.Book {
+		return game.PassMove, false
+	}
+	return utils.AlgebraicToPosition(string(best)), true
+}
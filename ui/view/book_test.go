@@ -0,0 +1,37 @@
+package view
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/engine"
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/opening"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+func TestBookMove(t *testing.T) {
+	// A line left by the named openings, continued by the games of the position book
+	const played, reply = "f5f4e3f6d3", "c5"
+	book, err := opening.ReadBook(strings.NewReader(played + reply + " white\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := utils.AlgebraicToPositions(played)
+
+	ctx := context.Background()
+	if _, ok := BookMove(ctx, engine.New(engine.WithDepth(1)), history, game.Standard); ok {
+		t.Fatalf("%s: book move without the position book", played)
+	}
+	e := engine.New(engine.WithDepth(1), engine.WithPositionBook(book, 1, opening.DefaultBookMinWinRate))
+	if move, ok := BookMove(ctx, e, history, game.Standard); !ok || utils.PositionToAlgebraic(move) != reply {
+		t.Errorf("%s: book move %s, %v, want %s", played, utils.PositionToAlgebraic(move), ok, reply)
+	}
+	if _, ok := BookMove(ctx, e, history, game.Misere); ok {
+		t.Errorf("%s: book move in a misere game", played)
+	}
+	if _, ok := BookMove(ctx, nil, history, game.Standard); ok {
+		t.Errorf("%s: book move without an engine", played)
+	}
+}
@@ -0,0 +1,112 @@
+// Package view holds the state and the text of the screens of the UI that do not need
+// ebiten, so they are tested without a display
+package view
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// Bounds of the progressive evaluation depth
+const (
+	MinEvalDepth = 2
+	MaxEvalDepth = 12
+)
+
+// evalHistoryLimit is the number of scores kept in the evaluation history
+const evalHistoryLimit = 100
+
+// EvalUpdate is the result of one iteration of the progressive evaluation
+type EvalUpdate struct {
+	Gen   int // Generation of the evaluation that produced it
+	Depth int
+	Score int
+	Final bool // Last iteration of the evaluation
+}
+
+// Evaluation is the state of the progressive evaluation shown by the game screen
+type Evaluation struct {
+	Value     int   // Score of the deepest completed iteration
+	Depth     int   // Depth of Value
+	MaxDepth  int   // Depth of the evaluation, changed with ChangeDepth
+	Searching bool  // An evaluation runs and has not completed MaxDepth
+	History   []int // Scores of the completed iterations, the last evalHistoryLimit
+	gen       int   // Generation of the running evaluation, updates of older ones are dropped
+}
+
+// NewEvaluation creates the state of an evaluation searching up to maxDepth
+func NewEvaluation(maxDepth int) *Evaluation {
+	return &Evaluation{MaxDepth: maxDepth}
+}
+
+// Start starts a new evaluation and returns the generation its updates must carry
+func (e *Evaluation) Start() int {
+	e.gen++
+	e.Searching = true
+	return e.gen
+}
+
+// Stop stops the running evaluation, its updates still to come are dropped
+func (e *Evaluation) Stop() {
+	e.gen++
+	e.Searching = false
+}
+
+// Apply applies an update of the running evaluation and reports whether it was applied,
+// the updates of older evaluations are not
+func (e *Evaluation) Apply(u EvalUpdate) bool {
+	if u.Gen != e.gen {
+		return false
+	}
+	e.Value = u.Score
+	e.Depth = u.Depth
+	e.History = append(e.History, u.Score)
+	if len(e.History) > evalHistoryLimit {
+		e.History = e.History[len(e.History)-evalHistoryLimit:]
+	}
+	if u.Final {
+		e.Searching = false
+	}
+	return true
+}
+
+// ChangeDepth adds delta to the maximum depth, kept between MinEvalDepth and MaxEvalDepth,
+// and reports whether it changed
+func (e *Evaluation) ChangeDepth(delta int) bool {
+	depth := min(max(e.MaxDepth+delta, MinEvalDepth), MaxEvalDepth)
+	if depth == e.MaxDepth {
+		return false
+	}
+	e.MaxDepth = depth
+	return true
+}
+
+// Text is the score and its depth, with the depth being searched while searching
+func (e *Evaluation) Text() string {
+	if e.Searching {
+		return fmt.Sprintf("%+d d:%d (searching d:%d...)", e.Value, e.Depth, e.MaxDepth)
+	}
+	return fmt.Sprintf("%+d d:%d", e.Value, e.Depth)
+}
+
+// Progressive searches b with evaluation.SolveIterative up to maxDepth, sending each
+// completed iteration from MinEvalDepth to out, with the generation gen, until ctx is
+// cancelled, which also stops the search in progress
+func Progressive(ctx context.Context, gen int, b game.Board, player game.Piece, eval evaluation.Evaluation, maxDepth int, out chan<- EvalUpdate) {
+	evaluation.SolveIterative(ctx, b, player, int8(maxDepth), eval, func(r evaluation.IterationResult) {
+		if r.Depth < MinEvalDepth {
+			return
+		}
+		// Cancellation wins over a ready result
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case out <- EvalUpdate{Gen: gen, Depth: int(r.Depth), Score: int(r.Score), Final: int(r.Depth) == maxDepth}:
+		case <-ctx.Done():
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package view
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+func TestEvaluationDropsOlderUpdates(t *testing.T) {
+	e := NewEvaluation(5)
+	old := e.Start()
+	current := e.Start()
+
+	if e.Apply(EvalUpdate{Gen: old, Depth: 4, Score: 300}) {
+		t.Error("update of an older evaluation applied")
+	}
+	if !e.Apply(EvalUpdate{Gen: current, Depth: 2, Score: -40}) {
+		t.Error("update of the running evaluation dropped")
+	}
+	if e.Value != -40 || e.Depth != 2 || !e.Searching {
+		t.Errorf("value %d at depth %d, searching %v, want -40 at depth 2 while searching", e.Value, e.Depth, e.Searching)
+	}
+	if got, want := e.Text(), "-40 d:2 (searching d:5...)"; got != want {
+		t.Errorf("text %q, want %q", got, want)
+	}
+
+	e.Apply(EvalUpdate{Gen: current, Depth: 5, Score: 12, Final: true})
+	if e.Searching {
+		t.Error("still searching after the final update")
+	}
+	if got, want := e.Text(), "+12 d:5"; got != want {
+		t.Errorf("text %q, want %q", got, want)
+	}
+
+	// The updates of a stopped evaluation are not shown
+	gen := e.Start()
+	e.Stop()
+	if e.Apply(EvalUpdate{Gen: gen, Depth: 3, Score: 99}) || e.Value != 12 || e.Searching {
+		t.Errorf("stopped evaluation updated: value %d, searching %v", e.Value, e.Searching)
+	}
+}
+
+func TestEvaluationHistory(t *testing.T) {
+	e := NewEvaluation(5)
+	gen := e.Start()
+	for i := range evalHistoryLimit + 10 {
+		e.Apply(EvalUpdate{Gen: gen, Depth: 2, Score: i})
+	}
+	if len(e.History) != evalHistoryLimit || e.History[0] != 10 || e.History[evalHistoryLimit-1] != evalHistoryLimit+9 {
+		t.Errorf("history of %d scores from %d, want the last %d", len(e.History), e.History[0], evalHistoryLimit)
+	}
+}
+
+func TestEvaluationChangeDepth(t *testing.T) {
+	e := NewEvaluation(MaxEvalDepth - 1)
+	if !e.ChangeDepth(1) || e.MaxDepth != MaxEvalDepth {
+		t.Errorf("depth %d, want %d", e.MaxDepth, MaxEvalDepth)
+	}
+	if e.ChangeDepth(1) || e.MaxDepth != MaxEvalDepth {
+		t.Errorf("depth raised above the maximum to %d", e.MaxDepth)
+	}
+	if e.ChangeDepth(0) {
+		t.Error("no change reported as a change")
+	}
+	e.ChangeDepth(-100)
+	if e.MaxDepth != MinEvalDepth {
+		t.Errorf("depth %d, want the minimum %d", e.MaxDepth, MinEvalDepth)
+	}
+}
+
+func TestProgressive(t *testing.T) {
+	b := game.NewGame("Black", "White").Board
+	eval := evaluation.NewMixedEvaluation(evaluation.V1Coeff)
+	out := make(chan EvalUpdate, MaxEvalDepth)
+	Progressive(context.Background(), 7, b, game.Black, eval, 4, out)
+	close(out)
+
+	// Every completed depth from MinEvalDepth, the last one final
+	depth := MinEvalDepth
+	for update := range out {
+		_, score := evaluation.Solve(b, game.Black, int8(update.Depth), eval)
+		if update.Gen != 7 || update.Depth != depth || update.Score != int(score) || update.Final != (depth == 4) {
+			t.Errorf("update %+v, want generation 7, depth %d, score %d, final %v", update, depth, score, depth == 4)
+		}
+		depth++
+	}
+	if depth != 5 {
+		t.Errorf("updates up to depth %d, want 4", depth-1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	out = make(chan EvalUpdate, MaxEvalDepth)
+	Progressive(ctx, 8, b, game.Black, eval, 4, out)
+	if len(out) != 0 {
+		t.Errorf("cancelled evaluation sent %d updates", len(out))
+	}
+}
@@ -0,0 +1,12 @@
+package view
+
+// CenterText returns the origin at which a text of width textW and height textH is drawn
+// to be centered in the rectangle of top left corner x, y. The origin is on the baseline.
+func CenterText(x, y, w, h, textW, textH int) (int, int) {
+	return x + (w-textW)/2, y + (h+textH)/2
+}
+
+// DiscRadius is the radius of the discs drawn in squares of size cell, 0 when too small
+func DiscRadius(cell int) int {
+	return max(cell/2-4, 0)
+}
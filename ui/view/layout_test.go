@@ -0,0 +1,23 @@
+package view
+
+import "testing"
+
+func TestCenterText(t *testing.T) {
+	// A text of 20x10 in a button of 100x30 at 10, 40: 40 pixels on each side, the
+	// baseline 10 pixels above the bottom
+	if x, y := CenterText(10, 40, 100, 30, 20, 10); x != 50 || y != 60 {
+		t.Errorf("origin %d, %d, want 50, 60", x, y)
+	}
+	// A text wider than its rectangle overflows on both sides
+	if x, _ := CenterText(0, 0, 10, 10, 30, 10); x != -10 {
+		t.Errorf("x %d, want -10", x)
+	}
+}
+
+func TestDiscRadius(t *testing.T) {
+	for _, tt := range []struct{ cell, want int }{{60, 26}, {9, 0}, {8, 0}, {0, 0}} {
+		if got := DiscRadius(tt.cell); got != tt.want {
+			t.Errorf("DiscRadius(%d) = %d, want %d", tt.cell, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package view
+
+// RowHeight is the height of a row of a List, button and spacing
+const RowHeight = 36
+
+// List is a scrollable column of rows, one per model, of which one can be selected
+type List struct {
+	Bounds   [4]int // Visible area: x, y, width, height
+	Offset   int    // First visible row, changed with the mouse wheel
+	Selected int    // -1: none
+	Hovered  int    // -1: none
+}
+
+// NewList creates a list with nothing selected
+func NewList() *List {
+	return &List{Selected: -1, Hovered: -1}
+}
+
+// VisibleRows is the number of rows fitting in the list area
+func (l *List) VisibleRows() int {
+	return max(1, l.Bounds[3]/RowHeight)
+}
+
+// RowBounds returns the bounds of the button of the ith visible row
+func (l *List) RowBounds(i int) [4]int {
+	return [4]int{l.Bounds[0], l.Bounds[1] + i*RowHeight, l.Bounds[2] - 8, RowHeight - 6}
+}
+
+// Update places a list of count rows, scrolls it by the wheel movement when hovered and
+// selects the clicked row. It returns true when the selection changed.
+func (l *List) Update(count int, bounds [4]int, mouseX, mouseY int, wheel float64, clicked bool) bool {
+	l.Bounds = bounds
+	if l.Selected >= count {
+		l.Selected = -1
+	}
+	if wheel != 0 && InBounds(bounds, mouseX, mouseY) {
+		l.Offset -= int(wheel)
+	}
+	l.Offset = min(max(l.Offset, 0), max(count-l.VisibleRows(), 0))
+
+	l.Hovered = -1
+	for i := 0; i < l.VisibleRows() && l.Offset+i < count; i++ {
+		if InBounds(l.RowBounds(i), mouseX, mouseY) {
+			l.Hovered = l.Offset + i
+		}
+	}
+	if clicked && l.Hovered >= 0 && l.Hovered != l.Selected {
+		l.Selected = l.Hovered
+		return true
+	}
+	return false
+}
+
+// ScrollThumb returns the top and the height of the thumb of the scroll bar of a list of
+// count rows, ok being false when the rows fit and the list has no scroll bar
+func (l *List) ScrollThumb(count int) (top, height float64, ok bool) {
+	visible := l.VisibleRows()
+	if count <= visible {
+		return 0, 0, false
+	}
+	h := float64(l.Bounds[3])
+	height = h * float64(visible) / float64(count)
+	top = float64(l.Bounds[1]) + (h-height)*float64(l.Offset)/float64(count-visible)
+	return top, height, true
+}
+
+// InBounds tells if a point is inside a rectangle given as x, y, width, height
+func InBounds(bounds [4]int, x, y int) bool {
+	return x >= bounds[0] && x < bounds[0]+bounds[2] && y >= bounds[1] && y < bounds[1]+bounds[3]
+}
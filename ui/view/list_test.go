@@ -0,0 +1,79 @@
+package view
+
+import "testing"
+
+func TestListSelection(t *testing.T) {
+	l := NewList()
+	bounds := [4]int{100, 50, 200, 3 * RowHeight}
+
+	// The second row is hovered, then selected by a click
+	x, y := 150, 50+RowHeight+5
+	if l.Update(5, bounds, x, y, 0, false) || l.Hovered != 1 || l.Selected != -1 {
+		t.Errorf("hover: hovered %d, selected %d, want 1 and -1", l.Hovered, l.Selected)
+	}
+	if !l.Update(5, bounds, x, y, 0, true) || l.Selected != 1 {
+		t.Errorf("click: selected %d, want 1", l.Selected)
+	}
+	if l.Update(5, bounds, x, y, 0, true) {
+		t.Error("click on the selected row reported as a change")
+	}
+
+	// The gap under a button and the scroll bar column are not rows
+	if l.Update(5, bounds, x, 50+RowHeight-3, 0, false); l.Hovered != -1 {
+		t.Errorf("gap between rows hovers row %d", l.Hovered)
+	}
+	if l.Update(5, bounds, 100+200-4, y, 0, false); l.Hovered != -1 {
+		t.Errorf("scroll bar hovers row %d", l.Hovered)
+	}
+
+	// A selection beyond a shorter list is cleared
+	l.Update(1, bounds, 0, 0, 0, false)
+	if l.Selected != -1 {
+		t.Errorf("selected %d of a list of 1 row", l.Selected)
+	}
+}
+
+func TestListScroll(t *testing.T) {
+	l := NewList()
+	bounds := [4]int{0, 0, 200, 3 * RowHeight}
+
+	// The wheel scrolls only when the list is hovered, within the rows
+	l.Update(10, bounds, 500, 500, -2, false)
+	if l.Offset != 0 {
+		t.Errorf("offset %d after a wheel outside the list", l.Offset)
+	}
+	l.Update(10, bounds, 10, 10, -2, false)
+	if l.Offset != 2 {
+		t.Errorf("offset %d, want 2", l.Offset)
+	}
+	l.Update(10, bounds, 10, 10, -100, false)
+	if l.Offset != 7 {
+		t.Errorf("offset %d, want the last page at 7", l.Offset)
+	}
+	if !l.Update(10, bounds, 10, 10, 0, true) || l.Selected != 7 {
+		t.Errorf("click on the first visible row selected %d, want 7", l.Selected)
+	}
+	l.Update(10, bounds, 10, 10, 100, false)
+	if l.Offset != 0 {
+		t.Errorf("offset %d, want 0", l.Offset)
+	}
+
+	// The thumb covers the visible share of the rows and moves with the offset
+	if _, _, ok := l.ScrollThumb(3); ok {
+		t.Error("scroll bar on a list that fits")
+	}
+	top, height, ok := l.ScrollThumb(10)
+	if !ok || top != 0 || height != float64(3*RowHeight)*3/10 {
+		t.Errorf("thumb at %v of height %v", top, height)
+	}
+	l.Update(10, bounds, 10, 10, -100, false)
+	if top, height, _ = l.ScrollThumb(10); top+height != float64(3*RowHeight) {
+		t.Errorf("thumb of the last page ends at %v, want the bottom %d", top+height, 3*RowHeight)
+	}
+
+	// A list shrunk under the offset scrolls back
+	l.Update(4, bounds, 10, 10, 0, false)
+	if l.Offset != 1 {
+		t.Errorf("offset %d of a list of 4 rows, want 1", l.Offset)
+	}
+}
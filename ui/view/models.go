@@ -0,0 +1,58 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+)
+
+// ModelNames returns the names of models, in order
+func ModelNames(models []evaluation.EvaluationCoefficients) []string {
+	names := make([]string, len(models))
+	for i, model := range models {
+		names[i] = model.Name
+	}
+	return names
+}
+
+// LoadModelDir reads the models of the JSON files of a directory, either plain coefficients
+// or a model saved by the trainer. A model without a name is named after its file.
+func LoadModelDir(dir string) ([]evaluation.EvaluationCoefficients, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	models := make([]evaluation.EvaluationCoefficients, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return models, err
+		}
+		var file struct {
+			evaluation.EvaluationCoefficients
+			Coeffs *evaluation.EvaluationCoefficients `json:"coeffs"` // Model saved by the trainer
+		}
+		if err := json.Unmarshal(data, &file); err != nil {
+			return models, fmt.Errorf("%s: %w", path, err)
+		}
+		coeffs := file.EvaluationCoefficients
+		if file.Coeffs != nil {
+			coeffs = *file.Coeffs
+		}
+		if coeffs.Name == "" {
+			coeffs.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+		if err := coeffs.Validate(); err != nil {
+			return models, fmt.Errorf("%s: %w", path, err)
+		}
+		models = append(models, coeffs)
+	}
+	return models, nil
+}
@@ -0,0 +1,67 @@
+package view
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/ai/evaluation"
+)
+
+// writeJSON writes v to the file name of dir
+func writeJSON(t *testing.T, dir, name string, v any) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadModelDir(t *testing.T) {
+	dir := t.TempDir()
+	plain := evaluation.V2Coeff
+	unnamed := evaluation.V3Coeff
+	unnamed.Name = ""
+	writeJSON(t, dir, "b_plain.json", plain)
+	writeJSON(t, dir, "a_trained.json", map[string]any{"coeffs": unnamed, "generation": 4})
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a model"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	models, err := LoadModelDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sorted by file, a model saved by the trainer named after its file
+	if got := strings.Join(ModelNames(models), ","); got != "a_trained,"+plain.Name {
+		t.Errorf("models %s", got)
+	}
+	if len(models) == 2 && !slices.Equal(models[0].StabilityCoeffs, unnamed.StabilityCoeffs) {
+		t.Error("coefficients of the trained model not loaded")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "c_broken.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	models, err = LoadModelDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "c_broken.json") {
+		t.Errorf("invalid file: error %v", err)
+	}
+	if len(models) != 2 {
+		t.Errorf("%d models loaded before the invalid file, want 2", len(models))
+	}
+}
+
+func TestLoadModelDirInvalidCoefficients(t *testing.T) {
+	dir := t.TempDir()
+	writeJSON(t, dir, "empty.json", map[string]any{"name": "empty"})
+	if _, err := LoadModelDir(dir); err == nil {
+		t.Error("model without coefficients loaded")
+	}
+}
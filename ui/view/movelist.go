@@ -0,0 +1,109 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+)
+
+// MoveRecord is a move of the move list
+type MoveRecord struct {
+	Position game.Position // {-1, -1} for a move not played yet
+	Pass     bool
+	Book     bool // Played from an opening book rather than searched
+}
+
+// unplayed is the record of a move not played yet
+var unplayed = MoveRecord{Position: game.Position{Row: -1, Col: -1}}
+
+// String is the square of the move, such as "D3", "Pass", or empty for a move not played yet.
+// A book move is marked, such as "D3 bk".
+func (m MoveRecord) String() string {
+	if m.Pass {
+		return "Pass"
+	}
+	if m.Book {
+		return SquareName(m.Position) + " bk"
+	}
+	return SquareName(m.Position)
+}
+
+// SquareName is the name of a square with an upper case column, such as "D3", empty
+// outside the board
+func SquareName(pos game.Position) string {
+	if pos.Row < 0 || pos.Row >= 8 || pos.Col < 0 || pos.Col >= 8 {
+		return ""
+	}
+	return fmt.Sprintf("%c%d", 'A'+rune(pos.Col), pos.Row+1)
+}
+
+// MoveList is the move list of the game screen, a row per turn holding the black and the
+// white move, scrolled to show Visible turns from Offset
+type MoveList struct {
+	Turns   [][2]MoveRecord
+	Offset  int // First visible turn
+	Visible int // Number of turns fitting in the panel
+}
+
+// NewMoveList creates an empty move list showing visible turns
+func NewMoveList(visible int) *MoveList {
+	return &MoveList{Visible: visible}
+}
+
+// Reset empties the list
+func (l *MoveList) Reset() {
+	l.Turns = nil
+	l.Offset = 0
+}
+
+// Add records a move or, with game.PassMove, a pass of player and scrolls to the last turn.
+// A black move starts a turn, a white move completes the last one.
+func (l *MoveList) Add(pos game.Position, player game.Piece) {
+	l.add(MoveRecord{Position: pos, Pass: pos == game.PassMove}, player)
+}
+
+// AddBook records a move of player played from an opening book, see Add
+func (l *MoveList) AddBook(pos game.Position, player game.Piece) {
+	l.add(MoveRecord{Position: pos, Book: true}, player)
+}
+
+// add records a move of player and scrolls to the last turn
+func (l *MoveList) add(record MoveRecord, player game.Piece) {
+	switch {
+	case player == game.Black:
+		l.Turns = append(l.Turns, [2]MoveRecord{record, unplayed})
+	case len(l.Turns) > 0:
+		l.Turns[len(l.Turns)-1][1] = record
+	default:
+		// White moving first, as in a game set up from a position
+		l.Turns = append(l.Turns, [2]MoveRecord{unplayed, record})
+	}
+	l.Offset = l.maxOffset()
+}
+
+// Scroll moves the list by turns, towards the end when positive, within its turns
+func (l *MoveList) Scroll(turns int) {
+	l.Offset = min(max(l.Offset+turns, 0), l.maxOffset())
+}
+
+// SetVisible changes the number of turns fitting in the panel, keeping the offset valid
+func (l *MoveList) SetVisible(visible int) {
+	l.Visible = max(visible, 1)
+	l.Scroll(0)
+}
+
+// Range returns the turns to show, from start included to end excluded
+func (l *MoveList) Range() (start, end int) {
+	start = l.Offset
+	return start, min(len(l.Turns), start+l.Visible)
+}
+
+// More tells whether turns are hidden above and below the visible ones
+func (l *MoveList) More() (above, below bool) {
+	return l.Offset > 0, l.Offset+l.Visible < len(l.Turns)
+}
+
+// maxOffset is the offset showing the last turn at the bottom
+func (l *MoveList) maxOffset() int {
+	return max(0, len(l.Turns)-l.Visible)
+}
@@ -0,0 +1,110 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/Coloc3G/othello-engine/models/game"
+	"github.com/Coloc3G/othello-engine/models/utils"
+)
+
+// turnTexts returns the texts of the turns of l
+func turnTexts(l *MoveList) [][2]string {
+	texts := make([][2]string, len(l.Turns))
+	for i, turn := range l.Turns {
+		texts[i] = [2]string{turn[0].String(), turn[1].String()}
+	}
+	return texts
+}
+
+func TestMoveListTurns(t *testing.T) {
+	l := NewMoveList(10)
+	l.Add(utils.AlgebraicToPosition("d3"), game.Black)
+	if got := turnTexts(l); len(got) != 1 || got[0] != [2]string{"D3", ""} {
+		t.Errorf("turns %q, want D3 and a white move not played yet", got)
+	}
+	l.Add(utils.AlgebraicToPosition("c5"), game.White)
+	l.Add(game.PassMove, game.Black)
+	l.Add(utils.AlgebraicToPosition("h8"), game.White)
+	want := [][2]string{{"D3", "C5"}, {"Pass", "H8"}}
+	if got := turnTexts(l); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("turns %q, want %q", got, want)
+	}
+
+	// White moving first leaves the black move of the first turn empty
+	l.Reset()
+	l.Add(utils.AlgebraicToPosition("a1"), game.White)
+	if got := turnTexts(l); len(got) != 1 || got[0] != [2]string{"", "A1"} {
+		t.Errorf("turns %q, want an empty black move and A1", got)
+	}
+}
+
+func TestMoveListBookMoves(t *testing.T) {
+	l := NewMoveList(10)
+	l.AddBook(utils.AlgebraicToPosition("f5"), game.Black)
+	l.Add(utils.AlgebraicToPosition("d6"), game.White)
+	if got := turnTexts(l); len(got) != 1 || got[0] != [2]string{"F5 bk", "D6"} {
+		t.Errorf("turns %q, want the book move F5 marked and D6", got)
+	}
+}
+
+func TestMoveListScroll(t *testing.T) {
+	l := NewMoveList(3)
+	for i := range 5 {
+		l.Add(game.Position{Row: int8(i), Col: 0}, game.Black)
+	}
+	// Adding a move shows the last turns
+	if start, end := l.Range(); start != 2 || end != 5 {
+		t.Errorf("range %d-%d, want 2-5", start, end)
+	}
+	if above, below := l.More(); !above || below {
+		t.Errorf("more above %v, below %v, want only above", above, below)
+	}
+
+	l.Scroll(-10)
+	if start, end := l.Range(); start != 0 || end != 3 {
+		t.Errorf("range %d-%d after scrolling up, want 0-3", start, end)
+	}
+	if above, below := l.More(); above || !below {
+		t.Errorf("more above %v, below %v, want only below", above, below)
+	}
+	l.Scroll(1)
+	if l.Offset != 1 {
+		t.Errorf("offset %d, want 1", l.Offset)
+	}
+	l.Scroll(10)
+	if l.Offset != 2 {
+		t.Errorf("offset %d, want the last turns at 2", l.Offset)
+	}
+
+	// A taller panel shows every turn
+	l.SetVisible(8)
+	if start, end := l.Range(); start != 0 || end != 5 {
+		t.Errorf("range %d-%d in a panel of 8 turns, want 0-5", start, end)
+	}
+	if above, below := l.More(); above || below {
+		t.Error("scroll indicators on a list that fits")
+	}
+
+	l.Reset()
+	if start, end := l.Range(); start != 0 || end != 0 {
+		t.Errorf("range %d-%d of an empty list", start, end)
+	}
+}
+
+func TestSquareName(t *testing.T) {
+	tests := []struct {
+		pos  game.Position
+		want string
+	}{
+		{game.Position{Row: 0, Col: 0}, "A1"},
+		{game.Position{Row: 2, Col: 3}, "D3"},
+		{game.Position{Row: 7, Col: 7}, "H8"},
+		{game.PassMove, ""},
+		{game.Position{Row: 8, Col: 0}, ""},
+	}
+	for _, tt := range tests {
+		if got := SquareName(tt.pos); got != tt.want {
+			t.Errorf("SquareName(%v) = %q, want %q", tt.pos, got, tt.want)
+		}
+	}
+}